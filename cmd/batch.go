@@ -0,0 +1,98 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mixanemca/cdnscli/internal/workerpool"
+	"github.com/spf13/cobra"
+)
+
+var (
+	recordTimeout time.Duration
+	batchTimeout  time.Duration
+	concurrency   int
+)
+
+var (
+	// errBatchDeadline stops a batch whose overall deadline has passed.
+	errBatchDeadline = errors.New("batch deadline exceeded, use --batch-timeout to allow more time")
+	// errBatchInterrupted stops a batch canceled by Ctrl+C.
+	errBatchInterrupted = errors.New("batch interrupted")
+)
+
+// addBatchTimeoutFlags adds the --record-timeout and --batch-timeout flags of bulk commands.
+func addBatchTimeoutFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().DurationVar(&recordTimeout, "record-timeout", 0, "timeout of the API calls for a single record (default --timeout)")
+	cmd.PersistentFlags().DurationVar(&batchTimeout, "batch-timeout", 0, "overall deadline of the batch, 0 for none")
+}
+
+// addConcurrencyFlag adds the --concurrency flag of bulk commands running records in parallel.
+func addConcurrencyFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().IntVar(&concurrency, "concurrency", workerpool.DefaultSize, fmt.Sprintf("number of records processed at the same time, 1 to %d", workerpool.MaxSize))
+}
+
+// batchRunner runs the steps of a bulk command, such as creating one record.
+// Every step gets a fresh context with its own timeout, so a long batch is not
+// aborted by a single timeout, while the batch deadline bounds all of them.
+// Up to concurrency steps run at the same time, one if it is zero.
+type batchRunner struct {
+	ctx           context.Context
+	recordTimeout time.Duration
+	concurrency   int
+}
+
+// newBatchRunner returns a runner whose steps time out after recordTimeout, or
+// the client timeout if it is zero. A non-zero batchTimeout sets the batch deadline.
+func newBatchRunner(parent context.Context, recordTimeout, batchTimeout time.Duration) (*batchRunner, context.CancelFunc) {
+	if recordTimeout <= 0 {
+		recordTimeout = getTimeout()
+	}
+
+	ctx, cancel := parent, context.CancelFunc(func() {})
+	if batchTimeout > 0 {
+		ctx, cancel = context.WithTimeout(parent, batchTimeout)
+	}
+
+	return &batchRunner{ctx: ctx, recordTimeout: recordTimeout}, cancel
+}
+
+// run calls step with a context of its own. Once the batch deadline has passed,
+// errBatchDeadline is returned without calling step, errBatchInterrupted once
+// the batch is canceled.
+func (b *batchRunner) run(step func(ctx context.Context) error) error {
+	if err := b.ctx.Err(); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return errBatchDeadline
+		}
+		return errBatchInterrupted
+	}
+
+	ctx, cancel := context.WithTimeout(b.ctx, b.recordTimeout)
+	defer cancel()
+
+	return step(ctx)
+}
+
+// stopped reports whether err ends the whole batch rather than a single step.
+func (b *batchRunner) stopped(err error) bool {
+	return errors.Is(err, errBatchDeadline) || errors.Is(err, errBatchInterrupted) || errors.Is(b.ctx.Err(), context.Canceled)
+}
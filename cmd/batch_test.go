@@ -0,0 +1,214 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBatchRunner returns a runner without a batch deadline.
+func testBatchRunner(t *testing.T) *batchRunner {
+	t.Helper()
+
+	b, cancel := newBatchRunner(context.Background(), time.Minute, 0)
+	t.Cleanup(cancel)
+	return b
+}
+
+// slowProvider is a listProvider whose AddRR blocks until its context is done.
+type slowProvider struct {
+	listProvider
+}
+
+func (p *slowProvider) AddRR(ctx context.Context, zone string, params models.CreateDNSRecordParams) (models.DNSRecord, error) {
+	<-ctx.Done()
+	return models.DNSRecord{}, ctx.Err()
+}
+
+// parallelProvider is a listProvider whose AddRR takes a while and may run
+// concurrently. It records the most calls running at the same time and fails
+// for the names in addErr.
+type parallelProvider struct {
+	listProvider
+	mu      sync.Mutex
+	running int
+	peak    int
+	calls   int
+}
+
+func (p *parallelProvider) AddRR(ctx context.Context, zone string, params models.CreateDNSRecordParams) (models.DNSRecord, error) {
+	p.mu.Lock()
+	p.running++
+	p.calls++
+	p.peak = max(p.peak, p.running)
+	p.mu.Unlock()
+
+	time.Sleep(2 * time.Millisecond)
+
+	p.mu.Lock()
+	p.running--
+	p.mu.Unlock()
+
+	if err := p.addErr[params.Name]; err != nil {
+		return models.DNSRecord{}, err
+	}
+	return models.DNSRecord{Name: params.Name, Type: params.Type, Content: params.Content}, nil
+}
+
+func TestImportRecordsConcurrency(t *testing.T) {
+	params := make([]models.CreateDNSRecordParams, 20)
+	for i := range params {
+		params[i] = models.CreateDNSRecordParams{Name: fmt.Sprintf("host%d.example.com", i), Type: "A", Content: "192.0.2.1"}
+	}
+
+	t.Run("all records", func(t *testing.T) {
+		b := testBatchRunner(t)
+		b.concurrency = 4
+
+		p := &parallelProvider{}
+		created, errored, err := importRecords(b, p, "example.com", params, false, false)
+		require.NoError(t, err)
+		assert.Empty(t, errored)
+		assert.Equal(t, len(params), p.calls)
+		assert.LessOrEqual(t, p.peak, 4)
+		assert.Greater(t, p.peak, 1)
+
+		// Records are reported in the given order
+		require.Len(t, created, len(params))
+		for i, rr := range created {
+			assert.Equal(t, params[i].Name, rr.Name)
+		}
+	})
+
+	t.Run("continue on error", func(t *testing.T) {
+		b := testBatchRunner(t)
+		b.concurrency = 3
+
+		p := &parallelProvider{listProvider: listProvider{addErr: map[string]error{
+			"host3.example.com":  errors.New("record already exists"),
+			"host11.example.com": errors.New("rate limited"),
+		}}}
+		created, errored, err := importRecords(b, p, "example.com", params, true, false)
+		require.NoError(t, err)
+		assert.Len(t, created, len(params)-2)
+		require.Len(t, errored, 2)
+		assert.Equal(t, "host3.example.com", errored[0].Record.Name)
+		assert.Equal(t, "host11.example.com", errored[1].Record.Name)
+	})
+
+	t.Run("stop on error", func(t *testing.T) {
+		b := testBatchRunner(t)
+		b.concurrency = 3
+
+		p := &parallelProvider{listProvider: listProvider{addErr: map[string]error{
+			"host2.example.com": errors.New("record already exists"),
+		}}}
+		created, errored, err := importRecords(b, p, "example.com", params, false, false)
+		assert.ErrorContains(t, err, "record already exists")
+		require.Len(t, errored, 1)
+		// Records already running finish, no more are started
+		assert.Less(t, p.calls, len(params))
+		assert.Len(t, created, p.calls-1)
+	})
+}
+
+func TestBatchRunnerRecordTimeout(t *testing.T) {
+	b, cancel := newBatchRunner(context.Background(), 10*time.Millisecond, 0)
+	defer cancel()
+
+	// Every step gets the full record timeout, however long the batch runs
+	for range 3 {
+		err := b.run(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.False(t, b.stopped(err))
+	}
+
+	assert.NoError(t, b.run(func(ctx context.Context) error { return nil }))
+}
+
+func TestBatchRunnerDeadline(t *testing.T) {
+	b, cancel := newBatchRunner(context.Background(), time.Minute, 20*time.Millisecond)
+	defer cancel()
+
+	err := b.run(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	called := false
+	err = b.run(func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+	assert.ErrorIs(t, err, errBatchDeadline)
+	assert.True(t, b.stopped(err))
+	assert.False(t, called)
+}
+
+func TestBatchRunnerCanceled(t *testing.T) {
+	parent, cancelParent := context.WithCancel(context.Background())
+	b, cancel := newBatchRunner(parent, time.Minute, 0)
+	defer cancel()
+
+	cancelParent()
+	err := b.run(func(ctx context.Context) error { return nil })
+	assert.ErrorIs(t, err, errBatchInterrupted)
+	assert.True(t, b.stopped(err))
+}
+
+func TestImportRecordsTimeouts(t *testing.T) {
+	params := []models.CreateDNSRecordParams{
+		{Name: "www.example.com", Type: "A", Content: "192.0.2.1"},
+		{Name: "mail.example.com", Type: "A", Content: "192.0.2.2"},
+	}
+
+	t.Run("record timeout", func(t *testing.T) {
+		b, cancel := newBatchRunner(context.Background(), 10*time.Millisecond, 0)
+		defer cancel()
+
+		// Each record times out on its own and the import goes on
+		created, errored, err := importRecords(b, &slowProvider{}, "example.com", params, true, false)
+		require.NoError(t, err)
+		assert.Empty(t, created)
+		require.Len(t, errored, 2)
+		assert.ErrorIs(t, errored[1].Err, context.DeadlineExceeded)
+	})
+
+	t.Run("batch deadline", func(t *testing.T) {
+		b, cancel := newBatchRunner(context.Background(), time.Minute, 20*time.Millisecond)
+		defer cancel()
+
+		// The first record runs into the batch deadline, the second is not tried
+		created, errored, err := importRecords(b, &slowProvider{}, "example.com", params, true, false)
+		assert.ErrorIs(t, err, errBatchDeadline)
+		assert.Empty(t, created)
+		assert.Len(t, errored, 1)
+	})
+}
@@ -0,0 +1,181 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mixanemca/cdnscli/internal/app"
+	"github.com/mixanemca/cdnscli/internal/models"
+	pp "github.com/mixanemca/cdnscli/internal/prettyprint"
+	"github.com/mixanemca/cdnscli/internal/providers"
+	"github.com/spf13/cobra"
+)
+
+// zoneFlagNames are the flags taking a zone name, completed from the zone cache.
+var zoneFlagNames = []string{"zone", "from-zone", "to-zone"}
+
+// completionWarmCmd represents the completion warm command
+var completionWarmCmd = &cobra.Command{
+	Args:  cobra.NoArgs,
+	Use:   "warm",
+	Short: "Cache the zone list for completing --zone",
+	Long: `Fetch the zones of a provider and store them in the zone cache, so shell
+completion of --zone reads the cache instead of calling the API on every TAB.
+Zones deleted since the last run are dropped from the cache.
+
+The zone cache must be turned on with cache.zone_ttl in the config. Once the
+cached zones expire, completion fetches and caches them again.`,
+	Example: `  cdnscli completion warm
+  cdnscli completion warm --provider cf-staging
+  # in ~/.bashrc, refresh the cache in the background:
+  (cdnscli completion warm --quiet &)`,
+	Run: completionWarmRun,
+}
+
+func init() {
+	completionWarmCmd.PersistentFlags().StringVarP(&providerName, "provider", "p", "", "provider name from config (default is the default provider)")
+}
+
+// initCompletion adds the warm command to the completion command and
+// completes the zone flags of all commands. Cobra adds the completion command
+// only when executing, so this is called from Execute.
+func initCompletion() {
+	rootCmd.InitDefaultCompletionCmd()
+	for _, c := range rootCmd.Commands() {
+		if c.Name() == "completion" {
+			c.AddCommand(completionWarmCmd)
+		}
+	}
+	registerZoneCompletion(rootCmd)
+}
+
+// registerZoneCompletion completes the zone flags declared by cmd and its subcommands.
+func registerZoneCompletion(cmd *cobra.Command) {
+	for _, name := range zoneFlagNames {
+		if cmd.PersistentFlags().Lookup(name) != nil || cmd.Flags().Lookup(name) != nil {
+			// An error means the flag is already completed
+			_ = cmd.RegisterFlagCompletionFunc(name, completeZones)
+		}
+	}
+	for _, c := range cmd.Commands() {
+		registerZoneCompletion(c)
+	}
+}
+
+// completeZones completes a zone name of the default provider.
+func completeZones(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	a, err := app.New(app.WithConfig(appConfig))
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var cache *providers.ZoneCache
+	if pc, err := a.ProviderConfig(""); err == nil {
+		cache = providers.ZoneCacheFromConfig(pc)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), getTimeout())
+	defer cancel()
+
+	names, err := zoneNames(cache, func() ([]models.Zone, error) {
+		return a.Provider().ListZones(ctx)
+	})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var matches []string
+	for _, name := range names {
+		if strings.HasPrefix(name, strings.ToLower(toComplete)) {
+			matches = append(matches, name)
+		}
+	}
+
+	return matches, cobra.ShellCompDirectiveNoFileComp
+}
+
+// zoneNames returns the sorted zone names from cache. When it has none, for
+// instance because they expired, the zones are listed and cached again.
+// A nil cache always lists the zones.
+func zoneNames(cache *providers.ZoneCache, list func() ([]models.Zone, error)) ([]string, error) {
+	if cache != nil {
+		if names := cache.Names(); len(names) > 0 {
+			return names, nil
+		}
+	}
+
+	zones, err := list()
+	if err != nil {
+		return nil, err
+	}
+	if cache != nil {
+		// Completion works without the cache, it is only slower
+		_ = cache.Warm(zones)
+	}
+
+	names := make([]string, 0, len(zones))
+	for _, z := range zones {
+		names = append(names, strings.ToLower(strings.TrimSuffix(z.Name, ".")))
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func completionWarmRun(cmd *cobra.Command, args []string) {
+	a, err := app.New(
+		app.WithConfig(appConfig),
+		app.WithOutputFormat(outputFormat),
+		app.WithOutputFields(outputFields),
+		app.WithOutputWriter(outputWriter),
+	)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	p, err := a.GetProvider(providerName)
+	if err != nil {
+		exitWithError(err)
+	}
+	pc, err := a.ProviderConfig(providerName)
+	if err != nil {
+		exitWithError(err)
+	}
+	cache := providers.ZoneCacheFromConfig(pc)
+	if cache == nil {
+		exitWithError(errors.New("the zone cache is off, turn it on with cache.zone_ttl in the config"))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), getTimeout())
+	defer cancel()
+
+	zones, err := p.ListZones(ctx)
+	if err != nil {
+		exitWithError(err)
+	}
+	if err := cache.Warm(zones); err != nil {
+		exitWithError(err)
+	}
+
+	if outputFormat == pp.FormatText {
+		fmt.Fprintf(outputWriter, "Cached %d zones of %s\n", len(zones), pc.Name())
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/mixanemca/cdnscli/internal/providers"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZoneNames(t *testing.T) {
+	zones := []models.Zone{
+		{ID: "1", Name: "example.org"},
+		{ID: "2", Name: "Example.COM."},
+	}
+	calls := 0
+	list := func() ([]models.Zone, error) {
+		calls++
+		return zones, nil
+	}
+
+	t.Run("empty cache is populated", func(t *testing.T) {
+		calls = 0
+		cache := providers.NewZoneCache(t.TempDir(), "cf", time.Hour, false)
+
+		names, err := zoneNames(cache, list)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"example.com", "example.org"}, names)
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, names, cache.Names())
+
+		// The next completion reads the cache only
+		names, err = zoneNames(cache, list)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"example.com", "example.org"}, names)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("stale cache is listed again", func(t *testing.T) {
+		calls = 0
+		dir := t.TempDir()
+		stale := `{"deleted.com": {"id": "9", "updated": "2020-01-01T00:00:00Z"}}`
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "zones-cf.json"), []byte(stale), 0o600))
+		cache := providers.NewZoneCache(dir, "cf", time.Hour, false)
+
+		names, err := zoneNames(cache, list)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"example.com", "example.org"}, names)
+		assert.Equal(t, 1, calls)
+		// Warming dropped the deleted zone
+		assert.Equal(t, names, cache.Names())
+		_, ok := cache.Get("deleted.com")
+		assert.False(t, ok)
+	})
+
+	t.Run("no cache", func(t *testing.T) {
+		calls = 0
+		names, err := zoneNames(nil, list)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"example.com", "example.org"}, names)
+		_, err = zoneNames(nil, list)
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		cache := providers.NewZoneCache(t.TempDir(), "cf", time.Hour, false)
+		_, err := zoneNames(cache, func() ([]models.Zone, error) {
+			return nil, errors.New("unauthorized")
+		})
+		assert.EqualError(t, err, "unauthorized")
+		assert.Empty(t, cache.Names())
+	})
+}
+
+func TestRegisterZoneCompletion(t *testing.T) {
+	root := &cobra.Command{Use: "root"}
+	list := &cobra.Command{Use: "list"}
+	list.PersistentFlags().StringP("zone", "z", "", "")
+	cp := &cobra.Command{Use: "copy"}
+	cp.Flags().String("from-zone", "", "")
+	cp.Flags().String("to-zone", "", "")
+	cp.Flags().String("name", "", "")
+	root.AddCommand(list, cp)
+
+	registerZoneCompletion(root)
+	// Registering twice is harmless
+	registerZoneCompletion(root)
+
+	for _, c := range []struct {
+		cmd  *cobra.Command
+		flag string
+	}{{list, "zone"}, {cp, "from-zone"}, {cp, "to-zone"}} {
+		_, ok := c.cmd.GetFlagCompletionFunc(c.flag)
+		assert.True(t, ok, c.flag)
+	}
+	_, ok := cp.GetFlagCompletionFunc("name")
+	assert.False(t, ok)
+}
@@ -0,0 +1,51 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mixanemca/cdnscli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// configSchemaCmd represents the config schema command
+var configSchemaCmd = &cobra.Command{
+	Args:  cobra.NoArgs,
+	Use:   "schema",
+	Short: "Print a JSON Schema of the config file",
+	Long: `Print a JSON Schema of the config file, for editors to validate and
+complete it. The schema is generated from the configuration cdnscli decodes,
+so it always matches the installed version.`,
+	Example: `  cdnscli config schema > ~/.cdnscli.schema.json
+  # then add to the top of ~/.cdnscli.yaml for yaml-language-server:
+  # yaml-language-server: $schema=./.cdnscli.schema.json`,
+	Run: configSchemaRun,
+}
+
+func init() {
+	configCmd.AddCommand(configSchemaCmd)
+}
+
+func configSchemaRun(cmd *cobra.Command, args []string) {
+	data, err := json.MarshalIndent(config.Schema(), "", "  ")
+	if err != nil {
+		exitWithError(err)
+	}
+	fmt.Fprintln(outputWriter, string(data))
+}
@@ -0,0 +1,100 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/mixanemca/cdnscli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// configSetSecretCmd represents the config set-secret command
+var configSetSecretCmd = &cobra.Command{
+	Args:  cobra.ExactArgs(1),
+	Use:   "set-secret service/user",
+	Short: "Store a secret in the system keyring for use as a *_keyring credential",
+	Long: `Store a secret in the system keyring. Reference it from the config file
+by adding _keyring to the credential name, e.g. api_token_keyring: cdnscli/cf-production.
+
+The secret is read from STDIN, so it does not end up in the shell history.`,
+	Example: `  cdnscli config set-secret cdnscli/cf-production
+  pass show cloudflare | cdnscli config set-secret cdnscli/cf-production`,
+	Run: configSetSecretRun,
+}
+
+func init() {
+	configCmd.AddCommand(configSetSecretCmd)
+}
+
+func configSetSecretRun(cmd *cobra.Command, args []string) {
+	ref := args[0]
+	if _, _, err := config.ParseKeyringRef(ref); err != nil {
+		exitWithError(err)
+	}
+
+	secret, err := readSecret(os.Stdin, os.Stderr)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if err := config.SetKeyringSecret(ref, secret); err != nil {
+		exitWithError(err)
+	}
+
+	if !quiet {
+		fmt.Fprintf(os.Stderr, "Secret %s stored in the keyring\n", ref)
+	}
+}
+
+// readSecret reads a secret from in. On a terminal it prompts on prompt and does
+// not echo the input, otherwise the first line of in is the secret.
+func readSecret(in *os.File, prompt io.Writer) (string, error) {
+	var (
+		line string
+		err  error
+	)
+
+	if term.IsTerminal(in.Fd()) {
+		fmt.Fprint(prompt, "Secret: ")
+		var b []byte
+		b, err = term.ReadPassword(in.Fd())
+		fmt.Fprintln(prompt)
+		line = string(b)
+	} else {
+		line, err = bufio.NewReader(in).ReadString('\n')
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret: %w", err)
+	}
+
+	secret := strings.TrimSpace(line)
+	if secret == "" {
+		return "", errors.New("secret must not be empty")
+	}
+
+	return secret, nil
+}
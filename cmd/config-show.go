@@ -0,0 +1,87 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/mixanemca/cdnscli/internal/config"
+	pp "github.com/mixanemca/cdnscli/internal/prettyprint"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// configShowCmd represents the config show command
+var configShowCmd = &cobra.Command{
+	Args:  cobra.NoArgs,
+	Use:   "show",
+	Short: "Print the effective configuration with secrets redacted",
+	Long: `Print the effective configuration: the config file with the profile and
+command line flags applied. It is printed as YAML, or as JSON with -o json.
+Credential values are replaced by ***, so the output is safe to share.`,
+	Example: `  cdnscli config show
+  cdnscli config show --profile staging --timeout 30s
+  cdnscli config show -o json | jq .providers`,
+	Run: configShowRun,
+}
+
+func init() {
+	configCmd.AddCommand(configShowCmd)
+}
+
+func configShowRun(cmd *cobra.Command, args []string) {
+	if appConfigErr != nil {
+		exitWithError(appConfigErr)
+	}
+	if err := showConfig(outputWriter, outputFormat, appConfig); err != nil {
+		exitWithError(err)
+	}
+}
+
+// showConfig writes cfg to w in the given format with the credentials redacted.
+// JSON uses the YAML keys and durations, so both formats read the same.
+func showConfig(w io.Writer, format pp.OutputFormat, cfg *config.Config) error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg.Redacted()); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+
+	switch format {
+	case pp.FormatNone:
+		return nil
+	case pp.FormatJSON, pp.FormatJSONL:
+		var v map[string]interface{}
+		if err := yaml.Unmarshal(buf.Bytes(), &v); err != nil {
+			return err
+		}
+		enc := json.NewEncoder(w)
+		if format == pp.FormatJSON && cfg.JSONPretty != nil && *cfg.JSONPretty {
+			enc.SetIndent("", "  ")
+		}
+		return enc.Encode(v)
+	}
+
+	_, err := buf.WriteTo(w)
+	return err
+}
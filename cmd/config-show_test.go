@@ -0,0 +1,99 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mixanemca/cdnscli/internal/config"
+	pp "github.com/mixanemca/cdnscli/internal/prettyprint"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShowConfig(t *testing.T) {
+	pretty := false
+	cfg := &config.Config{
+		DefaultProvider: "cf",
+		ClientTimeout:   10 * time.Second,
+		JSONPretty:      &pretty,
+		Providers: map[string]config.ProviderConfig{
+			"cf": {
+				Type:        "cloudflare",
+				Credentials: map[string]interface{}{"api_key": "secret-key", "email": "user@example.com"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, showConfig(&buf, pp.FormatText, cfg))
+	assert.NotContains(t, buf.String(), "secret-key")
+	assert.NotContains(t, buf.String(), "user@example.com")
+	assert.Contains(t, buf.String(), "      api_key: '***'\n")
+	assert.Contains(t, buf.String(), "default-provider: cf\n")
+	assert.Contains(t, buf.String(), "client-timeout: 10s\n")
+
+	buf.Reset()
+	require.NoError(t, showConfig(&buf, pp.FormatJSON, cfg))
+	assert.NotContains(t, buf.String(), "secret-key")
+	var v map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &v))
+	assert.Equal(t, "10s", v["client-timeout"])
+	assert.Equal(t, map[string]interface{}{"api_key": "***", "email": "***"},
+		v["providers"].(map[string]interface{})["cf"].(map[string]interface{})["credentials"])
+
+	buf.Reset()
+	require.NoError(t, showConfig(&buf, pp.FormatNone, cfg))
+	assert.Empty(t, buf.String())
+}
+
+func TestShowConfig_FlagOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cdnscli.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`default_provider: cf
+client_timeout: 5s
+providers:
+  cf:
+    type: cloudflare
+    credentials:
+      api_token: secret-token
+`), 0o600))
+
+	viper.Reset()
+	cfgFile, clientTimeout, debug = path, 42*time.Second, true
+	t.Cleanup(func() {
+		viper.Reset()
+		cfgFile, clientTimeout, debug = "", 10*time.Second, false
+		appConfig, appConfigErr = nil, nil
+	})
+	initConfig()
+	require.NoError(t, appConfigErr)
+
+	var buf bytes.Buffer
+	require.NoError(t, showConfig(&buf, pp.FormatText, appConfig))
+	assert.Contains(t, buf.String(), "default-provider: cf\n")
+	// The flags win over the file
+	assert.Contains(t, buf.String(), "client-timeout: 42s\n")
+	assert.Contains(t, buf.String(), "debug: true\n")
+	assert.Contains(t, buf.String(), "api_token: '***'\n")
+	assert.NotContains(t, buf.String(), "secret-token")
+}
@@ -0,0 +1,67 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+)
+
+var (
+	// errNotConfirmed is returned when the user does not answer yes.
+	errNotConfirmed = errors.New("aborted")
+	// errConfirmNoTTY is returned when there is no terminal to ask on and --yes is not set.
+	errConfirmNoTTY = errors.New("STDIN is not a terminal, pass --yes to confirm")
+)
+
+// confirmStdin asks question on STDERR and reads the answer from STDIN,
+// unless yes is set. See confirm.
+func confirmStdin(question string, yes bool) error {
+	return confirm(os.Stdin, os.Stderr, question, yes, term.IsTerminal(os.Stdin.Fd()))
+}
+
+// confirm asks question on prompt and reads the answer from in. It returns nil
+// when yes is set or the answer is y or yes, and errNotConfirmed otherwise.
+// Without a terminal nobody can answer, so it fails with errConfirmNoTTY
+// unless yes is set.
+func confirm(in io.Reader, prompt io.Writer, question string, yes, tty bool) error {
+	if yes {
+		return nil
+	}
+	if !tty {
+		return errConfirmNoTTY
+	}
+
+	fmt.Fprintf(prompt, "%s [y/N]: ", question)
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read answer: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return nil
+	default:
+		return errNotConfirmed
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		answer string
+		want   error
+	}{
+		{answer: "y\n", want: nil},
+		{answer: "YES\n", want: nil},
+		{answer: " yes ", want: nil},
+		{answer: "n\n", want: errNotConfirmed},
+		{answer: "\n", want: errNotConfirmed},
+		{answer: "", want: errNotConfirmed},
+	}
+	for _, tt := range tests {
+		var prompt bytes.Buffer
+		err := confirm(strings.NewReader(tt.answer), &prompt, "Delete record?", false, true)
+		assert.Equal(t, tt.want, err, "answer %q", tt.answer)
+		assert.Equal(t, "Delete record? [y/N]: ", prompt.String())
+	}
+}
+
+func TestConfirm_Yes(t *testing.T) {
+	for _, tty := range []bool{true, false} {
+		var prompt bytes.Buffer
+		assert.NoError(t, confirm(strings.NewReader("n\n"), &prompt, "Delete record?", true, tty))
+		assert.Empty(t, prompt.String())
+	}
+}
+
+func TestConfirm_NoTTY(t *testing.T) {
+	var prompt bytes.Buffer
+	err := confirm(strings.NewReader("y\n"), &prompt, "Delete record?", false, false)
+	assert.ErrorIs(t, err, errConfirmNoTTY)
+	assert.Empty(t, prompt.String())
+}
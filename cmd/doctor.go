@@ -0,0 +1,303 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mixanemca/cdnscli/internal/app"
+	"github.com/mixanemca/cdnscli/internal/config"
+	pp "github.com/mixanemca/cdnscli/internal/prettyprint"
+	"github.com/mixanemca/cdnscli/internal/providers"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Aliases: []string{"diag"},
+	Args:    cobra.NoArgs,
+	Use:     "doctor",
+	Short:   "Check the configuration and provider credentials",
+	Long: `Check that the config file exists and is valid, that the default provider
+is resolvable, that the credentials of every provider verify and that its API
+answers a ping. Each failed check comes with a hint on how to fix it.`,
+	Example: `  cdnscli doctor
+  cdnscli doctor --config ./cdnscli.yaml`,
+	Run: doctorCmdRun,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+// doctorCheck is the result of a single diagnostic check.
+type doctorCheck struct {
+	Name    string `json:"name"`
+	OK      bool   `json:"ok"`
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+// doctorEnv is what the checks inspect. Providers are created by createProvider,
+// so tests can run the checks without calling any API.
+type doctorEnv struct {
+	cfg            *config.Config
+	configFile     string
+	loadErr        error
+	createProvider func(name string) (providers.Provider, error)
+}
+
+func doctorCmdRun(cmd *cobra.Command, args []string) {
+	// Load again, initConfig only warns about a broken config file.
+	// Stdin cannot be read twice, so its load error is kept by initConfig.
+	cfg, err := appConfig, appConfigErr
+	if cfgFile != config.StdinConfigFile {
+		cfg, err = config.Load(cfgFile, config.WithProfile(profile))
+	}
+	if err != nil {
+		cfg = appConfig
+	}
+
+	checks := runDoctorChecks(doctorEnv{
+		cfg:        cfg,
+		configFile: viper.ConfigFileUsed(),
+		loadErr:    err,
+		createProvider: func(name string) (providers.Provider, error) {
+			return app.CheckProvider(cfg, name)
+		},
+	})
+
+	if err := printDoctorChecks(outputWriter, outputFormat, checks); err != nil {
+		exitWithError(err)
+	}
+	if failed := countFailed(checks); failed > 0 {
+		fmt.Fprintf(os.Stderr, "%d of %d checks failed\n", failed, len(checks))
+		os.Exit(exitError)
+	}
+}
+
+// runDoctorChecks runs all checks in the order they are printed.
+func runDoctorChecks(env doctorEnv) []doctorCheck {
+	checks := []doctorCheck{checkConfigFile(env)}
+	if env.cfg == nil {
+		return checks
+	}
+
+	checks = append(checks,
+		checkConfigValid(env.cfg),
+		checkOutputFormat(env.cfg),
+		checkDefaultProvider(env.cfg),
+	)
+
+	for _, name := range providerNames(env.cfg) {
+		p, err := env.createProvider(name)
+		checks = append(checks, checkProvider(name, env.cfg.Providers[name], err))
+		if err == nil {
+			checks = append(checks, checkProviderPing(name, p))
+		}
+	}
+
+	return checks
+}
+
+func checkConfigFile(env doctorEnv) doctorCheck {
+	c := doctorCheck{Name: "config file"}
+	switch {
+	case env.loadErr != nil:
+		c.Message = env.loadErr.Error()
+		c.Hint = "fix the YAML syntax of the config file"
+	case env.configFile == "":
+		c.Message = "no config file found"
+		c.Hint = "copy cdnscli.yaml.example to ~/.cdnscli.yaml or pass --config"
+	default:
+		c.OK = true
+		c.Message = env.configFile
+	}
+	return c
+}
+
+func checkConfigValid(cfg *config.Config) doctorCheck {
+	c := doctorCheck{Name: "config is valid"}
+	if err := cfg.Validate(); err != nil {
+		c.Message = err.Error()
+		c.Hint = "compare the failing fields with cdnscli.yaml.example"
+		return c
+	}
+	c.OK = true
+	c.Message = "ok"
+	return c
+}
+
+func checkOutputFormat(cfg *config.Config) doctorCheck {
+	c := doctorCheck{Name: "output format"}
+
+	format := cfg.OutputFormat
+	if format == "" {
+		format = config.DefaultOutputFormat
+	}
+	for _, names := range outputFormatList {
+		for _, n := range names {
+			if strings.EqualFold(n, format) {
+				c.OK = true
+				c.Message = format
+				return c
+			}
+		}
+	}
+
+	c.Message = fmt.Sprintf("unknown output format %q", format)
+	c.Hint = "set output-format to one of: text, json, jsonl, none, markdown"
+	return c
+}
+
+func checkDefaultProvider(cfg *config.Config) doctorCheck {
+	c := doctorCheck{Name: "default provider"}
+
+	switch {
+	case len(cfg.Providers) == 0:
+		c.Message = "no providers configured"
+		c.Hint = "add a provider under providers: in the config file"
+	case cfg.DefaultProvider == "" && len(cfg.Providers) > 1:
+		c.Message = "default-provider is not set"
+		c.Hint = "set default-provider to one of: " + strings.Join(providerNames(cfg), ", ")
+	case cfg.DefaultProvider == "":
+		c.OK = true
+		c.Message = providerNames(cfg)[0] + " (the only provider)"
+	default:
+		if _, err := cfg.GetProvider(cfg.DefaultProvider); err != nil {
+			c.Message = err.Error()
+			c.Hint = "set default-provider to one of: " + strings.Join(providerNames(cfg), ", ")
+			return c
+		}
+		c.OK = true
+		c.Message = cfg.DefaultProvider
+	}
+
+	return c
+}
+
+func checkProvider(name string, pc config.ProviderConfig, err error) doctorCheck {
+	c := doctorCheck{Name: "provider " + name}
+	if err == nil {
+		c.OK = true
+		c.Message = pc.CanonicalType() + ", credentials verified"
+		return c
+	}
+
+	c.Message = err.Error()
+
+	switch {
+	case errors.Is(err, providers.ErrCredentials):
+		c.Hint = fmt.Sprintf("check providers.%s.credentials, the token may be wrong, expired or lack permissions", name)
+	case errors.Is(err, providers.ErrUnsupported):
+		c.Hint = fmt.Sprintf("set providers.%s.type to a supported type, see cdnscli providers list --supported", name)
+	default:
+		c.Hint = fmt.Sprintf("check providers.%s in the config file", name)
+	}
+
+	return c
+}
+
+// checkProviderPing pings the API of a provider created without errors.
+func checkProviderPing(name string, p providers.Provider) doctorCheck {
+	c := doctorCheck{Name: "provider " + name + " ping"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), getTimeout())
+	defer cancel()
+
+	start := time.Now()
+	if err := p.Ping(ctx); err != nil {
+		c.Message = err.Error()
+		switch {
+		case errors.Is(err, providers.ErrCredentials):
+			c.Hint = fmt.Sprintf("check providers.%s.credentials, the token may have been revoked or lack permissions", name)
+		case errors.Is(err, context.DeadlineExceeded):
+			c.Hint = "the API did not answer in time, check the network or raise --timeout"
+		default:
+			c.Hint = "check the network connection and the API URL of the provider"
+		}
+		return c
+	}
+
+	c.OK = true
+	c.Message = fmt.Sprintf("API answered in %s", time.Since(start).Round(time.Millisecond))
+	return c
+}
+
+// providerNames returns the sorted names of the configured providers.
+func providerNames(cfg *config.Config) []string {
+	names := make([]string, 0, len(cfg.Providers))
+	for name := range cfg.Providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func countFailed(checks []doctorCheck) int {
+	failed := 0
+	for _, c := range checks {
+		if !c.OK {
+			failed++
+		}
+	}
+	return failed
+}
+
+// printDoctorChecks writes the checklist to w in the given format.
+func printDoctorChecks(w io.Writer, format pp.OutputFormat, checks []doctorCheck) error {
+	switch format {
+	case pp.FormatNone:
+		return nil
+	case pp.FormatJSON:
+		return json.NewEncoder(w).Encode(checks)
+	case pp.FormatJSONL:
+		enc := json.NewEncoder(w)
+		for _, c := range checks {
+			if err := enc.Encode(c); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, c := range checks {
+		mark := "[ OK ]"
+		if !c.OK {
+			mark = "[FAIL]"
+		}
+		if _, err := fmt.Fprintf(w, "%s %s: %s\n", mark, c.Name, c.Message); err != nil {
+			return err
+		}
+		if c.Hint != "" {
+			if _, err := fmt.Fprintf(w, "       hint: %s\n", c.Hint); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
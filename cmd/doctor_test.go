@@ -0,0 +1,163 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mixanemca/cdnscli/internal/config"
+	pp "github.com/mixanemca/cdnscli/internal/prettyprint"
+	"github.com/mixanemca/cdnscli/internal/providers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func doctorTestConfig() *config.Config {
+	return &config.Config{
+		DefaultProvider: "cf",
+		ClientTimeout:   10 * time.Second,
+		OutputFormat:    "text",
+		Providers: map[string]config.ProviderConfig{
+			"cf": {
+				Type:        "cloudflare",
+				Credentials: map[string]interface{}{"api_token": "token"},
+			},
+			"pdns": {
+				Type:        "powerdns",
+				Credentials: map[string]interface{}{"api_url": "http://127.0.0.1:8081", "api_key": "key"},
+			},
+		},
+	}
+}
+
+// pingProvider is a providers.Provider whose Ping returns err.
+type pingProvider struct {
+	providers.Provider
+	err error
+}
+
+func (p *pingProvider) Ping(ctx context.Context) error {
+	return p.err
+}
+
+func TestRunDoctorChecks_AllPass(t *testing.T) {
+	var created []string
+	checks := runDoctorChecks(doctorEnv{
+		cfg:        doctorTestConfig(),
+		configFile: "/home/user/.cdnscli.yaml",
+		createProvider: func(name string) (providers.Provider, error) {
+			created = append(created, name)
+			return &pingProvider{}, nil
+		},
+	})
+
+	names := make([]string, 0, len(checks))
+	for _, c := range checks {
+		names = append(names, c.Name)
+		assert.True(t, c.OK, "%s: %s", c.Name, c.Message)
+		assert.Empty(t, c.Hint)
+	}
+	assert.Equal(t, []string{"config file", "config is valid", "output format", "default provider", "provider cf", "provider cf ping", "provider pdns", "provider pdns ping"}, names)
+	assert.Equal(t, []string{"cf", "pdns"}, created)
+	assert.Equal(t, 0, countFailed(checks))
+}
+
+func TestRunDoctorChecks_Failures(t *testing.T) {
+	cfg := doctorTestConfig()
+	cfg.DefaultProvider = "missing"
+	cfg.OutputFormat = "yaml"
+
+	checks := runDoctorChecks(doctorEnv{
+		cfg: cfg,
+		createProvider: func(name string) (providers.Provider, error) {
+			if name == "cf" {
+				return nil, providers.NewProviderCreationError(name, "cloudflare", "factory creation failed",
+					providers.NewProviderCredentialsError("cloudflare", "failed to verify API credentials", nil))
+			}
+			return &pingProvider{err: errors.New("dial tcp 127.0.0.1:8081: connection refused")}, nil
+		},
+	})
+
+	byName := make(map[string]doctorCheck)
+	for _, c := range checks {
+		byName[c.Name] = c
+	}
+
+	assert.False(t, byName["config file"].OK)
+	assert.Equal(t, "no config file found", byName["config file"].Message)
+	assert.False(t, byName["config is valid"].OK)
+	assert.False(t, byName["output format"].OK)
+	assert.Equal(t, "set output-format to one of: text, json, jsonl, none, markdown", byName["output format"].Hint)
+	assert.False(t, byName["default provider"].OK)
+	assert.Equal(t, "set default-provider to one of: cf, pdns", byName["default provider"].Hint)
+	assert.False(t, byName["provider cf"].OK)
+	assert.Contains(t, byName["provider cf"].Hint, "check providers.cf.credentials")
+	// A provider that failed to be created is not pinged
+	assert.NotContains(t, byName, "provider cf ping")
+	assert.True(t, byName["provider pdns"].OK)
+	assert.False(t, byName["provider pdns ping"].OK)
+	assert.Equal(t, "check the network connection and the API URL of the provider", byName["provider pdns ping"].Hint)
+	assert.Equal(t, 6, countFailed(checks))
+}
+
+func TestRunDoctorChecks_BrokenConfigFile(t *testing.T) {
+	checks := runDoctorChecks(doctorEnv{loadErr: errors.New("failed to read config file: yaml: line 3")})
+
+	require.Len(t, checks, 1)
+	assert.False(t, checks[0].OK)
+	assert.Equal(t, "fix the YAML syntax of the config file", checks[0].Hint)
+}
+
+func TestCheckDefaultProvider(t *testing.T) {
+	cfg := doctorTestConfig()
+	cfg.DefaultProvider = ""
+	assert.False(t, checkDefaultProvider(cfg).OK, "several providers need a default")
+
+	delete(cfg.Providers, "pdns")
+	c := checkDefaultProvider(cfg)
+	assert.True(t, c.OK)
+	assert.Equal(t, "cf (the only provider)", c.Message)
+
+	cfg.Providers = nil
+	assert.Equal(t, "no providers configured", checkDefaultProvider(cfg).Message)
+}
+
+func TestPrintDoctorChecks(t *testing.T) {
+	checks := []doctorCheck{
+		{Name: "config file", OK: true, Message: "/home/user/.cdnscli.yaml"},
+		{Name: "provider cf", Message: "bad token", Hint: "check providers.cf.credentials"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, printDoctorChecks(&buf, pp.FormatText, checks))
+	assert.Equal(t, "[ OK ] config file: /home/user/.cdnscli.yaml\n"+
+		"[FAIL] provider cf: bad token\n"+
+		"       hint: check providers.cf.credentials\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, printDoctorChecks(&buf, pp.FormatJSONL, checks))
+	assert.Equal(t, `{"name":"config file","ok":true,"message":"/home/user/.cdnscli.yaml"}`+"\n"+
+		`{"name":"provider cf","ok":false,"message":"bad token","hint":"check providers.cf.credentials"}`+"\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, printDoctorChecks(&buf, pp.FormatNone, checks))
+	assert.Empty(t, buf.String())
+}
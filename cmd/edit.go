@@ -0,0 +1,166 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// errNoChanges is returned by editRecord when the record was saved unchanged.
+var errNoChanges = errors.New("no changes made, update aborted")
+
+// editHeader is written above the record opened in the editor.
+const editHeader = `# Edit the record below, then save and close the editor to update it.
+# Leave it unchanged to abort. Lines starting with '#' are ignored.
+`
+
+// editableRecord is the part of a record that can be changed in the editor.
+type editableRecord struct {
+	Name     string   `yaml:"name"`
+	Type     string   `yaml:"type"`
+	Content  string   `yaml:"content"`
+	TTL      int      `yaml:"ttl"`
+	Proxied  bool     `yaml:"proxied"`
+	Priority int      `yaml:"priority,omitempty"`
+	Comment  string   `yaml:"comment,omitempty"`
+	Tags     []string `yaml:"tags,omitempty,flow"`
+}
+
+// marshalEditable serializes the editable fields of a record to YAML.
+func marshalEditable(rr models.DNSRecord) ([]byte, error) {
+	data, err := yaml.Marshal(editableRecord{
+		Name:     models.NameToUnicode(rr.Name),
+		Type:     rr.Type,
+		Content:  rr.Content,
+		TTL:      rr.TTL,
+		Proxied:  rr.Proxied,
+		Priority: rr.Priority,
+		Comment:  rr.Comment,
+		Tags:     rr.Tags,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(editHeader), data...), nil
+}
+
+// parseEditable applies the YAML written by marshalEditable, possibly edited,
+// to a copy of rr. Unknown fields are an error, the record ID and zone are kept.
+func parseEditable(rr models.DNSRecord, data []byte) (models.DNSRecord, error) {
+	var e editableRecord
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&e); err != nil {
+		return rr, fmt.Errorf("invalid record: %w", err)
+	}
+
+	name, err := models.NameToASCII(e.Name)
+	if err != nil {
+		return rr, err
+	}
+
+	edited := rr
+	edited.Name = name
+	edited.Type = strings.ToUpper(e.Type)
+	edited.Content = e.Content
+	edited.TTL = e.TTL
+	edited.Proxied = e.Proxied
+	edited.Priority = e.Priority
+	edited.Comment = e.Comment
+	edited.Tags = e.Tags
+	if err := edited.Validate(); err != nil {
+		return rr, err
+	}
+
+	return edited, nil
+}
+
+// editRecord lets edit change rr serialized to YAML in a temporary file and
+// returns the edited record with its changes. errNoChanges is returned when
+// the record was left unchanged.
+func editRecord(rr models.DNSRecord, edit func(path string) error) (models.DNSRecord, []models.FieldChange, error) {
+	data, err := marshalEditable(rr)
+	if err != nil {
+		return rr, nil, err
+	}
+
+	f, err := os.CreateTemp("", "cdnscli-*.yaml")
+	if err != nil {
+		return rr, nil, err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return rr, nil, err
+	}
+	if err := f.Close(); err != nil {
+		return rr, nil, err
+	}
+
+	if err := edit(f.Name()); err != nil {
+		return rr, nil, err
+	}
+
+	edited, err := os.ReadFile(f.Name())
+	if err != nil {
+		return rr, nil, err
+	}
+	updated, err := parseEditable(rr, edited)
+	if err != nil {
+		return rr, nil, err
+	}
+
+	changes := models.DiffRecord(rr, updated)
+	if len(changes) == 0 {
+		return rr, nil, errNoChanges
+	}
+
+	return updated, changes, nil
+}
+
+// runEditor opens path in $VISUAL or $EDITOR, falling back to vi.
+func runEditor(path string) error {
+	// The editor may come with arguments, e.g. "code --wait"
+	args := strings.Fields(os.Getenv("VISUAL"))
+	if len(args) == 0 {
+		args = strings.Fields(os.Getenv("EDITOR"))
+	}
+	if len(args) == 0 {
+		args = []string{"vi"}
+	}
+
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor %s failed: %w", args[0], err)
+	}
+
+	return nil
+}
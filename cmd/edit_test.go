@@ -0,0 +1,96 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditableRoundTrip(t *testing.T) {
+	rr := models.DNSRecord{
+		ID: "1", ZoneID: "42", Name: "www.example.com", Type: "A", Content: "192.0.2.1",
+		TTL: 300, Proxied: true, Comment: "web", Tags: []string{"env:prod"},
+	}
+
+	data, err := marshalEditable(rr)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), editHeader))
+	assert.Contains(t, string(data), "content: 192.0.2.1\n")
+	assert.Contains(t, string(data), "tags: ['env:prod']\n")
+	assert.NotContains(t, string(data), "42")
+
+	parsed, err := parseEditable(rr, data)
+	require.NoError(t, err)
+	assert.Equal(t, rr, parsed)
+}
+
+func TestParseEditable(t *testing.T) {
+	rr := models.DNSRecord{ID: "1", ZoneID: "42", Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 300}
+
+	parsed, err := parseEditable(rr, []byte("name: www.example.com\ntype: aaaa\ncontent: 2001:db8::1\nttl: 600\n"))
+	require.NoError(t, err)
+	assert.Equal(t, models.DNSRecord{ID: "1", ZoneID: "42", Name: "www.example.com", Type: "AAAA", Content: "2001:db8::1", TTL: 600}, parsed)
+
+	for name, data := range map[string]string{
+		"invalid YAML":    "name: [www\n",
+		"unknown field":   "name: www.example.com\ntype: A\ncontent: 192.0.2.1\nzone: example.com\n",
+		"invalid content": "name: www.example.com\ntype: A\ncontent: not-an-ip\n",
+		"empty name":      "type: A\ncontent: 192.0.2.1\n",
+		"invalid MX":      "name: example.com\ntype: MX\ncontent: 10 mail example.com\n",
+		"negative TTL":    "name: www.example.com\ntype: A\ncontent: 192.0.2.1\nttl: -1\n",
+	} {
+		_, err := parseEditable(rr, []byte(data))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestEditRecord(t *testing.T) {
+	rr := models.DNSRecord{ID: "1", Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 300}
+
+	edited, changes, err := editRecord(rr, func(path string) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, []byte(strings.Replace(string(data), "192.0.2.1", "192.0.2.2", 1)), 0o600)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "192.0.2.2", edited.Content)
+	assert.Equal(t, "1", edited.ID)
+	assert.Equal(t, []models.FieldChange{{Field: "content", Old: "192.0.2.1", New: "192.0.2.2"}}, changes)
+
+	// Saving the record unchanged aborts
+	_, _, err = editRecord(rr, func(string) error { return nil })
+	assert.ErrorIs(t, err, errNoChanges)
+
+	// So does a failing editor
+	editorErr := errors.New("editor failed")
+	_, _, err = editRecord(rr, func(string) error { return editorErr })
+	assert.ErrorIs(t, err, editorErr)
+
+	// The temporary file is removed
+	var tmp string
+	_, _, _ = editRecord(rr, func(path string) error { tmp = path; return nil })
+	assert.NoFileExists(t, tmp)
+}
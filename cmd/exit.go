@@ -0,0 +1,53 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/mixanemca/cdnscli/internal/providers"
+)
+
+// Exit codes of cdnscli commands, so scripts can tell an expected miss from a failure.
+const (
+	exitOK       = 0
+	exitError    = 1
+	exitNotFound = 2
+	exitAuth     = 3
+)
+
+// exitCode maps an error to the process exit code.
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, providers.ErrCredentials):
+		return exitAuth
+	case errors.Is(err, providers.ErrNotFound):
+		return exitNotFound
+	default:
+		return exitError
+	}
+}
+
+// exitWithError prints err to STDERR and exits with the code matching it.
+func exitWithError(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(exitCode(err))
+}
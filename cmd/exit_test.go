@@ -0,0 +1,47 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "no error", err: nil, want: exitOK},
+		{name: "generic error", err: errors.New("boom"), want: exitError},
+		{name: "not found", err: providers.NewNotFoundError("zone", "example.com", nil), want: exitNotFound},
+		{name: "wrapped not found", err: fmt.Errorf("lookup: %w", providers.NewNotFoundError("record", "www.example.com", nil)), want: exitNotFound},
+		{name: "credentials", err: providers.NewProviderCredentialsError("cloudflare", "request rejected", nil), want: exitAuth},
+		{name: "config error", err: providers.NewProviderConfigError("cf", "cloudflare", "api_token", "missing", nil), want: exitError},
+		{name: "provider not found", err: providers.NewProviderNotFoundError("cf", nil), want: exitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, exitCode(tt.err))
+		})
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/mixanemca/cdnscli/internal/app"
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/mixanemca/cdnscli/internal/providers"
+	"github.com/mixanemca/cdnscli/internal/workerpool"
+	"github.com/spf13/cobra"
+)
+
+// metricsCmd represents the metrics command
+var metricsCmd = &cobra.Command{
+	Args:  cobra.NoArgs,
+	Use:   "metrics",
+	Short: "Print the number of records per zone and type as Prometheus metrics",
+	Long: `Print the number of records of every zone by type in the Prometheus text
+exposition format, for inventory dashboards:
+
+  cdnscli_records{zone="example.com",type="A"} 12
+
+The output may be served by the node_exporter textfile collector. Zones are
+listed --concurrency at a time; when a zone cannot be listed nothing is printed,
+so a dashboard never shows partial counts.`,
+	Example: `  cdnscli metrics
+  cdnscli metrics --zone example.com
+  cdnscli metrics > /var/lib/node_exporter/textfile/cdnscli.prom`,
+	Run: metricsRun,
+}
+
+func init() {
+	rootCmd.AddCommand(metricsCmd)
+
+	metricsCmd.PersistentFlags().StringVarP(&zone, "zone", "z", "", "only count the records of this zone")
+	addConcurrencyFlag(metricsCmd)
+}
+
+func metricsRun(cmd *cobra.Command, args []string) {
+	a, err := app.New(
+		app.WithConfig(appConfig),
+		app.WithOutputFormat(outputFormat),
+		app.WithOutputFields(outputFields),
+		app.WithOutputWriter(outputWriter),
+	)
+	if err != nil {
+		exitWithError(err)
+	}
+	if err := workerpool.ValidateSize(concurrency); err != nil {
+		exitWithError(err)
+	}
+
+	ctx, stop := interruptContext(context.Background())
+	defer stop()
+
+	var zones []models.Zone
+	if zone != "" {
+		zones = []models.Zone{{Name: zone}}
+	} else {
+		listCtx, cancel := context.WithTimeout(ctx, getTimeout())
+		zones, err = a.Provider().ListZones(listCtx)
+		cancel()
+		if err != nil {
+			exitWithError(err)
+		}
+	}
+
+	counts, err := zoneRecordCounts(ctx, a.Provider(), zones, concurrency)
+	if err != nil {
+		exitWithError(err)
+	}
+	if err := writeRecordMetrics(outputWriter, counts); err != nil {
+		exitWithError(err)
+	}
+}
+
+// recordCounts are the numbers of records of a zone by type.
+type recordCounts map[string]int
+
+// countRecords returns the number of records of rrset by upper-cased type.
+func countRecords(rrset []models.DNSRecord) recordCounts {
+	counts := make(recordCounts)
+	for _, rr := range rrset {
+		counts[strings.ToUpper(rr.Type)]++
+	}
+	return counts
+}
+
+// zoneRecordCounts lists the records of zones, n zones at a time, and returns
+// their counts by zone name in ASCII without the trailing dot. The first zone
+// that cannot be listed fails the whole call.
+func zoneRecordCounts(ctx context.Context, p providers.Provider, zones []models.Zone, n int) (map[string]recordCounts, error) {
+	results := workerpool.Run(ctx, n, zones, func(ctx context.Context, z models.Zone) (recordCounts, error) {
+		ctx, cancel := context.WithTimeout(ctx, getTimeout())
+		defer cancel()
+		rrset, err := p.ListRecords(ctx, models.ListDNSRecordsParams{ZoneName: z.Name})
+		if err != nil {
+			return nil, err
+		}
+		return countRecords(rrset), nil
+	})
+
+	counts := make(map[string]recordCounts, len(zones))
+	for i, r := range results {
+		name := zones[i].Name
+		if !r.Done {
+			return nil, errBatchInterrupted
+		}
+		if r.Err != nil {
+			return nil, fmt.Errorf("%s: %w", models.NameToUnicode(name), r.Err)
+		}
+		ascii, err := models.NameToASCII(name)
+		if err != nil {
+			return nil, err
+		}
+		counts[strings.ToLower(strings.TrimSuffix(ascii, "."))] = r.Value
+	}
+	return counts, nil
+}
+
+// writeRecordMetrics writes counts to w in the Prometheus text exposition
+// format, one cdnscli_records sample per zone and type, sorted by both.
+func writeRecordMetrics(w io.Writer, counts map[string]recordCounts) error {
+	var b strings.Builder
+	b.WriteString("# HELP cdnscli_records Number of DNS records by zone and type.\n")
+	b.WriteString("# TYPE cdnscli_records gauge\n")
+
+	zones := make([]string, 0, len(counts))
+	for z := range counts {
+		zones = append(zones, z)
+	}
+	sort.Strings(zones)
+	for _, z := range zones {
+		types := make([]string, 0, len(counts[z]))
+		for t := range counts[z] {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+		for _, t := range types {
+			fmt.Fprintf(&b, "cdnscli_records{zone=\"%s\",type=\"%s\"} %d\n", escapeLabelValue(z), escapeLabelValue(t), counts[z][t])
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// escapeLabelValue escapes backslashes, double quotes and newlines of a label value.
+func escapeLabelValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,74 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountRecords(t *testing.T) {
+	assert.Equal(t, recordCounts{"A": 2, "MX": 1, "TXT": 1}, countRecords([]models.DNSRecord{
+		{Type: "A"}, {Type: "a"}, {Type: "MX"}, {Type: "TXT"},
+	}))
+	assert.Empty(t, countRecords(nil))
+}
+
+func TestZoneRecordCounts(t *testing.T) {
+	p := &exportProvider{
+		rrsets: map[string][]models.DNSRecord{
+			"example.com": {{Type: "A"}, {Type: "A"}, {Type: "NS"}},
+			"пример.рф":   {{Type: "AAAA"}},
+			"empty.net":   nil,
+		},
+	}
+
+	counts, err := zoneRecordCounts(context.Background(), p, []models.Zone{{Name: "example.com"}, {Name: "пример.рф"}, {Name: "Empty.NET."}}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]recordCounts{
+		"example.com":           {"A": 2, "NS": 1},
+		"xn--e1afmkfd.xn--p1ai": {"AAAA": 1},
+		"empty.net":             {},
+	}, counts)
+
+	p.listErr = map[string]error{"example.com": errors.New("boom")}
+	_, err = zoneRecordCounts(context.Background(), p, []models.Zone{{Name: "example.com"}}, 1)
+	assert.EqualError(t, err, "example.com: boom")
+}
+
+func TestWriteRecordMetrics(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeRecordMetrics(&buf, map[string]recordCounts{
+		"example.org": {"TXT": 1, "A": 3},
+		"example.com": {"A": 12},
+		`we"ird\zone`: {"A": 1},
+		"empty.net":   {},
+	}))
+	assert.Equal(t, `# HELP cdnscli_records Number of DNS records by zone and type.
+# TYPE cdnscli_records gauge
+cdnscli_records{zone="example.com",type="A"} 12
+cdnscli_records{zone="example.org",type="A"} 3
+cdnscli_records{zone="example.org",type="TXT"} 1
+cdnscli_records{zone="we\"ird\\zone",type="A"} 1
+`, buf.String())
+}
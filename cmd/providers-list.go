@@ -0,0 +1,86 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/mixanemca/cdnscli/internal/app"
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var (
+	capabilities bool
+	supported    bool
+)
+
+// providersListCmd represents the providers list command
+var providersListCmd = &cobra.Command{
+	Aliases: []string{"ls"},
+	Args:    cobra.NoArgs,
+	Use:     "list",
+	Short:   "Lists configured providers. Optionally lists supported provider types.",
+	Example: `  cdnscli providers list
+  cdnscli providers list --supported
+  cdnscli providers list --capabilities`,
+	Run: providersListRun,
+}
+
+func init() {
+	providersCmd.AddCommand(providersListCmd)
+
+	providersListCmd.PersistentFlags().BoolVar(&capabilities, "capabilities", false, "show the record types and features every provider supports")
+	providersListCmd.PersistentFlags().BoolVar(&supported, "supported", false, "list supported provider types instead of configured providers")
+}
+
+func providersListRun(cmd *cobra.Command, args []string) {
+	a, err := app.New(
+		app.WithConfig(appConfig),
+		app.WithOutputFormat(outputFormat),
+		app.WithOutputFields(outputFields),
+		app.WithOutputWriter(outputWriter),
+	)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if supported {
+		a.Printer().ProviderTypesList(a.SupportedProviderTypes())
+		return
+	}
+
+	infos := a.ProvidersInfo()
+	if capabilities {
+		if err := addCapabilities(a, infos); err != nil {
+			exitWithError(err)
+		}
+	}
+
+	a.Printer().ProvidersList(infos)
+}
+
+// addCapabilities fills in the capabilities of the configured providers.
+func addCapabilities(a app.App, infos []models.ProviderInfo) error {
+	for i := range infos {
+		p, err := a.GetProvider(infos[i].Name)
+		if err != nil {
+			return err
+		}
+		caps := p.Capabilities()
+		infos[i].Capabilities = &caps
+	}
+	return nil
+}
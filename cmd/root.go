@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"time"
@@ -26,6 +27,7 @@ import (
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
 	"github.com/mixanemca/cdnscli/internal/config"
 	pp "github.com/mixanemca/cdnscli/internal/prettyprint"
 	"github.com/mixanemca/cdnscli/internal/ui"
@@ -39,23 +41,49 @@ import (
 var (
 	cfgFile       string
 	clientTimeout time.Duration
+	comment       string
 	content       string
 	debug         bool
+	failOnDup     bool
+	jsonPretty    bool
 	name          string
+	nameFQDN      bool
+	noTUI         bool
+	outputFields  []string
+	outputFile    string
+	outputTmpl    string
+	profile       string
+	providerName  string
 	proxied       bool
+	quiet         bool
+	recordID      string
+	refreshCache  bool
 	rrtype        string
+	tags          []string
 	ttl           int
+	tui           bool
+	ttlArg        string
+	upsert        bool
+	yes           bool
 	zone          string
+	zoneID        string
 	appConfig     *config.Config
+	appConfigErr  error
 )
 
+// outputWriter is where commands print to: os.Stdout or the file given by --output-file.
+var outputWriter io.Writer = os.Stdout
+
 // define output format with default
 var outputFormat pp.OutputFormat = pp.FormatText
 
 var outputFormatList = map[pp.OutputFormat][]string{
-	pp.FormatText: {"text"},
-	pp.FormatJSON: {"json"},
-	pp.FormatNone: {"none"},
+	pp.FormatText:     {"text"},
+	pp.FormatJSON:     {"json"},
+	pp.FormatNone:     {"none"},
+	pp.FormatJSONL:    {"jsonl"},
+	pp.FormatTemplate: {"template"},
+	pp.FormatMarkdown: {"markdown"},
 }
 
 // rootCmd represents the base command when called without any subcommands
@@ -63,15 +91,51 @@ var rootCmd = &cobra.Command{
 	Use:     "cdnscli",
 	Short:   "Cloud DNS CLI - manage DNS records across multiple providers",
 	Version: ldflags.Version(),
+	Args:    cobra.NoArgs,
 	Run:     rootCmdRun,
+
+	PersistentPreRunE:  openOutputFile,
+	PersistentPostRunE: closeOutputFile,
+}
+
+// rootMode is what the root command does when run without a subcommand.
+type rootMode int
+
+const (
+	// rootModeTUI starts the interactive text user interface.
+	rootModeTUI rootMode = iota
+	// rootModeHelp prints usage and exits without starting the TUI.
+	rootModeHelp
+)
+
+// rootRunMode decides whether the root command starts the TUI.
+//
+// Precedence, highest first:
+//  1. --no-tui never starts the TUI.
+//  2. --tui always starts it, whatever --output-format says.
+//  3. An explicit --output-format asks for non-interactive output, so the TUI is not started.
+//  4. Otherwise the TUI is started. The output-format config option only affects the CLI.
+func rootRunMode(tui, noTUI, outputFormatSet bool) (rootMode, error) {
+	switch {
+	case tui && noTUI:
+		return rootModeHelp, fmt.Errorf("--tui and --no-tui are mutually exclusive")
+	case noTUI:
+		return rootModeHelp, nil
+	case tui:
+		return rootModeTUI, nil
+	case outputFormatSet:
+		return rootModeHelp, nil
+	default:
+		return rootModeTUI, nil
+	}
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
+	initCompletion()
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		exitWithError(err)
 	}
 }
 
@@ -87,13 +151,22 @@ func init() {
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
 
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.cdnscli.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file, - for stdin or an http(s) URL (default is $HOME/.cdnscli.yaml)")
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", "", "config profile to merge over the base config, e.g. prod or staging")
 	rootCmd.PersistentFlags().DurationVarP(&clientTimeout, "timeout", "T", 10*time.Second, "client timeout")
 	rootCmd.PersistentFlags().VarP(
 		enumflag.New(&outputFormat, "output-format", outputFormatList, enumflag.EnumCaseSensitive),
-		"output-format", "o", "print output in format: text/json/none",
+		"output-format", "o", "print output in format: text/json/jsonl/none/template/markdown",
 	)
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "print nothing but errors, same as --output-format none")
+	rootCmd.PersistentFlags().BoolVar(&jsonPretty, "json-pretty", false, "indent JSON output, --json-pretty=false prints compact JSON (default is indented on a terminal)")
+	rootCmd.PersistentFlags().StringSliceVar(&outputFields, "fields", nil, "comma separated list of record fields to print (id, name, ttl, type, proxied, content, priority)")
+	rootCmd.PersistentFlags().StringVar(&outputTmpl, "template", "", "print every record, zone, etc. through a Go template, e.g. '{{.Name}} {{.Content}}', implies --output-format template")
+	rootCmd.PersistentFlags().StringVar(&outputFile, "output-file", "", "write output to a file instead of STDOUT, creating or truncating it")
+	rootCmd.PersistentFlags().BoolVar(&refreshCache, "refresh-cache", false, "look zones up through the API again, ignoring and rewriting the zone cache")
 	rootCmd.PersistentFlags().BoolVarP(&debug, "debug", "d", false, "turn on debug output to STDERR")
+	rootCmd.Flags().BoolVar(&tui, "tui", false, "start the interactive interface even if --output-format is set")
+	rootCmd.Flags().BoolVar(&noTUI, "no-tui", false, "never start the interactive interface")
 
 	if err := viper.BindPFlag("timeout", rootCmd.PersistentFlags().Lookup("timeout")); err != nil {
 		log.Fatalf("Failed bind flag %q: %v", "timeout", err)
@@ -109,6 +182,63 @@ func init() {
 	}
 }
 
+// resolveOutputFormat returns the output format to use. --quiet wins over
+// --output-format, so errors on STDERR are the only output.
+func resolveOutputFormat(format pp.OutputFormat, quiet bool) pp.OutputFormat {
+	if quiet {
+		return pp.FormatNone
+	}
+	return format
+}
+
+// resolveJSONPretty reports whether JSON output is indented. The --json-pretty
+// flag wins over the json-pretty config setting, without either JSON is indented
+// only for a terminal, so pipelines get compact JSON.
+func resolveJSONPretty(flag, cfg *bool, tty bool) bool {
+	if flag != nil {
+		return *flag
+	}
+	if cfg != nil {
+		return *cfg
+	}
+	return tty
+}
+
+// stdoutIsTerminal reports whether output goes to a terminal rather than a pipe or --output-file.
+func stdoutIsTerminal() bool {
+	return outputFile == "" && term.IsTerminal(os.Stdout.Fd())
+}
+
+// openOutputFile redirects command output to --output-file, creating or truncating the file.
+func openOutputFile(cmd *cobra.Command, args []string) error {
+	if outputFile == "" {
+		return nil
+	}
+
+	f, err := os.Create(outputFile)
+	if err != nil {
+		return fmt.Errorf("failed to open output file: %w", err)
+	}
+	outputWriter = f
+
+	return nil
+}
+
+// closeOutputFile closes the file opened by openOutputFile and restores STDOUT.
+func closeOutputFile(cmd *cobra.Command, args []string) error {
+	f, ok := outputWriter.(*os.File)
+	if !ok || f == os.Stdout {
+		return nil
+	}
+	outputWriter = os.Stdout
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close output file: %w", err)
+	}
+
+	return nil
+}
+
 // getTimeout returns the timeout to use, checking config first, then flag, then default.
 func getTimeout() time.Duration {
 	if appConfig != nil && clientTimeout == 0 {
@@ -122,7 +252,8 @@ func getTimeout() time.Duration {
 
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
-	cfg, err := config.Load(cfgFile)
+	cfg, err := config.Load(cfgFile, config.WithProfile(profile))
+	appConfigErr = err
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "WARNING: Failed to load config: %v\n", err)
 		// Continue with default config
@@ -134,6 +265,12 @@ func initConfig() {
 		}
 	}
 
+	// --template implies the template output format unless another one is asked for
+	if outputTmpl != "" && !rootCmd.PersistentFlags().Changed("output-format") {
+		outputFormat = pp.FormatTemplate
+	}
+	outputFormat = resolveOutputFormat(outputFormat, quiet)
+
 	// Override with command line flags if set
 	if clientTimeout != 0 {
 		cfg.ClientTimeout = clientTimeout
@@ -150,6 +287,15 @@ func initConfig() {
 		cfg.Debug = debug
 		viper.Set("debug", debug)
 	}
+	cfg.Cache.Refresh = refreshCache
+	cfg.OutputTemplate = outputTmpl
+
+	var pretty *bool
+	if rootCmd.PersistentFlags().Changed("json-pretty") {
+		pretty = &jsonPretty
+	}
+	indent := resolveJSONPretty(pretty, cfg.JSONPretty, stdoutIsTerminal())
+	cfg.JSONPretty = &indent
 
 	appConfig = cfg
 
@@ -161,6 +307,15 @@ func initConfig() {
 }
 
 func rootCmdRun(cmd *cobra.Command, args []string) {
+	mode, err := rootRunMode(tui, noTUI, cmd.Flags().Changed("output-format"))
+	if err != nil {
+		exitWithError(err)
+	}
+	if mode == rootModeHelp {
+		_ = cmd.Help()
+		return
+	}
+
 	tableStyle := table.DefaultStyles()
 	tableStyle.Selected = lipgloss.NewStyle().Background(theme.Color.Highlight)
 
@@ -190,9 +345,10 @@ func rootCmdRun(cmd *cobra.Command, args []string) {
 		table.WithStyles(tableStyle),
 	)
 
-	m := ui.NewModel()
+	// The application is built by the first command of the model, so the
+	// UI shows up without waiting for the providers to be set up.
+	m := ui.NewModel(ui.WithConfig(appConfig))
 	m.ClientTimeout = getTimeout()
-	m.Config = appConfig
 	m.ZonesTable = zonesTable
 	m.RRSetTable = rrsetTable
 	m.TableStyle = tableStyle
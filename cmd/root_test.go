@@ -0,0 +1,101 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pp "github.com/mixanemca/cdnscli/internal/prettyprint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRootRunMode(t *testing.T) {
+	tests := []struct {
+		name            string
+		tui             bool
+		noTUI           bool
+		outputFormatSet bool
+		want            rootMode
+		wantErr         bool
+	}{
+		{name: "no flags starts TUI", want: rootModeTUI},
+		{name: "output format prints help", outputFormatSet: true, want: rootModeHelp},
+		{name: "tui wins over output format", tui: true, outputFormatSet: true, want: rootModeTUI},
+		{name: "tui", tui: true, want: rootModeTUI},
+		{name: "no-tui", noTUI: true, want: rootModeHelp},
+		{name: "no-tui with output format", noTUI: true, outputFormatSet: true, want: rootModeHelp},
+		{name: "tui and no-tui", tui: true, noTUI: true, want: rootModeHelp, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rootRunMode(tt.tui, tt.noTUI, tt.outputFormatSet)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestOutputFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.txt")
+	require.NoError(t, os.WriteFile(path, []byte("stale content that must be truncated\n"), 0o644))
+
+	outputFile = path
+	t.Cleanup(func() { outputFile = "" })
+
+	require.NoError(t, openOutputFile(rootCmd, nil))
+	assert.NotEqual(t, os.Stdout, outputWriter)
+	fmt.Fprintln(outputWriter, "fresh")
+	require.NoError(t, closeOutputFile(rootCmd, nil))
+	assert.Equal(t, os.Stdout, outputWriter)
+
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "fresh\n", string(got))
+}
+
+func TestOutputFile_Unset(t *testing.T) {
+	require.NoError(t, openOutputFile(rootCmd, nil))
+	assert.Equal(t, os.Stdout, outputWriter)
+	require.NoError(t, closeOutputFile(rootCmd, nil))
+	assert.Equal(t, os.Stdout, outputWriter)
+}
+
+func TestResolveOutputFormat(t *testing.T) {
+	assert.Equal(t, pp.FormatJSON, resolveOutputFormat(pp.FormatJSON, false))
+	assert.Equal(t, pp.FormatNone, resolveOutputFormat(pp.FormatJSON, true))
+	assert.Equal(t, pp.FormatNone, resolveOutputFormat(pp.FormatText, true))
+	assert.Equal(t, pp.FormatText, resolveOutputFormat(pp.FormatText, false))
+}
+
+func TestResolveJSONPretty(t *testing.T) {
+	yes, no := true, false
+
+	assert.True(t, resolveJSONPretty(nil, nil, true))
+	assert.False(t, resolveJSONPretty(nil, nil, false))
+	assert.False(t, resolveJSONPretty(nil, &no, true))
+	assert.True(t, resolveJSONPretty(nil, &yes, false))
+	assert.True(t, resolveJSONPretty(&yes, &no, false))
+	assert.False(t, resolveJSONPretty(&no, &yes, true))
+}
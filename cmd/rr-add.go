@@ -25,6 +25,7 @@ import (
 
 	"github.com/mixanemca/cdnscli/internal/app"
 	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/mixanemca/cdnscli/internal/providers"
 	"github.com/spf13/cobra"
 )
 
@@ -34,70 +35,171 @@ var rrAddCmd = &cobra.Command{
 	Args:    cobra.NoArgs,
 	Use:     "add",
 	Short:   "Add resource record to zone",
-	Example: `  cdnscli rr add --name www --zone example.com --type A --ttl 400 --content 192.0.2.1`,
-	Run:     rrAddCmdRun,
+	Example: `  cdnscli rr add --name www --zone example.com --type A --ttl 400 --content 192.0.2.1
+  cdnscli rr add --name www.example.com --zone-id 023e105f4ecef8ad9ca31a8372d0c353 --type A --content 192.0.2.1
+  cdnscli rr add --name www.example.com --zone example.com --fqdn --type A --content 192.0.2.1
+  cdnscli rr add --name www --zone example.com --type A --content 192.0.2.1 --comment "web frontend" --tag env:prod --tag team:web
+  cdnscli rr add --name www --zone example.com --type A --content 192.0.2.1 --wait=5m
+  cdnscli rr add --name www --zone example.com --type A --content 192.0.2.1 --ttl 300 --upsert
+  cdnscli rr add --name www --zone example.com --type A --content 192.0.2.1 --fail-on-duplicate`,
+	Run: rrAddCmdRun,
 }
 
 func init() {
 	rrCmd.AddCommand(rrAddCmd)
 
-	rrAddCmd.PersistentFlags().StringVarP(&content, "content", "c", "", "Comma separated IP address or domain name")
+	rrAddCmd.PersistentFlags().StringVar(&comment, "comment", "", "Comment of the resource record (Cloudflare only)")
+	rrAddCmd.PersistentFlags().StringVarP(&content, "content", "c", "", "Content of the resource record; comma separated values create one A, AAAA or MX record each")
 	if err := rrAddCmd.MarkPersistentFlagRequired("content"); err != nil {
 		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "content", err)
 	}
-	rrAddCmd.PersistentFlags().StringVarP(&zone, "zone", "z", "", "Zone name")
-	if err := rrAddCmd.MarkPersistentFlagRequired("zone"); err != nil {
-		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "zone", err)
-	}
+	rrAddCmd.PersistentFlags().BoolVar(&failOnDup, "fail-on-duplicate", false, "Fail if a record with the same name, type and content already exists")
+	addZoneFlags(rrAddCmd)
+	addWaitFlag(rrAddCmd)
 	rrAddCmd.PersistentFlags().StringVarP(&name, "name", "n", "", "Resource record name")
 	if err := rrAddCmd.MarkPersistentFlagRequired("name"); err != nil {
 		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "name", err)
 	}
+	rrAddCmd.PersistentFlags().BoolVar(&nameFQDN, "fqdn", false, "Take --name as the fully qualified name, never append the zone to it")
 	rrAddCmd.PersistentFlags().BoolVarP(&proxied, "proxied", "p", false, "Whether the record is receiving the performance and security benefits of Cloudflare")
-	rrAddCmd.PersistentFlags().IntVarP(&ttl, "ttl", "l", 1800, "The time to live of the resource record in seconds")
+	rrAddCmd.PersistentFlags().StringArrayVar(&tags, "tag", nil, "Tag of the resource record, may be repeated (Cloudflare only)")
+	rrAddCmd.PersistentFlags().StringVarP(&ttlArg, "ttl", "l", "", "The time to live of the resource record in seconds or \"auto\" (default is provider's default_ttl or 1800)")
 	rrAddCmd.PersistentFlags().StringVarP(&rrtype, "type", "t", "", "Type of the resource record (A, CNAME)")
 	if err := rrAddCmd.MarkPersistentFlagRequired("type"); err != nil {
 		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "type", err)
 	}
+	rrAddCmd.PersistentFlags().BoolVar(&upsert, "upsert", false, "Update the record with the same name, type and content instead of creating a duplicate")
 }
 
 func rrAddCmdRun(cmd *cobra.Command, args []string) {
 	a, err := app.New(
 		app.WithConfig(appConfig),
 		app.WithOutputFormat(outputFormat),
+		app.WithOutputFields(outputFields),
+		app.WithOutputWriter(outputWriter),
 	)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		exitWithError(err)
+	}
+
+	if err := namesToASCII(); err != nil {
+		exitWithError(err)
 	}
 
-	// check that name not FQDN
-	if strings.Contains(name, zone) {
-		fmt.Printf("ERROR: Name (%s) must not be a FQDN. Without domain %s\n", name, zone)
-		os.Exit(1)
+	// Without a zone name (--zone-id only) the name is taken as a FQDN
+	name, err = recordName(name, zone, nameFQDN)
+	if err != nil {
+		exitWithError(err)
 	}
-	// name = hostname + example.com
-	name = strings.Join([]string{name, zone}, ".")
 
 	rrtype = strings.ToUpper(rrtype)
 
+	ttl, err = models.ParseTTL(ttlArg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+		os.Exit(exitError)
+	}
+
 	params := models.CreateDNSRecordParams{
-		Content:  content,
-		Name:     name,
-		Proxied:  proxied,
-		TTL:      ttl,
-		Type:     rrtype,
-		ZoneName: zone,
+		Comment:         comment,
+		Content:         content,
+		Name:            name,
+		Proxied:         proxied,
+		Tags:            tags,
+		TTL:             ttl,
+		Type:            rrtype,
+		ZoneID:          zoneID,
+		ZoneName:        zone,
+		FailOnDuplicate: failOnDup,
+	}
+
+	// Check every record before creating any, so a bad value does not leave some created
+	records := createParams(params, splitContent(rrtype, content))
+	if len(records) == 0 {
+		// Only commas, validating params reports the empty content
+		records = []models.CreateDNSRecordParams{params}
+	}
+	for _, p := range records {
+		if err := p.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+			os.Exit(exitError)
+		}
+	}
+
+	// Reject options the provider would refuse or silently drop
+	if err := providers.CheckCapabilities(a.Provider().Capabilities(), params); err != nil {
+		exitWithError(err)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), getTimeout())
 	defer cancel()
 
-	rr, err := a.Provider().AddRR(ctx, zone, params)
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+	var added []models.DNSRecord
+	for _, p := range records {
+		add := a.Provider().AddRR
+		if upsert {
+			add = a.Provider().UpsertRR
+		}
+		rr, err := add(ctx, zone, p)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		a.Printer().RecordAdd(rr)
+		added = append(added, rr)
+	}
+
+	if err := waitForRecords(added...); err != nil {
+		exitWithError(err)
+	}
+}
+
+// recordName returns the fully qualified name of a record of zone given as name.
+// Unless isFQDN is set the name is completed as by models.FQDN: a name ending
+// with the zone is taken as already qualified. With isFQDN the name is always
+// taken as qualified, so it must be in the zone.
+func recordName(name, zone string, isFQDN bool) (string, error) {
+	if !isFQDN {
+		return models.FQDN(name, zone), nil
+	}
+
+	n := strings.TrimSuffix(strings.TrimSpace(name), ".")
+	if n == "" || n == models.ApexName {
+		return "", fmt.Errorf("--fqdn needs a fully qualified name, got %q", name)
+	}
+	if zone != "" && models.FQDN(n, zone) != n {
+		return "", fmt.Errorf("%s is not in zone %s", n, strings.TrimSuffix(zone, "."))
+	}
+	return n, nil
+}
+
+// splitContent splits comma separated --content into one value per record for
+// types that cannot hold a comma. Other types, such as TXT, keep the value as is.
+func splitContent(rrtype, content string) []string {
+	switch strings.ToUpper(rrtype) {
+	case "A", "AAAA", "MX":
+	default:
+		return []string{content}
+	}
+
+	values := make([]string, 0, strings.Count(content, ",")+1)
+	for _, v := range strings.Split(content, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+// createParams returns a copy of params for each content value.
+func createParams(params models.CreateDNSRecordParams, values []string) []models.CreateDNSRecordParams {
+	list := make([]models.CreateDNSRecordParams, 0, len(values))
+	for _, v := range values {
+		p := params
+		p.Content = v
+		list = append(list, p)
 	}
 
-	a.Printer().RecordAdd(rr)
+	return list
 }
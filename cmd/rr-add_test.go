@@ -0,0 +1,102 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		rrtype  string
+		content string
+		want    []string
+	}{
+		{name: "single A", rrtype: "A", content: "192.0.2.1", want: []string{"192.0.2.1"}},
+		{name: "multi A", rrtype: "A", content: "192.0.2.1,192.0.2.2", want: []string{"192.0.2.1", "192.0.2.2"}},
+		{name: "spaces and empty values", rrtype: "a", content: " 192.0.2.1 , ,192.0.2.2,", want: []string{"192.0.2.1", "192.0.2.2"}},
+		{name: "multi AAAA", rrtype: "AAAA", content: "2001:db8::1,2001:db8::2", want: []string{"2001:db8::1", "2001:db8::2"}},
+		{name: "multi MX", rrtype: "MX", content: "mx1.example.com,mx2.example.com", want: []string{"mx1.example.com", "mx2.example.com"}},
+		{name: "TXT keeps commas", rrtype: "TXT", content: "a,b,c", want: []string{"a,b,c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, splitContent(tt.rrtype, tt.content))
+		})
+	}
+}
+
+func TestCreateParams_MultiValueA(t *testing.T) {
+	params := models.CreateDNSRecordParams{
+		Content:  "192.0.2.1,192.0.2.2",
+		Name:     "www.example.com",
+		TTL:      300,
+		Type:     "A",
+		ZoneName: "example.com",
+	}
+
+	got := createParams(params, splitContent(params.Type, params.Content))
+
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, "192.0.2.1", got[0].Content)
+		assert.Equal(t, "192.0.2.2", got[1].Content)
+		for _, p := range got {
+			assert.Equal(t, "www.example.com", p.Name)
+			assert.Equal(t, 300, p.TTL)
+			assert.Equal(t, "A", p.Type)
+			assert.Equal(t, "example.com", p.ZoneName)
+		}
+	}
+}
+
+func TestRecordName(t *testing.T) {
+	tests := []struct {
+		name    string
+		rrName  string
+		zone    string
+		isFQDN  bool
+		want    string
+		wantErr string
+	}{
+		{name: "relative", rrName: "www", zone: "example.com", want: "www.example.com"},
+		{name: "apex", rrName: "@", zone: "example.com", want: "example.com"},
+		{name: "ending with the zone", rrName: "www.example.com", zone: "example.com", want: "www.example.com"},
+		{name: "containing the zone", rrName: "example.com.www", zone: "example.com", want: "example.com.www.example.com"},
+		{name: "fqdn", rrName: "www.example.com", zone: "example.com", isFQDN: true, want: "www.example.com"},
+		{name: "fqdn with trailing dot", rrName: "WWW.Example.com.", zone: "example.com", isFQDN: true, want: "WWW.Example.com"},
+		{name: "fqdn of the apex", rrName: "example.com", zone: "example.com.", isFQDN: true, want: "example.com"},
+		{name: "fqdn without zone", rrName: "www.example.com", isFQDN: true, want: "www.example.com"},
+		{name: "fqdn outside the zone", rrName: "www.example.org", zone: "example.com", isFQDN: true, wantErr: "www.example.org is not in zone example.com"},
+		{name: "fqdn only sharing the suffix", rrName: "wwwexample.com", zone: "example.com", isFQDN: true, wantErr: "wwwexample.com is not in zone example.com"},
+		{name: "fqdn apex shortcut", rrName: "@", zone: "example.com", isFQDN: true, wantErr: `--fqdn needs a fully qualified name, got "@"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := recordName(tt.rrName, tt.zone, tt.isFQDN)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,337 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mixanemca/cdnscli/internal/app"
+	"github.com/mixanemca/cdnscli/internal/models"
+	pp "github.com/mixanemca/cdnscli/internal/prettyprint"
+	"github.com/mixanemca/cdnscli/internal/providers"
+	"github.com/spf13/cobra"
+)
+
+var (
+	onlyChanged bool
+	prune       bool
+)
+
+// rrApplyCmd represents the apply command
+var rrApplyCmd = &cobra.Command{
+	Args:  cobra.NoArgs,
+	Use:   "apply",
+	Short: "Make the records of a zone match a zone file",
+	Long: `Make the records of a zone match a zone file in the BIND (RFC 1035) format,
+read as by rr import --file.
+
+Records are matched by name, type and content. A record of the zone equal to
+its record in the file, with the same TTL, proxying and priority, is left
+alone, otherwise it is updated. Records of the file missing in the zone are
+created, records of the zone missing in the file are deleted with --prune.
+The SOA and NS records of the zone apex are skipped unless --include-apex is
+given.
+
+Every record is reported on a line of its own: + created, ~ updated with the
+changed fields, - deleted and = unchanged. --only-changed leaves the unchanged
+records out.`,
+	Example: `  cdnscli rr apply --zone example.com --file example.com.zone --dry-run
+  cdnscli rr apply --zone example.com --file example.com.zone --only-changed
+  cdnscli rr apply --zone example.com --file example.com.zone --prune`,
+	Run: rrApplyCmdRun,
+}
+
+func init() {
+	rrCmd.AddCommand(rrApplyCmd)
+
+	rrApplyCmd.PersistentFlags().StringVarP(&zone, "zone", "z", "", "Zone name")
+	if err := rrApplyCmd.MarkPersistentFlagRequired("zone"); err != nil {
+		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "zone", err)
+	}
+	rrApplyCmd.PersistentFlags().StringVar(&zoneID, "zone-id", "", "Zone ID, skips looking the zone up by name")
+	rrApplyCmd.PersistentFlags().StringVarP(&importFile, "file", "f", "", "Zone file in the BIND format with the desired records, - for STDIN")
+	if err := rrApplyCmd.MarkPersistentFlagRequired("file"); err != nil {
+		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "file", err)
+	}
+	rrApplyCmd.PersistentFlags().BoolVar(&includeApex, "include-apex", false, "Also apply the SOA and NS records of the zone apex")
+	rrApplyCmd.PersistentFlags().BoolVar(&prune, "prune", false, "Delete the records of the zone missing in the file")
+	rrApplyCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print the changes without applying them")
+	rrApplyCmd.PersistentFlags().BoolVar(&onlyChanged, "only-changed", false, "Leave the unchanged records out of the report")
+	rrApplyCmd.PersistentFlags().BoolVar(&continueOnError, "continue-on-error", false, "Keep applying the remaining changes when one fails")
+	addBatchTimeoutFlags(rrApplyCmd)
+}
+
+func rrApplyCmdRun(cmd *cobra.Command, args []string) {
+	a, err := app.New(
+		app.WithConfig(appConfig),
+		app.WithOutputFormat(outputFormat),
+		app.WithOutputFields(outputFields),
+		app.WithOutputWriter(outputWriter),
+	)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if err := checkApplyFormat(outputFormat); err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+		os.Exit(exitError)
+	}
+	if err := namesToASCII(); err != nil {
+		exitWithError(err)
+	}
+
+	params, err := readZoneFile(importFile, zone, includeApex)
+	if err != nil {
+		exitWithError(err)
+	}
+	desired := make([]models.DNSRecord, 0, len(params))
+	for _, p := range params {
+		desired = append(desired, models.DNSRecord{Name: p.Name, TTL: p.TTL, Type: p.Type, Content: p.Content, Priority: p.Priority, Proxied: p.Proxied})
+	}
+
+	ctx, stop := interruptContext(context.Background())
+	defer stop()
+
+	b, cancel := newBatchRunner(ctx, recordTimeout, batchTimeout)
+	defer cancel()
+
+	start := time.Now()
+	var current []models.DNSRecord
+	err = b.run(func(ctx context.Context) error {
+		var err error
+		current, err = a.Provider().ListRecords(ctx, zoneParams())
+		return err
+	})
+	if err != nil {
+		exitWithError(err)
+	}
+
+	plan := models.PlanRecords(skipApex(current, zone, includeApex), desired)
+	if !prune {
+		plan.Delete = nil
+	}
+
+	done := plan
+	var errored []batchError
+	if !dryRun {
+		done, errored, err = applyPlan(b, a.Provider(), plan, continueOnError)
+	}
+	printRecordsPlan(outputWriter, outputFormat, done, onlyChanged)
+	if !quiet {
+		for _, e := range errored {
+			fmt.Fprintf(os.Stderr, "error: %s %s: %v\n", models.NameToUnicode(e.Record.Name), e.Record.Type, e.Err)
+		}
+	}
+	a.Printer().BatchSummary(models.BatchResult{
+		DryRun:  dryRun,
+		Created: len(done.Create),
+		Updated: len(done.Update),
+		Deleted: len(done.Delete),
+		Failed:  len(errored),
+		Elapsed: time.Since(start),
+	})
+	if err != nil {
+		exitWithError(err)
+	}
+	if len(errored) > 0 {
+		exitWithError(fmt.Errorf("%d records could not be applied", len(errored)))
+	}
+}
+
+// skipApex returns rrset without the SOA and NS records of the zone apex,
+// unless includeApex is set.
+func skipApex(rrset []models.DNSRecord, zone string, includeApex bool) []models.DNSRecord {
+	if includeApex {
+		return rrset
+	}
+
+	apex := strings.TrimSuffix(zone, ".")
+	kept := make([]models.DNSRecord, 0, len(rrset))
+	for _, rr := range rrset {
+		if (rr.Type == "SOA" || rr.Type == "NS") && strings.EqualFold(strings.TrimSuffix(rr.Name, "."), apex) {
+			continue
+		}
+		kept = append(kept, rr)
+	}
+
+	return kept
+}
+
+// applyPlan creates, updates and deletes the records of plan through the provider,
+// each change in a step of b, and returns the part of plan that was applied.
+// Unless continueOnError is set, the first failed change stops applying the plan
+// and is returned. Passing the batch deadline always stops it.
+func applyPlan(b *batchRunner, p providers.Provider, plan models.RecordsPlan, continueOnError bool) (models.RecordsPlan, []batchError, error) {
+	done := models.RecordsPlan{Unchanged: plan.Unchanged}
+	var errored []batchError
+
+	// apply runs a single change. A failed change is added to errored, the
+	// returned error stops applying the plan.
+	apply := func(rr models.DNSRecord, change func(ctx context.Context) error) (bool, error) {
+		err := b.run(change)
+		if err == nil {
+			return true, nil
+		}
+		if errors.Is(err, errBatchDeadline) || errors.Is(err, errBatchInterrupted) {
+			return false, err
+		}
+		errored = append(errored, batchError{Record: rr, Err: err})
+		if b.stopped(err) {
+			return false, err
+		}
+		if !continueOnError {
+			return false, errors.Join(fmt.Errorf("apply stopped, use --continue-on-error to keep going"), err)
+		}
+		return false, nil
+	}
+
+	for _, rr := range plan.Create {
+		ok, err := apply(rr, func(ctx context.Context) error {
+			_, err := p.AddRR(ctx, zone, models.CreateDNSRecordParams{
+				Content:  rr.Content,
+				Name:     rr.Name,
+				Priority: rr.Priority,
+				Proxied:  rr.Proxied,
+				TTL:      rr.TTL,
+				Type:     rr.Type,
+				ZoneID:   zoneID,
+				ZoneName: zone,
+			})
+			return err
+		})
+		if err != nil {
+			return done, errored, err
+		}
+		if ok {
+			done.Create = append(done.Create, rr)
+		}
+	}
+
+	for _, c := range plan.Update {
+		rr := c.New
+		if rr.ZoneID == "" {
+			rr.ZoneID = zoneID
+		}
+		ok, err := apply(rr, func(ctx context.Context) error {
+			_, err := p.UpdateRR(ctx, zone, rr)
+			return err
+		})
+		if err != nil {
+			return done, errored, err
+		}
+		if ok {
+			done.Update = append(done.Update, c)
+		}
+	}
+
+	for _, rr := range plan.Delete {
+		if rr.ZoneID == "" {
+			rr.ZoneID = zoneID
+		}
+		ok, err := apply(rr, func(ctx context.Context) error {
+			return p.DeleteRR(ctx, zone, rr)
+		})
+		if err != nil {
+			return done, errored, err
+		}
+		if ok {
+			done.Delete = append(done.Delete, rr)
+		}
+	}
+
+	return done, errored, nil
+}
+
+// planChange is a change printed by rr apply in JSON output formats.
+type planChange struct {
+	Change  string               `json:"change"`
+	Record  models.DNSRecord     `json:"record"`
+	Changes []models.FieldChange `json:"changes,omitempty"`
+}
+
+// checkApplyFormat returns an error for the output formats rr apply can not
+// print changes in, rather than falling back to text.
+func checkApplyFormat(format pp.OutputFormat) error {
+	switch format {
+	case pp.FormatText, pp.FormatJSON, pp.FormatJSONL, pp.FormatNone:
+		return nil
+	default:
+		return fmt.Errorf("rr apply does not support the %s output format, use text, json, jsonl or none", outputFormatList[format][0])
+	}
+}
+
+// printRecordsPlan prints the changes of rr apply, one per line, followed by the
+// unchanged records unless onlyChanged is set. JSON output formats print a JSON
+// object per change, FormatNone prints nothing.
+func printRecordsPlan(w io.Writer, format pp.OutputFormat, plan models.RecordsPlan, onlyChanged bool) {
+	var changes []planChange
+	for _, rr := range plan.Create {
+		changes = append(changes, planChange{Change: "create", Record: rr})
+	}
+	for _, c := range plan.Update {
+		changes = append(changes, planChange{Change: "update", Record: c.New, Changes: models.DiffRecord(c.Old, c.New)})
+	}
+	for _, rr := range plan.Delete {
+		changes = append(changes, planChange{Change: "delete", Record: rr})
+	}
+	if !onlyChanged {
+		for _, rr := range plan.Unchanged {
+			changes = append(changes, planChange{Change: "unchanged", Record: rr})
+		}
+	}
+
+	for _, c := range changes {
+		switch format {
+		case pp.FormatNone:
+			return
+		case pp.FormatJSON, pp.FormatJSONL:
+			j, _ := json.Marshal(c)
+			fmt.Fprintln(w, string(j))
+		default:
+			fmt.Fprintln(w, c.String())
+		}
+	}
+}
+
+// String returns the change as a line of text: the kind of change (+, ~, - or =)
+// and the record, with the changed fields for updated records.
+func (c planChange) String() string {
+	mark := map[string]string{"create": "+", "update": "~", "delete": "-", "unchanged": "="}[c.Change]
+	rr := c.Record
+	line := strings.Join([]string{
+		mark, models.NameToUnicode(rr.Name), strconv.Itoa(rr.TTL), rr.Type, rr.Content,
+	}, " ")
+
+	if len(c.Changes) > 0 {
+		changes := make([]string, 0, len(c.Changes))
+		for _, fc := range c.Changes {
+			changes = append(changes, fc.String())
+		}
+		line += " (" + strings.Join(changes, ", ") + ")"
+	}
+
+	return line
+}
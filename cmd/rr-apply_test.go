@@ -0,0 +1,123 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	pp "github.com/mixanemca/cdnscli/internal/prettyprint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyPlan(t *testing.T) {
+	www := models.DNSRecord{ID: "1", Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 300}
+	wwwTTL := www
+	wwwTTL.TTL = 600
+	plan := models.RecordsPlan{
+		Create: []models.DNSRecord{
+			{Name: "blog.example.com", Type: "CNAME", Content: "example.github.io", TTL: 300},
+			{Name: "example.com", Type: "MX", Content: "mail.example.com", Priority: 10, TTL: 300},
+		},
+		Update:    []models.RecordChange{{Old: www, New: wwwTTL}},
+		Delete:    []models.DNSRecord{{ID: "2", Name: "old.example.com", Type: "A", Content: "192.0.2.2", TTL: 300}},
+		Unchanged: []models.DNSRecord{{ID: "3", Name: "api.example.com", Type: "A", Content: "192.0.2.3", TTL: 300}},
+	}
+
+	p := &listProvider{}
+	done, errored, err := applyPlan(testBatchRunner(t), p, plan, false)
+	require.NoError(t, err)
+	assert.Empty(t, errored)
+	assert.Equal(t, plan, done)
+	require.Len(t, p.added, 2)
+	assert.Equal(t, 10, p.added[1].Priority)
+	assert.Equal(t, []models.DNSRecord{wwwTTL}, p.updated)
+	assert.Equal(t, plan.Delete, p.deleted)
+
+	addErr := map[string]error{"blog.example.com": errors.New("record already exists")}
+	p = &listProvider{addErr: addErr}
+	done, errored, err = applyPlan(testBatchRunner(t), p, plan, false)
+	assert.ErrorContains(t, err, "record already exists")
+	require.Len(t, errored, 1)
+	assert.Empty(t, done.Create)
+	assert.Empty(t, p.updated)
+
+	p = &listProvider{addErr: addErr}
+	done, errored, err = applyPlan(testBatchRunner(t), p, plan, true)
+	require.NoError(t, err)
+	assert.Len(t, errored, 1)
+	assert.Equal(t, plan.Create[1:], done.Create)
+	assert.Equal(t, plan.Update, done.Update)
+	assert.Equal(t, plan.Delete, done.Delete)
+}
+
+func TestSkipApex(t *testing.T) {
+	rrset := []models.DNSRecord{
+		{Name: "example.com", Type: "SOA", Content: "ns1.example.com admin.example.com 1 7200 3600 1209600 3600"},
+		{Name: "example.com", Type: "NS", Content: "ns1.example.com"},
+		{Name: "sub.example.com", Type: "NS", Content: "ns1.sub.example.com"},
+		{Name: "example.com", Type: "A", Content: "192.0.2.1"},
+	}
+
+	assert.Equal(t, rrset[2:], skipApex(rrset, "example.com.", false))
+	assert.Equal(t, rrset, skipApex(rrset, "example.com", true))
+}
+
+func TestPrintRecordsPlan(t *testing.T) {
+	www := models.DNSRecord{ID: "1", Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 300}
+	wwwTTL := www
+	wwwTTL.TTL = 600
+	plan := models.RecordsPlan{
+		Create:    []models.DNSRecord{{Name: "new.example.com", Type: "A", Content: "192.0.2.3", TTL: 300}},
+		Update:    []models.RecordChange{{Old: www, New: wwwTTL}},
+		Delete:    []models.DNSRecord{{ID: "2", Name: "old.example.com", Type: "CNAME", Content: "www.example.com", TTL: 300}},
+		Unchanged: []models.DNSRecord{{ID: "3", Name: "api.example.com", Type: "A", Content: "192.0.2.4", TTL: 300}},
+	}
+
+	var buf bytes.Buffer
+	printRecordsPlan(&buf, pp.FormatText, plan, false)
+	assert.Equal(t, "+ new.example.com 300 A 192.0.2.3\n"+
+		"~ www.example.com 600 A 192.0.2.1 (ttl: 300 -> 600)\n"+
+		"- old.example.com 300 CNAME www.example.com\n"+
+		"= api.example.com 300 A 192.0.2.4\n", buf.String())
+
+	buf.Reset()
+	printRecordsPlan(&buf, pp.FormatText, plan, true)
+	assert.NotContains(t, buf.String(), "api.example.com")
+	assert.Contains(t, buf.String(), "~ www.example.com")
+
+	buf.Reset()
+	printRecordsPlan(&buf, pp.FormatJSONL, models.RecordsPlan{Unchanged: plan.Unchanged}, false)
+	assert.Equal(t, `{"change":"unchanged","record":{"content":"192.0.2.4","id":"3","name":"api.example.com","ttl":300,"type":"A"}}`+"\n", buf.String())
+
+	// Without changes --only-changed prints nothing
+	buf.Reset()
+	printRecordsPlan(&buf, pp.FormatJSONL, models.RecordsPlan{Unchanged: plan.Unchanged}, true)
+	assert.Empty(t, buf.String())
+
+	printRecordsPlan(&buf, pp.FormatNone, plan, false)
+	assert.Empty(t, buf.String())
+}
+
+func TestCheckApplyFormat(t *testing.T) {
+	assert.NoError(t, checkApplyFormat(pp.FormatText))
+	assert.NoError(t, checkApplyFormat(pp.FormatJSONL))
+	assert.EqualError(t, checkApplyFormat(pp.FormatMarkdown), "rr apply does not support the markdown output format, use text, json, jsonl or none")
+}
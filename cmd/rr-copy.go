@@ -0,0 +1,238 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mixanemca/cdnscli/internal/app"
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/mixanemca/cdnscli/internal/providers"
+	"github.com/mixanemca/cdnscli/internal/workerpool"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fromZone     string
+	toZone       string
+	fromProvider string
+	toProvider   string
+)
+
+// rrCopyCmd represents the copy command
+var rrCopyCmd = &cobra.Command{
+	Aliases: []string{"cp"},
+	Args:    cobra.NoArgs,
+	Use:     "copy",
+	Short:   "Copy resource records from one zone to another",
+	Long: `Copy resource records from one zone to another, possibly of another provider.
+
+Records of the source zone, narrowed down by --name and --type, are created in
+the destination zone with their names moved to it, so www.a.com becomes
+www.b.com. Record content is copied as is. The SOA and NS records of the zone
+apex belong to the source name servers and are skipped, unless --include-apex
+is given. Proxying, comments and tags are dropped when the destination provider
+does not support them.`,
+	Example: `  cdnscli rr copy --from-zone a.com --to-zone b.com
+  cdnscli rr copy --from-zone a.com --to-zone b.com --type A --name www --dry-run
+  cdnscli rr copy --from-zone a.com --to-zone a.com --from-provider cf --to-provider pdns`,
+	Run: rrCopyCmdRun,
+}
+
+func init() {
+	rrCmd.AddCommand(rrCopyCmd)
+
+	rrCopyCmd.PersistentFlags().StringVar(&fromZone, "from-zone", "", "Zone to copy the records from")
+	if err := rrCopyCmd.MarkPersistentFlagRequired("from-zone"); err != nil {
+		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "from-zone", err)
+	}
+	rrCopyCmd.PersistentFlags().StringVar(&toZone, "to-zone", "", "Zone to create the records in")
+	if err := rrCopyCmd.MarkPersistentFlagRequired("to-zone"); err != nil {
+		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "to-zone", err)
+	}
+	rrCopyCmd.PersistentFlags().StringVar(&fromProvider, "from-provider", "", "provider name of the source zone (default is the default provider)")
+	rrCopyCmd.PersistentFlags().StringVar(&toProvider, "to-provider", "", "provider name of the destination zone (default is the default provider)")
+	rrCopyCmd.PersistentFlags().StringVarP(&name, "name", "n", "", "only copy records with this name")
+	rrCopyCmd.PersistentFlags().StringSliceVarP(&filterTypes, "type", "t", nil, "only copy records of this type, may be repeated or comma separated")
+	rrCopyCmd.PersistentFlags().BoolVar(&includeApex, "include-apex", false, "Also copy the SOA and NS records of the zone apex")
+	rrCopyCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print the records that would be created without creating them")
+	rrCopyCmd.PersistentFlags().BoolVar(&continueOnError, "continue-on-error", false, "Keep creating the remaining records when a creation fails")
+	rrCopyCmd.PersistentFlags().BoolVar(&upsert, "upsert", false, "Update records already in the destination zone instead of failing")
+	addBatchTimeoutFlags(rrCopyCmd)
+	addConcurrencyFlag(rrCopyCmd)
+}
+
+func rrCopyCmdRun(cmd *cobra.Command, args []string) {
+	a, err := app.New(
+		app.WithConfig(appConfig),
+		app.WithOutputFormat(outputFormat),
+		app.WithOutputFields(outputFields),
+		app.WithOutputWriter(outputWriter),
+	)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	for _, n := range []*string{&fromZone, &toZone, &name} {
+		if *n, err = models.NameToASCII(*n); err != nil {
+			exitWithError(err)
+		}
+	}
+	if err := workerpool.ValidateSize(concurrency); err != nil {
+		exitWithError(err)
+	}
+
+	src, err := a.GetProvider(fromProvider)
+	if err != nil {
+		exitWithError(err)
+	}
+	dst, err := a.GetProvider(toProvider)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	ctx, stop := interruptContext(context.Background())
+	defer stop()
+
+	listCtx, cancel := context.WithTimeout(ctx, getTimeout())
+	rrset, err := src.ListRecords(listCtx, models.ListDNSRecordsParams{ZoneName: fromZone})
+	cancel()
+	if err != nil {
+		exitWithError(err)
+	}
+
+	caps := dst.Capabilities()
+	params := copyParams(selectCopyRecords(rrset, fromZone, name, filterTypes, includeApex), fromZone, toZone)
+	for i := range params {
+		params[i] = dropUnsupported(caps, params[i])
+		if err := providers.CheckCapabilities(caps, params[i]); err != nil {
+			exitWithError(fmt.Errorf("%s %s: %w", models.NameToUnicode(params[i].Name), params[i].Type, err))
+		}
+	}
+
+	if dryRun {
+		rrs := make([]models.DNSRecord, 0, len(params))
+		for _, p := range params {
+			rrs = append(rrs, models.DNSRecord{Name: p.Name, TTL: p.TTL, Type: p.Type, Content: p.Content, Proxied: p.Proxied})
+		}
+		a.Printer().RecordsList(rrs)
+		return
+	}
+
+	b, cancel := newBatchRunner(ctx, recordTimeout, batchTimeout)
+	defer cancel()
+	b.concurrency = concurrency
+
+	start := time.Now()
+	created, errored, err := importRecords(b, dst, toZone, params, continueOnError, upsert)
+	for _, rr := range created {
+		a.Printer().RecordAdd(rr)
+	}
+	if !quiet {
+		for _, e := range errored {
+			fmt.Fprintf(os.Stderr, "error: %s %s: %v\n", models.NameToUnicode(e.Record.Name), e.Record.Type, e.Err)
+		}
+	}
+	a.Printer().BatchSummary(models.BatchResult{
+		Created: len(created),
+		Failed:  len(errored),
+		Elapsed: time.Since(start),
+	})
+	if err != nil {
+		exitWithError(err)
+	}
+	if len(errored) > 0 {
+		exitWithError(fmt.Errorf("%d records could not be copied", len(errored)))
+	}
+}
+
+// selectCopyRecords returns the records of zone to copy. A non-empty name or types
+// narrow the selection down. The apex SOA and NS records are skipped unless
+// includeApex is set.
+func selectCopyRecords(rrset []models.DNSRecord, zone, name string, types []string, includeApex bool) []models.DNSRecord {
+	filters := []models.RecordFilter{models.ByTypes(types...)}
+	if name != "" {
+		fqdn := models.FQDN(name, zone)
+		filters = append(filters, func(rr models.DNSRecord) bool {
+			return strings.EqualFold(strings.TrimSuffix(rr.Name, "."), fqdn)
+		})
+	}
+	if !includeApex {
+		apex := strings.TrimSuffix(zone, ".")
+		filters = append(filters, func(rr models.DNSRecord) bool {
+			isApex := strings.EqualFold(strings.TrimSuffix(rr.Name, "."), apex)
+			return !isApex || (!strings.EqualFold(rr.Type, "SOA") && !strings.EqualFold(rr.Type, "NS"))
+		})
+	}
+	return models.FilterRecords(rrset, filters...)
+}
+
+// copyParams converts the records of fromZone to params for creating them in toZone.
+func copyParams(rrset []models.DNSRecord, fromZone, toZone string) []models.CreateDNSRecordParams {
+	params := make([]models.CreateDNSRecordParams, 0, len(rrset))
+	for _, rr := range rrset {
+		params = append(params, models.CreateDNSRecordParams{
+			Comment:  rr.Comment,
+			Content:  rr.Content,
+			Name:     rewriteZone(rr.Name, fromZone, toZone),
+			Priority: rr.Priority,
+			Proxied:  rr.Proxied,
+			Tags:     rr.Tags,
+			TTL:      rr.TTL,
+			Type:     rr.Type,
+			ZoneName: strings.TrimSuffix(toZone, "."),
+		})
+	}
+	return params
+}
+
+// rewriteZone moves name from the fromZone suffix to toZone. A name outside
+// fromZone is taken as relative to it, like the --name flag.
+func rewriteZone(name, fromZone, toZone string) string {
+	n := strings.TrimSuffix(name, ".")
+	from := strings.TrimSuffix(fromZone, ".")
+	to := strings.TrimSuffix(toZone, ".")
+
+	switch {
+	case strings.EqualFold(n, from):
+		return to
+	case strings.HasSuffix(strings.ToLower(n), "."+strings.ToLower(from)):
+		return n[:len(n)-len(from)] + to
+	default:
+		return n + "." + to
+	}
+}
+
+// dropUnsupported clears the proxying, comment and tags of params when the
+// provider described by caps does not support them.
+func dropUnsupported(caps models.Capabilities, params models.CreateDNSRecordParams) models.CreateDNSRecordParams {
+	if !caps.Proxied {
+		params.Proxied = false
+	}
+	if !caps.Comments {
+		params.Comment = ""
+	}
+	if !caps.Tags {
+		params.Tags = nil
+	}
+	return params
+}
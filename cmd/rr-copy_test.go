@@ -0,0 +1,71 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriteZone(t *testing.T) {
+	assert.Equal(t, "www.b.com", rewriteZone("www.a.com", "a.com", "b.com"))
+	assert.Equal(t, "b.com", rewriteZone("a.com.", "a.com", "b.com."))
+	assert.Equal(t, "deep.sub.b.com", rewriteZone("deep.sub.A.com", "a.com", "b.com"))
+	assert.Equal(t, "WWW.b.com", rewriteZone("WWW.a.com", "a.com", "b.com"))
+	// Only the zone suffix is rewritten, not a lookalike label
+	assert.Equal(t, "nota.com.b.com", rewriteZone("nota.com", "a.com", "b.com"))
+	assert.Equal(t, "www.b.com", rewriteZone("www", "a.com", "b.com"))
+}
+
+func TestSelectCopyRecords(t *testing.T) {
+	rrset := []models.DNSRecord{
+		{Name: "a.com", Type: "SOA", Content: "ns1.a.com admin.a.com 1 7200 3600 1209600 3600"},
+		{Name: "a.com", Type: "NS", Content: "ns1.a.com"},
+		{Name: "a.com", Type: "MX", Content: "10 mail.a.com"},
+		{Name: "www.a.com", Type: "A", Content: "192.0.2.1"},
+		{Name: "www.a.com", Type: "AAAA", Content: "2001:db8::1"},
+		{Name: "sub.a.com", Type: "NS", Content: "ns1.sub.a.com"},
+	}
+
+	all := selectCopyRecords(rrset, "a.com", "", nil, false)
+	assert.Equal(t, rrset[2:], all)
+
+	assert.Equal(t, rrset, selectCopyRecords(rrset, "a.com", "", nil, true))
+	assert.Equal(t, rrset[3:5], selectCopyRecords(rrset, "a.com", "www", nil, false))
+	assert.Equal(t, rrset[3:4], selectCopyRecords(rrset, "a.com", "www.a.com", []string{"a"}, false))
+	assert.Equal(t, []models.DNSRecord{rrset[1], rrset[5]}, selectCopyRecords(rrset, "a.com", "", []string{"NS"}, true))
+	assert.Empty(t, selectCopyRecords(rrset, "a.com", "mail", nil, false))
+}
+
+func TestCopyParams(t *testing.T) {
+	rrset := []models.DNSRecord{
+		{ID: "1", Name: "a.com", TTL: 300, Type: "MX", Content: "10 mail.a.com", Priority: 10, ZoneID: "zone-a"},
+		{ID: "2", Name: "www.a.com", TTL: 1, Type: "A", Content: "192.0.2.1", Proxied: true, Comment: "web", Tags: []string{"env:prod"}},
+	}
+
+	params := copyParams(rrset, "a.com", "b.com.")
+	assert.Equal(t, []models.CreateDNSRecordParams{
+		{Name: "b.com", TTL: 300, Type: "MX", Content: "10 mail.a.com", Priority: 10, ZoneName: "b.com"},
+		{Name: "www.b.com", TTL: 1, Type: "A", Content: "192.0.2.1", Proxied: true, Comment: "web", Tags: []string{"env:prod"}, ZoneName: "b.com"},
+	}, params)
+
+	assert.Equal(t, models.CreateDNSRecordParams{Name: "www.b.com", TTL: 1, Type: "A", Content: "192.0.2.1", ZoneName: "b.com"},
+		dropUnsupported(models.Capabilities{}, params[1]))
+	assert.Equal(t, params[1], dropUnsupported(models.Capabilities{Proxied: true, Comments: true, Tags: true}, params[1]))
+}
@@ -20,7 +20,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"os"
 	"strings"
 
 	"github.com/mixanemca/cdnscli/internal/app"
@@ -33,8 +32,9 @@ var rrDelCmd = &cobra.Command{
 	Args:    cobra.NoArgs,
 	Use:     "delete",
 	Short:   "Delete resource record from zone",
-	Example: `  cdnscli rr delete --name www --zone example.com --type A --content 192.0.2.1`,
-	Run:     rrDelCmdRun,
+	Example: `  cdnscli rr delete --name www --zone example.com --type A --content 192.0.2.1
+  cdnscli rr delete --name www.example.com --zone-id 023e105f4ecef8ad9ca31a8372d0c353 --type A --content 192.0.2.1 --yes`,
+	Run: rrDelCmdRun,
 }
 
 func init() {
@@ -44,10 +44,7 @@ func init() {
 	if err := rrDelCmd.MarkPersistentFlagRequired("content"); err != nil {
 		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "content", err)
 	}
-	rrDelCmd.PersistentFlags().StringVarP(&zone, "zone", "z", "", "Zone name")
-	if err := rrDelCmd.MarkPersistentFlagRequired("zone"); err != nil {
-		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "zone", err)
-	}
+	addZoneFlags(rrDelCmd)
 	rrDelCmd.PersistentFlags().StringVarP(&name, "name", "n", "", "Resource record name")
 	if err := rrDelCmd.MarkPersistentFlagRequired("name"); err != nil {
 		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "name", err)
@@ -56,22 +53,22 @@ func init() {
 	if err := rrDelCmd.MarkPersistentFlagRequired("type"); err != nil {
 		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "type", err)
 	}
+	rrDelCmd.PersistentFlags().BoolVarP(&yes, "yes", "y", false, "delete without asking for confirmation, required when STDIN is not a terminal")
 }
 
 func rrDelCmdRun(cmd *cobra.Command, args []string) {
 	a, err := app.New(
 		app.WithConfig(appConfig),
 		app.WithOutputFormat(outputFormat),
+		app.WithOutputFields(outputFields),
+		app.WithOutputWriter(outputWriter),
 	)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		exitWithError(err)
 	}
 
-	// check that name not FQDN
-	if strings.Contains(name, zone) {
-		fmt.Printf("ERROR: Name (%s) must not be a FQDN. Without domain %s\n", name, zone)
-		os.Exit(1)
+	if err := namesToASCII(); err != nil {
+		exitWithError(err)
 	}
 
 	rrtype = strings.ToUpper(rrtype)
@@ -79,16 +76,23 @@ func rrDelCmdRun(cmd *cobra.Command, args []string) {
 	ctx, cancel := context.WithTimeout(context.Background(), getTimeout())
 	defer cancel()
 
-	rr, err := a.Provider().GetRRByName(ctx, zone, name)
+	rr, err := findRecord(ctx, a.Provider(), name, rrtype, content)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		exitWithError(err)
+	}
+
+	question := fmt.Sprintf("Delete %s record %s with content %s (ID %s)?", rr.Type, rr.Name, rr.Content, rr.ID)
+	if err := confirmStdin(question, yes); err != nil {
+		exitWithError(err)
 	}
 
+	// The timeout does not include the time spent answering
+	ctx, cancel = context.WithTimeout(context.Background(), getTimeout())
+	defer cancel()
+
 	err = a.Provider().DeleteRR(ctx, zone, rr)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		exitWithError(err)
 	}
 
 	a.Printer().RecordDel(rr)
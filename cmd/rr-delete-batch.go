@@ -0,0 +1,256 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mixanemca/cdnscli/internal/app"
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/mixanemca/cdnscli/internal/providers"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	batchFile       string
+	continueOnError bool
+	dryRun          bool
+)
+
+// rrDeleteBatchCmd represents the delete-batch command
+var rrDeleteBatchCmd = &cobra.Command{
+	Aliases: []string{"del-batch", "rm-batch"},
+	Args:    cobra.NoArgs,
+	Use:     "delete-batch",
+	Short:   "Delete resource records listed in a file",
+	Long: `Delete resource records listed in a YAML (or JSON) file.
+
+Each entry gives the record name and type and, optionally, its content:
+
+  - name: www
+    type: A
+    content: 192.0.2.1
+  - name: old
+    type: CNAME
+
+Every record of the zone matching an entry is deleted. An entry without content
+matches all records with that name and type.`,
+	Example: `  cdnscli rr delete-batch --zone example.com --file records.yaml
+  cdnscli rr delete-batch --zone example.com --file records.yaml --dry-run`,
+	Run: rrDeleteBatchCmdRun,
+}
+
+func init() {
+	rrCmd.AddCommand(rrDeleteBatchCmd)
+
+	addZoneFlags(rrDeleteBatchCmd)
+	rrDeleteBatchCmd.PersistentFlags().StringVarP(&batchFile, "file", "f", "", "YAML or JSON file with the records to delete")
+	if err := rrDeleteBatchCmd.MarkPersistentFlagRequired("file"); err != nil {
+		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "file", err)
+	}
+	rrDeleteBatchCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print the records that would be deleted without deleting them")
+	rrDeleteBatchCmd.PersistentFlags().BoolVar(&continueOnError, "continue-on-error", false, "Keep deleting the remaining records when a deletion fails")
+	addBatchTimeoutFlags(rrDeleteBatchCmd)
+}
+
+func rrDeleteBatchCmdRun(cmd *cobra.Command, args []string) {
+	a, err := app.New(
+		app.WithConfig(appConfig),
+		app.WithOutputFormat(outputFormat),
+		app.WithOutputFields(outputFields),
+		app.WithOutputWriter(outputWriter),
+	)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if err := namesToASCII(); err != nil {
+		exitWithError(err)
+	}
+
+	entries, err := readBatchRecords(batchFile)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	ctx, stop := interruptContext(context.Background())
+	defer stop()
+
+	b, cancel := newBatchRunner(ctx, recordTimeout, batchTimeout)
+	defer cancel()
+
+	start := time.Now()
+	summary, err := deleteBatch(b, a.Provider(), entries, dryRun, continueOnError)
+	if dryRun {
+		a.Printer().RecordsList(summary.Deleted)
+	} else {
+		for _, rr := range summary.Deleted {
+			a.Printer().RecordDel(rr)
+		}
+	}
+	if s := summary.String(); s != "" && !quiet {
+		fmt.Fprintln(os.Stderr, s)
+	}
+	a.Printer().BatchSummary(summary.Result(time.Since(start)))
+	if err != nil {
+		exitWithError(err)
+	}
+	if len(summary.Errored) > 0 {
+		exitWithError(fmt.Errorf("%d records could not be deleted", len(summary.Errored)))
+	}
+}
+
+// batchRecord is an entry of the file read by rr delete-batch.
+type batchRecord struct {
+	Name    string `yaml:"name"`
+	Type    string `yaml:"type"`
+	Content string `yaml:"content"`
+}
+
+// String returns the entry as "name type [content]".
+func (r batchRecord) String() string {
+	return strings.TrimSpace(strings.Join([]string{r.Name, r.Type, r.Content}, " "))
+}
+
+// batchError is a record that could not be deleted.
+type batchError struct {
+	Record models.DNSRecord
+	Err    error
+}
+
+// deleteBatchSummary holds the outcome of rr delete-batch.
+type deleteBatchSummary struct {
+	DryRun   bool
+	Deleted  []models.DNSRecord
+	NotFound []batchRecord
+	Errored  []batchError
+}
+
+// String returns the entries not found and the failed deletions, one per line.
+func (s deleteBatchSummary) String() string {
+	lines := make([]string, 0, len(s.NotFound)+len(s.Errored))
+	for _, r := range s.NotFound {
+		lines = append(lines, fmt.Sprintf("not found: %s", r))
+	}
+	for _, e := range s.Errored {
+		lines = append(lines, fmt.Sprintf("error: %s %s: %v", models.NameToUnicode(e.Record.Name), e.Record.Type, e.Err))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Result returns the counts of the summary for the printer.
+func (s deleteBatchSummary) Result(elapsed time.Duration) models.BatchResult {
+	return models.BatchResult{
+		DryRun:   s.DryRun,
+		Deleted:  len(s.Deleted),
+		NotFound: len(s.NotFound),
+		Failed:   len(s.Errored),
+		Elapsed:  elapsed,
+	}
+}
+
+// readBatchRecords reads the list of records from a YAML or JSON file.
+func readBatchRecords(path string) ([]batchRecord, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []batchRecord
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for i, e := range entries {
+		if e.Name == "" || e.Type == "" {
+			return nil, fmt.Errorf("%s: entry %d: name and type are required", path, i+1)
+		}
+		if entries[i].Name, err = models.NameToASCII(e.Name); err != nil {
+			return nil, fmt.Errorf("%s: entry %d: %w", path, i+1, err)
+		}
+	}
+
+	return entries, nil
+}
+
+// deleteBatch deletes the records of the zone matching the entries. The zone
+// is listed once and matched with selectRecords, a record matched by several
+// entries is deleted once. Unless continueOnError is set, the first failed
+// deletion stops the batch and is returned along with the summary so far.
+// Listing the zone and every deletion run as separate steps of b.
+func deleteBatch(b *batchRunner, p providers.Provider, entries []batchRecord, dryRun, continueOnError bool) (deleteBatchSummary, error) {
+	summary := deleteBatchSummary{DryRun: dryRun}
+
+	var rrset []models.DNSRecord
+	err := b.run(func(ctx context.Context) error {
+		var err error
+		rrset, err = p.ListRecords(ctx, zoneParams())
+		return err
+	})
+	if err != nil {
+		return summary, err
+	}
+
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		matches := selectRecords(rrset, models.FQDN(e.Name, zone), e.Type, e.Content)
+		if len(matches) == 0 {
+			summary.NotFound = append(summary.NotFound, e)
+			continue
+		}
+
+		for _, rr := range matches {
+			if seen[rr.ID] {
+				continue
+			}
+			seen[rr.ID] = true
+
+			if dryRun {
+				summary.Deleted = append(summary.Deleted, rr)
+				continue
+			}
+
+			rr.ZoneID = zoneID
+			err := b.run(func(ctx context.Context) error {
+				return p.DeleteRR(ctx, zone, rr)
+			})
+			if errors.Is(err, errBatchDeadline) || errors.Is(err, errBatchInterrupted) {
+				return summary, err
+			}
+			if err != nil {
+				summary.Errored = append(summary.Errored, batchError{Record: rr, Err: err})
+				if b.stopped(err) {
+					return summary, err
+				}
+				if !continueOnError {
+					return summary, errors.Join(fmt.Errorf("batch stopped, use --continue-on-error to keep going"), err)
+				}
+				continue
+			}
+			summary.Deleted = append(summary.Deleted, rr)
+		}
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadBatchRecords(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "records.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`- name: www
+  type: A
+  content: 192.0.2.1
+- name: münchen
+  type: CNAME
+`), 0o600))
+
+	entries, err := readBatchRecords(path)
+	require.NoError(t, err)
+	assert.Equal(t, []batchRecord{
+		{Name: "www", Type: "A", Content: "192.0.2.1"},
+		{Name: "xn--mnchen-3ya", Type: "CNAME"},
+	}, entries)
+
+	// JSON is valid YAML
+	path = filepath.Join(dir, "records.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{"name": "www", "type": "AAAA"}]`), 0o600))
+	entries, err = readBatchRecords(path)
+	require.NoError(t, err)
+	assert.Equal(t, []batchRecord{{Name: "www", Type: "AAAA"}}, entries)
+
+	path = filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("- name: www\n"), 0o600))
+	_, err = readBatchRecords(path)
+	assert.EqualError(t, err, path+": entry 1: name and type are required")
+
+	_, err = readBatchRecords(filepath.Join(dir, "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestDeleteBatch(t *testing.T) {
+	rrset := []models.DNSRecord{
+		{ID: "1", Name: "www.example.com", Type: "A", Content: "192.0.2.1"},
+		{ID: "2", Name: "www.example.com", Type: "A", Content: "192.0.2.2"},
+		{ID: "3", Name: "www.example.com", Type: "AAAA", Content: "2001:db8::1"},
+		{ID: "4", Name: "old.example.com", Type: "CNAME", Content: "www.example.com"},
+	}
+	entries := []batchRecord{
+		{Name: "www", Type: "A", Content: "192.0.2.2"},
+		{Name: "old", Type: "CNAME"},
+		{Name: "www", Type: "MX"},
+		// Matches record 4 again, which must not be deleted twice
+		{Name: "old.example.com", Type: "cname", Content: "www.example.com."},
+		{Name: "www", Type: "AAAA"},
+	}
+
+	t.Cleanup(func() { zone, zoneID = "", "" })
+	zone, zoneID = "example.com", ""
+
+	ids := func(rrset []models.DNSRecord) []string {
+		var ids []string
+		for _, rr := range rrset {
+			ids = append(ids, rr.ID)
+		}
+		return ids
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		p := &listProvider{rrset: rrset}
+
+		summary, err := deleteBatch(testBatchRunner(t), p, entries, true, false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2", "4", "3"}, ids(summary.Deleted))
+		assert.Equal(t, []batchRecord{{Name: "www", Type: "MX"}}, summary.NotFound)
+		assert.Empty(t, p.deleted)
+		assert.Equal(t, "not found: www MX", summary.String())
+		assert.Equal(t, models.BatchResult{DryRun: true, Deleted: 3, NotFound: 1, Elapsed: time.Second}, summary.Result(time.Second))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		p := &listProvider{rrset: rrset}
+
+		summary, err := deleteBatch(testBatchRunner(t), p, entries, false, false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2", "4", "3"}, ids(p.deleted))
+		assert.Equal(t, p.deleted, summary.Deleted)
+		assert.Equal(t, "not found: www MX", summary.String())
+		assert.Equal(t, models.BatchResult{Deleted: 3, NotFound: 1, Elapsed: time.Second}, summary.Result(time.Second))
+	})
+
+	t.Run("stop on error", func(t *testing.T) {
+		p := &listProvider{rrset: rrset, deleteErr: map[string]error{"4": errors.New("rate limited")}}
+
+		summary, err := deleteBatch(testBatchRunner(t), p, entries, false, false)
+		assert.ErrorContains(t, err, "rate limited")
+		assert.Equal(t, []string{"2"}, ids(p.deleted))
+		require.Len(t, summary.Errored, 1)
+		assert.Equal(t, "4", summary.Errored[0].Record.ID)
+	})
+
+	t.Run("continue on error", func(t *testing.T) {
+		p := &listProvider{rrset: rrset, deleteErr: map[string]error{"4": errors.New("rate limited")}}
+
+		summary, err := deleteBatch(testBatchRunner(t), p, entries, false, true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2", "3"}, ids(p.deleted))
+		assert.Equal(t, "not found: www MX\nerror: old.example.com CNAME: rate limited", summary.String())
+		assert.Equal(t, models.BatchResult{Deleted: 2, NotFound: 1, Failed: 1, Elapsed: time.Second}, summary.Result(time.Second))
+	})
+}
@@ -0,0 +1,127 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+)
+
+// readCloudflareExport parses the Cloudflare export in the file at path, or
+// STDIN for -, see parseCloudflareExport.
+func readCloudflareExport(path, zone string, includeApex bool) ([]models.CreateDNSRecordParams, error) {
+	if path == "-" {
+		return parseCloudflareExport(os.Stdin, zone, includeApex)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseCloudflareExport(f, zone, includeApex)
+}
+
+// cloudflareExportRecord is a DNS record as listed by the Cloudflare API and its
+// dashboard export. Fields cdnscli does not import are ignored.
+type cloudflareExportRecord struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Content  string   `json:"content"`
+	TTL      int      `json:"ttl"`
+	Proxied  *bool    `json:"proxied"`
+	Priority *int     `json:"priority"`
+	Comment  string   `json:"comment"`
+	Tags     []string `json:"tags"`
+	ZoneName string   `json:"zone_name"`
+}
+
+// parseCloudflareExport parses DNS records exported from Cloudflare as JSON, a
+// bare array of records or an API response with them in "result", to params for
+// creating them in zone. Records of another zone, as named by their zone_name,
+// are moved to zone. The apex SOA and NS records are skipped unless includeApex
+// is set. TXT content is unquoted and the priority kept for MX, SRV and URI.
+func parseCloudflareExport(r io.Reader, zone string, includeApex bool) ([]models.CreateDNSRecordParams, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+
+	var records []cloudflareExportRecord
+	switch {
+	case bytes.HasPrefix(data, []byte("[")):
+		err = json.Unmarshal(data, &records)
+	case bytes.HasPrefix(data, []byte("{")):
+		var resp struct {
+			Result []cloudflareExportRecord `json:"result"`
+		}
+		err = json.Unmarshal(data, &resp)
+		records = resp.Result
+	default:
+		err = fmt.Errorf("expected a JSON array of DNS records")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid Cloudflare export: %w", err)
+	}
+
+	apex := strings.TrimSuffix(zone, ".")
+	params := make([]models.CreateDNSRecordParams, 0, len(records))
+	for i, rec := range records {
+		if strings.TrimSpace(rec.Name) == "" || strings.TrimSpace(rec.Type) == "" {
+			return nil, fmt.Errorf("invalid Cloudflare export: record %d: name and type are required", i+1)
+		}
+
+		rrType := strings.ToUpper(strings.TrimSpace(rec.Type))
+		name := models.FQDN(rec.Name, zone)
+		if rec.ZoneName != "" && !strings.EqualFold(strings.TrimSuffix(rec.ZoneName, "."), apex) {
+			name = rewriteZone(rec.Name, rec.ZoneName, zone)
+		}
+		if !includeApex && (rrType == "SOA" || rrType == "NS") && strings.EqualFold(name, apex) {
+			continue
+		}
+
+		p := models.CreateDNSRecordParams{
+			Comment:  rec.Comment,
+			Content:  rec.Content,
+			Name:     name,
+			Tags:     rec.Tags,
+			TTL:      rec.TTL,
+			Type:     rrType,
+			ZoneName: zone,
+		}
+		if rrType == "TXT" {
+			p.Content = models.JoinTXT(rec.Content)
+		}
+		if rec.Proxied != nil {
+			p.Proxied = *rec.Proxied
+		}
+		if rec.Priority != nil && (rrType == "MX" || rrType == "SRV" || rrType == "URI") {
+			p.Priority = *rec.Priority
+		}
+		params = append(params, p)
+	}
+
+	return params, nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cloudflareTestExport is a zone as exported from Cloudflare.
+const cloudflareTestExport = `[
+  {"id": "1", "zone_name": "example.com", "name": "example.com", "type": "NS", "content": "ada.ns.cloudflare.com", "ttl": 86400, "proxied": false},
+  {"id": "2", "zone_name": "example.com", "name": "www.example.com", "type": "A", "content": "192.0.2.1", "ttl": 1, "proxied": true, "comment": "web", "tags": ["env:prod"]},
+  {"id": "3", "zone_name": "example.com", "name": "api.example.com", "type": "A", "content": "192.0.2.2", "ttl": 300, "proxied": false},
+  {"id": "4", "zone_name": "example.com", "name": "example.com", "type": "TXT", "content": "\"v=spf1 -all\"", "ttl": 3600},
+  {"id": "5", "zone_name": "example.com", "name": "long.example.com", "type": "txt", "content": "\"first part \" \"second part\"", "ttl": 300},
+  {"id": "6", "zone_name": "example.com", "name": "example.com", "type": "MX", "content": "mail.example.com", "ttl": 3600, "priority": 10},
+  {"id": "7", "zone_name": "example.com", "name": "sub.example.com", "type": "NS", "content": "ns1.sub.example.com", "ttl": 3600, "priority": 5}
+]`
+
+func TestParseCloudflareExport(t *testing.T) {
+	params, err := parseCloudflareExport(strings.NewReader(cloudflareTestExport), "example.com", false)
+	require.NoError(t, err)
+	assert.Equal(t, []models.CreateDNSRecordParams{
+		{Name: "www.example.com", TTL: 1, Type: "A", Content: "192.0.2.1", Proxied: true, Comment: "web", Tags: []string{"env:prod"}, ZoneName: "example.com"},
+		{Name: "api.example.com", TTL: 300, Type: "A", Content: "192.0.2.2", ZoneName: "example.com"},
+		{Name: "example.com", TTL: 3600, Type: "TXT", Content: "v=spf1 -all", ZoneName: "example.com"},
+		{Name: "long.example.com", TTL: 300, Type: "TXT", Content: "first part second part", ZoneName: "example.com"},
+		{Name: "example.com", TTL: 3600, Type: "MX", Content: "mail.example.com", Priority: 10, ZoneName: "example.com"},
+		// Delegations are kept, only the apex NS records are skipped
+		{Name: "sub.example.com", TTL: 3600, Type: "NS", Content: "ns1.sub.example.com", ZoneName: "example.com"},
+	}, params)
+
+	params, err = parseCloudflareExport(strings.NewReader(cloudflareTestExport), "example.com", true)
+	require.NoError(t, err)
+	require.Len(t, params, 7)
+	assert.Equal(t, models.CreateDNSRecordParams{Name: "example.com", TTL: 86400, Type: "NS", Content: "ada.ns.cloudflare.com", ZoneName: "example.com"}, params[0])
+}
+
+func TestParseCloudflareExport_APIResponse(t *testing.T) {
+	// The records of another zone are moved to the target zone
+	resp := `{"success": true, "result": [
+	  {"zone_name": "example.org", "name": "www.example.org", "type": "A", "content": "192.0.2.1", "ttl": 1, "proxied": true},
+	  {"zone_name": "example.org", "name": "example.org", "type": "CNAME", "content": "www.example.org", "ttl": 1, "proxied": true}
+	]}`
+	params, err := parseCloudflareExport(strings.NewReader(resp), "example.com.", false)
+	require.NoError(t, err)
+	assert.Equal(t, []models.CreateDNSRecordParams{
+		{Name: "www.example.com", TTL: 1, Type: "A", Content: "192.0.2.1", Proxied: true, ZoneName: "example.com."},
+		{Name: "example.com", TTL: 1, Type: "CNAME", Content: "www.example.org", Proxied: true, ZoneName: "example.com."},
+	}, params)
+
+	// Names relative to the zone are qualified
+	params, err = parseCloudflareExport(strings.NewReader(`[{"name": "www", "type": "A", "content": "192.0.2.1"}]`), "example.com", false)
+	require.NoError(t, err)
+	require.Len(t, params, 1)
+	assert.Equal(t, "www.example.com", params[0].Name)
+}
+
+func TestParseCloudflareExport_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "empty", input: "", want: "invalid Cloudflare export: expected a JSON array of DNS records"},
+		{name: "zone file", input: "www.example.com. 300 IN A 192.0.2.1", want: "invalid Cloudflare export: expected a JSON array of DNS records"},
+		{name: "malformed", input: `[{"name": "www.example.com",`, want: "invalid Cloudflare export: unexpected end of JSON input"},
+		{name: "no type", input: `[{"name": "www.example.com", "type": "A"}, {"name": "example.com"}]`, want: "invalid Cloudflare export: record 2: name and type are required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseCloudflareExport(strings.NewReader(tt.input), "example.com", false)
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
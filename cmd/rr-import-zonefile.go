@@ -0,0 +1,65 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/miekg/dns"
+	"github.com/mixanemca/cdnscli/internal/models"
+)
+
+// readZoneFile parses the zone file at path, or STDIN for -, see parseZoneFile.
+func readZoneFile(path, zone string, includeApex bool) ([]models.CreateDNSRecordParams, error) {
+	if path == "-" {
+		return parseZoneFile(os.Stdin, "", zone, includeApex)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseZoneFile(f, path, zone, includeApex)
+}
+
+// parseZoneFile parses an RFC 1035 master file to params for creating its records
+// in zone. Relative names are completed by $ORIGIN, which starts as zone, records
+// without a TTL take the one of $TTL or the previous record. $INCLUDE is not
+// followed. The records are converted as transferred ones: the apex SOA and NS
+// records are skipped unless includeApex is set and MX content keeps its priority.
+// file names the input in parse errors.
+func parseZoneFile(r io.Reader, file, zone string, includeApex bool) ([]models.CreateDNSRecordParams, error) {
+	apex := dns.Fqdn(zone)
+
+	zp := dns.NewZoneParser(r, apex, file)
+	var rrs []dns.RR
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		if !dns.IsSubDomain(apex, rr.Header().Name) {
+			return nil, fmt.Errorf("invalid zone file: %s %s is not in zone %s", rr.Header().Name, dns.TypeToString[rr.Header().Rrtype], zone)
+		}
+		rrs = append(rrs, rr)
+	}
+	if err := zp.Err(); err != nil {
+		return nil, fmt.Errorf("invalid zone file: %w", err)
+	}
+
+	return convFromAXFR(rrs, zone, includeApex), nil
+}
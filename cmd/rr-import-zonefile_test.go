@@ -0,0 +1,99 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// zoneFileTest is a zone file as maintained for BIND.
+const zoneFileTest = `; example.com
+$TTL 1h
+@	IN SOA	ns1 hostmaster (
+		2026101501 ; serial
+		2h         ; refresh
+		1h         ; retry
+		2w         ; expire
+		1h )       ; minimum
+	IN NS	ns1
+	IN NS	ns2.example.net.
+	IN MX	10 mail
+	IN MX	20 mail.example.net.
+	IN TXT	"v=spf1 mx -all"
+
+www	300	IN A	192.0.2.1 ; web
+	300	IN AAAA	2001:db8::1
+mail	IN A	192.0.2.25
+long	IN TXT	( "first part "
+		  "second part" )
+blog.example.com.	IN CNAME	example.github.io.
+
+$ORIGIN sub.example.com.
+@	IN NS	ns1
+ns1	IN A	192.0.2.53
+`
+
+func TestParseZoneFile(t *testing.T) {
+	params, err := parseZoneFile(strings.NewReader(zoneFileTest), "example.com.zone", "example.com", false)
+	require.NoError(t, err)
+	assert.Equal(t, []models.CreateDNSRecordParams{
+		{Name: "example.com", TTL: 3600, Type: "MX", Content: "mail.example.com", Priority: 10, ZoneName: "example.com"},
+		{Name: "example.com", TTL: 3600, Type: "MX", Content: "mail.example.net", Priority: 20, ZoneName: "example.com"},
+		{Name: "example.com", TTL: 3600, Type: "TXT", Content: "v=spf1 mx -all", ZoneName: "example.com"},
+		{Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1", ZoneName: "example.com"},
+		// The owner is carried over from the previous record
+		{Name: "www.example.com", TTL: 300, Type: "AAAA", Content: "2001:db8::1", ZoneName: "example.com"},
+		{Name: "mail.example.com", TTL: 3600, Type: "A", Content: "192.0.2.25", ZoneName: "example.com"},
+		{Name: "long.example.com", TTL: 3600, Type: "TXT", Content: "first part second part", ZoneName: "example.com"},
+		{Name: "blog.example.com", TTL: 3600, Type: "CNAME", Content: "example.github.io", ZoneName: "example.com"},
+		// Relative to the changed $ORIGIN
+		{Name: "sub.example.com", TTL: 3600, Type: "NS", Content: "ns1.sub.example.com", ZoneName: "example.com"},
+		{Name: "ns1.sub.example.com", TTL: 3600, Type: "A", Content: "192.0.2.53", ZoneName: "example.com"},
+	}, params)
+
+	params, err = parseZoneFile(strings.NewReader(zoneFileTest), "", "example.com.", true)
+	require.NoError(t, err)
+	require.Len(t, params, 13)
+	assert.Equal(t, "SOA", params[0].Type)
+	assert.Equal(t, "ns1.example.com. hostmaster.example.com. 2026101501 7200 3600 1209600 3600", params[0].Content)
+	assert.Equal(t, models.CreateDNSRecordParams{Name: "example.com", TTL: 3600, Type: "NS", Content: "ns2.example.net", ZoneName: "example.com."}, params[2])
+}
+
+func TestParseZoneFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "bad address", input: "www 300 IN A 192.0.2.300\n", want: `invalid zone file: dns: bad A A: "192.0.2.300" at line: 1:24`},
+		{name: "unclosed parenthesis", input: "long 300 IN TXT ( \"first part\"\n", want: "invalid zone file: dns: "},
+		{name: "out of zone", input: "www.example.org. 300 IN A 192.0.2.1\n", want: "invalid zone file: www.example.org. A is not in zone example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseZoneFile(strings.NewReader(tt.input), "", "example.com", false)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
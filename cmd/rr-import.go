@@ -0,0 +1,328 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/mixanemca/cdnscli/internal/app"
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/mixanemca/cdnscli/internal/providers"
+	"github.com/mixanemca/cdnscli/internal/workerpool"
+	"github.com/spf13/cobra"
+)
+
+var (
+	axfrServer     string
+	cloudflareJSON string
+	importFile     string
+	includeApex    bool
+)
+
+// rrImportCmd represents the import command
+var rrImportCmd = &cobra.Command{
+	Args:  cobra.NoArgs,
+	Use:   "import",
+	Short: "Import resource records by a zone transfer (AXFR), from a zone file or a Cloudflare export",
+	Long: `Import resource records by a zone transfer (AXFR) from a name server, from
+a zone file in the BIND (RFC 1035) format, or from the DNS records of a zone
+exported from Cloudflare as JSON: the array of records, or the response of the
+API listing them. Relative names of a zone file are completed by its $ORIGIN,
+the zone by default.
+
+The records are created in the zone of the current provider. The SOA and NS
+records of the zone apex describe the source name servers and are skipped,
+unless --include-apex is given. DNSSEC records are always skipped. Proxying,
+comments and tags of a Cloudflare export are dropped when the provider does
+not support them.`,
+	Example: `  cdnscli rr import --zone example.com --axfr ns1.example.com:53
+  cdnscli rr import --zone example.com --axfr 192.0.2.53 --dry-run
+  cdnscli rr import --zone example.com --axfr ns1.example.com:53 --upsert
+  cdnscli rr import --zone example.com --file example.com.zone
+  cdnscli rr import --zone example.com --cloudflare-json records.json
+  curl -s -H "Authorization: Bearer $CF_API_TOKEN" "https://api.cloudflare.com/client/v4/zones/$ZONE_ID/dns_records?per_page=5000" | cdnscli rr import --zone example.com --cloudflare-json - --provider pdns`,
+	Run: rrImportCmdRun,
+}
+
+func init() {
+	rrCmd.AddCommand(rrImportCmd)
+
+	rrImportCmd.PersistentFlags().StringVarP(&zone, "zone", "z", "", "Zone name")
+	if err := rrImportCmd.MarkPersistentFlagRequired("zone"); err != nil {
+		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "zone", err)
+	}
+	rrImportCmd.PersistentFlags().StringVar(&zoneID, "zone-id", "", "Zone ID, skips looking the zone up by name")
+	rrImportCmd.PersistentFlags().StringVar(&axfrServer, "axfr", "", "Name server to transfer the zone from, port defaults to 53")
+	rrImportCmd.PersistentFlags().StringVar(&cloudflareJSON, "cloudflare-json", "", "File with the DNS records exported from Cloudflare as JSON, - for STDIN")
+	rrImportCmd.PersistentFlags().StringVarP(&importFile, "file", "f", "", "Zone file in the BIND format to import, - for STDIN")
+	rrImportCmd.MarkFlagsOneRequired("axfr", "file", "cloudflare-json")
+	rrImportCmd.MarkFlagsMutuallyExclusive("axfr", "file", "cloudflare-json")
+	rrImportCmd.PersistentFlags().BoolVar(&includeApex, "include-apex", false, "Also import the SOA and NS records of the zone apex")
+	rrImportCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print the records that would be created without creating them")
+	rrImportCmd.PersistentFlags().BoolVar(&continueOnError, "continue-on-error", false, "Keep creating the remaining records when a creation fails")
+	rrImportCmd.PersistentFlags().BoolVar(&upsert, "upsert", false, "Update records already in the zone instead of failing, so an import can be re-run")
+	addBatchTimeoutFlags(rrImportCmd)
+	addConcurrencyFlag(rrImportCmd)
+}
+
+func rrImportCmdRun(cmd *cobra.Command, args []string) {
+	a, err := app.New(
+		app.WithConfig(appConfig),
+		app.WithOutputFormat(outputFormat),
+		app.WithOutputFields(outputFields),
+		app.WithOutputWriter(outputWriter),
+	)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if err := namesToASCII(); err != nil {
+		exitWithError(err)
+	}
+	if err := workerpool.ValidateSize(concurrency); err != nil {
+		exitWithError(err)
+	}
+
+	var params []models.CreateDNSRecordParams
+	switch {
+	case cloudflareJSON != "":
+		params, err = readCloudflareExport(cloudflareJSON, zone, includeApex)
+		if err != nil {
+			exitWithError(err)
+		}
+		caps := a.Provider().Capabilities()
+		for i := range params {
+			params[i] = dropUnsupported(caps, params[i])
+		}
+	case importFile != "":
+		params, err = readZoneFile(importFile, zone, includeApex)
+		if err != nil {
+			exitWithError(err)
+		}
+	default:
+		rrs, err := transferZone(axfrServer, zone)
+		if err != nil {
+			exitWithError(err)
+		}
+		params = convFromAXFR(rrs, zone, includeApex)
+	}
+	for i := range params {
+		params[i].ZoneID = zoneID
+	}
+
+	if dryRun {
+		rrset := make([]models.DNSRecord, 0, len(params))
+		for _, p := range params {
+			rrset = append(rrset, models.DNSRecord{Name: p.Name, TTL: p.TTL, Type: p.Type, Content: p.Content, Priority: p.Priority, Proxied: p.Proxied})
+		}
+		a.Printer().RecordsList(rrset)
+		return
+	}
+
+	ctx, stop := interruptContext(context.Background())
+	defer stop()
+
+	b, cancel := newBatchRunner(ctx, recordTimeout, batchTimeout)
+	defer cancel()
+	b.concurrency = concurrency
+
+	start := time.Now()
+	created, errored, err := importRecords(b, a.Provider(), zone, params, continueOnError, upsert)
+	for _, rr := range created {
+		a.Printer().RecordAdd(rr)
+	}
+	if !quiet {
+		for _, e := range errored {
+			fmt.Fprintf(os.Stderr, "error: %s %s: %v\n", models.NameToUnicode(e.Record.Name), e.Record.Type, e.Err)
+		}
+	}
+	a.Printer().BatchSummary(models.BatchResult{
+		Created: len(created),
+		Failed:  len(errored),
+		Elapsed: time.Since(start),
+	})
+	if err != nil {
+		exitWithError(err)
+	}
+	if len(errored) > 0 {
+		exitWithError(fmt.Errorf("%d records could not be created", len(errored)))
+	}
+}
+
+// transferZone transfers the zone from server by AXFR. The SOA record closing
+// the transfer is dropped.
+func transferZone(server, zone string) ([]dns.RR, error) {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "53")
+	}
+
+	m := new(dns.Msg)
+	m.SetAxfr(dns.Fqdn(zone))
+
+	t := &dns.Transfer{
+		DialTimeout:  getTimeout(),
+		ReadTimeout:  getTimeout(),
+		WriteTimeout: getTimeout(),
+	}
+	ch, err := t.In(m, server)
+	if err != nil {
+		return nil, fmt.Errorf("zone transfer of %s from %s failed: %w", zone, server, err)
+	}
+
+	var rrs []dns.RR
+	for env := range ch {
+		if env.Error != nil {
+			return nil, fmt.Errorf("zone transfer of %s from %s failed: %w", zone, server, env.Error)
+		}
+		rrs = append(rrs, env.RR...)
+	}
+
+	// A transfer starts and ends with the SOA record, keep it once
+	if n := len(rrs); n > 1 && rrs[n-1].Header().Rrtype == dns.TypeSOA {
+		rrs = rrs[:n-1]
+	}
+
+	return rrs, nil
+}
+
+// axfrSkipTypes are record types maintained by the signing name server, not imported.
+var axfrSkipTypes = map[uint16]bool{
+	dns.TypeDNSKEY:     true,
+	dns.TypeNSEC:       true,
+	dns.TypeNSEC3:      true,
+	dns.TypeNSEC3PARAM: true,
+	dns.TypeRRSIG:      true,
+}
+
+// convFromAXFR converts transferred records to params for creating them in zone.
+// The apex SOA and NS records are skipped unless includeApex is set.
+func convFromAXFR(rrs []dns.RR, zone string, includeApex bool) []models.CreateDNSRecordParams {
+	apex := dns.Fqdn(zone)
+
+	params := make([]models.CreateDNSRecordParams, 0, len(rrs))
+	for _, rr := range rrs {
+		h := rr.Header()
+		if axfrSkipTypes[h.Rrtype] {
+			continue
+		}
+		if !includeApex && (h.Rrtype == dns.TypeSOA || h.Rrtype == dns.TypeNS) && strings.EqualFold(h.Name, apex) {
+			continue
+		}
+		params = append(params, convFromAXFRRR(rr, zone))
+	}
+
+	return params
+}
+
+// convFromAXFRRR converts a single transferred record. Names and content lose the
+// trailing dot and TXT character-strings are joined, as in records read from providers.
+// The MX and SRV priority is split off the content, see models.JoinPriority.
+func convFromAXFRRR(rr dns.RR, zone string) models.CreateDNSRecordParams {
+	h := rr.Header()
+	p := models.CreateDNSRecordParams{
+		Name:     strings.TrimSuffix(h.Name, "."),
+		TTL:      int(h.Ttl),
+		Type:     dns.TypeToString[h.Rrtype],
+		ZoneName: zone,
+	}
+
+	switch rr := rr.(type) {
+	case *dns.TXT:
+		p.Content = models.JoinTXT(strings.TrimPrefix(rr.String(), h.String()))
+	case *dns.MX:
+		p.Priority = int(rr.Preference)
+		p.Content = strings.TrimSuffix(rr.Mx, ".")
+	case *dns.SRV:
+		p.Priority = int(rr.Priority)
+		p.Content = fmt.Sprintf("%d %d %s", rr.Weight, rr.Port, strings.TrimSuffix(rr.Target, "."))
+	default:
+		p.Content = strings.TrimSuffix(strings.TrimPrefix(rr.String(), h.String()), ".")
+	}
+
+	return p
+}
+
+// importRecords creates the records in zone through the provider, each in a step of b.
+// Up to b.concurrency records are created at the same time, the results are kept
+// in the order of params. Unless continueOnError is set, the first failed creation
+// stops the import and is returned. Passing the batch deadline always stops it.
+// With upsert records already in the zone are updated rather than created again.
+func importRecords(b *batchRunner, p providers.Provider, zone string, params []models.CreateDNSRecordParams, continueOnError, upsert bool) ([]models.DNSRecord, []batchError, error) {
+	var (
+		created []models.DNSRecord
+		errored []batchError
+		stopErr error
+	)
+
+	add := p.AddRR
+	if upsert {
+		add = p.UpsertRR
+	}
+
+	// Canceling ctx starts no more records, those running are finished
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+
+	results := workerpool.Run(ctx, b.concurrency, params, func(_ context.Context, param models.CreateDNSRecordParams) (models.DNSRecord, error) {
+		var rr models.DNSRecord
+		err := b.run(func(ctx context.Context) error {
+			var err error
+			rr, err = add(ctx, zone, param)
+			return err
+		})
+		if err != nil && (!continueOnError || b.stopped(err)) {
+			stop()
+		}
+		return rr, err
+	})
+
+	for i, r := range results {
+		if !r.Done {
+			continue
+		}
+		if errors.Is(r.Err, errBatchDeadline) || errors.Is(r.Err, errBatchInterrupted) {
+			if stopErr == nil {
+				stopErr = r.Err
+			}
+			continue
+		}
+		if r.Err != nil {
+			param := params[i]
+			errored = append(errored, batchError{
+				Record: models.DNSRecord{Name: param.Name, TTL: param.TTL, Type: param.Type, Content: param.Content},
+				Err:    r.Err,
+			})
+			if stopErr == nil && b.stopped(r.Err) {
+				stopErr = r.Err
+			} else if stopErr == nil && !continueOnError {
+				stopErr = errors.Join(fmt.Errorf("import stopped, use --continue-on-error to keep going"), r.Err)
+			}
+			continue
+		}
+		created = append(created, r.Value)
+	}
+
+	return created, errored, stopErr
+}
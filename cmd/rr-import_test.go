@@ -0,0 +1,141 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// axfrTestZone is the zone used by the AXFR import tests.
+var axfrTestZone = []string{
+	"example.com. 3600 IN SOA ns1.example.com. admin.example.com. 1 7200 3600 1209600 3600",
+	"example.com. 3600 IN NS ns1.example.com.",
+	"example.com. 3600 IN MX 10 mail.example.com.",
+	"_sip._tcp.example.com. 3600 IN SRV 10 5 5060 sip.example.com.",
+	`example.com. 3600 IN TXT "v=spf1 -all"`,
+	`long.example.com. 300 IN TXT "first part " "second part"`,
+	"www.example.com. 300 IN A 192.0.2.1",
+	"blog.example.com. 300 IN CNAME example.github.io.",
+	"sub.example.com. 3600 IN NS ns1.sub.example.com.",
+	"example.com. 3600 IN RRSIG A 13 2 300 20261101000000 20261001000000 12345 example.com. AAAA",
+}
+
+func axfrTestRRs(t *testing.T) []dns.RR {
+	t.Helper()
+
+	rrs := make([]dns.RR, 0, len(axfrTestZone))
+	for _, s := range axfrTestZone {
+		rr, err := dns.NewRR(s)
+		require.NoError(t, err)
+		rrs = append(rrs, rr)
+	}
+	return rrs
+}
+
+func TestConvFromAXFR(t *testing.T) {
+	params := convFromAXFR(axfrTestRRs(t), "example.com", false)
+	assert.Equal(t, []models.CreateDNSRecordParams{
+		// The priority is kept apart from the content
+		{Name: "example.com", TTL: 3600, Type: "MX", Content: "mail.example.com", Priority: 10, ZoneName: "example.com"},
+		{Name: "_sip._tcp.example.com", TTL: 3600, Type: "SRV", Content: "5 5060 sip.example.com", Priority: 10, ZoneName: "example.com"},
+		{Name: "example.com", TTL: 3600, Type: "TXT", Content: "v=spf1 -all", ZoneName: "example.com"},
+		{Name: "long.example.com", TTL: 300, Type: "TXT", Content: "first part second part", ZoneName: "example.com"},
+		{Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1", ZoneName: "example.com"},
+		{Name: "blog.example.com", TTL: 300, Type: "CNAME", Content: "example.github.io", ZoneName: "example.com"},
+		// Delegations are kept, only the apex NS records are skipped
+		{Name: "sub.example.com", TTL: 3600, Type: "NS", Content: "ns1.sub.example.com", ZoneName: "example.com"},
+	}, params)
+
+	params = convFromAXFR(axfrTestRRs(t), "example.com.", true)
+	require.Len(t, params, 9)
+	assert.Equal(t, "SOA", params[0].Type)
+	assert.Equal(t, "ns1.example.com. admin.example.com. 1 7200 3600 1209600 3600", params[0].Content)
+	assert.Equal(t, models.CreateDNSRecordParams{Name: "example.com", TTL: 3600, Type: "NS", Content: "ns1.example.com", ZoneName: "example.com."}, params[1])
+}
+
+func TestTransferZone(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	started := make(chan struct{})
+	srv := &dns.Server{
+		Listener:          ln,
+		NotifyStartedFunc: func() { close(started) },
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+			rrs := axfrTestRRs(t)
+			ch := make(chan *dns.Envelope, 1)
+			ch <- &dns.Envelope{RR: append(rrs, rrs[0])}
+			close(ch)
+			_ = new(dns.Transfer).Out(w, req, ch)
+			w.Hijack()
+		}),
+	}
+	go func() { _ = srv.ActivateAndServe() }()
+	t.Cleanup(func() { _ = srv.Shutdown() })
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("fake name server did not start")
+	}
+
+	rrs, err := transferZone(ln.Addr().String(), "example.com")
+	require.NoError(t, err)
+	// The closing SOA record is dropped
+	assert.Len(t, rrs, len(axfrTestZone))
+
+	_, err = transferZone("127.0.0.1:1", "example.com")
+	assert.Error(t, err)
+}
+
+func TestImportRecords(t *testing.T) {
+	params := []models.CreateDNSRecordParams{
+		{Name: "www.example.com", Type: "A", Content: "192.0.2.1"},
+		{Name: "blog.example.com", Type: "CNAME", Content: "example.github.io"},
+		{Name: "example.com", Type: "MX", Content: "10 mail.example.com"},
+	}
+	addErr := map[string]error{"blog.example.com": errors.New("record already exists")}
+
+	p := &listProvider{addErr: addErr}
+	created, errored, err := importRecords(testBatchRunner(t), p, "example.com", params, false, false)
+	assert.ErrorContains(t, err, "record already exists")
+	assert.Len(t, created, 1)
+	require.Len(t, errored, 1)
+	assert.Equal(t, "blog.example.com", errored[0].Record.Name)
+
+	p = &listProvider{addErr: addErr}
+	created, errored, err = importRecords(testBatchRunner(t), p, "example.com", params, true, false)
+	require.NoError(t, err)
+	assert.Len(t, created, 2)
+	assert.Len(t, errored, 1)
+	assert.Equal(t, []models.CreateDNSRecordParams{params[0], params[2]}, p.added)
+
+	p = &listProvider{addErr: addErr}
+	created, errored, err = importRecords(testBatchRunner(t), p, "example.com", params, false, true)
+	require.NoError(t, err)
+	assert.Len(t, created, 3)
+	assert.Empty(t, errored)
+	assert.Empty(t, p.added)
+	assert.Equal(t, params, p.upserted)
+}
@@ -18,56 +18,105 @@ package cmd
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"os"
+	"strings"
 
 	"github.com/mixanemca/cdnscli/internal/app"
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/mixanemca/cdnscli/internal/providers"
 	"github.com/spf13/cobra"
 )
 
 // rrInfoCmd represents the info (details) command
 var rrInfoCmd = &cobra.Command{
-	Aliases: []string{"details"},
+	Aliases: []string{"details", "get"},
 	Args:    cobra.NoArgs,
 	Use:     "info",
 	Short:   "Details for a single DNS record",
-	Example: `  cdnscli rr info --name www --zone example.com`,
-	Run:     rrInfoCmdRun,
+	Example: `  cdnscli rr info --name www --zone example.com
+  cdnscli rr info --name www --zone example.com --type AAAA
+  cdnscli rr get --id 372e67954025e0ba6aaa6d586b9e0b59 --zone example.com
+  cdnscli rr info --name www.example.com --zone-id 023e105f4ecef8ad9ca31a8372d0c353`,
+	Run: rrInfoCmdRun,
 }
 
 func init() {
 	rrCmd.AddCommand(rrInfoCmd)
 
 	rrInfoCmd.PersistentFlags().StringVarP(&name, "name", "n", "", "recource record name")
-	if err := rrInfoCmd.MarkPersistentFlagRequired("name"); err != nil {
-		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "name", err)
-	}
-	rrInfoCmd.PersistentFlags().StringVarP(&zone, "zone", "z", "", "zone name")
-	if err := rrInfoCmd.MarkPersistentFlagRequired("zone"); err != nil {
-		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "zone", err)
-	}
-
+	rrInfoCmd.PersistentFlags().StringVarP(&recordID, "id", "i", "", "resource record ID, fetches the record directly")
+	rrInfoCmd.PersistentFlags().StringVarP(&rrtype, "type", "t", "", "select only records of this type")
+	rrInfoCmd.PersistentFlags().StringVarP(&content, "content", "c", "", "select only records with this content")
+	addZoneFlags(rrInfoCmd)
+	rrInfoCmd.MarkFlagsOneRequired("name", "id")
+	rrInfoCmd.MarkFlagsMutuallyExclusive("name", "id")
 }
 
 func rrInfoCmdRun(cmd *cobra.Command, args []string) {
 	a, err := app.New(
 		app.WithConfig(appConfig),
 		app.WithOutputFormat(outputFormat),
+		app.WithOutputFields(outputFields),
+		app.WithOutputWriter(outputWriter),
 	)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		exitWithError(err)
+	}
+
+	if err := namesToASCII(); err != nil {
+		exitWithError(err)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), getTimeout())
 	defer cancel()
 
-	rr, err := a.Provider().GetRRByName(ctx, zone, name)
+	if recordID != "" {
+		params := zoneParams()
+		params.ID = recordID
+		rr, err := a.Provider().GetRRByID(ctx, params)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		a.Printer().RecordInfo(rr)
+		return
+	}
+
+	rrset, err := a.Provider().ListRecords(ctx, zoneParams())
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		exitWithError(err)
+	}
+
+	matches := selectRecords(rrset, models.FQDN(name, zone), rrtype, content)
+	switch len(matches) {
+	case 0:
+		exitWithError(providers.NewNotFoundError("record", models.NameToUnicode(models.FQDN(name, zone)), nil))
+	case 1:
+		a.Printer().RecordInfo(matches[0])
+	default:
+		// Several records share the name, print them all rather than an arbitrary one
+		if err := models.SortRecords(matches, models.DefaultRecordSort, false); err != nil {
+			exitWithError(err)
+		}
+		a.Printer().RecordsList(matches)
+	}
+}
+
+// selectRecords returns the records with the given name. A non-empty rrtype or
+// content narrows the result to records of that type or with that content.
+func selectRecords(rrset []models.DNSRecord, name, rrtype, content string) []models.DNSRecord {
+	var matches []models.DNSRecord
+	for _, rr := range rrset {
+		if !strings.EqualFold(strings.TrimSuffix(rr.Name, "."), strings.TrimSuffix(name, ".")) {
+			continue
+		}
+		if rrtype != "" && !strings.EqualFold(rr.Type, rrtype) {
+			continue
+		}
+		if content != "" && strings.TrimSuffix(rr.Content, ".") != strings.TrimSuffix(content, ".") {
+			continue
+		}
+		matches = append(matches, rr)
 	}
 
-	a.Printer().RecordInfo(rr)
+	return matches
 }
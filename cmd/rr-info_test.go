@@ -0,0 +1,62 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectRecords(t *testing.T) {
+	rrset := []models.DNSRecord{
+		{ID: "1", Name: "www.example.com", Type: "A", Content: "192.0.2.1"},
+		{ID: "2", Name: "www.example.com", Type: "A", Content: "192.0.2.2"},
+		{ID: "3", Name: "www.example.com", Type: "AAAA", Content: "2001:db8::1"},
+		{ID: "4", Name: "mail.example.com", Type: "A", Content: "192.0.2.1"},
+	}
+
+	ids := func(rrset []models.DNSRecord) []string {
+		var ids []string
+		for _, rr := range rrset {
+			ids = append(ids, rr.ID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name    string
+		rrname  string
+		rrtype  string
+		content string
+		want    []string
+	}{
+		{name: "all records with name", rrname: "www.example.com", want: []string{"1", "2", "3"}},
+		{name: "by type", rrname: "www.example.com", rrtype: "aaaa", want: []string{"3"}},
+		{name: "by type and content", rrname: "www.example.com", rrtype: "A", content: "192.0.2.2", want: []string{"2"}},
+		{name: "by content", rrname: "www.example.com", content: "192.0.2.1", want: []string{"1"}},
+		{name: "name is case insensitive", rrname: "WWW.example.com.", rrtype: "AAAA", want: []string{"3"}},
+		{name: "no match", rrname: "www.example.com", rrtype: "MX"},
+		{name: "unknown name", rrname: "ftp.example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ids(selectRecords(rrset, tt.rrname, tt.rrtype, tt.content)))
+		})
+	}
+}
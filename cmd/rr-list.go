@@ -18,12 +18,9 @@ package cmd
 
 import (
 	"context"
-	"fmt"
-	"log"
 	"os"
 
 	"github.com/mixanemca/cdnscli/internal/app"
-	"github.com/mixanemca/cdnscli/internal/models"
 	"github.com/spf13/cobra"
 )
 
@@ -33,39 +30,55 @@ var rrListCmd = &cobra.Command{
 	Args:    cobra.NoArgs,
 	Use:     "list",
 	Short:   "List of zone resource records",
-	Example: `  cdnscli rr list --zone example.com`,
-	Run:     rrListCmdRun,
+	Example: `  cdnscli rr list --zone example.com
+  cdnscli rr list --zone-id 023e105f4ecef8ad9ca31a8372d0c353
+  cdnscli rr list --zone example.com --sort ttl --reverse
+  cdnscli rr list --zone example.com --type A --type AAAA --proxied-only
+  cdnscli rr list --zone example.com --since 24h
+  cdnscli rr list --zone example.com --sort ttl --max 10`,
+	Run: rrListCmdRun,
 }
 
 func init() {
 	rrCmd.AddCommand(rrListCmd)
 
-	rrListCmd.PersistentFlags().StringVarP(&zone, "zone", "z", "", "zone name")
-	if err := rrListCmd.MarkPersistentFlagRequired("zone"); err != nil {
-		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "zone", err)
-	}
+	addZoneFlags(rrListCmd)
+	addSortFlags(rrListCmd)
+	addFilterFlags(rrListCmd)
+	addMaxFlag(rrListCmd)
 }
 
 func rrListCmdRun(cmd *cobra.Command, args []string) {
+	if err := checkSortFlags(); err != nil {
+		exitWithError(err)
+	}
+
 	a, err := app.New(
 		app.WithConfig(appConfig),
 		app.WithOutputFormat(outputFormat),
+		app.WithOutputFields(outputFields),
+		app.WithOutputWriter(outputWriter),
 	)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		exitWithError(err)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), getTimeout())
 	defer cancel()
 
-	recs, err := a.Provider().ListRecords(ctx, models.ListDNSRecordsParams{
-		ZoneName: zone,
-	})
+	recs, err := a.Provider().ListRecords(ctx, zoneParams())
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		exitWithError(err)
 	}
 
+	recs = filterRecords(recs)
+	if err := sortRecords(recs); err != nil {
+		exitWithError(err)
+	}
+
+	recs, omitted := limitRecords(recs)
 	a.Printer().RecordsList(recs)
+	if !quiet {
+		noteTruncated(os.Stderr, len(recs), omitted)
+	}
 }
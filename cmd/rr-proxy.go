@@ -0,0 +1,118 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"log"
+
+	"github.com/mixanemca/cdnscli/internal/app"
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/mixanemca/cdnscli/internal/providers"
+	"github.com/spf13/cobra"
+)
+
+var (
+	proxyOn  bool
+	proxyOff bool
+)
+
+// rrProxyCmd represents the proxy command
+var rrProxyCmd = &cobra.Command{
+	Args:  cobra.NoArgs,
+	Use:   "proxy",
+	Short: "Turn proxying of a DNS record on or off",
+	Long: `Turn proxying of a DNS record through the provider's CDN on or off.
+Only the proxied flag changes, the content, TTL, comment and tags are kept.
+Only A, AAAA and CNAME records can be proxied.`,
+	Example: `  cdnscli rr proxy --name www --zone example.com --on
+  cdnscli rr proxy --name www --zone example.com --type AAAA --off`,
+	Run: rrProxyCmdRun,
+}
+
+func init() {
+	rrCmd.AddCommand(rrProxyCmd)
+
+	rrProxyCmd.PersistentFlags().StringVarP(&name, "name", "n", "", "recource record name")
+	if err := rrProxyCmd.MarkPersistentFlagRequired("name"); err != nil {
+		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "name", err)
+	}
+	rrProxyCmd.PersistentFlags().StringVarP(&rrtype, "type", "t", "", "select only records of this type")
+	rrProxyCmd.PersistentFlags().StringVarP(&content, "content", "c", "", "select only records with this content")
+	rrProxyCmd.PersistentFlags().BoolVar(&proxyOn, "on", false, "Proxy the record")
+	rrProxyCmd.PersistentFlags().BoolVar(&proxyOff, "off", false, "Stop proxying the record")
+	rrProxyCmd.MarkFlagsOneRequired("on", "off")
+	rrProxyCmd.MarkFlagsMutuallyExclusive("on", "off")
+	addZoneFlags(rrProxyCmd)
+}
+
+func rrProxyCmdRun(cmd *cobra.Command, args []string) {
+	a, err := app.New(
+		app.WithConfig(appConfig),
+		app.WithOutputFormat(outputFormat),
+		app.WithOutputFields(outputFields),
+		app.WithOutputWriter(outputWriter),
+	)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if err := namesToASCII(); err != nil {
+		exitWithError(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), getTimeout())
+	defer cancel()
+
+	rr, err := findRecord(ctx, a.Provider(), name, rrtype, content)
+	if err != nil {
+		exitWithError(err)
+	}
+	if rr.Proxied == proxyOn {
+		// Nothing to change
+		a.Printer().RecordInfo(rr)
+		return
+	}
+
+	rr, err = setProxied(a.Provider().Capabilities(), rr, proxyOn)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	updated, err := a.Provider().UpdateRR(ctx, zone, rr)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	a.Printer().RecordUpdate(updated)
+}
+
+// setProxied returns rr with only the proxied flag set to on. Turning proxying on
+// fails for providers without a CDN and for record types that cannot be proxied.
+func setProxied(caps models.Capabilities, rr models.DNSRecord, on bool) (models.DNSRecord, error) {
+	if on {
+		if err := providers.CheckCapabilities(caps, models.CreateDNSRecordParams{Type: rr.Type, Proxied: true}); err != nil {
+			return models.DNSRecord{}, err
+		}
+		if err := models.ValidateProxied(rr.Type); err != nil {
+			return models.DNSRecord{}, err
+		}
+	}
+
+	rr.Proxied = on
+	return rr, nil
+}
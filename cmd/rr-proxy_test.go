@@ -0,0 +1,61 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/mixanemca/cdnscli/internal/providers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetProxied(t *testing.T) {
+	caps := models.Capabilities{RecordTypes: []string{"A", "AAAA", "CNAME", "MX"}, Proxied: true, Comments: true, Tags: true}
+	rr := models.DNSRecord{
+		ID:      "372e67954025e0ba6aaa6d586b9e0b59",
+		Name:    "www.example.com",
+		TTL:     300,
+		Type:    "A",
+		Content: "192.0.2.1",
+		Comment: "web frontend",
+		Tags:    []string{"env:prod"},
+		ZoneID:  "023e105f4ecef8ad9ca31a8372d0c353",
+	}
+
+	on, err := setProxied(caps, rr, true)
+	require.NoError(t, err)
+	want := rr
+	want.Proxied = true
+	// Only the proxied flag changes
+	assert.Equal(t, want, on)
+
+	off, err := setProxied(caps, on, false)
+	require.NoError(t, err)
+	assert.Equal(t, rr, off)
+
+	mx := models.DNSRecord{Name: "example.com", Type: "MX", Content: "mail.example.com", Priority: 10}
+	_, err = setProxied(caps, mx, true)
+	assert.EqualError(t, err, "MX records cannot be proxied, only A, AAAA, CNAME records")
+	// Turning proxying off is always allowed
+	_, err = setProxied(caps, mx, false)
+	assert.NoError(t, err)
+
+	_, err = setProxied(models.Capabilities{RecordTypes: []string{"A"}}, rr, true)
+	assert.ErrorIs(t, err, providers.ErrUnsupported)
+}
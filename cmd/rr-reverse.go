@@ -0,0 +1,194 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/mixanemca/cdnscli/internal/app"
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/mixanemca/cdnscli/internal/providers"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reverseIP  string
+	reverseSet string
+)
+
+// rrReverseCmd represents the reverse command
+var rrReverseCmd = &cobra.Command{
+	Aliases: []string{"ptr"},
+	Args:    cobra.NoArgs,
+	Use:     "reverse",
+	Short:   "Show or set the PTR record of an IP address",
+	Long: `Show or set the PTR record of an IP address. The record name is computed
+from the address: 1.2.0.192.in-addr.arpa for 192.0.2.1, and one label per
+nibble under ip6.arpa for IPv6 addresses.
+
+The reverse zone is the longest zone of the provider the name belongs to,
+or the one given with --zone. With --set the PTR record is created, or the
+existing one is changed to point to the given host name.`,
+	Example: `  cdnscli rr reverse --ip 192.0.2.1
+  cdnscli rr reverse --ip 192.0.2.1 --set www.example.com
+  cdnscli rr reverse --ip 2001:db8::1 --set www.example.com --ttl 3600
+  cdnscli rr ptr --ip 192.0.2.1 --zone 2.0.192.in-addr.arpa`,
+	Run: rrReverseCmdRun,
+}
+
+func init() {
+	rrCmd.AddCommand(rrReverseCmd)
+
+	rrReverseCmd.PersistentFlags().StringVar(&reverseIP, "ip", "", "IPv4 or IPv6 address")
+	if err := rrReverseCmd.MarkPersistentFlagRequired("ip"); err != nil {
+		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "ip", err)
+	}
+	rrReverseCmd.PersistentFlags().StringVar(&reverseSet, "set", "", "Host name the PTR record points to, creates or changes the record")
+	rrReverseCmd.PersistentFlags().StringVarP(&ttlArg, "ttl", "l", "", "The time to live of the PTR record in seconds or \"auto\", used with --set (default is provider's default_ttl or 1800)")
+	rrReverseCmd.PersistentFlags().StringVarP(&zone, "zone", "z", "", "Reverse zone name (default is the longest zone of the provider matching the address)")
+}
+
+func rrReverseCmdRun(cmd *cobra.Command, args []string) {
+	a, err := app.New(
+		app.WithConfig(appConfig),
+		app.WithOutputFormat(outputFormat),
+		app.WithOutputFields(outputFields),
+		app.WithOutputWriter(outputWriter),
+	)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	arpa, err := models.ReverseName(reverseIP)
+	if err != nil {
+		exitWithError(err)
+	}
+	if reverseSet != "" {
+		if err := models.ValidateName(reverseSet); err != nil {
+			exitWithError(err)
+		}
+		if ttl, err = models.ParseTTL(ttlArg); err != nil {
+			exitWithError(err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), getTimeout())
+	defer cancel()
+
+	if zone == "" {
+		zones, err := a.Provider().ListZones(ctx)
+		if err != nil {
+			exitWithError(err)
+		}
+		z, ok := reverseZone(zones, arpa)
+		if !ok {
+			exitWithError(providers.NewNotFoundError("reverse zone", arpa, nil))
+		}
+		zone, zoneID = z.Name, z.ID
+	} else if models.FQDN(arpa, zone) != arpa {
+		exitWithError(fmt.Errorf("%s is not in zone %s", arpa, zone))
+	}
+
+	if reverseSet == "" {
+		ptrs, err := findPTR(ctx, a.Provider(), arpa)
+		if err != nil {
+			exitWithError(err)
+		}
+		if len(ptrs) == 0 {
+			exitWithError(providers.NewNotFoundError("PTR record", arpa, nil))
+		}
+		if len(ptrs) == 1 {
+			a.Printer().RecordInfo(ptrs[0])
+		} else {
+			a.Printer().RecordsList(ptrs)
+		}
+		return
+	}
+
+	rr, created, err := setPTR(ctx, a.Provider(), arpa, reverseSet, ttl, cmd.Flags().Changed("ttl"))
+	if err != nil {
+		exitWithError(err)
+	}
+	if created {
+		a.Printer().RecordAdd(rr)
+	} else {
+		a.Printer().RecordUpdate(rr)
+	}
+}
+
+// reverseZone returns the zone with the longest name that name belongs to.
+func reverseZone(zones []models.Zone, name string) (models.Zone, bool) {
+	var found models.Zone
+	for _, z := range zones {
+		zn := strings.TrimSuffix(z.Name, ".")
+		if zn == "" || models.FQDN(name, zn) != name {
+			continue
+		}
+		if len(zn) > len(strings.TrimSuffix(found.Name, ".")) {
+			found = z
+		}
+	}
+
+	return found, found.Name != ""
+}
+
+// findPTR returns the PTR records named arpa in the current zone.
+func findPTR(ctx context.Context, p providers.Provider, arpa string) ([]models.DNSRecord, error) {
+	rrset, err := p.ListRecords(ctx, zoneParams())
+	if err != nil {
+		return nil, err
+	}
+
+	return selectRecords(rrset, arpa, "PTR", ""), nil
+}
+
+// setPTR points the PTR record named arpa to host. Without a PTR record one is
+// created, an existing one is updated, keeping its TTL unless setTTL is true.
+// Several PTR records for the name are an error, as it is unclear which to change.
+func setPTR(ctx context.Context, p providers.Provider, arpa, host string, ttl int, setTTL bool) (models.DNSRecord, bool, error) {
+	ptrs, err := findPTR(ctx, p, arpa)
+	if err != nil {
+		return models.DNSRecord{}, false, err
+	}
+
+	switch len(ptrs) {
+	case 0:
+		rr, err := p.AddRR(ctx, zone, models.CreateDNSRecordParams{
+			Content:  host,
+			Name:     arpa,
+			TTL:      ttl,
+			Type:     "PTR",
+			ZoneID:   zoneID,
+			ZoneName: zone,
+		})
+		return rr, true, err
+	case 1:
+		rr := ptrs[0]
+		rr.Content = host
+		rr.ZoneID = zoneID
+		if setTTL {
+			rr.TTL = ttl
+		}
+		rr, err := p.UpdateRR(ctx, zone, rr)
+		return rr, false, err
+	default:
+		return models.DNSRecord{}, false, fmt.Errorf("%d PTR records named %s exist, delete the extra ones with rr delete first", len(ptrs), arpa)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReverseZone(t *testing.T) {
+	zones := []models.Zone{
+		{ID: "1", Name: "example.com"},
+		{ID: "2", Name: "in-addr.arpa"},
+		{ID: "3", Name: "2.0.192.in-addr.arpa"},
+		{ID: "4", Name: "0.192.in-addr.arpa"},
+		{ID: "5", Name: "8.b.d.0.1.0.0.2.ip6.arpa."},
+		{ID: "6", Name: "12.0.192.in-addr.arpa"},
+	}
+
+	tests := []struct {
+		name   string
+		arpa   string
+		wantID string
+	}{
+		{name: "longest match", arpa: "1.2.0.192.in-addr.arpa", wantID: "3"},
+		{name: "shorter zone", arpa: "1.3.0.192.in-addr.arpa", wantID: "4"},
+		{name: "labels, not suffixes", arpa: "1.112.0.192.in-addr.arpa", wantID: "4"},
+		{name: "top of the tree", arpa: "1.2.51.198.in-addr.arpa", wantID: "2"},
+		{name: "IPv6 with trailing dot zone", arpa: "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa", wantID: "5"},
+		{name: "no zone", arpa: "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.ip6.arpa"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			z, ok := reverseZone(zones, tt.arpa)
+			assert.Equal(t, tt.wantID != "", ok)
+			assert.Equal(t, tt.wantID, z.ID)
+		})
+	}
+}
+
+func TestSetPTR(t *testing.T) {
+	const arpa = "1.2.0.192.in-addr.arpa"
+
+	t.Cleanup(func() { zone, zoneID = "", "" })
+	zone, zoneID = "2.0.192.in-addr.arpa", "023e105f4ecef8ad9ca31a8372d0c353"
+
+	t.Run("create", func(t *testing.T) {
+		p := &listProvider{rrset: []models.DNSRecord{
+			{ID: "1", Name: "2.2.0.192.in-addr.arpa", Type: "PTR", Content: "mail.example.com"},
+		}}
+		rr, created, err := setPTR(context.Background(), p, arpa, "www.example.com", 300, true)
+		require.NoError(t, err)
+		assert.True(t, created)
+		assert.Equal(t, models.DNSRecord{Name: arpa, TTL: 300, Type: "PTR", Content: "www.example.com"}, rr)
+		assert.Equal(t, []models.CreateDNSRecordParams{{
+			Content:  "www.example.com",
+			Name:     arpa,
+			TTL:      300,
+			Type:     "PTR",
+			ZoneID:   zoneID,
+			ZoneName: zone,
+		}}, p.added)
+		assert.Equal(t, []models.ListDNSRecordsParams{{ZoneID: zoneID, ZoneName: zone}}, p.params)
+	})
+
+	t.Run("update keeps TTL", func(t *testing.T) {
+		p := &listProvider{rrset: []models.DNSRecord{
+			{ID: "1", Name: arpa, Type: "PTR", Content: "old.example.com", TTL: 3600},
+			{ID: "2", Name: arpa, Type: "TXT", Content: "owner=ops"},
+		}}
+		rr, created, err := setPTR(context.Background(), p, arpa, "www.example.com", 300, false)
+		require.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, "1", rr.ID)
+		assert.Equal(t, "www.example.com", rr.Content)
+		assert.Equal(t, 3600, rr.TTL)
+		assert.Equal(t, zoneID, rr.ZoneID)
+		assert.Empty(t, p.added)
+	})
+
+	t.Run("update with TTL", func(t *testing.T) {
+		p := &listProvider{rrset: []models.DNSRecord{
+			{ID: "1", Name: arpa + ".", Type: "ptr", Content: "old.example.com", TTL: 3600},
+		}}
+		rr, _, err := setPTR(context.Background(), p, arpa, "www.example.com", 300, true)
+		require.NoError(t, err)
+		assert.Equal(t, 300, rr.TTL)
+		assert.Len(t, p.updated, 1)
+	})
+
+	t.Run("several PTR records", func(t *testing.T) {
+		p := &listProvider{rrset: []models.DNSRecord{
+			{ID: "1", Name: arpa, Type: "PTR", Content: "a.example.com"},
+			{ID: "2", Name: arpa, Type: "PTR", Content: "b.example.com"},
+		}}
+		_, _, err := setPTR(context.Background(), p, arpa, "www.example.com", 300, true)
+		assert.EqualError(t, err, "2 PTR records named 1.2.0.192.in-addr.arpa exist, delete the extra ones with rr delete first")
+		assert.Empty(t, p.added)
+		assert.Empty(t, p.updated)
+	})
+}
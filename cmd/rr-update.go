@@ -18,74 +18,164 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"os"
 
 	"github.com/mixanemca/cdnscli/internal/app"
+	"github.com/mixanemca/cdnscli/internal/models"
+	pp "github.com/mixanemca/cdnscli/internal/prettyprint"
 	"github.com/spf13/cobra"
 )
 
+var (
+	editRR   bool
+	showDiff bool
+)
+
 // rrUpdateCmd represents the update command
 var rrUpdateCmd = &cobra.Command{
 	Aliases: []string{"change", "move", "mv", "patch"},
 	Args:    cobra.NoArgs,
 	Use:     "update",
 	Short:   "Update an existing DNS record",
-	Example: `  cdnscli rr update --name www --zone example.com --type A --content 192.0.2.1`,
-	Run:     rrUpdateCmdRun,
+	Long: `Update an existing DNS record.
+
+With --edit the record is opened in $VISUAL or $EDITOR as YAML instead of
+being given on the command line, and the saved result is applied. Saving the
+record unchanged or with errors aborts the update.
+
+With --show-diff the changed fields are printed as "field: old -> new" before
+the update, --dry-run prints them without updating the record.`,
+	Example: `  cdnscli rr update --name www --zone example.com --type A --content 192.0.2.1
+  cdnscli rr update --name www.example.com --zone-id 023e105f4ecef8ad9ca31a8372d0c353 --type A --content 192.0.2.1
+  cdnscli rr update --name www --zone example.com --type A --content 192.0.2.2 --wait
+  cdnscli rr update --name www --zone example.com --edit
+  cdnscli rr update --name www --zone example.com --type A --content 192.0.2.2 --dry-run`,
+	Run: rrUpdateCmdRun,
 }
 
 func init() {
 	rrCmd.AddCommand(rrUpdateCmd)
 
+	rrUpdateCmd.PersistentFlags().StringVar(&comment, "comment", "", "Comment of the resource record, an empty value removes it (Cloudflare only)")
 	rrUpdateCmd.PersistentFlags().StringVarP(&content, "content", "c", "", "Comma separated IP address or domain name")
-	if err := rrUpdateCmd.MarkPersistentFlagRequired("content"); err != nil {
-		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "content", err)
-	}
+	rrUpdateCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print the changes to the record without updating it")
+	rrUpdateCmd.PersistentFlags().BoolVarP(&editRR, "edit", "e", false, "Edit the record in $VISUAL or $EDITOR")
 	rrUpdateCmd.PersistentFlags().StringVarP(&name, "name", "n", "", "recource record name")
 	if err := rrUpdateCmd.MarkPersistentFlagRequired("name"); err != nil {
 		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "name", err)
 	}
 	// rrUpdateCmd.PersistentFlags().BoolVarP(&proxied, "proxied", "p", false, "Whether the record is receiving the performance and security benefits of Cloudflare")
-	rrUpdateCmd.PersistentFlags().StringVarP(&rrtype, "type", "t", "", "Type of the resource record (A, CNAME)")
-	if err := rrUpdateCmd.MarkPersistentFlagRequired("type"); err != nil {
-		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "type", err)
-	}
+	rrUpdateCmd.PersistentFlags().BoolVar(&showDiff, "show-diff", false, "Print the changes to the record before updating it")
+	rrUpdateCmd.PersistentFlags().StringVarP(&rrtype, "type", "t", "", "Type of the resource record (A, CNAME), narrows the record to edit with --edit")
+	rrUpdateCmd.PersistentFlags().StringArrayVar(&tags, "tag", nil, "Tag of the resource record, may be repeated; replaces the current tags (Cloudflare only)")
 	// rrUpdateCmd.PersistentFlags().IntVarP(&ttl, "ttl", "l", 1800, "The time to live of the resource record in seconds")
-	rrUpdateCmd.PersistentFlags().StringVarP(&zone, "zone", "z", "", "zone name")
-	if err := rrUpdateCmd.MarkPersistentFlagRequired("zone"); err != nil {
-		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "zone", err)
-	}
+	addZoneFlags(rrUpdateCmd)
+	addWaitFlag(rrUpdateCmd)
+	// Without --edit the new content and type are given on the command line
+	rrUpdateCmd.MarkFlagsOneRequired("content", "edit")
+	rrUpdateCmd.MarkFlagsMutuallyExclusive("content", "edit")
+	rrUpdateCmd.MarkFlagsRequiredTogether("content", "type")
 }
 
 func rrUpdateCmdRun(cmd *cobra.Command, args []string) {
 	a, err := app.New(
 		app.WithConfig(appConfig),
 		app.WithOutputFormat(outputFormat),
+		app.WithOutputFields(outputFields),
+		app.WithOutputWriter(outputWriter),
 	)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		exitWithError(err)
+	}
+
+	if err := namesToASCII(); err != nil {
+		exitWithError(err)
+	}
+
+	if err := models.ValidateName(name); err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+		os.Exit(exitError)
+	}
+	if !editRR {
+		if err := models.ValidateContent(rrtype, content); err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+			os.Exit(exitError)
+		}
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), getTimeout())
 	defer cancel()
 
-	rr, err := a.Provider().GetRRByName(ctx, zone, name)
+	rr, err := findRecord(ctx, a.Provider(), name, rrtype, "")
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		exitWithError(err)
+	}
+
+	original := rr
+	if editRR {
+		// The editor may stay open longer than the client timeout
+		rr, _, err = editRecord(rr, runEditor)
+		if err != nil {
+			exitWithError(err)
+		}
+		ctx, cancel = context.WithTimeout(context.Background(), getTimeout())
+		defer cancel()
+	} else {
+		rr.Content = content
+		rr.Type = rrtype
+	}
+	// Comment and tags are kept unless given on the command line
+	if cmd.Flags().Changed("comment") {
+		rr.Comment = comment
+	}
+	if cmd.Flags().Changed("tag") {
+		rr.Tags = tags
 	}
-	rr.Content = content
-	rr.Type = rrtype
 	// rr.TTL = ttl
 	// rr.Proxied = cloudflare.BoolPtr(proxied)
 
+	if showDiff || dryRun {
+		printRecordChanges(outputWriter, outputFormat, models.DiffRecord(original, rr))
+	}
+	if dryRun {
+		return
+	}
+
 	updated, err := a.Provider().UpdateRR(ctx, zone, rr)
 	if err != nil {
-		return
+		exitWithError(err)
 	}
 
 	a.Printer().RecordUpdate(updated)
+
+	if err := waitForRecords(updated); err != nil {
+		exitWithError(err)
+	}
+}
+
+// printRecordChanges prints the fields an update changes, a "field: old -> new"
+// line each. JSON output formats print the changes as a JSON array, FormatNone
+// prints nothing.
+func printRecordChanges(w io.Writer, format pp.OutputFormat, changes []models.FieldChange) {
+	switch format {
+	case pp.FormatNone:
+		return
+	case pp.FormatJSON, pp.FormatJSONL:
+		if changes == nil {
+			changes = []models.FieldChange{}
+		}
+		j, _ := json.Marshal(changes)
+		fmt.Fprintln(w, string(j))
+	default:
+		if len(changes) == 0 {
+			fmt.Fprintln(w, "No changes")
+		}
+		for _, c := range changes {
+			fmt.Fprintln(w, c.String())
+		}
+	}
 }
@@ -0,0 +1,51 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	pp "github.com/mixanemca/cdnscli/internal/prettyprint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintRecordChanges(t *testing.T) {
+	oldRR := models.DNSRecord{ID: "1", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1"}
+	newRR := oldRR
+	newRR.Content = "192.0.2.2"
+	newRR.Comment = "web"
+	changes := models.DiffRecord(oldRR, newRR)
+
+	tests := []struct {
+		format  pp.OutputFormat
+		changes []models.FieldChange
+		want    string
+	}{
+		{format: pp.FormatText, changes: changes, want: "content: 192.0.2.1 -> 192.0.2.2\ncomment:  -> web\n"},
+		{format: pp.FormatText, changes: nil, want: "No changes\n"},
+		{format: pp.FormatJSON, changes: changes, want: `[{"field":"content","old":"192.0.2.1","new":"192.0.2.2"},{"field":"comment","old":"","new":"web"}]` + "\n"},
+		{format: pp.FormatJSONL, changes: nil, want: "[]\n"},
+		{format: pp.FormatNone, changes: changes, want: ""},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		printRecordChanges(&buf, tt.format, tt.changes)
+		assert.Equal(t, tt.want, buf.String())
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mixanemca/cdnscli/internal/app"
+	"github.com/mixanemca/cdnscli/internal/models"
+	pp "github.com/mixanemca/cdnscli/internal/prettyprint"
+	"github.com/spf13/cobra"
+)
+
+var watchInterval time.Duration
+
+// rrWatchCmd represents the watch command
+var rrWatchCmd = &cobra.Command{
+	Args:  cobra.NoArgs,
+	Use:   "watch",
+	Short: "Watch zone resource records for changes",
+	Long: `Watch zone resource records for changes.
+
+The records are listed every interval and the added (+), removed (-) and
+changed (~) records are printed, one per line. With --output-format json or
+jsonl every change is printed as a JSON object. The template and markdown
+output formats are not supported. Stop watching with Ctrl+C.`,
+	Example: `  cdnscli rr watch --zone example.com
+  cdnscli rr watch --zone example.com --interval 10s -o jsonl`,
+	Run: rrWatchCmdRun,
+}
+
+func init() {
+	rrCmd.AddCommand(rrWatchCmd)
+
+	addZoneFlags(rrWatchCmd)
+	rrWatchCmd.PersistentFlags().DurationVar(&watchInterval, "interval", 30*time.Second, "How often to list the records")
+}
+
+func rrWatchCmdRun(cmd *cobra.Command, args []string) {
+	a, err := app.New(
+		app.WithConfig(appConfig),
+		app.WithOutputFormat(outputFormat),
+		app.WithOutputFields(outputFields),
+		app.WithOutputWriter(outputWriter),
+	)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if watchInterval <= 0 {
+		fmt.Fprintf(os.Stderr, "ERROR: interval must be positive, got %s\n", watchInterval)
+		os.Exit(exitError)
+	}
+	if err := checkWatchFormat(outputFormat); err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+		os.Exit(exitError)
+	}
+
+	ctx, stop := interruptContext(context.Background())
+	defer stop()
+
+	list := func() ([]models.DNSRecord, error) {
+		ctx, cancel := context.WithTimeout(ctx, getTimeout())
+		defer cancel()
+		return a.Provider().ListRecords(ctx, zoneParams())
+	}
+
+	rrset, err := list()
+	if err != nil {
+		exitWithError(err)
+	}
+	if !quiet {
+		fmt.Fprintf(os.Stderr, "Watching %d records every %s, press Ctrl+C to stop\n", len(rrset), watchInterval)
+	}
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			current, err := list()
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				// A failed poll is reported, the next one may succeed
+				fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+				continue
+			}
+			if err := printRecordsDiff(outputWriter, outputFormat, now, models.DiffRecords(rrset, current)); err != nil {
+				exitWithError(err)
+			}
+			rrset = current
+		}
+	}
+}
+
+// watchEvent is a change printed by rr watch in JSON output formats.
+type watchEvent struct {
+	Time    time.Time            `json:"time"`
+	Change  string               `json:"change"`
+	Record  models.DNSRecord     `json:"record"`
+	Changes []models.FieldChange `json:"changes,omitempty"`
+}
+
+// checkWatchFormat returns an error for the output formats rr watch can not
+// print changes in, rather than falling back to text.
+func checkWatchFormat(format pp.OutputFormat) error {
+	switch format {
+	case pp.FormatText, pp.FormatJSON, pp.FormatJSONL, pp.FormatNone:
+		return nil
+	default:
+		return fmt.Errorf("rr watch does not support the %s output format, use text, json, jsonl or none", outputFormatList[format][0])
+	}
+}
+
+// printRecordsDiff prints the changes found by a poll of rr watch, one per line.
+// JSON output formats print a JSON object per change, FormatNone prints nothing.
+func printRecordsDiff(w io.Writer, format pp.OutputFormat, now time.Time, diff models.RecordsDiff) error {
+	if err := checkWatchFormat(format); err != nil {
+		return err
+	}
+
+	var events []watchEvent
+	for _, rr := range diff.Added {
+		events = append(events, watchEvent{Time: now, Change: "added", Record: rr})
+	}
+	for _, rr := range diff.Removed {
+		events = append(events, watchEvent{Time: now, Change: "removed", Record: rr})
+	}
+	for _, c := range diff.Changed {
+		events = append(events, watchEvent{Time: now, Change: "changed", Record: c.New, Changes: models.DiffRecord(c.Old, c.New)})
+	}
+
+	for _, e := range events {
+		switch format {
+		case pp.FormatNone:
+			return nil
+		case pp.FormatJSON, pp.FormatJSONL:
+			j, err := json.Marshal(e)
+			if err != nil {
+				return fmt.Errorf("failed to encode change: %w", err)
+			}
+			fmt.Fprintln(w, string(j))
+		default:
+			fmt.Fprintln(w, e.String())
+		}
+	}
+
+	return nil
+}
+
+// String returns the change as a line of text: a timestamp, the kind of change
+// (+, - or ~) and the record, with the changed fields for changed records.
+func (e watchEvent) String() string {
+	mark := map[string]string{"added": "+", "removed": "-", "changed": "~"}[e.Change]
+	rr := e.Record
+	line := strings.Join([]string{
+		e.Time.Format(time.RFC3339), mark,
+		models.NameToUnicode(rr.Name), strconv.Itoa(rr.TTL), rr.Type, rr.Content,
+	}, " ")
+
+	if len(e.Changes) > 0 {
+		changes := make([]string, 0, len(e.Changes))
+		for _, c := range e.Changes {
+			changes = append(changes, c.String())
+		}
+		line += " (" + strings.Join(changes, ", ") + ")"
+	}
+
+	return line
+}
@@ -0,0 +1,71 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	pp "github.com/mixanemca/cdnscli/internal/prettyprint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintRecordsDiff(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	before := []models.DNSRecord{
+		{ID: "1", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1"},
+		{ID: "2", Name: "old.example.com", TTL: 300, Type: "CNAME", Content: "www.example.com"},
+	}
+	after := []models.DNSRecord{
+		{ID: "1", Name: "www.example.com", TTL: 600, Type: "A", Content: "192.0.2.2"},
+		{ID: "3", Name: "new.example.com", TTL: 300, Type: "A", Content: "192.0.2.3"},
+	}
+	diff := models.DiffRecords(before, after)
+
+	var buf bytes.Buffer
+	require.NoError(t, printRecordsDiff(&buf, pp.FormatText, now, diff))
+	assert.Equal(t, "2026-10-15T12:00:00Z + new.example.com 300 A 192.0.2.3\n"+
+		"2026-10-15T12:00:00Z - old.example.com 300 CNAME www.example.com\n"+
+		"2026-10-15T12:00:00Z ~ www.example.com 600 A 192.0.2.2 (ttl: 300 -> 600, content: 192.0.2.1 -> 192.0.2.2)\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, printRecordsDiff(&buf, pp.FormatJSONL, now, models.RecordsDiff{Changed: diff.Changed}))
+	assert.Equal(t, `{"time":"2026-10-15T12:00:00Z","change":"changed","record":{"content":"192.0.2.2","id":"1","name":"www.example.com","ttl":600,"type":"A"},`+
+		`"changes":[{"field":"ttl","old":"300","new":"600"},{"field":"content","old":"192.0.2.1","new":"192.0.2.2"}]}`+"\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, printRecordsDiff(&buf, pp.FormatNone, now, diff))
+	assert.Empty(t, buf.String())
+
+	// A poll without changes prints nothing
+	require.NoError(t, printRecordsDiff(&buf, pp.FormatText, now, models.DiffRecords(after, after)))
+	assert.Empty(t, buf.String())
+}
+
+func TestPrintRecordsDiff_UnsupportedFormat(t *testing.T) {
+	diff := models.RecordsDiff{Added: []models.DNSRecord{{Name: "www.example.com", Type: "A", Content: "192.0.2.1"}}}
+
+	for _, format := range []pp.OutputFormat{pp.FormatTemplate, pp.FormatMarkdown} {
+		var buf bytes.Buffer
+		err := printRecordsDiff(&buf, format, time.Now(), diff)
+		assert.ErrorContains(t, err, "rr watch does not support the "+outputFormatList[format][0]+" output format")
+		assert.Empty(t, buf.String())
+	}
+}
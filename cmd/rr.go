@@ -17,6 +17,14 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/mixanemca/cdnscli/internal/providers"
 	"github.com/spf13/cobra"
 )
 
@@ -29,3 +37,133 @@ var rrCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(rrCmd)
 }
+
+// namesToASCII converts the --name and --zone values to punycode,
+// so internationalized names may be given in either Unicode or xn-- form.
+func namesToASCII() error {
+	var err error
+	if name, err = models.NameToASCII(name); err != nil {
+		return err
+	}
+	if zone, err = models.NameToASCII(zone); err != nil {
+		return err
+	}
+	return nil
+}
+
+// addZoneFlags adds the --zone and --zone-id flags to cmd, one of which is required.
+// A known zone ID saves the provider a zone lookup by name.
+func addZoneFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&zone, "zone", "z", "", "Zone name")
+	cmd.PersistentFlags().StringVar(&zoneID, "zone-id", "", "Zone ID, skips looking the zone up by name")
+	cmd.MarkFlagsOneRequired("zone", "zone-id")
+}
+
+// Record listing order set by --sort and --reverse.
+var (
+	sortBy      string
+	sortReverse bool
+)
+
+// addSortFlags adds the --sort and --reverse flags to a command listing records.
+func addSortFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&sortBy, "sort", "", "sort records by field: "+strings.Join(models.RecordSortFields, ", ")+" (default is name, then type and content)")
+	cmd.PersistentFlags().BoolVar(&sortReverse, "reverse", false, "reverse the --sort order")
+}
+
+// checkSortFlags validates --sort before any API call is made.
+func checkSortFlags() error {
+	if sortBy == "" {
+		return nil
+	}
+	_, err := models.CompareRecords(sortBy)
+	return err
+}
+
+// sortRecords sorts rrset as asked by --sort and --reverse. Without --sort the
+// records are sorted by models.DefaultRecordSort, so every output format lists
+// them in the same order from run to run, whatever order the provider returns.
+func sortRecords(rrset []models.DNSRecord) error {
+	field := sortBy
+	if field == "" {
+		field = models.DefaultRecordSort
+	}
+	return models.SortRecords(rrset, field, sortReverse)
+}
+
+// maxRecords is the most records a listing prints, set by --max. Zero or less is no limit.
+var maxRecords int
+
+// addMaxFlag adds the --max flag to a command listing records.
+func addMaxFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().IntVarP(&maxRecords, "max", "m", 0, "list at most this many records, 0 for all")
+}
+
+// limitRecords returns the first --max records of rrset, after filtering and
+// sorting, and the number of records left out.
+func limitRecords(rrset []models.DNSRecord) ([]models.DNSRecord, int) {
+	if maxRecords <= 0 || len(rrset) <= maxRecords {
+		return rrset, 0
+	}
+	return rrset[:maxRecords], len(rrset) - maxRecords
+}
+
+// noteTruncated tells on w how many records --max left out of a listing.
+func noteTruncated(w io.Writer, shown, omitted int) {
+	if omitted > 0 {
+		fmt.Fprintf(w, "Showing %d of %d records, raise --max to see more\n", shown, shown+omitted)
+	}
+}
+
+// Record listing filters set by --type, --proxied-only and --since.
+var (
+	filterTypes []string
+	proxiedOnly bool
+	filterSince time.Duration
+)
+
+// addFilterFlags adds the --type, --proxied-only and --since flags to a command listing records.
+func addFilterFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringSliceVarP(&filterTypes, "type", "t", nil, "only list records of this type, may be repeated or comma separated")
+	cmd.PersistentFlags().BoolVar(&proxiedOnly, "proxied-only", false, "only list proxied records")
+	cmd.PersistentFlags().DurationVar(&filterSince, "since", 0, "only list records changed within this duration, e.g. 24h (Cloudflare only)")
+}
+
+// filterRecords returns the records of rrset matching --type, --proxied-only and --since.
+func filterRecords(rrset []models.DNSRecord) []models.DNSRecord {
+	filters := []models.RecordFilter{models.ByTypes(filterTypes...)}
+	if proxiedOnly {
+		filters = append(filters, models.ProxiedOnly)
+	}
+	if filterSince > 0 {
+		filters = append(filters, models.ModifiedSince(time.Now().Add(-filterSince)))
+	}
+	return models.FilterRecords(rrset, filters...)
+}
+
+// zoneParams returns the list params selecting the zone given by --zone or --zone-id.
+func zoneParams() models.ListDNSRecordsParams {
+	return models.ListDNSRecordsParams{ZoneID: zoneID, ZoneName: zone}
+}
+
+// findRecord returns the only record of the zone with the given name. A non-empty
+// rrtype or content narrows the search, several matching records are an error.
+func findRecord(ctx context.Context, p providers.Provider, name, rrtype, content string) (models.DNSRecord, error) {
+	rrset, err := p.ListRecords(ctx, zoneParams())
+	if err != nil {
+		return models.DNSRecord{}, err
+	}
+
+	fqdn := models.FQDN(name, zone)
+	matches := selectRecords(rrset, fqdn, rrtype, content)
+	switch len(matches) {
+	case 0:
+		return models.DNSRecord{}, providers.NewNotFoundError("record", models.NameToUnicode(fqdn), nil)
+	case 1:
+		rr := matches[0]
+		rr.ZoneID = zoneID
+		return rr, nil
+	default:
+		return models.DNSRecord{}, fmt.Errorf("%d records named %s match, narrow them down with --type or --content", len(matches), models.NameToUnicode(fqdn))
+	}
+}
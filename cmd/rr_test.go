@@ -0,0 +1,240 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	pp "github.com/mixanemca/cdnscli/internal/prettyprint"
+	"github.com/mixanemca/cdnscli/internal/providers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listProvider is a providers.Provider serving ListRecords from a fixed record set.
+// DeleteRR records the deleted records and fails for the IDs in deleteErr,
+// AddRR records the created records and fails for the names in addErr,
+// UpsertRR records the upserted records and UpdateRR the updated ones.
+type listProvider struct {
+	providers.Provider
+	rrset     []models.DNSRecord
+	params    []models.ListDNSRecordsParams
+	added     []models.CreateDNSRecordParams
+	upserted  []models.CreateDNSRecordParams
+	updated   []models.DNSRecord
+	addErr    map[string]error
+	deleted   []models.DNSRecord
+	deleteErr map[string]error
+}
+
+func (p *listProvider) AddRR(ctx context.Context, zone string, params models.CreateDNSRecordParams) (models.DNSRecord, error) {
+	if err := p.addErr[params.Name]; err != nil {
+		return models.DNSRecord{}, err
+	}
+	p.added = append(p.added, params)
+	return models.DNSRecord{Name: params.Name, TTL: params.TTL, Type: params.Type, Content: params.Content}, nil
+}
+
+func (p *listProvider) UpsertRR(ctx context.Context, zone string, params models.CreateDNSRecordParams) (models.DNSRecord, error) {
+	p.upserted = append(p.upserted, params)
+	return models.DNSRecord{Name: params.Name, TTL: params.TTL, Type: params.Type, Content: params.Content}, nil
+}
+
+func (p *listProvider) UpdateRR(ctx context.Context, zone string, rr models.DNSRecord) (models.DNSRecord, error) {
+	p.updated = append(p.updated, rr)
+	return rr, nil
+}
+
+func (p *listProvider) ListRecords(ctx context.Context, params models.ListDNSRecordsParams) ([]models.DNSRecord, error) {
+	p.params = append(p.params, params)
+	return p.rrset, nil
+}
+
+func (p *listProvider) DeleteRR(ctx context.Context, zone string, rr models.DNSRecord) error {
+	if err := p.deleteErr[rr.ID]; err != nil {
+		return err
+	}
+	p.deleted = append(p.deleted, rr)
+	return nil
+}
+
+func TestFindRecord(t *testing.T) {
+	p := &listProvider{rrset: []models.DNSRecord{
+		{ID: "1", Name: "www.example.com", Type: "A", Content: "192.0.2.1"},
+		{ID: "2", Name: "www.example.com", Type: "A", Content: "192.0.2.2"},
+		{ID: "3", Name: "www.example.com", Type: "AAAA", Content: "2001:db8::1"},
+	}}
+
+	t.Cleanup(func() { zone, zoneID = "", "" })
+	zone, zoneID = "", "023e105f4ecef8ad9ca31a8372d0c353"
+
+	rr, err := findRecord(context.Background(), p, "www.example.com", "AAAA", "")
+	require.NoError(t, err)
+	assert.Equal(t, "3", rr.ID)
+	// The zone ID is passed on, so the provider needs no lookup by name
+	assert.Equal(t, zoneID, rr.ZoneID)
+	assert.Equal(t, []models.ListDNSRecordsParams{{ZoneID: zoneID}}, p.params)
+
+	rr, err = findRecord(context.Background(), p, "www.example.com", "A", "192.0.2.2")
+	require.NoError(t, err)
+	assert.Equal(t, "2", rr.ID)
+
+	_, err = findRecord(context.Background(), p, "www.example.com", "A", "")
+	assert.EqualError(t, err, "2 records named www.example.com match, narrow them down with --type or --content")
+
+	zone, zoneID = "example.com", ""
+	_, err = findRecord(context.Background(), p, "ftp", "A", "")
+	var notFoundErr *providers.NotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+	assert.Equal(t, models.ListDNSRecordsParams{ZoneName: "example.com"}, p.params[len(p.params)-1])
+}
+
+func TestFilterAndSortRecords(t *testing.T) {
+	rrset := []models.DNSRecord{
+		{ID: "1", Name: "www.example.com", Type: "A", TTL: 300, Proxied: true},
+		{ID: "2", Name: "www.example.com", Type: "AAAA", TTL: 60, Proxied: true},
+		{ID: "3", Name: "mail.example.com", Type: "A", TTL: 1800},
+		{ID: "4", Name: "example.com", Type: "MX", TTL: 3600},
+	}
+	ids := func(rrset []models.DNSRecord) []string {
+		ids := []string{}
+		for _, rr := range rrset {
+			ids = append(ids, rr.ID)
+		}
+		return ids
+	}
+
+	t.Cleanup(func() { filterTypes, proxiedOnly, sortBy, sortReverse = nil, false, "", false })
+
+	assert.Equal(t, []string{"1", "2", "3", "4"}, ids(filterRecords(rrset)))
+
+	filterTypes = []string{"a", "aaaa"}
+	assert.Equal(t, []string{"1", "2", "3"}, ids(filterRecords(rrset)))
+
+	proxiedOnly = true
+	assert.Equal(t, []string{"1", "2"}, ids(filterRecords(rrset)))
+
+	filterTypes, proxiedOnly = nil, false
+	sortBy, sortReverse = "ttl", true
+	require.NoError(t, checkSortFlags())
+	sorted := filterRecords(rrset)
+	require.NoError(t, sortRecords(sorted))
+	assert.Equal(t, []string{"4", "3", "1", "2"}, ids(sorted))
+	// The listing from the provider is not reordered
+	assert.Equal(t, []string{"1", "2", "3", "4"}, ids(rrset))
+
+	// Without --sort the records are sorted by name, type and content
+	sortBy, sortReverse = "", false
+	sorted = filterRecords(rrset)
+	require.NoError(t, sortRecords(sorted))
+	assert.Equal(t, []string{"4", "3", "1", "2"}, ids(sorted))
+
+	sortBy = "proxied"
+	assert.Error(t, checkSortFlags())
+}
+
+func TestFilterRecords_Since(t *testing.T) {
+	now := time.Now()
+	rrset := []models.DNSRecord{
+		{ID: "1", Type: "A", ModifiedOn: now.Add(-time.Hour)},
+		{ID: "2", Type: "A", ModifiedOn: now.Add(-48 * time.Hour)},
+		{ID: "3", Type: "A"},
+	}
+
+	t.Cleanup(func() { filterSince = 0 })
+
+	assert.Len(t, filterRecords(rrset), 3)
+
+	filterSince = 24 * time.Hour
+	assert.Equal(t, []models.DNSRecord{rrset[0]}, filterRecords(rrset))
+}
+
+func TestSortRecords_SameOutput(t *testing.T) {
+	rrset := []models.DNSRecord{
+		{ID: "1", Name: "www.example.com", Type: "A", TTL: 300, Content: "192.0.2.2"},
+		{ID: "2", Name: "www.example.com", Type: "A", TTL: 300, Content: "192.0.2.1"},
+		{ID: "3", Name: "example.com", Type: "MX", TTL: 3600, Content: "10 mail.example.com"},
+		{ID: "4", Name: "example.com", Type: "TXT", TTL: 3600, Content: "v=spf1 -all", Tags: []string{"env:prod"}},
+		{ID: "5", Name: "mail.example.com", Type: "A", TTL: 300, Content: "192.0.2.25"},
+		// The same record twice is ordered by ID
+		{ID: "7", Name: "dup.example.com", Type: "A", TTL: 300, Content: "192.0.2.7"},
+		{ID: "6", Name: "dup.example.com", Type: "A", TTL: 600, Content: "192.0.2.7"},
+	}
+
+	t.Cleanup(func() { sortBy, sortReverse = "", false })
+	sortBy, sortReverse = "", false
+
+	// list prints the records as a provider in random order returns them
+	rnd := rand.New(rand.NewSource(1))
+	list := func(format pp.OutputFormat) string {
+		shuffled := append([]models.DNSRecord(nil), rrset...)
+		rnd.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+		require.NoError(t, sortRecords(shuffled))
+
+		var buf bytes.Buffer
+		pp.New(format, pp.WithWriter(&buf)).RecordsList(shuffled)
+		return buf.String()
+	}
+
+	for _, format := range []pp.OutputFormat{pp.FormatText, pp.FormatJSON, pp.FormatJSONL, pp.FormatMarkdown} {
+		want := list(format)
+		for i := 0; i < 20; i++ {
+			require.Equal(t, want, list(format))
+		}
+	}
+}
+
+func TestLimitRecords(t *testing.T) {
+	rrset := []models.DNSRecord{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+
+	t.Cleanup(func() { maxRecords = 0 })
+
+	tests := []struct {
+		name        string
+		max         int
+		wantLen     int
+		wantOmitted int
+	}{
+		{name: "unlimited", max: 0, wantLen: 3},
+		{name: "negative is unlimited", max: -1, wantLen: 3},
+		{name: "truncated", max: 2, wantLen: 2, wantOmitted: 1},
+		{name: "exactly max", max: 3, wantLen: 3},
+		{name: "above max", max: 10, wantLen: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maxRecords = tt.max
+			got, omitted := limitRecords(rrset)
+			assert.Equal(t, rrset[:tt.wantLen], got)
+			assert.Equal(t, tt.wantOmitted, omitted)
+		})
+	}
+}
+
+func TestNoteTruncated(t *testing.T) {
+	var buf bytes.Buffer
+	noteTruncated(&buf, 3, 0)
+	assert.Empty(t, buf.String())
+
+	noteTruncated(&buf, 2, 5)
+	assert.Equal(t, "Showing 2 of 7 records, raise --max to see more\n", buf.String())
+}
@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"strings"
 
 	"github.com/mixanemca/cdnscli/internal/app"
 	"github.com/mixanemca/cdnscli/internal/models"
@@ -30,10 +29,12 @@ import (
 
 // searchCmd represents the search command
 var searchCmd = &cobra.Command{
-	Use:     "search",
-	Short:   "Search resource records",
-	Example: "  cdnscli search --zone example.com --content 192.0.2.1",
-	Run:     searchCmdRun,
+	Use:   "search",
+	Short: "Search resource records",
+	Example: `  cdnscli search --zone example.com --content 192.0.2.1
+  cdnscli search --zone example.com --type A --sort content
+  cdnscli search --zone example.com --type TXT --max 5`,
+	Run: searchCmdRun,
 }
 
 func init() {
@@ -41,46 +42,65 @@ func init() {
 
 	searchCmd.PersistentFlags().StringVarP(&content, "content", "c", "", "the content string to search for")
 	searchCmd.PersistentFlags().StringVarP(&name, "name", "n", "", "the resourse record name to search for")
-	// searchCmd.PersistentFlags().IntVarP(&max, "max", "m", 10, "maximum number of entries to return")
-	searchCmd.PersistentFlags().StringVarP(&rrtype, "type", "t", "", "type of resorce record to search for")
 	searchCmd.PersistentFlags().StringVarP(&zone, "zone", "z", "", "the zone name")
 	if err := searchCmd.MarkPersistentFlagRequired("zone"); err != nil {
 		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "zone", err)
 	}
+	addSortFlags(searchCmd)
+	addFilterFlags(searchCmd)
+	addMaxFlag(searchCmd)
 }
 
 func searchCmdRun(cmd *cobra.Command, args []string) {
-	if len(content) == 0 && len(name) == 0 && len(rrtype) == 0 {
-		fmt.Println("ERROR: you must specify one of the search parameters - content, name or type")
-		os.Exit(1)
+	if len(content) == 0 && len(name) == 0 && len(filterTypes) == 0 && !proxiedOnly {
+		fmt.Fprintln(os.Stderr, "ERROR: you must specify one of the search parameters - content, name, type or proxied-only")
+		os.Exit(exitError)
+	}
+	if err := checkSortFlags(); err != nil {
+		exitWithError(err)
 	}
 
 	a, err := app.New(
 		app.WithConfig(appConfig),
 		app.WithOutputFormat(outputFormat),
+		app.WithOutputFields(outputFields),
+		app.WithOutputWriter(outputWriter),
 	)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		exitWithError(err)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), getTimeout())
 	defer cancel()
 
 	if len(name) > 0 {
-		name = strings.Join([]string{name, zone}, ".")
+		name = models.FQDN(name, zone)
+	}
+
+	// A single type is also passed to the provider, so it may filter server-side
+	var serverType string
+	if len(filterTypes) == 1 {
+		serverType = filterTypes[0]
 	}
 
 	results, err := a.Provider().ListRecords(ctx, models.ListDNSRecordsParams{
 		Content:  content,
 		Name:     name,
-		Type:     rrtype,
+		Type:     serverType,
 		ZoneName: zone,
 	})
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		exitWithError(err)
 	}
 
+	results = filterRecords(results)
+	if err := sortRecords(results); err != nil {
+		exitWithError(err)
+	}
+
+	results, omitted := limitRecords(results)
 	a.Printer().RecordsList(results)
+	if !quiet {
+		noteTruncated(os.Stderr, len(results), omitted)
+	}
 }
@@ -0,0 +1,58 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterruptContext(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sending os.Interrupt is not supported on Windows")
+	}
+
+	ctx, stop := interruptContext(context.Background())
+	defer stop()
+
+	b, cancel := newBatchRunner(ctx, time.Minute, 0)
+	defer cancel()
+
+	p, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	require.NoError(t, p.Signal(os.Interrupt))
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("context was not canceled by the interrupt")
+	}
+
+	// The remaining steps of a batch are not run
+	err = b.run(func(ctx context.Context) error {
+		t.Error("step called after the interrupt")
+		return nil
+	})
+	assert.ErrorIs(t, err, errBatchInterrupted)
+	assert.True(t, b.stopped(err))
+}
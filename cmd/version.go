@@ -0,0 +1,89 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+
+	pp "github.com/mixanemca/cdnscli/internal/prettyprint"
+	"github.com/spf13/cobra"
+	"github.com/version-go/ldflags"
+)
+
+var versionJSON bool
+
+// versionCmd represents the version command
+var versionCmd = &cobra.Command{
+	Args:  cobra.NoArgs,
+	Use:   "version",
+	Short: "Print version and build details",
+	Example: `  cdnscli version
+  cdnscli version --json`,
+	Run: versionCmdRun,
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+
+	versionCmd.PersistentFlags().BoolVar(&versionJSON, "json", false, "print build details as JSON (same as --output-format json)")
+}
+
+// versionInfo holds the build details printed by the version command.
+type versionInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+}
+
+// newVersionInfo returns the build details set by ldflags and the Go runtime.
+func newVersionInfo() versionInfo {
+	return versionInfo{
+		Version:   ldflags.Version(),
+		Commit:    ldflags.Build(),
+		BuildTime: ldflags.Time(),
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+}
+
+func versionCmdRun(cmd *cobra.Command, args []string) {
+	if quiet {
+		return
+	}
+
+	if err := printVersion(outputWriter, newVersionInfo(), versionJSON || outputFormat == pp.FormatJSON || outputFormat == pp.FormatJSONL); err != nil {
+		exitWithError(err)
+	}
+}
+
+// printVersion writes the build details to w, as a JSON object if asJSON is set.
+func printVersion(w io.Writer, info versionInfo, asJSON bool) error {
+	if asJSON {
+		return json.NewEncoder(w).Encode(info)
+	}
+
+	_, err := fmt.Fprintf(w, "cdnscli %s (commit %s, built %s)\n%s %s/%s\n",
+		info.Version, info.Commit, info.BuildTime, info.GoVersion, info.OS, info.Arch)
+	return err
+}
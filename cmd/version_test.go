@@ -0,0 +1,48 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintVersion_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, printVersion(&buf, newVersionInfo(), true))
+
+	var got map[string]string
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	for _, key := range []string{"version", "commit", "build_time", "go_version", "os", "arch"} {
+		assert.Contains(t, got, key)
+	}
+	assert.Equal(t, runtime.Version(), got["go_version"])
+	assert.Equal(t, runtime.GOOS, got["os"])
+	assert.Equal(t, runtime.GOARCH, got["arch"])
+}
+
+func TestPrintVersion_Text(t *testing.T) {
+	var buf bytes.Buffer
+	info := versionInfo{Version: "v1.2.3", Commit: "abc1234", BuildTime: "unknown", GoVersion: "go1.24.2", OS: "linux", Arch: "amd64"}
+	require.NoError(t, printVersion(&buf, info, false))
+	assert.Equal(t, "cdnscli v1.2.3 (commit abc1234, built unknown)\ngo1.24.2 linux/amd64\n", buf.String())
+}
@@ -0,0 +1,183 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// defaultWaitTimeout is used when --wait is given without a value.
+	defaultWaitTimeout = 2 * time.Minute
+	// waitPollInterval is the delay between two lookups of a record.
+	waitPollInterval = 5 * time.Second
+)
+
+var (
+	// waitTimeout is how long --wait polls for a record, zero disables waiting.
+	waitTimeout time.Duration
+	// waitResolver is the DNS server polled by --wait, empty for the
+	// authoritative name servers of the record's zone.
+	waitResolver string
+)
+
+// resolver looks up record contents by name and type.
+type resolver interface {
+	Lookup(ctx context.Context, name, rrtype string) ([]string, error)
+}
+
+// dnsResolver queries a DNS server directly, bypassing the local cache.
+type dnsResolver struct {
+	server string
+}
+
+// Lookup returns the contents of the name's records of the given type,
+// formatted the way providers return them.
+func (r dnsResolver) Lookup(ctx context.Context, name, rrtype string) ([]string, error) {
+	t, ok := dns.StringToType[strings.ToUpper(rrtype)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported record type %q", rrtype)
+	}
+
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(name), t)
+	m.RecursionDesired = true
+
+	resp, _, err := new(dns.Client).ExchangeContext(ctx, m, r.server)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Rcode != dns.RcodeSuccess && resp.Rcode != dns.RcodeNameError {
+		return nil, fmt.Errorf("lookup %s %s: %s", name, rrtype, dns.RcodeToString[resp.Rcode])
+	}
+
+	var contents []string
+	for _, rr := range resp.Answer {
+		if rr.Header().Rrtype != t {
+			continue
+		}
+		contents = append(contents, strings.TrimPrefix(rr.String(), rr.Header().String()))
+	}
+
+	return contents, nil
+}
+
+// addWaitFlag adds --wait and --wait-resolver to cmd. Without a value --wait
+// waits defaultWaitTimeout.
+func addWaitFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().DurationVar(&waitTimeout, "wait", 0, "after the change poll the zone's name servers until the record resolves, up to the given duration (--wait=5m)")
+	cmd.PersistentFlags().Lookup("wait").NoOptDefVal = defaultWaitTimeout.String()
+	cmd.PersistentFlags().StringVar(&waitResolver, "wait-resolver", "", "DNS server to poll with --wait instead of the zone's name servers, e.g. 1.1.1.1")
+}
+
+// waitServer returns the address of the DNS server to poll for name: the one
+// set with --wait-resolver, or the first authoritative name server found
+// walking up from name to the zone apex.
+func waitServer(ctx context.Context, lookupNS func(ctx context.Context, name string) ([]*net.NS, error), name string) (string, error) {
+	if waitResolver != "" {
+		if _, _, err := net.SplitHostPort(waitResolver); err == nil {
+			return waitResolver, nil
+		}
+		return net.JoinHostPort(waitResolver, "53"), nil
+	}
+
+	for n := dns.Fqdn(name); n != "."; {
+		if ns, err := lookupNS(ctx, n); err == nil && len(ns) > 0 {
+			return net.JoinHostPort(strings.TrimSuffix(ns[0].Host, "."), "53"), nil
+		}
+		i, end := dns.NextLabel(n, 0)
+		if end {
+			break
+		}
+		n = n[i:]
+	}
+
+	return "", fmt.Errorf("no name servers found for %s, set --wait-resolver", name)
+}
+
+// waitForRecords waits for every record to resolve with its content, if --wait is set.
+// Ctrl+C stops waiting, the change itself is already done.
+func waitForRecords(rrset ...models.DNSRecord) error {
+	if waitTimeout <= 0 {
+		return nil
+	}
+
+	ctx, stop := interruptContext(context.Background())
+	defer stop()
+
+	ctx, cancel := context.WithTimeout(ctx, waitTimeout)
+	defer cancel()
+
+	for _, rr := range rrset {
+		server, err := waitServer(ctx, net.DefaultResolver.LookupNS, rr.Name)
+		if err != nil {
+			return err
+		}
+		if err := waitForRecord(ctx, dnsResolver{server: server}, rr, waitPollInterval); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// waitForRecord polls r every interval until rr resolves with its content or
+// ctx is done. Proxied records resolve to the CDN addresses, so for them any
+// answer is enough.
+func waitForRecord(ctx context.Context, r resolver, rr models.DNSRecord, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		contents, err := r.Lookup(ctx, rr.Name, rr.Type)
+		if err == nil && resolved(rr, contents) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if err == nil {
+				err = ctx.Err()
+			}
+			return fmt.Errorf("record %s %s %s did not propagate: %w", rr.Name, rr.Type, rr.Content, err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// resolved reports whether contents hold the content of rr.
+func resolved(rr models.DNSRecord, contents []string) bool {
+	if rr.Proxied {
+		return len(contents) > 0
+	}
+
+	for _, c := range contents {
+		if rr.SameRecord(models.DNSRecord{Name: rr.Name, Type: rr.Type, Content: c}) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,189 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seqResolver returns the next answer on each lookup and repeats the last one.
+type seqResolver struct {
+	answers [][]string
+	errs    []error
+	calls   int
+}
+
+func (r *seqResolver) Lookup(ctx context.Context, name, rrtype string) ([]string, error) {
+	i := min(r.calls, len(r.answers)-1)
+	r.calls++
+
+	var err error
+	if i < len(r.errs) {
+		err = r.errs[i]
+	}
+
+	return r.answers[i], err
+}
+
+func TestWaitForRecord(t *testing.T) {
+	rr := models.DNSRecord{Name: "www.example.com", Type: "A", Content: "192.0.2.2"}
+
+	t.Run("resolves after a few polls", func(t *testing.T) {
+		r := &seqResolver{
+			answers: [][]string{nil, {"192.0.2.1"}, {"192.0.2.1"}, {"192.0.2.1", "192.0.2.2"}},
+			errs:    []error{errors.New("timeout")},
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		require.NoError(t, waitForRecord(ctx, r, rr, time.Millisecond))
+		assert.Equal(t, 4, r.calls)
+	})
+
+	t.Run("never propagates", func(t *testing.T) {
+		r := &seqResolver{answers: [][]string{{"192.0.2.1"}}}
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		err := waitForRecord(ctx, r, rr, time.Millisecond)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "www.example.com A 192.0.2.2 did not propagate")
+	})
+
+	t.Run("lookup error is reported", func(t *testing.T) {
+		lookupErr := errors.New("connection refused")
+		r := &seqResolver{answers: [][]string{nil}, errs: []error{lookupErr}}
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		assert.ErrorIs(t, waitForRecord(ctx, r, rr, time.Millisecond), lookupErr)
+	})
+}
+
+func TestResolved(t *testing.T) {
+	tests := []struct {
+		name     string
+		rr       models.DNSRecord
+		contents []string
+		want     bool
+	}{
+		{name: "A", rr: models.DNSRecord{Type: "A", Content: "192.0.2.1"}, contents: []string{"192.0.2.1"}, want: true},
+		{name: "A other address", rr: models.DNSRecord{Type: "A", Content: "192.0.2.1"}, contents: []string{"192.0.2.9"}},
+		{name: "AAAA canonical form", rr: models.DNSRecord{Type: "AAAA", Content: "2001:DB8:0:0::1"}, contents: []string{"2001:db8::1"}, want: true},
+		{name: "CNAME trailing dot and case", rr: models.DNSRecord{Type: "CNAME", Content: "Example.GitHub.io"}, contents: []string{"example.github.io."}, want: true},
+		{name: "MX with priority", rr: models.DNSRecord{Type: "MX", Content: "10 mail.example.com"}, contents: []string{"10 mail.example.com."}, want: true},
+		{name: "MX priority kept apart", rr: models.DNSRecord{Type: "MX", Content: "mail.example.com", Priority: 10}, contents: []string{"10 mail.example.com."}, want: true},
+		{name: "TXT quoted", rr: models.DNSRecord{Type: "TXT", Content: "v=spf1 -all"}, contents: []string{`"v=spf1 -all"`}, want: true},
+		{name: "TXT split", rr: models.DNSRecord{Type: "TXT", Content: "abcdef"}, contents: []string{`"abc" "def"`}, want: true},
+		{name: "proxied resolves to any address", rr: models.DNSRecord{Type: "A", Content: "192.0.2.1", Proxied: true}, contents: []string{"104.16.0.1"}, want: true},
+		{name: "proxied without answer", rr: models.DNSRecord{Type: "A", Content: "192.0.2.1", Proxied: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, resolved(tt.rr, tt.contents))
+		})
+	}
+}
+
+func TestDNSResolver_Lookup(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	started := make(chan struct{})
+	srv := &dns.Server{
+		PacketConn:        pc,
+		NotifyStartedFunc: func() { close(started) },
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+			m := new(dns.Msg)
+			m.SetReply(req)
+			if req.Question[0].Name == "www.example.com." {
+				for _, s := range []string{
+					"www.example.com. 300 IN CNAME web.example.com.",
+					"web.example.com. 300 IN A 192.0.2.1",
+				} {
+					rr, err := dns.NewRR(s)
+					require.NoError(t, err)
+					m.Answer = append(m.Answer, rr)
+				}
+			} else {
+				m.Rcode = dns.RcodeNameError
+			}
+			_ = w.WriteMsg(m)
+		}),
+	}
+	go func() { _ = srv.ActivateAndServe() }()
+	t.Cleanup(func() { _ = srv.Shutdown() })
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("fake name server did not start")
+	}
+
+	r := dnsResolver{server: pc.LocalAddr().String()}
+	ctx := context.Background()
+
+	// Records of other types in the answer, like the CNAME chain, are skipped
+	contents, err := r.Lookup(ctx, "www.example.com", "a")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.1"}, contents)
+
+	contents, err = r.Lookup(ctx, "ftp.example.com", "A")
+	require.NoError(t, err)
+	assert.Empty(t, contents)
+
+	_, err = r.Lookup(ctx, "www.example.com", "BOGUS")
+	assert.Error(t, err)
+}
+
+func TestWaitServer(t *testing.T) {
+	lookups := []string{}
+	lookupNS := func(ctx context.Context, name string) ([]*net.NS, error) {
+		lookups = append(lookups, name)
+		if name == "example.com." {
+			return []*net.NS{{Host: "ns1.example.net."}, {Host: "ns2.example.net."}}, nil
+		}
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	ctx := context.Background()
+
+	// The lookup walks up from the record to the zone apex
+	server, err := waitServer(ctx, lookupNS, "new.www.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "ns1.example.net:53", server)
+	assert.Equal(t, []string{"new.www.example.com.", "www.example.com.", "example.com."}, lookups)
+
+	_, err = waitServer(ctx, lookupNS, "www.example.org")
+	assert.ErrorContains(t, err, "no name servers found for www.example.org")
+
+	t.Cleanup(func() { waitResolver = "" })
+	for resolver, want := range map[string]string{"1.1.1.1": "1.1.1.1:53", "127.0.0.1:5353": "127.0.0.1:5353", "2001:db8::1": "[2001:db8::1]:53"} {
+		waitResolver = resolver
+		server, err := waitServer(ctx, lookupNS, "www.example.com")
+		require.NoError(t, err)
+		assert.Equal(t, want, server)
+	}
+}
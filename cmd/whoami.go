@@ -0,0 +1,68 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/mixanemca/cdnscli/internal/app"
+	"github.com/spf13/cobra"
+)
+
+// whoamiCmd represents the whoami command
+var whoamiCmd = &cobra.Command{
+	Aliases: []string{"account"},
+	Args:    cobra.NoArgs,
+	Use:     "whoami",
+	Short:   "Shows the account a provider is authenticated as",
+	Example: `  cdnscli whoami
+  cdnscli whoami --provider cf-staging`,
+	Run: whoamiCmdRun,
+}
+
+func init() {
+	rootCmd.AddCommand(whoamiCmd)
+
+	whoamiCmd.PersistentFlags().StringVarP(&providerName, "provider", "p", "", "provider name from config (default is the default provider)")
+}
+
+func whoamiCmdRun(cmd *cobra.Command, args []string) {
+	a, err := app.New(
+		app.WithConfig(appConfig),
+		app.WithOutputFormat(outputFormat),
+		app.WithOutputFields(outputFields),
+		app.WithOutputWriter(outputWriter),
+	)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	p, err := a.GetProvider(providerName)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), getTimeout())
+	defer cancel()
+
+	info, err := p.AccountInfo(ctx)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	a.Printer().AccountInfo(info)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/mixanemca/cdnscli/internal/app"
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dnssecEnable  bool
+	dnssecDisable bool
+	dnssecStatus  bool
+)
+
+// zoneDNSSECCmd represents the dnssec command
+var zoneDNSSECCmd = &cobra.Command{
+	Args:  cobra.NoArgs,
+	Use:   "dnssec",
+	Short: "Show, enable or disable DNSSEC signing of a zone",
+	Long: `Show, enable or disable DNSSEC signing of a zone. Without --enable or
+--disable the current status is shown.
+
+While the zone is signed the DS record is printed: add it at the registrar to
+complete the chain of trust. Before disabling DNSSEC remove the DS record at
+the registrar and wait for its TTL, otherwise resolvers fail to validate the
+zone. Disabling asks for confirmation unless --yes is given.
+
+Supported by the Cloudflare provider, others return an error.`,
+	Example: `  cdnscli zone dnssec --zone example.com
+  cdnscli zone dnssec --zone example.com --enable
+  cdnscli zone dnssec --zone example.com --disable --yes`,
+	Run: zoneDNSSECRun,
+}
+
+func init() {
+	zoneCmd.AddCommand(zoneDNSSECCmd)
+
+	zoneDNSSECCmd.PersistentFlags().StringVarP(&zone, "zone", "z", "", "Zone name")
+	if err := zoneDNSSECCmd.MarkPersistentFlagRequired("zone"); err != nil {
+		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "zone", err)
+	}
+	zoneDNSSECCmd.PersistentFlags().BoolVar(&dnssecEnable, "enable", false, "Sign the zone")
+	zoneDNSSECCmd.PersistentFlags().BoolVar(&dnssecDisable, "disable", false, "Stop signing the zone")
+	zoneDNSSECCmd.PersistentFlags().BoolVar(&dnssecStatus, "status", false, "Show the DNSSEC status, the default")
+	zoneDNSSECCmd.MarkFlagsMutuallyExclusive("enable", "disable", "status")
+	zoneDNSSECCmd.PersistentFlags().BoolVarP(&yes, "yes", "y", false, "disable without asking for confirmation, required when STDIN is not a terminal")
+}
+
+func zoneDNSSECRun(cmd *cobra.Command, args []string) {
+	a, err := app.New(
+		app.WithConfig(appConfig),
+		app.WithOutputFormat(outputFormat),
+		app.WithOutputFields(outputFields),
+		app.WithOutputWriter(outputWriter),
+	)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if dnssecDisable {
+		question := fmt.Sprintf("Disable DNSSEC of %s? Remove its DS record at the registrar first", models.NameToUnicode(zone))
+		if err := confirmStdin(question, yes); err != nil {
+			exitWithError(err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), getTimeout())
+	defer cancel()
+
+	var status models.DNSSECStatus
+	if dnssecEnable || dnssecDisable {
+		status, err = a.Provider().SetDNSSEC(ctx, zone, dnssecEnable)
+	} else {
+		status, err = a.Provider().GetDNSSEC(ctx, zone)
+	}
+	if err != nil {
+		exitWithError(err)
+	}
+
+	a.Printer().DNSSEC(status)
+}
@@ -0,0 +1,189 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/mixanemca/cdnscli/internal/app"
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/mixanemca/cdnscli/internal/providers"
+	"github.com/mixanemca/cdnscli/internal/workerpool"
+	"github.com/mixanemca/cdnscli/internal/zonefile"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportDir          string
+	exportAllProviders bool
+)
+
+// zoneExportAllCmd represents the export-all command
+var zoneExportAllCmd = &cobra.Command{
+	Args:  cobra.NoArgs,
+	Use:   "export-all",
+	Short: "Export every zone to a BIND zone file",
+	Long: `Export every zone of the default provider to a BIND zone file named
+<zone>.zone in the given directory, which is created when missing.
+
+Each file starts with the $ORIGIN, the SOA record and the NS records of the
+zone apex. Providers that do not expose the SOA record get a file without it.
+With --all-providers the zones of every configured provider are exported, each
+provider to a subdirectory named after it. Zones are exported --concurrency at
+a time; a zone that fails does not stop the others.`,
+	Example: `  cdnscli zone export-all --dir ./zones
+  cdnscli zone export-all --dir ./zones --all-providers --concurrency 8`,
+	Run: zoneExportAllRun,
+}
+
+func init() {
+	zoneCmd.AddCommand(zoneExportAllCmd)
+
+	zoneExportAllCmd.PersistentFlags().StringVar(&exportDir, "dir", "", "Directory to write the zone files to")
+	if err := zoneExportAllCmd.MarkPersistentFlagRequired("dir"); err != nil {
+		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "dir", err)
+	}
+	zoneExportAllCmd.PersistentFlags().BoolVar(&exportAllProviders, "all-providers", false, "Export the zones of all configured providers, each to a subdirectory")
+	addConcurrencyFlag(zoneExportAllCmd)
+}
+
+func zoneExportAllRun(cmd *cobra.Command, args []string) {
+	a, err := app.New(
+		app.WithConfig(appConfig),
+		app.WithOutputFormat(outputFormat),
+		app.WithOutputFields(outputFields),
+		app.WithOutputWriter(outputWriter),
+	)
+	if err != nil {
+		exitWithError(err)
+	}
+	if err := workerpool.ValidateSize(concurrency); err != nil {
+		exitWithError(err)
+	}
+
+	names := []string{a.DefaultProviderName()}
+	if exportAllProviders {
+		names = a.ProviderNames()
+	}
+
+	ctx, stop := interruptContext(context.Background())
+	defer stop()
+
+	var failed int
+	for _, providerName := range names {
+		p, err := a.GetProvider(providerName)
+		if err != nil {
+			exitWithError(err)
+		}
+		dir := exportDir
+		if exportAllProviders {
+			dir = filepath.Join(exportDir, providerName)
+		}
+
+		listCtx, cancel := context.WithTimeout(ctx, getTimeout())
+		zones, err := p.ListZones(listCtx)
+		cancel()
+		if err != nil {
+			exitWithError(fmt.Errorf("%s: %w", providerName, err))
+		}
+
+		for _, e := range exportZones(ctx, p, zones, dir, concurrency) {
+			if e.Err != nil {
+				failed++
+				fmt.Fprintf(os.Stderr, "error: %s: %v\n", models.NameToUnicode(e.Zone), e.Err)
+				continue
+			}
+			if !quiet {
+				fmt.Fprintf(outputWriter, "Exported %s to %s\n", models.NameToUnicode(e.Zone), e.Path)
+			}
+		}
+	}
+	if failed > 0 {
+		exitWithError(fmt.Errorf("%d zones could not be exported", failed))
+	}
+}
+
+// zoneExport is the outcome of exporting a single zone.
+type zoneExport struct {
+	Zone string
+	Path string
+	Err  error
+}
+
+// exportZones writes the zone files of zones to dir, n zones at a time. The
+// results are in the order of zones.
+func exportZones(ctx context.Context, p providers.Provider, zones []models.Zone, dir string, n int) []zoneExport {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		exports := make([]zoneExport, 0, len(zones))
+		for _, z := range zones {
+			exports = append(exports, zoneExport{Zone: z.Name, Err: err})
+		}
+		return exports
+	}
+
+	results := workerpool.Run(ctx, n, zones, func(ctx context.Context, z models.Zone) (string, error) {
+		ctx, cancel := context.WithTimeout(ctx, getTimeout())
+		defer cancel()
+		return exportZone(ctx, p, z.Name, dir)
+	})
+
+	exports := make([]zoneExport, 0, len(zones))
+	for i, r := range results {
+		e := zoneExport{Zone: zones[i].Name, Path: r.Value, Err: r.Err}
+		if !r.Done {
+			e.Err = errBatchInterrupted
+		}
+		exports = append(exports, e)
+	}
+	return exports
+}
+
+// exportZone writes the zone file of zone to dir and returns its path. The SOA
+// record is left out when the provider does not expose it.
+func exportZone(ctx context.Context, p providers.Provider, zone, dir string) (string, error) {
+	file, err := zonefile.FileName(zone)
+	if err != nil {
+		return "", err
+	}
+
+	rrset, err := p.ListRecords(ctx, models.ListDNSRecordsParams{ZoneName: zone})
+	if err != nil {
+		return "", err
+	}
+	var soa *models.SOA
+	if s, err := p.GetSOA(ctx, zone); err == nil {
+		soa = &s
+	} else if !errors.Is(err, providers.ErrUnsupported) {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := zonefile.Write(&buf, zone, soa, rrset); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, file)
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
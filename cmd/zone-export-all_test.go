@@ -0,0 +1,115 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/mixanemca/cdnscli/internal/providers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exportProvider is a providers.Provider serving the records and SOA of zones.
+// Zones without a SOA report that reading it is unsupported, ListRecords fails
+// for the zones in listErr.
+type exportProvider struct {
+	providers.Provider
+	mu      sync.Mutex
+	rrsets  map[string][]models.DNSRecord
+	soas    map[string]models.SOA
+	listErr map[string]error
+}
+
+func (p *exportProvider) ListRecords(ctx context.Context, params models.ListDNSRecordsParams) ([]models.DNSRecord, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.listErr[params.ZoneName]; err != nil {
+		return nil, err
+	}
+	return p.rrsets[params.ZoneName], nil
+}
+
+func (p *exportProvider) GetSOA(ctx context.Context, zone string) (models.SOA, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	soa, ok := p.soas[zone]
+	if !ok {
+		return models.SOA{}, &providers.UnsupportedFeatureError{Feature: "reading the SOA record"}
+	}
+	return soa, nil
+}
+
+func TestExportZones(t *testing.T) {
+	p := &exportProvider{
+		rrsets: map[string][]models.DNSRecord{
+			"example.com": {
+				{Name: "example.com", Type: "NS", Content: "ns1.example.net", TTL: 86400},
+				{Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 300},
+			},
+			"Example.ORG": {
+				{Name: "example.org", Type: "A", Content: "192.0.2.2", TTL: 300},
+			},
+		},
+		soas: map[string]models.SOA{
+			"example.com": {MName: "ns1.example.net", RName: "hostmaster.example.com", Serial: 1, Refresh: 7200, Retry: 3600, Expire: 1209600, Minimum: 300},
+		},
+		listErr: map[string]error{"broken.net": errors.New("boom")},
+	}
+	zones := []models.Zone{{Name: "example.com"}, {Name: "Example.ORG"}, {Name: "broken.net"}}
+	dir := filepath.Join(t.TempDir(), "zones")
+
+	exports := exportZones(context.Background(), p, zones, dir, 2)
+	require.Len(t, exports, 3)
+
+	assert.Equal(t, zoneExport{Zone: "example.com", Path: filepath.Join(dir, "example.com.zone")}, exports[0])
+	assert.Equal(t, zoneExport{Zone: "Example.ORG", Path: filepath.Join(dir, "example.org.zone")}, exports[1])
+	assert.Equal(t, "broken.net", exports[2].Zone)
+	assert.EqualError(t, exports[2].Err, "boom")
+
+	data, err := os.ReadFile(filepath.Join(dir, "example.com.zone"))
+	require.NoError(t, err)
+	assert.Equal(t, "$ORIGIN example.com.\n"+
+		"example.com.\t300\tIN\tSOA\tns1.example.net. hostmaster.example.com. 1 7200 3600 1209600 300\n"+
+		"example.com.\t86400\tIN\tNS\tns1.example.net.\n"+
+		"\n"+
+		"www.example.com.\t300\tIN\tA\t192.0.2.1\n", string(data))
+
+	// Without a SOA from the provider the file has none
+	data, err = os.ReadFile(filepath.Join(dir, "example.org.zone"))
+	require.NoError(t, err)
+	assert.Equal(t, "$ORIGIN example.org.\n\nexample.org.\t300\tIN\tA\t192.0.2.2\n", string(data))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestExportZones_DirError(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o644))
+
+	exports := exportZones(context.Background(), &exportProvider{}, []models.Zone{{Name: "example.com"}}, filepath.Join(file, "zones"), 1)
+	require.Len(t, exports, 1)
+	assert.Error(t, exports[0].Err)
+}
@@ -18,8 +18,6 @@ package cmd
 
 import (
 	"context"
-	"fmt"
-	"os"
 
 	"github.com/mixanemca/cdnscli/internal/app"
 	"github.com/mixanemca/cdnscli/internal/models"
@@ -45,10 +43,11 @@ func zoneListRun(cmd *cobra.Command, args []string) {
 	a, err := app.New(
 		app.WithConfig(appConfig),
 		app.WithOutputFormat(outputFormat),
+		app.WithOutputFields(outputFields),
+		app.WithOutputWriter(outputWriter),
 	)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		exitWithError(err)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), getTimeout())
@@ -61,10 +60,11 @@ func zoneListRun(cmd *cobra.Command, args []string) {
 		zones, err = a.Provider().ListZones(ctx)
 	}
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		exitWithError(err)
 	}
 
+	models.SortZones(zones)
+
 	providerName := a.DefaultProviderName()
 	a.Printer().ZonesList(zones, providerName)
 }
@@ -0,0 +1,80 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"log"
+
+	"github.com/mixanemca/cdnscli/internal/app"
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var nameServers []string
+
+// zoneSetNSCmd represents the set-ns command
+var zoneSetNSCmd = &cobra.Command{
+	Args:  cobra.NoArgs,
+	Use:   "set-ns",
+	Short: "Set the name servers of a zone",
+	Long: `Set the name servers of a zone, 2 to 4 of them.
+
+Cloudflare sets them as custom (vanity) name servers, which needs a plan that
+includes them. Providers that assign the name servers themselves return an error.`,
+	Example: "  cdnscli zone set-ns --zone example.com --ns ns1.example.net --ns ns2.example.net",
+	Run:     zoneSetNSRun,
+}
+
+func init() {
+	zoneCmd.AddCommand(zoneSetNSCmd)
+
+	zoneSetNSCmd.PersistentFlags().StringVarP(&zone, "zone", "z", "", "Zone name")
+	if err := zoneSetNSCmd.MarkPersistentFlagRequired("zone"); err != nil {
+		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "zone", err)
+	}
+	zoneSetNSCmd.PersistentFlags().StringSliceVar(&nameServers, "ns", nil, "Name server of the zone, may be repeated or comma separated")
+	if err := zoneSetNSCmd.MarkPersistentFlagRequired("ns"); err != nil {
+		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "ns", err)
+	}
+}
+
+func zoneSetNSRun(cmd *cobra.Command, args []string) {
+	// Check the name servers before creating the providers
+	if err := models.ValidateNameServers(nameServers); err != nil {
+		exitWithError(err)
+	}
+
+	a, err := app.New(
+		app.WithConfig(appConfig),
+		app.WithOutputFormat(outputFormat),
+		app.WithOutputFields(outputFields),
+		app.WithOutputWriter(outputWriter),
+	)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), getTimeout())
+	defer cancel()
+
+	if err := a.Provider().UpdateNameServers(ctx, zone, nameServers); err != nil {
+		exitWithError(err)
+	}
+
+	a.Printer().ZonesList([]models.Zone{{Name: zone, NameServers: nameServers}}, a.DefaultProviderName())
+}
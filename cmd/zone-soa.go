@@ -0,0 +1,70 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"log"
+
+	"github.com/mixanemca/cdnscli/internal/app"
+	"github.com/spf13/cobra"
+)
+
+// zoneSOACmd represents the soa command
+var zoneSOACmd = &cobra.Command{
+	Args:  cobra.NoArgs,
+	Use:   "soa",
+	Short: "Show the SOA record of a zone",
+	Long: `Show the SOA record of a zone: the primary name server, the responsible
+mailbox, the serial and the refresh, retry, expire and minimum times in seconds.
+
+Supported by the Cloudflare and RFC 2136 providers, others return an error.`,
+	Example: `  cdnscli zone soa --zone example.com
+  cdnscli zone soa --zone example.com -o json | jq .serial`,
+	Run: zoneSOARun,
+}
+
+func init() {
+	zoneCmd.AddCommand(zoneSOACmd)
+
+	zoneSOACmd.PersistentFlags().StringVarP(&zone, "zone", "z", "", "Zone name")
+	if err := zoneSOACmd.MarkPersistentFlagRequired("zone"); err != nil {
+		log.Fatalf("Failed to mark persistent flag %q as a required: %v", "zone", err)
+	}
+}
+
+func zoneSOARun(cmd *cobra.Command, args []string) {
+	a, err := app.New(
+		app.WithConfig(appConfig),
+		app.WithOutputFormat(outputFormat),
+		app.WithOutputFields(outputFields),
+		app.WithOutputWriter(outputWriter),
+	)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), getTimeout())
+	defer cancel()
+
+	soa, err := a.Provider().GetSOA(ctx, zone)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	a.Printer().SOA(soa)
+}
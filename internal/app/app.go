@@ -19,9 +19,12 @@ package app
 
 import (
 	"fmt"
+	"io"
+	"sort"
 	"sync"
 
 	"github.com/mixanemca/cdnscli/internal/config"
+	"github.com/mixanemca/cdnscli/internal/models"
 	pp "github.com/mixanemca/cdnscli/internal/prettyprint"
 	"github.com/mixanemca/cdnscli/internal/providers"
 )
@@ -39,18 +42,32 @@ func initDefaultRegistry() {
 		// Register all available providers
 		defaultRegistry.Register(providers.NewCloudflareFactory())
 		defaultRegistry.Register(providers.NewRegRuFactory())
+		defaultRegistry.Register(providers.NewPowerDNSFactory())
+		defaultRegistry.Register(providers.NewVultrFactory())
+		defaultRegistry.Register(providers.NewRFC2136Factory())
 		// Add more providers here as they are implemented
 		// defaultRegistry.Register(providers.NewRoute53Factory())
 		// defaultRegistry.Register(providers.NewDigitalOceanFactory())
 	})
 }
 
+// CheckProvider creates the provider configured under name the same way New does,
+// including the factory's credentials verification, and returns it or the error.
+// Unlike New it checks a single provider, so every one can be diagnosed on its own.
+func CheckProvider(cfg *config.Config, name string) (providers.Provider, error) {
+	initDefaultRegistry()
+
+	return defaultRegistry.CreateProvider(name, cfg)
+}
+
 type app struct {
 	providers            map[string]providers.Provider
 	providerDisplayNames map[string]string // Maps provider name to display name
 	defaultProvider      providers.Provider
 	pp                   pp.PrettyPrinter
 	output               pp.OutputFormat
+	fields               []string
+	writer               io.Writer
 	cfg                  *config.Config
 	providerName         string
 	registry             providers.ProviderRegistry
@@ -92,7 +109,7 @@ func New(opts ...Option) (App, error) {
 
 			// Store display name for the provider
 			providerCfg := a.cfg.Providers[name]
-			displayName := providers.GetDisplayName(providerCfg.Type, providerCfg.DisplayName)
+			displayName := providers.GetDisplayName(providerCfg.CanonicalType(), providerCfg.DisplayName)
 			a.providerDisplayNames[name] = displayName
 		}
 
@@ -118,7 +135,16 @@ func New(opts ...Option) (App, error) {
 		return nil, fmt.Errorf("no configuration provided")
 	}
 
-	a.pp = pp.New(pp.OutputFormat(a.output))
+	indent := a.cfg != nil && a.cfg.JSONPretty != nil && *a.cfg.JSONPretty
+	ppOpts := []pp.Option{pp.WithFields(a.fields), pp.WithWriter(a.writer), pp.WithIndent(indent)}
+	if a.output == pp.FormatTemplate {
+		tmpl, err := pp.ParseTemplate(a.cfg.OutputTemplate)
+		if err != nil {
+			return nil, err
+		}
+		ppOpts = append(ppOpts, pp.WithTemplate(tmpl))
+	}
+	a.pp = pp.New(pp.OutputFormat(a.output), ppOpts...)
 
 	return a, nil
 }
@@ -140,6 +166,19 @@ func (a *app) GetProvider(name string) (providers.Provider, error) {
 	return provider, nil
 }
 
+func (a *app) ProviderConfig(name string) (*config.ProviderConfig, error) {
+	if name == "" {
+		name = a.defaultProviderKey()
+	}
+
+	if _, exists := a.providers[name]; !exists || a.cfg == nil {
+		return nil, providers.NewProviderNotFoundError(name, a.ProviderNames())
+	}
+
+	// GetProvider also sets the name and cache settings of the provider config
+	return a.cfg.GetProvider(name)
+}
+
 func (a *app) ProviderNames() []string {
 	names := make([]string, 0, len(a.providers))
 	for name := range a.providers {
@@ -166,13 +205,49 @@ func (a *app) DefaultProviderName() string {
 	// Final fallback: use default display name for provider type if available
 	if a.cfg != nil {
 		if providerCfg, exists := a.cfg.Providers[a.providerName]; exists {
-			return providers.GetDisplayName(providerCfg.Type, providerCfg.DisplayName)
+			return providers.GetDisplayName(providerCfg.CanonicalType(), providerCfg.DisplayName)
 		}
 	}
 	// Last resort: use provider name as-is
 	return a.providerName
 }
 
+func (a *app) ProvidersInfo() []models.ProviderInfo {
+	defaultName := a.defaultProviderKey()
+
+	infos := make([]models.ProviderInfo, 0, len(a.providers))
+	for name := range a.providers {
+		info := models.ProviderInfo{
+			Name:        name,
+			DisplayName: a.providerDisplayNames[name],
+			Default:     name == defaultName,
+		}
+		if a.cfg != nil {
+			info.Type = config.CanonicalProviderType(a.cfg.Providers[name].Type)
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+
+	return infos
+}
+
+func (a *app) SupportedProviderTypes() []string {
+	types := a.registry.GetSupportedTypes()
+	sort.Strings(types)
+	return types
+}
+
+// defaultProviderKey returns the config name of the default provider.
+func (a *app) defaultProviderKey() string {
+	for name, provider := range a.providers {
+		if provider == a.defaultProvider {
+			return name
+		}
+	}
+	return ""
+}
+
 func (a *app) Printer() pp.PrettyPrinter {
 	return a.pp
 }
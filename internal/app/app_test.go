@@ -18,14 +18,18 @@ package app
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/mixanemca/cdnscli/internal/config"
 	"github.com/mixanemca/cdnscli/internal/models"
 	pp "github.com/mixanemca/cdnscli/internal/prettyprint"
 	"github.com/mixanemca/cdnscli/internal/providers"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockProvider is a mock implementation of Provider.
@@ -33,6 +37,11 @@ type MockProvider struct {
 	mock.Mock
 }
 
+func (m *MockProvider) AccountInfo(ctx context.Context) (models.AccountInfo, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(models.AccountInfo), args.Error(1)
+}
+
 func (m *MockProvider) AddRR(ctx context.Context, zone string, params models.CreateDNSRecordParams) (models.DNSRecord, error) {
 	args := m.Called(ctx, zone, params)
 	return args.Get(0).(models.DNSRecord), args.Error(1)
@@ -43,6 +52,11 @@ func (m *MockProvider) DeleteRR(ctx context.Context, zone string, rr models.DNSR
 	return args.Error(0)
 }
 
+func (m *MockProvider) GetRRByID(ctx context.Context, params models.ListDNSRecordsParams) (models.DNSRecord, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(models.DNSRecord), args.Error(1)
+}
+
 func (m *MockProvider) GetRRByName(ctx context.Context, zone, name string) (models.DNSRecord, error) {
 	args := m.Called(ctx, zone, name)
 	return args.Get(0).(models.DNSRecord), args.Error(1)
@@ -73,6 +87,46 @@ func (m *MockProvider) UpdateRR(ctx context.Context, zone string, rr models.DNSR
 	return args.Get(0).(models.DNSRecord), args.Error(1)
 }
 
+func (m *MockProvider) UpsertRR(ctx context.Context, zone string, params models.CreateDNSRecordParams) (models.DNSRecord, error) {
+	args := m.Called(ctx, zone, params)
+	return args.Get(0).(models.DNSRecord), args.Error(1)
+}
+
+func (m *MockProvider) Capabilities() models.Capabilities {
+	args := m.Called()
+	return args.Get(0).(models.Capabilities)
+}
+
+func (m *MockProvider) UpdateNameServers(ctx context.Context, zone string, ns []string) error {
+	args := m.Called(ctx, zone, ns)
+	return args.Error(0)
+}
+
+func (m *MockProvider) GetSOA(ctx context.Context, zone string) (models.SOA, error) {
+	args := m.Called(ctx, zone)
+	return args.Get(0).(models.SOA), args.Error(1)
+}
+
+func (m *MockProvider) GetDNSSEC(ctx context.Context, zone string) (models.DNSSECStatus, error) {
+	args := m.Called(ctx, zone)
+	return args.Get(0).(models.DNSSECStatus), args.Error(1)
+}
+
+func (m *MockProvider) SetDNSSEC(ctx context.Context, zone string, enable bool) (models.DNSSECStatus, error) {
+	args := m.Called(ctx, zone, enable)
+	return args.Get(0).(models.DNSSECStatus), args.Error(1)
+}
+
+func (m *MockProvider) Ping(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockProvider) CountRecords(ctx context.Context, zone string) (int, error) {
+	args := m.Called(ctx, zone)
+	return args.Int(0), args.Error(1)
+}
+
 func TestNew_WithConfig_InvalidProvider(t *testing.T) {
 	cfg := &config.Config{
 		DefaultProvider: "non-existent",
@@ -129,6 +183,31 @@ func TestNew_WithProvider_InvalidCredentials(t *testing.T) {
 	assert.True(t, ok1 || ok2, "error should be ProviderCredentialsError or ProviderCreationError, got: %T", err)
 }
 
+func TestNew_MixedCaseProviderType(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cdnscli.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+default_provider: bind
+providers:
+  bind:
+    type: " RFC2136 "
+    credentials:
+      server: 127.0.0.1
+      key_name: cdnscli
+      key_secret: c2VjcmV0
+    options:
+      zones: [example.com]
+`), 0o600))
+	t.Cleanup(viper.Reset)
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	a, err := New(WithConfig(cfg))
+	require.NoError(t, err)
+	assert.Equal(t, []models.ProviderInfo{{Name: "bind", DisplayName: "RFC 2136", Type: "rfc2136", Default: true}}, a.ProvidersInfo())
+}
+
 func TestNew_NoConfig(t *testing.T) {
 	app, err := New()
 	assert.Error(t, err)
@@ -268,3 +347,83 @@ func TestWithProvider(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "test-provider", a.providerName)
 }
+
+func TestWithOutputFields(t *testing.T) {
+	a := &app{}
+	err := WithOutputFields([]string{"Name", "content"})(a)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"name", "content"}, a.fields)
+
+	err = WithOutputFields([]string{"unknown"})(a)
+	assert.Error(t, err)
+}
+
+func TestApp_ProvidersInfo(t *testing.T) {
+	production := new(MockProvider)
+	staging := new(MockProvider)
+
+	a := &app{
+		cfg: &config.Config{
+			DefaultProvider: "cf-staging",
+			Providers: map[string]config.ProviderConfig{
+				"cf-production": {Type: "cloudflare", DisplayName: "Cloudflare Production"},
+				"cf-staging":    {Type: "cloudflare"},
+			},
+		},
+		providers: map[string]providers.Provider{
+			"cf-production": production,
+			"cf-staging":    staging,
+		},
+		providerDisplayNames: map[string]string{
+			"cf-production": "Cloudflare Production",
+			"cf-staging":    "Cloudflare",
+		},
+		defaultProvider: staging,
+	}
+
+	assert.Equal(t, []models.ProviderInfo{
+		{Name: "cf-production", Type: "cloudflare", DisplayName: "Cloudflare Production"},
+		{Name: "cf-staging", Type: "cloudflare", DisplayName: "Cloudflare", Default: true},
+	}, a.ProvidersInfo())
+}
+
+func TestApp_SupportedProviderTypes(t *testing.T) {
+	initDefaultRegistry()
+	a := &app{registry: defaultRegistry}
+
+	assert.Equal(t, []string{"cloudflare", "powerdns", "regru", "rfc2136", "vultr"}, a.SupportedProviderTypes())
+}
+
+func TestApp_ProviderConfig(t *testing.T) {
+	production := new(MockProvider)
+	staging := new(MockProvider)
+
+	a := &app{
+		cfg: &config.Config{
+			Providers: map[string]config.ProviderConfig{
+				"cf-production": {Type: "cloudflare", Options: map[string]interface{}{"default_ttl": 300}},
+				"cf-staging":    {Type: "cloudflare"},
+			},
+		},
+		providers: map[string]providers.Provider{
+			"cf-production": production,
+			"cf-staging":    staging,
+		},
+		defaultProvider: production,
+	}
+
+	pc, err := a.ProviderConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, 300, pc.Options["default_ttl"])
+
+	pc, err = a.ProviderConfig("cf-staging")
+	require.NoError(t, err)
+	assert.Equal(t, "cloudflare", pc.Type)
+	// The name keys the zone cache of the provider
+	assert.Equal(t, "cf-staging", pc.Name())
+
+	_, err = a.ProviderConfig("non-existent")
+	assert.Error(t, err)
+	_, ok := err.(*providers.ProviderNotFoundError)
+	assert.True(t, ok)
+}
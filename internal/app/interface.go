@@ -17,6 +17,8 @@ limitations under the License.
 package app
 
 import (
+	"github.com/mixanemca/cdnscli/internal/config"
+	"github.com/mixanemca/cdnscli/internal/models"
 	"github.com/mixanemca/cdnscli/internal/prettyprint"
 	"github.com/mixanemca/cdnscli/internal/providers"
 )
@@ -28,10 +30,17 @@ type App interface {
 	// GetProvider returns a provider by name. Returns an error if the provider is not found.
 	// If name is empty, returns the default provider.
 	GetProvider(name string) (providers.Provider, error)
+	// ProviderConfig returns the configuration of a provider by name. Returns an error if the provider is not found.
+	// If name is empty, returns the configuration of the default provider.
+	ProviderConfig(name string) (*config.ProviderConfig, error)
 	// ProviderNames returns a list of all available provider names.
 	ProviderNames() []string
 	// DefaultProviderName returns the name of the default provider.
 	DefaultProviderName() string
+	// ProvidersInfo returns the configured providers sorted by name.
+	ProvidersInfo() []models.ProviderInfo
+	// SupportedProviderTypes returns the sorted list of provider types the application can create.
+	SupportedProviderTypes() []string
 	// Printer returns a specialized API for pretty printing.
 	Printer() prettyprint.PrettyPrinter
 }
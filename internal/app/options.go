@@ -17,6 +17,8 @@ limitations under the License.
 package app
 
 import (
+	"io"
+
 	"github.com/mixanemca/cdnscli/internal/config"
 	"github.com/mixanemca/cdnscli/internal/prettyprint"
 )
@@ -29,6 +31,26 @@ func WithOutputFormat(output prettyprint.OutputFormat) Option {
 	}
 }
 
+// WithOutputWriter makes the printer write to w instead of os.Stdout.
+func WithOutputWriter(w io.Writer) Option {
+	return func(a *app) error {
+		a.writer = w
+		return nil
+	}
+}
+
+// WithOutputFields restricts records output to the given fields.
+func WithOutputFields(fields []string) Option {
+	return func(a *app) error {
+		normalized, err := prettyprint.NormalizeFields(fields)
+		if err != nil {
+			return err
+		}
+		a.fields = normalized
+		return nil
+	}
+}
+
 // WithConfig sets the application configuration
 func WithConfig(cfg *config.Config) Option {
 	return func(a *app) error {
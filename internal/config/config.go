@@ -18,7 +18,13 @@ limitations under the License.
 package config
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/mixanemca/cdnscli/internal/models"
 )
 
 // Config represents the main configuration structure.
@@ -35,8 +41,81 @@ type Config struct {
 	// OutputFormat is the default output format
 	OutputFormat string `mapstructure:"output_format" yaml:"output-format,omitempty"`
 
+	// OutputTemplate is the Go template of the template output format, set by --template
+	OutputTemplate string `mapstructure:"-" yaml:"-"`
+
+	// JSONPretty indents JSON output, by default only when printing to a terminal
+	JSONPretty *bool `mapstructure:"json_pretty" yaml:"json-pretty,omitempty"`
+
 	// Debug enables debug output
 	Debug bool `mapstructure:"debug" yaml:"debug"`
+
+	// UI holds settings of the text user interface
+	UI UIConfig `mapstructure:"ui" yaml:"ui,omitempty"`
+
+	// Cache holds settings of the on-disk cache
+	Cache CacheConfig `mapstructure:"cache" yaml:"cache,omitempty"`
+}
+
+// CacheConfig holds settings of the on-disk cache of zone name to ID mappings.
+type CacheConfig struct {
+	// Dir is the cache directory, by default $XDG_CACHE_HOME/cdnscli
+	Dir string `mapstructure:"dir" yaml:"dir,omitempty"`
+
+	// ZoneTTL is how long a cached zone ID is used, zero disables the cache
+	ZoneTTL time.Duration `mapstructure:"zone_ttl" yaml:"zone_ttl,omitempty"`
+
+	// Refresh ignores cached entries and looks every zone up again, set by --refresh-cache
+	Refresh bool `mapstructure:"-" yaml:"-"`
+}
+
+// UIConfig holds settings of the text user interface.
+type UIConfig struct {
+	// ConfirmEdits asks for confirmation before saving a record whose type or content was changed
+	ConfirmEdits bool `mapstructure:"confirm_edits" yaml:"confirm_edits,omitempty"`
+
+	// WatchInterval is how often the watch mode reloads the records of the selected zone
+	WatchInterval time.Duration `mapstructure:"watch_interval" yaml:"watch_interval,omitempty"`
+
+	// Keybindings maps TUI actions to keys, overriding DefaultKeybindings
+	Keybindings map[string]string `mapstructure:"keybindings" yaml:"keybindings,omitempty"`
+}
+
+// DefaultKeybindings maps the TUI actions to their default keys. The arrow keys,
+// Esc, Enter and Ctrl+C keep their meaning whatever the bindings are.
+var DefaultKeybindings = map[string]string{
+	"up":     "k",
+	"down":   "j",
+	"edit":   "e",
+	"create": "c",
+	"clone":  "C",
+	"delete": "d",
+	"ids":    "i",
+	"watch":  "w",
+	"reload": "r",
+	"quit":   "q",
+}
+
+// reservedKeys are the keys that cannot be bound to an action.
+var reservedKeys = map[string]bool{
+	"up":     true,
+	"down":   true,
+	"esc":    true,
+	"enter":  true,
+	" ":      true,
+	"ctrl+c": true,
+}
+
+// KeyMap returns the TUI keybindings: DefaultKeybindings overridden by ui.keybindings.
+func (u UIConfig) KeyMap() map[string]string {
+	keys := make(map[string]string, len(DefaultKeybindings))
+	for action, key := range DefaultKeybindings {
+		keys[action] = key
+	}
+	for action, key := range u.Keybindings {
+		keys[strings.ToLower(action)] = key
+	}
+	return keys
 }
 
 // ProviderConfig holds configuration for a specific DNS provider.
@@ -46,13 +125,69 @@ type ProviderConfig struct {
 
 	// DisplayName is the custom display name for the provider (e.g., "Cloudflare", "Рога и Копыта")
 	// If not set, a default display name will be used based on the provider type.
-	DisplayName string `mapstructure:"display_name" yaml:"display-name,omitempty"`
+	DisplayName string `mapstructure:"display-name" yaml:"display-name,omitempty"`
 
 	// Credentials holds provider-specific credentials
 	Credentials map[string]interface{} `mapstructure:"credentials" yaml:"credentials"`
 
 	// Options holds provider-specific options
 	Options map[string]interface{} `mapstructure:"options" yaml:"options"`
+
+	// name, cache, debug and timeout are filled in by Config.GetProvider
+	name    string
+	cache   CacheConfig
+	debug   bool
+	timeout time.Duration
+}
+
+// Name returns the name the provider is configured under.
+// It is empty unless the configuration was returned by Config.GetProvider.
+func (pc *ProviderConfig) Name() string {
+	return pc.name
+}
+
+// Cache returns the cache settings for the provider.
+func (pc *ProviderConfig) Cache() CacheConfig {
+	return pc.cache
+}
+
+// Debug reports whether debug output is on, e.g. to trace the API requests.
+func (pc *ProviderConfig) Debug() bool {
+	return pc.debug
+}
+
+// ClientTimeout returns the timeout of the provider API requests.
+func (pc *ProviderConfig) ClientTimeout() time.Duration {
+	if pc.timeout <= 0 {
+		return DefaultClientTimeout
+	}
+	return pc.timeout
+}
+
+// ProviderTypeAliases maps alternative spellings of provider types to their
+// canonical names. Types are compared in lower case.
+var ProviderTypeAliases = map[string]string{
+	"cf":       "cloudflare",
+	"pdns":     "powerdns",
+	"reg.ru":   "regru",
+	"reg-ru":   "regru",
+	"nsupdate": "rfc2136",
+}
+
+// CanonicalProviderType returns the canonical name of a provider type: it is
+// trimmed, lower-cased and resolved through ProviderTypeAliases, so cloudFlare
+// and cf are both cloudflare. Unknown types are returned normalized.
+func CanonicalProviderType(t string) string {
+	t = strings.ToLower(strings.TrimSpace(t))
+	if canonical, ok := ProviderTypeAliases[t]; ok {
+		return canonical
+	}
+	return t
+}
+
+// CanonicalType returns the canonical name of the provider type.
+func (pc *ProviderConfig) CanonicalType() string {
+	return CanonicalProviderType(pc.Type)
 }
 
 // CloudflareCredentials holds Cloudflare-specific credentials.
@@ -71,15 +206,20 @@ type CloudflareCredentials struct {
 func (pc *ProviderConfig) GetCloudflareCredentials() (*CloudflareCredentials, error) {
 	creds := &CloudflareCredentials{}
 
-	if apiToken, ok := pc.Credentials["api_token"].(string); ok {
+	values, err := pc.ResolveCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	if apiToken, ok := values["api_token"].(string); ok {
 		creds.APIToken = apiToken
 	}
 
-	if apiKey, ok := pc.Credentials["api_key"].(string); ok {
+	if apiKey, ok := values["api_key"].(string); ok {
 		creds.APIKey = apiKey
 	}
 
-	if email, ok := pc.Credentials["email"].(string); ok {
+	if email, ok := values["email"].(string); ok {
 		creds.Email = email
 	}
 
@@ -99,13 +239,266 @@ type RegRuCredentials struct {
 func (pc *ProviderConfig) GetRegRuCredentials() (*RegRuCredentials, error) {
 	creds := &RegRuCredentials{}
 
-	if username, ok := pc.Credentials["username"].(string); ok {
+	values, err := pc.ResolveCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	if username, ok := values["username"].(string); ok {
 		creds.Username = username
 	}
 
-	if password, ok := pc.Credentials["password"].(string); ok {
+	if password, ok := values["password"].(string); ok {
 		creds.Password = password
 	}
 
 	return creds, nil
 }
+
+// PowerDNSCredentials holds PowerDNS-specific credentials.
+type PowerDNSCredentials struct {
+	// APIURL is the base URL of the PowerDNS HTTP API (e.g., "http://127.0.0.1:8081")
+	APIURL string `mapstructure:"api_url" yaml:"api_url"`
+
+	// APIKey is the PowerDNS API key
+	APIKey string `mapstructure:"api_key" yaml:"api_key"`
+
+	// ServerID is the PowerDNS server ID (defaults to "localhost")
+	ServerID string `mapstructure:"server_id" yaml:"server_id"`
+}
+
+// GetPowerDNSCredentials extracts PowerDNS credentials from provider config.
+func (pc *ProviderConfig) GetPowerDNSCredentials() (*PowerDNSCredentials, error) {
+	creds := &PowerDNSCredentials{}
+
+	values, err := pc.ResolveCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	if apiURL, ok := values["api_url"].(string); ok {
+		creds.APIURL = apiURL
+	}
+
+	if apiKey, ok := values["api_key"].(string); ok {
+		creds.APIKey = apiKey
+	}
+
+	if serverID, ok := values["server_id"].(string); ok {
+		creds.ServerID = serverID
+	}
+
+	return creds, nil
+}
+
+// VultrCredentials holds Vultr-specific credentials.
+type VultrCredentials struct {
+	// APIKey is the Vultr API key
+	APIKey string `mapstructure:"api_key" yaml:"api_key"`
+
+	// APIURL is the base URL of the Vultr API (defaults to "https://api.vultr.com")
+	APIURL string `mapstructure:"api_url" yaml:"api_url"`
+}
+
+// GetVultrCredentials extracts Vultr credentials from provider config.
+func (pc *ProviderConfig) GetVultrCredentials() (*VultrCredentials, error) {
+	creds := &VultrCredentials{}
+
+	values, err := pc.ResolveCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	if apiKey, ok := values["api_key"].(string); ok {
+		creds.APIKey = apiKey
+	}
+
+	if apiURL, ok := values["api_url"].(string); ok {
+		creds.APIURL = apiURL
+	}
+
+	return creds, nil
+}
+
+// RFC2136Credentials holds credentials for dynamic DNS updates (RFC 2136) signed with TSIG.
+type RFC2136Credentials struct {
+	// Server is the address of the primary name server (e.g., "ns1.example.com:53")
+	Server string `mapstructure:"server" yaml:"server"`
+
+	// KeyName is the name of the TSIG key
+	KeyName string `mapstructure:"key_name" yaml:"key_name"`
+
+	// KeySecret is the base64 encoded TSIG secret
+	KeySecret string `mapstructure:"key_secret" yaml:"key_secret"`
+
+	// KeyAlgo is the TSIG algorithm (defaults to "hmac-sha256")
+	KeyAlgo string `mapstructure:"key_algo" yaml:"key_algo"`
+}
+
+// GetRFC2136Credentials extracts RFC 2136 credentials from provider config.
+func (pc *ProviderConfig) GetRFC2136Credentials() (*RFC2136Credentials, error) {
+	creds := &RFC2136Credentials{}
+
+	values, err := pc.ResolveCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	if server, ok := values["server"].(string); ok {
+		creds.Server = server
+	}
+
+	if keyName, ok := values["key_name"].(string); ok {
+		creds.KeyName = keyName
+	}
+
+	if keySecret, ok := values["key_secret"].(string); ok {
+		creds.KeySecret = keySecret
+	}
+
+	if keyAlgo, ok := values["key_algo"].(string); ok {
+		creds.KeyAlgo = keyAlgo
+	}
+
+	return creds, nil
+}
+
+// GetZones returns the zones listed in provider options ("zones"), for providers
+// that cannot list zones themselves. Accepts a list or a comma separated string.
+func (pc *ProviderConfig) GetZones() []string {
+	var zones []string
+
+	switch v := pc.Options["zones"].(type) {
+	case []interface{}:
+		for _, z := range v {
+			if s, ok := z.(string); ok {
+				zones = append(zones, s)
+			}
+		}
+	case []string:
+		zones = append(zones, v...)
+	case string:
+		zones = strings.Split(v, ",")
+	}
+
+	result := make([]string, 0, len(zones))
+	for _, z := range zones {
+		if z = strings.TrimSpace(z); z != "" {
+			result = append(result, z)
+		}
+	}
+
+	return result
+}
+
+// GetDefaultTTL returns the default TTL from provider options ("default_ttl").
+// Returns 0 if the option is not set.
+func (pc *ProviderConfig) GetDefaultTTL() (int, error) {
+	v, ok := pc.Options["default_ttl"]
+	if !ok || v == nil {
+		return 0, nil
+	}
+
+	switch ttl := v.(type) {
+	case int:
+		return ttl, nil
+	case int64:
+		return int(ttl), nil
+	case float64:
+		return int(ttl), nil
+	case string:
+		return models.ParseTTL(ttl)
+	default:
+		return 0, fmt.Errorf("invalid default_ttl value %v", v)
+	}
+}
+
+// GetDefaultType returns the default record type from provider options ("default_type").
+// Returns an empty string if the option is not set.
+func (pc *ProviderConfig) GetDefaultType() string {
+	v, ok := pc.Options["default_type"].(string)
+	if !ok {
+		return ""
+	}
+	return strings.ToUpper(strings.TrimSpace(v))
+}
+
+// GetZoneName returns the zone name from provider options ("zone_name"), the
+// single zone of credentials that may not list zones. Returns an empty string
+// if the option is not set.
+func (pc *ProviderConfig) GetZoneName() string {
+	v, ok := pc.Options["zone_name"].(string)
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(v)
+}
+
+// GetZoneID returns the ID of the zone named by "zone_name" from provider options
+// ("zone_id"). Returns an empty string if the option is not set.
+func (pc *ProviderConfig) GetZoneID() string {
+	v, ok := pc.Options["zone_id"].(string)
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(v)
+}
+
+// GetDefaultProxied returns the default proxied flag from provider options ("default_proxied").
+// Returns false if the option is not set.
+func (pc *ProviderConfig) GetDefaultProxied() (bool, error) {
+	v, ok := pc.Options["default_proxied"]
+	if !ok || v == nil {
+		return false, nil
+	}
+
+	switch proxied := v.(type) {
+	case bool:
+		return proxied, nil
+	case string:
+		return strconv.ParseBool(proxied)
+	default:
+		return false, fmt.Errorf("invalid default_proxied value %v", v)
+	}
+}
+
+// GetHTTPProxy returns the URL of the proxy for the provider API from provider
+// options ("http_proxy"). Returns an empty string if the option is not set, the
+// HTTP_PROXY and HTTPS_PROXY environment variables apply then.
+func (pc *ProviderConfig) GetHTTPProxy() string {
+	v, ok := pc.Options["http_proxy"].(string)
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(v)
+}
+
+// GetInsecureSkipVerify returns whether the TLS certificate of the provider API
+// is not verified, from provider options ("insecure_skip_verify").
+// Returns false if the option is not set.
+func (pc *ProviderConfig) GetInsecureSkipVerify() (bool, error) {
+	v, ok := pc.Options["insecure_skip_verify"]
+	if !ok || v == nil {
+		return false, nil
+	}
+
+	switch skip := v.(type) {
+	case bool:
+		return skip, nil
+	case string:
+		return strconv.ParseBool(skip)
+	default:
+		return false, fmt.Errorf("invalid insecure_skip_verify value %v", v)
+	}
+}
+
+// GetCABundle returns the path of a PEM file with additional CA certificates
+// for the provider API from provider options ("ca_bundle"), with a leading ~
+// expanded. Returns an empty string if the option is not set.
+func (pc *ProviderConfig) GetCABundle() (string, error) {
+	v, ok := pc.Options["ca_bundle"].(string)
+	if !ok || strings.TrimSpace(v) == "" {
+		return "", nil
+	}
+	return homedir.Expand(strings.TrimSpace(v))
+}
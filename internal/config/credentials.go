@@ -0,0 +1,197 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/zalando/go-keyring"
+)
+
+// credentialSource reads a secret referenced by a credential with the given suffix.
+type credentialSource struct {
+	suffix string
+	read   func(ref string) (string, error)
+}
+
+// credentialSources are the places a secret may be read from instead of the config file:
+//
+//	api_token_file: ~/.secrets/cloudflare-token
+//	api_token_keyring: cdnscli/cf-production
+var credentialSources = []credentialSource{
+	{suffix: "_file", read: readCredentialFile},
+	{suffix: "_keyring", read: readCredentialKeyring},
+}
+
+// ResolveCredentials returns the provider credentials with secrets referenced by
+// "<key>_file" and "<key>_keyring" entries loaded and stored under "<key>".
+// File contents are trimmed of surrounding whitespace. A key may be set only once,
+// either inline or by one reference.
+func (pc *ProviderConfig) ResolveCredentials() (map[string]interface{}, error) {
+	resolved := make(map[string]interface{}, len(pc.Credentials))
+	for k, v := range pc.Credentials {
+		if _, ok := credentialReference(k); !ok {
+			resolved[k] = v
+		}
+	}
+
+	for k, v := range pc.Credentials {
+		src, ok := credentialReference(k)
+		if !ok {
+			continue
+		}
+		key := strings.TrimSuffix(k, src.suffix)
+		if err := credentialSetOnce(pc.Credentials, key); err != nil {
+			return nil, err
+		}
+
+		ref, ok := v.(string)
+		if !ok || strings.TrimSpace(ref) == "" {
+			return nil, fmt.Errorf("credential %s must be a non-empty string", k)
+		}
+		secret, err := src.read(strings.TrimSpace(ref))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read credential %s: %w", k, err)
+		}
+		resolved[key] = secret
+	}
+
+	return resolved, nil
+}
+
+// credentialReference returns the source of a credential referencing a secret stored elsewhere.
+func credentialReference(k string) (credentialSource, bool) {
+	for _, src := range credentialSources {
+		if key, ok := strings.CutSuffix(k, src.suffix); ok && key != "" {
+			return src, true
+		}
+	}
+	return credentialSource{}, false
+}
+
+// credentialSetOnce checks that key is set either inline or by a single reference.
+func credentialSetOnce(credentials map[string]interface{}, key string) error {
+	var set []string
+	if _, ok := credentials[key]; ok {
+		set = append(set, key)
+	}
+	for _, src := range credentialSources {
+		if _, ok := credentials[key+src.suffix]; ok {
+			set = append(set, key+src.suffix)
+		}
+	}
+
+	if len(set) > 1 {
+		return fmt.Errorf("credentials %s are mutually exclusive", strings.Join(set, " and "))
+	}
+	return nil
+}
+
+// readCredentialFile returns the trimmed contents of the file at path, expanding a leading ~.
+func readCredentialFile(path string) (string, error) {
+	path, err := homedir.Expand(path)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// readCredentialKeyring returns the secret stored in the system keyring under ref ("service/user").
+func readCredentialKeyring(ref string) (string, error) {
+	service, user, err := ParseKeyringRef(ref)
+	if err != nil {
+		return "", err
+	}
+
+	secret, err := keyring.Get(service, user)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", fmt.Errorf("no keyring entry %s, store it with: cdnscli config set-secret %s", ref, ref)
+	}
+	if err != nil {
+		return "", fmt.Errorf("keyring: %w", err)
+	}
+
+	return secret, nil
+}
+
+// ParseKeyringRef splits a keyring reference "service/user" into its parts.
+func ParseKeyringRef(ref string) (service, user string, err error) {
+	service, user, ok := strings.Cut(ref, "/")
+	if !ok || service == "" || user == "" {
+		return "", "", fmt.Errorf("invalid keyring reference %q, want service/user", ref)
+	}
+	return service, user, nil
+}
+
+// SetKeyringSecret stores secret in the system keyring under ref ("service/user").
+func SetKeyringSecret(ref, secret string) error {
+	service, user, err := ParseKeyringRef(ref)
+	if err != nil {
+		return err
+	}
+	if err := keyring.Set(service, user, secret); err != nil {
+		return fmt.Errorf("keyring: %w", err)
+	}
+	return nil
+}
+
+// RedactedValue replaces credential values in Redacted configurations.
+const RedactedValue = "***"
+
+// Redacted returns a copy of the provider configuration safe to display or log:
+// every credential value is replaced by RedactedValue. Keys are kept, so the
+// credentials in use can still be told apart, and empty values are left empty.
+func (pc *ProviderConfig) Redacted() ProviderConfig {
+	r := *pc
+	r.Options = maps.Clone(pc.Options)
+	if pc.Credentials != nil {
+		r.Credentials = make(map[string]interface{}, len(pc.Credentials))
+		for k, v := range pc.Credentials {
+			if v == nil || v == "" {
+				r.Credentials[k] = v
+				continue
+			}
+			r.Credentials[k] = RedactedValue
+		}
+	}
+	return r
+}
+
+// Redacted returns a copy of the configuration with the credentials of all
+// providers redacted, see ProviderConfig.Redacted.
+func (c *Config) Redacted() *Config {
+	r := *c
+	r.UI.Keybindings = maps.Clone(c.UI.Keybindings)
+	if c.Providers != nil {
+		r.Providers = make(map[string]ProviderConfig, len(c.Providers))
+		for name, pc := range c.Providers {
+			r.Providers[name] = pc.Redacted()
+		}
+	}
+	return &r
+}
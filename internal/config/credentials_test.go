@@ -0,0 +1,236 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zalando/go-keyring"
+)
+
+func writeSecret(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestResolveCredentials_File(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := writeSecret(t, dir, "token", "  secret-token\n")
+
+	pc := &ProviderConfig{
+		Type: "cloudflare",
+		Credentials: map[string]interface{}{
+			"api_token_file": tokenFile,
+			"email":          "user@example.com",
+		},
+	}
+
+	values, err := pc.ResolveCredentials()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"api_token": "secret-token",
+		"email":     "user@example.com",
+	}, values)
+
+	// The config itself is left untouched
+	assert.Contains(t, pc.Credentials, "api_token_file")
+	assert.NotContains(t, pc.Credentials, "api_token")
+
+	creds, err := pc.GetCloudflareCredentials()
+	require.NoError(t, err)
+	assert.Equal(t, "secret-token", creds.APIToken)
+}
+
+func TestResolveCredentials_HomeDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	homedir.Reset()
+	t.Cleanup(homedir.Reset)
+
+	writeSecret(t, home, "tsig.key", "c2VjcmV0\n")
+
+	pc := &ProviderConfig{
+		Type: "rfc2136",
+		Credentials: map[string]interface{}{
+			"server":          "ns1.example.com:53",
+			"key_name":        "cdnscli.",
+			"key_secret_file": "~/tsig.key",
+		},
+	}
+
+	creds, err := pc.GetRFC2136Credentials()
+	require.NoError(t, err)
+	assert.Equal(t, "c2VjcmV0", creds.KeySecret)
+}
+
+func TestResolveCredentials_Errors(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := writeSecret(t, dir, "token", "secret-token")
+
+	tests := []struct {
+		name        string
+		credentials map[string]interface{}
+		wantErr     string
+	}{
+		{
+			name:        "missing file",
+			credentials: map[string]interface{}{"api_key_file": filepath.Join(dir, "missing")},
+			wantErr:     "failed to read credential api_key_file: open " + filepath.Join(dir, "missing"),
+		},
+		{
+			name:        "both value and file",
+			credentials: map[string]interface{}{"api_token": "inline", "api_token_file": tokenFile},
+			wantErr:     "credentials api_token and api_token_file are mutually exclusive",
+		},
+		{
+			name:        "file and keyring",
+			credentials: map[string]interface{}{"api_key_keyring": "cdnscli/vultr", "api_key_file": tokenFile},
+			wantErr:     "credentials api_key_file and api_key_keyring are mutually exclusive",
+		},
+		{
+			name:        "empty path",
+			credentials: map[string]interface{}{"api_key_file": " "},
+			wantErr:     "credential api_key_file must be a non-empty string",
+		},
+		{
+			name:        "not a string",
+			credentials: map[string]interface{}{"api_key_file": 42},
+			wantErr:     "credential api_key_file must be a non-empty string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := &ProviderConfig{Type: "vultr", Credentials: tt.credentials}
+
+			_, err := pc.ResolveCredentials()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+
+			_, err = pc.GetVultrCredentials()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestValidate_CredentialFile(t *testing.T) {
+	dir := t.TempDir()
+
+	pc := ProviderConfig{
+		Type:        "vultr",
+		Credentials: map[string]interface{}{"api_key_file": writeSecret(t, dir, "key", "vultr-key\n")},
+	}
+	assert.NoError(t, pc.Validate("vultr"))
+
+	pc.Credentials = map[string]interface{}{"api_key_file": filepath.Join(dir, "missing")}
+	err := pc.Validate("vultr")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read credential api_key_file")
+}
+
+func TestResolveCredentials_Keyring(t *testing.T) {
+	keyring.MockInit()
+	require.NoError(t, keyring.Set("cdnscli", "cf-production", "keyring-token"))
+
+	pc := &ProviderConfig{
+		Type:        "cloudflare",
+		Credentials: map[string]interface{}{"api_token_keyring": "cdnscli/cf-production"},
+	}
+	creds, err := pc.GetCloudflareCredentials()
+	require.NoError(t, err)
+	assert.Equal(t, "keyring-token", creds.APIToken)
+
+	pc.Credentials = map[string]interface{}{"api_token_keyring": "cdnscli/cf-staging"}
+	_, err = pc.ResolveCredentials()
+	assert.EqualError(t, err, "failed to read credential api_token_keyring: no keyring entry cdnscli/cf-staging, store it with: cdnscli config set-secret cdnscli/cf-staging")
+
+	pc.Credentials = map[string]interface{}{"api_token_keyring": "cf-staging"}
+	_, err = pc.ResolveCredentials()
+	assert.EqualError(t, err, `failed to read credential api_token_keyring: invalid keyring reference "cf-staging", want service/user`)
+
+	keyring.MockInitWithError(errors.New("no secret service"))
+	pc.Credentials = map[string]interface{}{"api_token_keyring": "cdnscli/cf-production"}
+	_, err = pc.ResolveCredentials()
+	assert.EqualError(t, err, "failed to read credential api_token_keyring: keyring: no secret service")
+}
+
+func TestSetKeyringSecret(t *testing.T) {
+	keyring.MockInit()
+
+	require.NoError(t, SetKeyringSecret("cdnscli/cf-production", "s3cret"))
+	secret, err := keyring.Get("cdnscli", "cf-production")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", secret)
+
+	assert.Error(t, SetKeyringSecret("/cf-production", "s3cret"))
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := &Config{
+		DefaultProvider: "cf",
+		ClientTimeout:   DefaultClientTimeout,
+		Providers: map[string]ProviderConfig{
+			"cf": {
+				Type:        "cloudflare",
+				DisplayName: "Production",
+				Credentials: map[string]interface{}{
+					"api_token": "secret-token",
+					"email":     "",
+				},
+				Options: map[string]interface{}{"default_ttl": "5m"},
+			},
+			"pdns": {
+				Type: "powerdns",
+				Credentials: map[string]interface{}{
+					"api_url":      "http://127.0.0.1:8081",
+					"api_key_file": "~/.secrets/pdns",
+				},
+			},
+		},
+	}
+
+	r := cfg.Redacted()
+	assert.Equal(t, "cf", r.DefaultProvider)
+	assert.Equal(t, DefaultClientTimeout, r.ClientTimeout)
+	assert.Equal(t, ProviderConfig{
+		Type:        "cloudflare",
+		DisplayName: "Production",
+		Credentials: map[string]interface{}{
+			"api_token": RedactedValue,
+			"email":     "",
+		},
+		Options: map[string]interface{}{"default_ttl": "5m"},
+	}, r.Providers["cf"])
+	assert.Equal(t, map[string]interface{}{
+		"api_url":      RedactedValue,
+		"api_key_file": RedactedValue,
+	}, r.Providers["pdns"].Credentials)
+
+	// The original is left untouched
+	assert.Equal(t, "secret-token", cfg.Providers["cf"].Credentials["api_token"])
+	r.Providers["cf"].Options["default_ttl"] = "1h"
+	assert.Equal(t, "5m", cfg.Providers["cf"].Options["default_ttl"])
+
+	assert.Nil(t, (&Config{}).Redacted().Providers)
+}
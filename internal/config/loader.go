@@ -17,7 +17,10 @@ limitations under the License.
 package config
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -44,11 +47,42 @@ const (
 
 	// DefaultOutputFormat is the default output format
 	DefaultOutputFormat = "text"
+
+	// StdinConfigFile is the config file name that reads the config from stdin
+	StdinConfigFile = "-"
+
+	// maxRemoteConfigSize limits the size of a config read from stdin or a URL
+	maxRemoteConfigSize = 1 << 20
 )
 
+// LoadOption customizes how Load reads the configuration.
+type LoadOption func(*loadOptions)
+
+type loadOptions struct {
+	profile string
+	stdin   io.Reader
+}
+
+// WithProfile merges the named profile over the base configuration. A profile is
+// the profiles.<name> sub-tree of the config file or, failing that, the file
+// profiles/<name>.yaml next to it.
+func WithProfile(name string) LoadOption {
+	return func(o *loadOptions) {
+		o.profile = name
+	}
+}
+
 // Load loads configuration from file, environment variables, and command line flags.
-// Priority order: flags > env > config file > defaults
-func Load(cfgFile string) (*Config, error) {
+// Priority order: flags > env > profile > config file > defaults
+//
+// A cfgFile of "-" reads the config from stdin and an http:// or https:// URL
+// fetches it. Such a config must be valid, there is no file to fix it in.
+func Load(cfgFile string, opts ...LoadOption) (*Config, error) {
+	o := loadOptions{stdin: os.Stdin}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	cfg := &Config{
 		DefaultProvider: "",
 		Providers:       make(map[string]ProviderConfig),
@@ -57,8 +91,12 @@ func Load(cfgFile string) (*Config, error) {
 		Debug:           false,
 	}
 
+	remote := cfgFile == StdinConfigFile || isConfigURL(cfgFile)
+
 	// Setup Viper
-	if cfgFile != "" {
+	if remote {
+		viper.SetConfigType(DefaultConfigType)
+	} else if cfgFile != "" {
 		// Use config file from the flag
 		viper.SetConfigFile(cfgFile)
 	} else {
@@ -82,8 +120,18 @@ func Load(cfgFile string) (*Config, error) {
 	viper.SetDefault("output_format", DefaultOutputFormat)
 	viper.SetDefault("debug", false)
 
-	// Read config file (optional - don't fail if it doesn't exist)
-	if err := viper.ReadInConfig(); err != nil {
+	if remote {
+		data, err := readRemoteConfig(cfgFile, o.stdin)
+		if err != nil {
+			return nil, err
+		}
+		if err := viper.ReadConfig(bytes.NewReader(data)); err != nil {
+			return nil, fmt.Errorf("failed to read config from %s: %w", configSourceName(cfgFile), err)
+		}
+		// Record the source for ConfigFileUsed, it is not read again
+		viper.SetConfigFile(cfgFile)
+	} else if err := viper.ReadInConfig(); err != nil {
+		// Read config file (optional - don't fail if it doesn't exist)
 		// Config file not found; this is OK if we have env vars or flags
 		// Check if it's a "file not found" error by checking the error message
 		if err.Error() != "config file not found" && !contains(err.Error(), "not found") {
@@ -91,14 +139,113 @@ func Load(cfgFile string) (*Config, error) {
 		}
 	}
 
+	if o.profile != "" {
+		// Profile files are only looked up next to a local config file
+		var profileDir string
+		if !remote && viper.ConfigFileUsed() != "" {
+			profileDir = filepath.Join(filepath.Dir(viper.ConfigFileUsed()), "profiles")
+		}
+		if err := mergeProfile(o.profile, profileDir); err != nil {
+			return nil, err
+		}
+	}
+
 	// Unmarshal config
 	if err := viper.Unmarshal(cfg); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
+	normalizeProviderTypes(cfg)
+
+	if remote {
+		if err := cfg.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid config from %s: %w", configSourceName(cfgFile), err)
+		}
+	}
 
 	return cfg, nil
 }
 
+// isConfigURL reports whether the config file is given as an HTTP(S) URL.
+func isConfigURL(cfgFile string) bool {
+	return strings.HasPrefix(cfgFile, "http://") || strings.HasPrefix(cfgFile, "https://")
+}
+
+// configSourceName names a config read from stdin or a URL in errors.
+func configSourceName(cfgFile string) string {
+	if cfgFile == StdinConfigFile {
+		return "stdin"
+	}
+	return cfgFile
+}
+
+// readRemoteConfig reads the config from stdin or fetches it from a URL.
+func readRemoteConfig(cfgFile string, stdin io.Reader) ([]byte, error) {
+	r := stdin
+	if isConfigURL(cfgFile) {
+		client := &http.Client{Timeout: DefaultClientTimeout}
+		resp, err := client.Get(cfgFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch config: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to fetch config from %s: %s", cfgFile, resp.Status)
+		}
+		r = resp.Body
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, maxRemoteConfigSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config from %s: %w", configSourceName(cfgFile), err)
+	}
+	if len(data) > maxRemoteConfigSize {
+		return nil, fmt.Errorf("config from %s is larger than %d bytes", configSourceName(cfgFile), maxRemoteConfigSize)
+	}
+
+	return data, nil
+}
+
+// mergeProfile merges the named profile over the configuration read by viper.
+// Maps are merged key by key, so a profile may override a single provider option.
+// Profiles missing from the configuration are read from dir, unless it is empty.
+func mergeProfile(name, dir string) error {
+	if name != filepath.Base(name) || name == "." || name == ".." {
+		return fmt.Errorf("invalid profile name %q", name)
+	}
+
+	key := "profiles." + name
+	if viper.IsSet(key) {
+		return viper.MergeConfigMap(viper.GetStringMap(key))
+	}
+
+	if dir == "" {
+		return fmt.Errorf("profile %q not found in the config", name)
+	}
+	path := filepath.Join(dir, name+"."+DefaultConfigType)
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("profile %q not found in %s or %s", name, viper.ConfigFileUsed(), path)
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read profile %q: %w", name, err)
+	}
+
+	return viper.MergeConfigMap(v.AllSettings())
+}
+
+// normalizeProviderTypes replaces the provider types by their canonical names,
+// so validation and the provider registry see the same type for Cloudflare, cf
+// and cloudflare.
+func normalizeProviderTypes(cfg *Config) {
+	for name, pc := range cfg.Providers {
+		pc.Type = CanonicalProviderType(pc.Type)
+		cfg.Providers[name] = pc
+	}
+}
+
 // GetConfigPath returns the path to the config file that would be used.
 func GetConfigPath() (string, error) {
 	home, err := homedir.Dir()
@@ -129,6 +276,9 @@ func Save(cfg *Config) error {
 	// Set providers
 	for name, provider := range cfg.Providers {
 		viper.Set(fmt.Sprintf("providers.%s.type", name), provider.Type)
+		if provider.DisplayName != "" {
+			viper.Set(fmt.Sprintf("providers.%s.display-name", name), provider.DisplayName)
+		}
 		if provider.Credentials != nil {
 			for key, value := range provider.Credentials {
 				// Normalize keys to use dashes (api_token -> api-token)
@@ -0,0 +1,376 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resetViper isolates a test from the global viper state and the home directory.
+func resetViper(t *testing.T) string {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	homedir.Reset()
+	viper.Reset()
+	t.Cleanup(func() {
+		viper.Reset()
+		homedir.Reset()
+	})
+
+	return home
+}
+
+func writeConfig(t *testing.T, data string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "cdnscli.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	return path
+}
+
+func TestLoad_DisplayName(t *testing.T) {
+	resetViper(t)
+
+	cfg, err := Load(writeConfig(t, `
+providers:
+  cf-production:
+    type: cloudflare
+    display-name: Cloudflare Production
+    credentials:
+      api_token: token
+  cf-staging:
+    type: cloudflare
+    display-name: Cloudflare Staging
+    credentials:
+      api_token: token
+  cf-personal:
+    type: cloudflare
+    credentials:
+      api_token: token
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "Cloudflare Production", cfg.Providers["cf-production"].DisplayName)
+	assert.Equal(t, "Cloudflare Staging", cfg.Providers["cf-staging"].DisplayName)
+	assert.Equal(t, "", cfg.Providers["cf-personal"].DisplayName)
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_EmptyDisplayName(t *testing.T) {
+	resetViper(t)
+
+	cfg, err := Load(writeConfig(t, `
+providers:
+  cloudflare:
+    type: cloudflare
+    display-name: "  "
+    credentials:
+      api_token: token
+`))
+	require.NoError(t, err)
+	assert.ErrorContains(t, cfg.Validate(), `field "providers.cloudflare.display-name": display name must not be empty`)
+
+	pc := ProviderConfig{Type: "cloudflare", DisplayName: "  ", Credentials: map[string]interface{}{"api_token": "token"}}
+	assert.ErrorContains(t, pc.Validate("cloudflare"), "display name must not be empty")
+}
+
+func TestCanonicalProviderType(t *testing.T) {
+	for typ, want := range map[string]string{
+		"cloudflare":   "cloudflare",
+		" cloudFlare ": "cloudflare",
+		"CF":           "cloudflare",
+		"pdns":         "powerdns",
+		"reg.ru":       "regru",
+		"nsupdate":     "rfc2136",
+		"Route53":      "route53",
+		"":             "",
+	} {
+		assert.Equal(t, want, CanonicalProviderType(typ), typ)
+	}
+}
+
+func TestLoad_ProviderTypeCase(t *testing.T) {
+	resetViper(t)
+
+	cfg, err := Load(writeConfig(t, `
+providers:
+  cloudflare:
+    type: " Cloudflare "
+    credentials:
+      api_token: token
+  pdns:
+    type: PDNS
+    credentials:
+      api_url: http://127.0.0.1:8081
+      api_key: key
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "cloudflare", cfg.Providers["cloudflare"].Type)
+	assert.Equal(t, "powerdns", cfg.Providers["pdns"].Type)
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_TypeAlias(t *testing.T) {
+	// An alias is validated as its canonical type
+	pc := ProviderConfig{Type: "CF"}
+	assert.ErrorContains(t, pc.Validate("cf"), `field "providers.cf.credentials": must have either api_token or (api_key + email)`)
+
+	pc.Credentials = map[string]interface{}{"api_token": "token"}
+	assert.NoError(t, pc.Validate("cf"))
+
+	pc = ProviderConfig{Type: "  "}
+	assert.ErrorContains(t, pc.Validate("blank"), "provider type is required")
+}
+
+func TestSave_DisplayNameRoundTrip(t *testing.T) {
+	home := resetViper(t)
+
+	require.NoError(t, Save(&Config{
+		DefaultProvider: "cf-production",
+		Providers: map[string]ProviderConfig{
+			"cf-production": {
+				Type:        "cloudflare",
+				DisplayName: "Рога и Копыта",
+				Credentials: map[string]interface{}{"api_token": "token"},
+			},
+			"cf-personal": {
+				Type:        "cloudflare",
+				Credentials: map[string]interface{}{"api_token": "token"},
+			},
+		},
+	}))
+
+	path := filepath.Join(home, DefaultConfigName+"."+DefaultConfigType)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "display-name: Рога и Копыта")
+
+	viper.Reset()
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "cloudflare", cfg.Providers["cf-production"].Type)
+	assert.Equal(t, "Рога и Копыта", cfg.Providers["cf-production"].DisplayName)
+	assert.Equal(t, "", cfg.Providers["cf-personal"].DisplayName)
+}
+
+const profilesConfig = `
+default_provider: cf-staging
+output_format: text
+providers:
+  cf-staging:
+    type: cloudflare
+    credentials:
+      api_token: staging-token
+    options:
+      default_ttl: 300
+      default_proxied: true
+profiles:
+  prod:
+    default_provider: cf-prod
+    output_format: json
+    providers:
+      cf-prod:
+        type: cloudflare
+        display-name: Cloudflare Production
+        credentials:
+          api_token: prod-token
+      cf-staging:
+        options:
+          default_ttl: 3600
+`
+
+func TestLoad_WithoutProfile(t *testing.T) {
+	resetViper(t)
+
+	cfg, err := Load(writeConfig(t, profilesConfig))
+	require.NoError(t, err)
+	assert.Equal(t, "cf-staging", cfg.DefaultProvider)
+	assert.Equal(t, "text", cfg.OutputFormat)
+	assert.NotContains(t, cfg.Providers, "cf-prod")
+	assert.Equal(t, 300, cfg.Providers["cf-staging"].Options["default_ttl"])
+}
+
+func TestLoad_Profile(t *testing.T) {
+	resetViper(t)
+
+	cfg, err := Load(writeConfig(t, profilesConfig), WithProfile("prod"))
+	require.NoError(t, err)
+
+	// The profile overrides the base config
+	assert.Equal(t, "cf-prod", cfg.DefaultProvider)
+	assert.Equal(t, "json", cfg.OutputFormat)
+	assert.Equal(t, "Cloudflare Production", cfg.Providers["cf-prod"].DisplayName)
+	assert.Equal(t, "prod-token", cfg.Providers["cf-prod"].Credentials["api_token"])
+
+	// Maps are merged, so base settings the profile leaves alone are kept
+	staging := cfg.Providers["cf-staging"]
+	assert.Equal(t, "cloudflare", staging.Type)
+	assert.Equal(t, "staging-token", staging.Credentials["api_token"])
+	assert.Equal(t, 3600, staging.Options["default_ttl"])
+	assert.Equal(t, true, staging.Options["default_proxied"])
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestLoad_ProfileFile(t *testing.T) {
+	resetViper(t)
+
+	path := writeConfig(t, profilesConfig)
+	dir := filepath.Join(filepath.Dir(path), "profiles")
+	require.NoError(t, os.Mkdir(dir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "dev.yaml"), []byte(`
+default_provider: cf-dev
+providers:
+  cf-dev:
+    type: cloudflare
+    credentials:
+      api_token: dev-token
+`), 0o600))
+	// An inline profile wins over a file of the same name
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "prod.yaml"), []byte("default_provider: cf-other\n"), 0o600))
+
+	cfg, err := Load(path, WithProfile("dev"))
+	require.NoError(t, err)
+	assert.Equal(t, "cf-dev", cfg.DefaultProvider)
+	assert.Equal(t, "dev-token", cfg.Providers["cf-dev"].Credentials["api_token"])
+	assert.Contains(t, cfg.Providers, "cf-staging")
+
+	viper.Reset()
+	cfg, err = Load(path, WithProfile("prod"))
+	require.NoError(t, err)
+	assert.Equal(t, "cf-prod", cfg.DefaultProvider)
+}
+
+func TestLoad_ProfileNotFound(t *testing.T) {
+	resetViper(t)
+
+	path := writeConfig(t, profilesConfig)
+	_, err := Load(path, WithProfile("qa"))
+	assert.ErrorContains(t, err, `profile "qa" not found`)
+
+	viper.Reset()
+	_, err = Load(path, WithProfile("../prod"))
+	assert.ErrorContains(t, err, `invalid profile name "../prod"`)
+}
+
+// withStdin makes Load read a "-" config file from r.
+func withStdin(r io.Reader) LoadOption {
+	return func(o *loadOptions) {
+		o.stdin = r
+	}
+}
+
+func TestLoad_Stdin(t *testing.T) {
+	resetViper(t)
+
+	cfg, err := Load(StdinConfigFile, withStdin(strings.NewReader(profilesConfig)), WithProfile("prod"))
+	require.NoError(t, err)
+	assert.Equal(t, "cf-prod", cfg.DefaultProvider)
+	assert.Equal(t, "staging-token", cfg.Providers["cf-staging"].Credentials["api_token"])
+	assert.Equal(t, StdinConfigFile, viper.ConfigFileUsed())
+
+	// Profile files are not looked up without a config file on disk
+	viper.Reset()
+	_, err = Load(StdinConfigFile, withStdin(strings.NewReader(profilesConfig)), WithProfile("dev"))
+	assert.ErrorContains(t, err, `profile "dev" not found in the config`)
+
+	viper.Reset()
+	_, err = Load(StdinConfigFile, withStdin(strings.NewReader("providers: [")))
+	assert.ErrorContains(t, err, "failed to read config from stdin")
+}
+
+func TestLoad_URL(t *testing.T) {
+	resetViper(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cdnscli.yaml":
+			_, _ = io.WriteString(w, profilesConfig)
+		case "/invalid.yaml":
+			_, _ = io.WriteString(w, "providers:\n  cf:\n    credentials:\n      api_token: token\n")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	cfg, err := Load(srv.URL + "/cdnscli.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "cf-staging", cfg.DefaultProvider)
+	assert.Equal(t, "cloudflare", cfg.Providers["cf-staging"].Type)
+	assert.Equal(t, srv.URL+"/cdnscli.yaml", viper.ConfigFileUsed())
+
+	viper.Reset()
+	_, err = Load(srv.URL + "/missing.yaml")
+	assert.ErrorContains(t, err, "404 Not Found")
+
+	// A fetched config must be valid
+	viper.Reset()
+	_, err = Load(srv.URL + "/invalid.yaml")
+	assert.ErrorContains(t, err, "invalid config from "+srv.URL+"/invalid.yaml")
+}
+
+func TestLoad_Keybindings(t *testing.T) {
+	resetViper(t)
+
+	cfg, err := Load(writeConfig(t, `
+ui:
+  keybindings:
+    edit: x
+    reload: ctrl+r
+`))
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	keys := cfg.UI.KeyMap()
+	assert.Equal(t, "x", keys["edit"])
+	assert.Equal(t, "ctrl+r", keys["reload"])
+	assert.Equal(t, "q", keys["quit"])
+	assert.Equal(t, "e", DefaultKeybindings["edit"])
+}
+
+func TestValidate_Keybindings(t *testing.T) {
+	tests := map[string]struct {
+		bindings map[string]string
+		want     string
+	}{
+		"clash with a default": {map[string]string{"reload": "e"}, `key "e" is bound to several actions: edit, reload`},
+		"clash in config":      {map[string]string{"edit": "x", "delete": "x"}, `key "x" is bound to several actions: delete, edit`},
+		"unknown action":       {map[string]string{"search": "/"}, `"ui.keybindings.search": unknown action`},
+		"empty key":            {map[string]string{"edit": ""}, `"ui.keybindings.edit": key must not be empty`},
+		"reserved key":         {map[string]string{"quit": "esc"}, `key "esc" is reserved`},
+	}
+	for name, tt := range tests {
+		cfg := Config{ClientTimeout: DefaultClientTimeout, UI: UIConfig{Keybindings: tt.bindings}}
+		assert.ErrorContains(t, cfg.Validate(), tt.want, name)
+	}
+
+	// Swapping two keys is fine
+	cfg := Config{ClientTimeout: DefaultClientTimeout, UI: UIConfig{Keybindings: map[string]string{"edit": "c", "create": "e"}}}
+	assert.NoError(t, cfg.Validate())
+}
@@ -0,0 +1,140 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SchemaDraft is the JSON Schema dialect of Schema.
+const SchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// durationPattern matches the durations accepted by time.ParseDuration, e.g. 10s or 1h30m.
+const durationPattern = `^[-+]?([0-9]+(\.[0-9]*)?(ns|us|µs|ms|s|m|h))+$`
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// schemaEnums are the allowed values of string keys, by their dotted path.
+var schemaEnums = map[string][]string{
+	"output_format": outputFormats,
+}
+
+// credentialTypes are the credentials of the provider types, their keys are
+// offered for providers.<name>.credentials.
+var credentialTypes = []interface{}{
+	CloudflareCredentials{},
+	RegRuCredentials{},
+	PowerDNSCredentials{},
+	VultrCredentials{},
+	RFC2136Credentials{},
+}
+
+// Schema returns a JSON Schema of the config file. It is generated from Config,
+// so it describes exactly the keys the loader decodes. Other keys are allowed,
+// the loader ignores them.
+func Schema() map[string]interface{} {
+	s := typeSchema(reflect.TypeOf(Config{}), "")
+	s["$schema"] = SchemaDraft
+	s["title"] = "cdnscli configuration"
+	return s
+}
+
+// typeSchema returns the schema of values of type t found at the dotted path.
+// The keys of maps are * in the path.
+func typeSchema(t reflect.Type, path string) map[string]interface{} {
+	if t == durationType {
+		// A duration is a string such as 10s or a number of nanoseconds
+		return map[string]interface{}{
+			"type":    []string{"string", "integer"},
+			"pattern": durationPattern,
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return typeSchema(t.Elem(), path)
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		s := map[string]interface{}{"type": "string"}
+		if enum, ok := schemaEnums[path]; ok {
+			s["enum"] = enum
+		}
+		return s
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": typeSchema(t.Elem(), joinPath(path, "*"))}
+	case reflect.Map:
+		s := map[string]interface{}{"type": "object"}
+		if t.Elem().Kind() != reflect.Interface {
+			s["additionalProperties"] = typeSchema(t.Elem(), joinPath(path, "*"))
+		}
+		if path == "providers.*.credentials" {
+			s["properties"] = credentialProperties()
+		}
+		return s
+	case reflect.Struct:
+		return map[string]interface{}{"type": "object", "properties": structProperties(t, path)}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// structProperties returns the schemas of the exported fields of t by their
+// mapstructure names. Fields tagged mapstructure:"-" are not read from the file.
+func structProperties(t reflect.Type, path string) map[string]interface{} {
+	props := make(map[string]interface{}, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		key, _, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+		if key == "-" {
+			continue
+		}
+		if key == "" {
+			key = strings.ToLower(f.Name)
+		}
+		props[key] = typeSchema(f.Type, joinPath(path, key))
+	}
+	return props
+}
+
+// credentialProperties returns the credential keys of all provider types.
+func credentialProperties() map[string]interface{} {
+	props := make(map[string]interface{})
+	for _, c := range credentialTypes {
+		for key, s := range structProperties(reflect.TypeOf(c), "") {
+			props[key] = s
+		}
+	}
+	return props
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,211 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const schemaSampleConfig = `
+default_provider: cf
+client_timeout: 1m30s
+output_format: jsonl
+json_pretty: true
+debug: false
+providers:
+  cf:
+    type: cloudflare
+    display-name: Production
+    credentials:
+      api_token_env: CF_API_TOKEN
+    options:
+      default_ttl: 5m
+      default_proxied: true
+  bind:
+    type: rfc2136
+    credentials:
+      server: ns1.example.com
+      key_name: cdnscli
+      key_secret: c2VjcmV0
+    options:
+      zones: [example.com]
+ui:
+  confirm_edits: true
+  watch_interval: 30s
+  keybindings:
+    quit: x
+cache:
+  dir: /tmp/cdnscli
+  zone_ttl: 24h
+`
+
+// toJSON converts v to the types encoding/json decodes into, as a schema validator sees it.
+func toJSON(t *testing.T, v interface{}) interface{} {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	var out interface{}
+	require.NoError(t, json.Unmarshal(data, &out))
+	return out
+}
+
+func loadSchema(t *testing.T) map[string]interface{} {
+	t.Helper()
+	return toJSON(t, Schema()).(map[string]interface{})
+}
+
+func loadYAML(t *testing.T, data string) interface{} {
+	t.Helper()
+
+	var v interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(data), &v))
+	return toJSON(t, v)
+}
+
+// validate checks v against the subset of JSON Schema used by Schema: type,
+// enum, pattern, properties, additionalProperties and items.
+func validate(schema map[string]interface{}, v interface{}, path string) []string {
+	var errs []string
+
+	if typ, ok := schema["type"]; ok {
+		var types []string
+		switch typ := typ.(type) {
+		case string:
+			types = []string{typ}
+		case []interface{}:
+			for _, s := range typ {
+				types = append(types, s.(string))
+			}
+		}
+		if !slices.Contains(types, jsonType(v)) && (jsonType(v) != "integer" || !slices.Contains(types, "number")) {
+			return append(errs, fmt.Sprintf("%s: %s is not %v", path, jsonType(v), types))
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok && !slices.Contains(enum, v) {
+		errs = append(errs, fmt.Sprintf("%s: %v is not one of %v", path, v, enum))
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		if s, ok := v.(string); ok && !regexp.MustCompile(pattern).MatchString(s) {
+			errs = append(errs, fmt.Sprintf("%s: %q does not match %s", path, s, pattern))
+		}
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		props, _ := schema["properties"].(map[string]interface{})
+		extra, _ := schema["additionalProperties"].(map[string]interface{})
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if s, ok := props[key].(map[string]interface{}); ok {
+				errs = append(errs, validate(s, v[key], path+"."+key)...)
+			} else if extra != nil {
+				errs = append(errs, validate(extra, v[key], path+"."+key)...)
+			}
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				errs = append(errs, validate(items, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+
+	return errs
+}
+
+func jsonType(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == float64(int64(v)) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func TestSchema(t *testing.T) {
+	schema := loadSchema(t)
+	assert.Equal(t, SchemaDraft, schema["$schema"])
+
+	props := schema["properties"].(map[string]interface{})
+	for _, key := range []string{"default_provider", "providers", "client_timeout", "output_format", "json_pretty", "debug", "ui", "cache"} {
+		assert.Contains(t, props, key)
+	}
+	// Keys set by flags only are not part of the file
+	assert.NotContains(t, props, "output_template")
+	assert.NotContains(t, props["cache"].(map[string]interface{})["properties"], "refresh")
+
+	provider := props["providers"].(map[string]interface{})["additionalProperties"].(map[string]interface{})
+	creds := provider["properties"].(map[string]interface{})["credentials"].(map[string]interface{})
+	assert.Contains(t, creds["properties"], "api_token")
+	assert.Contains(t, creds["properties"], "key_secret")
+}
+
+func TestSchema_Sample(t *testing.T) {
+	schema := loadSchema(t)
+
+	assert.Empty(t, validate(schema, loadYAML(t, schemaSampleConfig), "$"))
+
+	example, err := os.ReadFile("../../cdnscli.yaml.example")
+	require.NoError(t, err)
+	assert.Empty(t, validate(schema, loadYAML(t, string(example)), "$"))
+
+	errs := validate(schema, loadYAML(t, `
+client_timeout: soon
+output_format: xml
+debug: "yes"
+providers:
+  cf:
+    type: [cloudflare]
+ui:
+  keybindings:
+    quit: 1
+`), "$")
+	assert.Equal(t, []string{
+		`$.client_timeout: "soon" does not match ` + durationPattern,
+		`$.debug: string is not [boolean]`,
+		`$.output_format: xml is not one of [text json jsonl none template markdown]`,
+		`$.providers.cf.type: array is not [string]`,
+		`$.ui.keybindings.quit: integer is not [string]`,
+	}, errs)
+}
@@ -18,10 +18,15 @@ package config
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 )
 
+// outputFormats are the valid values of output_format.
+var outputFormats = []string{"text", "json", "jsonl", "none", "template", "markdown"}
+
 // ValidationError represents a configuration validation error.
 type ValidationError struct {
 	Field   string
@@ -45,18 +50,22 @@ func (c *Config) Validate() error {
 	}
 
 	// Validate output format
-	validFormats := map[string]bool{
-		"text": true,
-		"json": true,
-		"none": true,
-	}
-	if c.OutputFormat != "" && !validFormats[strings.ToLower(c.OutputFormat)] {
+	if c.OutputFormat != "" && !slices.Contains(outputFormats, strings.ToLower(c.OutputFormat)) {
 		errors = append(errors, &ValidationError{
 			Field:   "output_format",
-			Message: fmt.Sprintf("must be one of: text, json, none (got: %s)", c.OutputFormat),
+			Message: fmt.Sprintf("must be one of: %s (got: %s)", strings.Join(outputFormats, ", "), c.OutputFormat),
+		})
+	}
+
+	if c.Cache.ZoneTTL < 0 {
+		errors = append(errors, &ValidationError{
+			Field:   "cache.zone_ttl",
+			Message: "must not be negative",
 		})
 	}
 
+	errors = append(errors, c.UI.validateKeybindings()...)
+
 	// Validate providers
 	// It's OK if no providers are configured (credentials may come from env vars)
 	// We'll validate provider-specific credentials when they're used
@@ -89,24 +98,88 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// validateKeybindings checks that ui.keybindings binds known actions to distinct keys.
+func (u UIConfig) validateKeybindings() []error {
+	var errors []error
+
+	actions := make([]string, 0, len(u.Keybindings))
+	for action := range u.Keybindings {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	for _, action := range actions {
+		field := "ui.keybindings." + action
+		key := u.Keybindings[action]
+		switch {
+		case DefaultKeybindings[strings.ToLower(action)] == "":
+			errors = append(errors, &ValidationError{Field: field, Message: "unknown action"})
+		case key == "":
+			errors = append(errors, &ValidationError{Field: field, Message: "key must not be empty"})
+		case reservedKeys[key]:
+			errors = append(errors, &ValidationError{Field: field, Message: fmt.Sprintf("key %q is reserved", key)})
+		}
+	}
+
+	// Two actions must not share a key, the defaults included
+	bound := make(map[string][]string)
+	for action, key := range u.KeyMap() {
+		bound[key] = append(bound[key], action)
+	}
+	keys := make([]string, 0, len(bound))
+	for key := range bound {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if len(bound[key]) > 1 {
+			sort.Strings(bound[key])
+			errors = append(errors, &ValidationError{
+				Field:   "ui.keybindings",
+				Message: fmt.Sprintf("key %q is bound to several actions: %s", key, strings.Join(bound[key], ", ")),
+			})
+		}
+	}
+
+	return errors
+}
+
 // Validate validates a provider configuration.
 func (pc *ProviderConfig) Validate(name string) error {
 	var errors []error
 
 	// Validate provider type
-	if pc.Type == "" {
+	if strings.TrimSpace(pc.Type) == "" {
 		errors = append(errors, &ValidationError{
 			Field:   fmt.Sprintf("providers.%s.type", name),
 			Message: "provider type is required",
 		})
 	}
 
+	if pc.DisplayName != "" && strings.TrimSpace(pc.DisplayName) == "" {
+		errors = append(errors, &ValidationError{
+			Field:   fmt.Sprintf("providers.%s.display-name", name),
+			Message: "display name must not be empty, remove it to use the default",
+		})
+	}
+
 	// Validate provider-specific credentials
-	switch strings.ToLower(pc.Type) {
+	switch pc.CanonicalType() {
 	case "cloudflare":
 		if err := pc.validateCloudflare(name); err != nil {
 			errors = append(errors, err)
 		}
+	case "powerdns":
+		if err := pc.validatePowerDNS(name); err != nil {
+			errors = append(errors, err)
+		}
+	case "vultr":
+		if err := pc.validateVultr(name); err != nil {
+			errors = append(errors, err)
+		}
+	case "rfc2136":
+		if err := pc.validateRFC2136(name); err != nil {
+			errors = append(errors, err)
+		}
 	default:
 		// Unknown provider type - just warn but don't fail
 		// This allows for future provider types
@@ -161,6 +234,90 @@ func (pc *ProviderConfig) validateCloudflare(name string) error {
 	return nil
 }
 
+// validatePowerDNS validates PowerDNS-specific configuration.
+func (pc *ProviderConfig) validatePowerDNS(name string) error {
+	var errors []error
+
+	creds, err := pc.GetPowerDNSCredentials()
+	if err != nil {
+		return fmt.Errorf("failed to get PowerDNS credentials: %w", err)
+	}
+
+	if strings.TrimSpace(creds.APIURL) == "" {
+		errors = append(errors, &ValidationError{
+			Field:   fmt.Sprintf("providers.%s.credentials.api_url", name),
+			Message: "api_url is required",
+		})
+	}
+
+	if strings.TrimSpace(creds.APIKey) == "" {
+		errors = append(errors, &ValidationError{
+			Field:   fmt.Sprintf("providers.%s.credentials.api_key", name),
+			Message: "api_key is required",
+		})
+	}
+
+	if len(errors) > 0 {
+		var errMsgs []string
+		for _, err := range errors {
+			errMsgs = append(errMsgs, err.Error())
+		}
+		return fmt.Errorf("powerdns provider validation failed: %s", strings.Join(errMsgs, "; "))
+	}
+
+	return nil
+}
+
+// validateVultr validates Vultr-specific configuration.
+func (pc *ProviderConfig) validateVultr(name string) error {
+	creds, err := pc.GetVultrCredentials()
+	if err != nil {
+		return fmt.Errorf("failed to get Vultr credentials: %w", err)
+	}
+
+	if strings.TrimSpace(creds.APIKey) == "" {
+		return fmt.Errorf("vultr provider validation failed: %w", &ValidationError{
+			Field:   fmt.Sprintf("providers.%s.credentials.api_key", name),
+			Message: "api_key is required",
+		})
+	}
+
+	return nil
+}
+
+// validateRFC2136 validates RFC 2136 specific configuration.
+func (pc *ProviderConfig) validateRFC2136(name string) error {
+	var errors []error
+
+	creds, err := pc.GetRFC2136Credentials()
+	if err != nil {
+		return fmt.Errorf("failed to get RFC 2136 credentials: %w", err)
+	}
+
+	for _, f := range []struct{ field, value string }{
+		{"server", creds.Server},
+		{"key_name", creds.KeyName},
+		{"key_secret", creds.KeySecret},
+	} {
+		if strings.TrimSpace(f.value) == "" {
+			errors = append(errors, &ValidationError{
+				Field:   fmt.Sprintf("providers.%s.credentials.%s", name, f.field),
+				Message: f.field + " is required",
+			})
+		}
+	}
+
+	if len(errors) > 0 {
+		var errMsgs []string
+		for _, err := range errors {
+			errMsgs = append(errMsgs, err.Error())
+		}
+		return fmt.Errorf("rfc2136 provider validation failed: %s", strings.Join(errMsgs, "; "))
+	}
+
+	return nil
+}
+
 // GetProvider returns the provider configuration by name.
 // Returns an error if the provider is not found.
 func (c *Config) GetProvider(name string) (*ProviderConfig, error) {
@@ -176,6 +333,10 @@ func (c *Config) GetProvider(name string) (*ProviderConfig, error) {
 	if !exists {
 		return nil, fmt.Errorf("provider %q not found", name)
 	}
+	provider.name = name
+	provider.cache = c.Cache
+	provider.debug = c.Debug
+	provider.timeout = c.GetClientTimeout()
 
 	return &provider, nil
 }
@@ -187,4 +348,3 @@ func (c *Config) GetClientTimeout() time.Duration {
 	}
 	return c.ClientTimeout
 }
-
@@ -0,0 +1,69 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BatchResult summarizes a bulk command, such as an import or a batch delete.
+type BatchResult struct {
+	DryRun   bool          `json:"dry_run,omitempty"`
+	Created  int           `json:"created"`
+	Updated  int           `json:"updated"`
+	Deleted  int           `json:"deleted"`
+	NotFound int           `json:"not_found"`
+	Failed   int           `json:"failed"`
+	Elapsed  time.Duration `json:"-"`
+}
+
+// OK reports whether no record of the batch failed.
+func (r BatchResult) OK() bool {
+	return r.Failed == 0
+}
+
+// String returns the counts and elapsed time on one line, e.g.
+// "3 created, 0 updated, 0 deleted, 1 failed in 1.2s".
+func (r BatchResult) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%d created, %d updated, %d deleted", r.Created, r.Updated, r.Deleted)
+	if r.NotFound > 0 {
+		fmt.Fprintf(&b, ", %d not found", r.NotFound)
+	}
+	fmt.Fprintf(&b, ", %d failed in %s", r.Failed, r.Elapsed.Round(time.Millisecond))
+	if r.DryRun {
+		b.WriteString(" (dry run)")
+	}
+
+	return b.String()
+}
+
+// MarshalJSON adds the elapsed time in seconds to the counts.
+func (r BatchResult) MarshalJSON() ([]byte, error) {
+	type result BatchResult
+	return json.Marshal(struct {
+		result
+		ElapsedSeconds float64 `json:"elapsed_seconds"`
+	}{
+		result:         result(r),
+		ElapsedSeconds: float64(r.Elapsed.Milliseconds()) / 1000,
+	})
+}
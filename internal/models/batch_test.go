@@ -0,0 +1,44 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchResult(t *testing.T) {
+	r := BatchResult{Created: 3, Failed: 1, Elapsed: 1235 * time.Millisecond}
+	assert.Equal(t, "3 created, 0 updated, 0 deleted, 1 failed in 1.235s", r.String())
+	assert.False(t, r.OK())
+
+	j, err := json.Marshal(r)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"created":3,"updated":0,"deleted":0,"not_found":0,"failed":1,"elapsed_seconds":1.235}`, string(j))
+
+	r = BatchResult{DryRun: true, Deleted: 2, NotFound: 1}
+	assert.Equal(t, "0 created, 0 updated, 2 deleted, 1 not found, 0 failed in 0s (dry run)", r.String())
+	assert.True(t, r.OK())
+
+	j, err = json.Marshal(r)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"dry_run":true,"created":0,"updated":0,"deleted":2,"not_found":1,"failed":0,"elapsed_seconds":0}`, string(j))
+}
@@ -0,0 +1,229 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FieldChange is a field with different values in two versions of a record.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// String returns the change as "field: old -> new".
+func (c FieldChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Field, c.Old, c.New)
+}
+
+// RecordChange is a record present in both sets with different fields.
+type RecordChange struct {
+	Old DNSRecord `json:"old"`
+	New DNSRecord `json:"new"`
+}
+
+// RecordsDiff holds the differences between two sets of records.
+type RecordsDiff struct {
+	Added   []DNSRecord    `json:"added,omitempty"`
+	Removed []DNSRecord    `json:"removed,omitempty"`
+	Changed []RecordChange `json:"changed,omitempty"`
+}
+
+// Empty reports whether the sets of records are the same.
+func (d RecordsDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffRecords compares two sets of records. Records are matched by ID, or by
+// their normalized name, type and content when they have no ID. Added and changed records keep
+// the order of newSet, removed records the order of oldSet.
+func DiffRecords(oldSet, newSet []DNSRecord) RecordsDiff {
+	var diff RecordsDiff
+
+	old := make(map[string]DNSRecord, len(oldSet))
+	for _, rr := range oldSet {
+		old[recordKey(rr)] = rr
+	}
+
+	seen := make(map[string]bool, len(newSet))
+	for _, rr := range newSet {
+		key := recordKey(rr)
+		seen[key] = true
+
+		prev, ok := old[key]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, rr)
+		case len(DiffRecord(prev, rr)) > 0:
+			diff.Changed = append(diff.Changed, RecordChange{Old: prev, New: rr})
+		}
+	}
+
+	for _, rr := range oldSet {
+		if !seen[recordKey(rr)] {
+			diff.Removed = append(diff.Removed, rr)
+		}
+	}
+
+	return diff
+}
+
+// DiffRecord returns the fields that differ between two versions of a record.
+// Name, type and content are compared normalized, see DNSRecord.Normalize, and
+// reported as given. The MX and SRV priority compares equal whether it is kept
+// apart or in the content.
+func DiffRecord(oldRR, newRR DNSRecord) []FieldChange {
+	var changes []FieldChange
+
+	oldNorm, newNorm := comparableRecord(oldRR), comparableRecord(newRR)
+	for _, f := range []struct {
+		field    string
+		old, new string
+		same     bool
+	}{
+		{"name", oldRR.Name, newRR.Name, oldNorm.Name == newNorm.Name},
+		{"type", oldRR.Type, newRR.Type, oldNorm.Type == newNorm.Type},
+		{"ttl", strconv.Itoa(oldRR.TTL), strconv.Itoa(newRR.TTL), oldRR.TTL == newRR.TTL},
+		{"proxied", strconv.FormatBool(oldRR.Proxied), strconv.FormatBool(newRR.Proxied), oldRR.Proxied == newRR.Proxied},
+		{"priority", strconv.Itoa(oldRR.Priority), strconv.Itoa(newRR.Priority), oldNorm.Priority == newNorm.Priority},
+		{"content", oldRR.Content, newRR.Content, oldNorm.Content == newNorm.Content},
+		{"comment", oldRR.Comment, newRR.Comment, oldRR.Comment == newRR.Comment},
+		{"tags", strings.Join(oldRR.Tags, ","), strings.Join(newRR.Tags, ","), strings.Join(oldRR.Tags, ",") == strings.Join(newRR.Tags, ",")},
+	} {
+		if !f.same {
+			changes = append(changes, FieldChange{Field: f.field, Old: f.old, New: f.new})
+		}
+	}
+
+	return changes
+}
+
+// Equal reports whether rr and other hold the same data: the same name, type,
+// content, TTL, proxying and priority. Name, type, content and priority are
+// compared as by DiffRecord, IDs, comments and tags are not compared.
+func (rr DNSRecord) Equal(other DNSRecord) bool {
+	a, b := comparableRecord(rr), comparableRecord(other)
+	return a.SameRecord(b) &&
+		a.TTL == b.TTL &&
+		a.Proxied == b.Proxied &&
+		a.Priority == b.Priority
+}
+
+// RecordsPlan holds the changes that make a zone hold a desired set of records.
+// Unchanged records already match a desired one.
+type RecordsPlan struct {
+	Create    []DNSRecord    `json:"create,omitempty"`
+	Update    []RecordChange `json:"update,omitempty"`
+	Delete    []DNSRecord    `json:"delete,omitempty"`
+	Unchanged []DNSRecord    `json:"unchanged,omitempty"`
+}
+
+// Empty reports whether the plan changes no record.
+func (p RecordsPlan) Empty() bool {
+	return len(p.Create) == 0 && len(p.Update) == 0 && len(p.Delete) == 0
+}
+
+// PlanRecords compares the records of a zone with the desired ones. Records are
+// matched by name, type and content, desired records have no IDs. A record equal
+// to its desired one, see DNSRecord.Equal, is unchanged, otherwise it is updated
+// to the TTL, proxying and priority of the desired record. Desired records
+// without a match are created and records of the zone matching none are deleted.
+// Deleted records keep the order of current, the others the order of desired.
+func PlanRecords(current, desired []DNSRecord) RecordsPlan {
+	var plan RecordsPlan
+
+	byKey := make(map[string][]DNSRecord, len(current))
+	for _, rr := range current {
+		key := contentKey(rr)
+		byKey[key] = append(byKey[key], rr)
+	}
+
+	matched := make(map[string]int, len(current))
+	for _, want := range desired {
+		key := contentKey(want)
+		if matched[key] >= len(byKey[key]) {
+			plan.Create = append(plan.Create, want)
+			continue
+		}
+		have := byKey[key][matched[key]]
+		matched[key]++
+
+		if have.Equal(want) {
+			plan.Unchanged = append(plan.Unchanged, have)
+			continue
+		}
+		updated := have
+		// The content may hold the priority, as with some providers
+		updated.Content = want.Content
+		updated.TTL = want.TTL
+		updated.Proxied = want.Proxied
+		updated.Priority = want.Priority
+		plan.Update = append(plan.Update, RecordChange{Old: have, New: updated})
+	}
+
+	for key, rrset := range byKey {
+		byKey[key] = rrset[matched[key]:]
+	}
+	for _, rr := range current {
+		key := contentKey(rr)
+		if len(byKey[key]) > 0 {
+			plan.Delete = append(plan.Delete, byKey[key][0])
+			byKey[key] = byKey[key][1:]
+		}
+	}
+
+	return plan
+}
+
+// SameRecord reports whether rr and other are the same resource record: they
+// have the same name, type and content, compared as by DiffRecord. The MX and
+// SRV priority is not compared, whether it is kept apart or in the content.
+func (rr DNSRecord) SameRecord(other DNSRecord) bool {
+	return contentKey(rr) == contentKey(other)
+}
+
+// recordKey identifies a record when comparing sets of records.
+func recordKey(rr DNSRecord) string {
+	if rr.ID != "" {
+		return rr.ID
+	}
+	return contentKey(rr)
+}
+
+// contentKey identifies a record by its name, type and content, see comparableRecord.
+func contentKey(rr DNSRecord) string {
+	rr = comparableRecord(rr)
+	return strings.Join([]string{rr.Name, rr.Type, rr.Content}, "/")
+}
+
+// comparableRecord returns rr normalized, with an MX or SRV priority kept in
+// the content moved to Priority, as JoinPriority in reverse.
+func comparableRecord(rr DNSRecord) DNSRecord {
+	rr = rr.Normalize()
+	fields := strings.Fields(rr.Content)
+	if (rr.Type == "MX" && len(fields) == 2) || (rr.Type == "SRV" && len(fields) == 4) {
+		if p, err := strconv.Atoi(fields[0]); err == nil {
+			rr.Priority = p
+			rr.Content = strings.Join(fields[1:], " ")
+		}
+	}
+	return rr
+}
@@ -0,0 +1,222 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffRecords(t *testing.T) {
+	oldSet := []DNSRecord{
+		{ID: "1", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1"},
+		{ID: "2", Name: "mail.example.com", TTL: 300, Type: "A", Content: "192.0.2.2"},
+		{ID: "3", Name: "old.example.com", TTL: 300, Type: "CNAME", Content: "www.example.com"},
+	}
+	newSet := []DNSRecord{
+		{ID: "1", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1"},
+		{ID: "2", Name: "mail.example.com", TTL: 600, Type: "A", Content: "192.0.2.3"},
+		{ID: "4", Name: "new.example.com", TTL: 300, Type: "A", Content: "192.0.2.4"},
+	}
+
+	diff := DiffRecords(oldSet, newSet)
+	assert.Equal(t, []DNSRecord{newSet[2]}, diff.Added)
+	assert.Equal(t, []DNSRecord{oldSet[2]}, diff.Removed)
+	assert.Equal(t, []RecordChange{{Old: oldSet[1], New: newSet[1]}}, diff.Changed)
+	assert.False(t, diff.Empty())
+
+	assert.True(t, DiffRecords(oldSet, oldSet).Empty())
+	assert.True(t, DiffRecords(nil, nil).Empty())
+}
+
+func TestDiffRecords_WithoutIDs(t *testing.T) {
+	oldSet := []DNSRecord{{Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 300}}
+	newSet := []DNSRecord{
+		{Name: "WWW.example.com.", Type: "a", Content: "192.0.2.1", TTL: 600},
+		{Name: "www.example.com", Type: "A", Content: "192.0.2.2", TTL: 300},
+	}
+
+	diff := DiffRecords(oldSet, newSet)
+	assert.Equal(t, []DNSRecord{newSet[1]}, diff.Added)
+	assert.Empty(t, diff.Removed)
+	assert.Equal(t, []RecordChange{{Old: oldSet[0], New: newSet[0]}}, diff.Changed)
+}
+
+func TestDiffRecords_Normalized(t *testing.T) {
+	oldSet := []DNSRecord{
+		{Name: "blog.example.com", Type: "CNAME", Content: "example.github.io", TTL: 300},
+		{Name: "example.com", Type: "TXT", Content: "v=spf1 -all", TTL: 300},
+		{Name: "example.com", Type: "MX", Content: "10 mail.example.com", TTL: 300},
+	}
+	newSet := []DNSRecord{
+		{Name: "Blog.Example.com.", Type: "CNAME", Content: "Example.GitHub.io.", TTL: 300},
+		{Name: "example.com", Type: "TXT", Content: `"v=spf1 -all"`, TTL: 300},
+		{Name: "example.com", Type: "MX", Content: "10 mail.example.com.", TTL: 600},
+	}
+
+	diff := DiffRecords(oldSet, newSet)
+	assert.Empty(t, diff.Added)
+	assert.Empty(t, diff.Removed)
+	assert.Equal(t, []RecordChange{{Old: oldSet[2], New: newSet[2]}}, diff.Changed)
+	assert.Equal(t, []FieldChange{{Field: "ttl", Old: "300", New: "600"}}, DiffRecord(oldSet[2], newSet[2]))
+}
+
+func TestDiffRecord(t *testing.T) {
+	oldRR := DNSRecord{ID: "1", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1"}
+
+	assert.Empty(t, DiffRecord(oldRR, oldRR))
+
+	newRR := oldRR
+	newRR.TTL = 600
+	newRR.Proxied = true
+	newRR.Content = "192.0.2.2"
+	assert.Equal(t, []FieldChange{
+		{Field: "ttl", Old: "300", New: "600"},
+		{Field: "proxied", Old: "false", New: "true"},
+		{Field: "content", Old: "192.0.2.1", New: "192.0.2.2"},
+	}, DiffRecord(oldRR, newRR))
+
+	newRR = oldRR
+	newRR.Comment = "web"
+	newRR.Tags = []string{"env:prod", "team:web"}
+	assert.Equal(t, []FieldChange{
+		{Field: "comment", Old: "", New: "web"},
+		{Field: "tags", Old: "", New: "env:prod,team:web"},
+	}, DiffRecord(oldRR, newRR))
+}
+
+func TestDNSRecord_Equal(t *testing.T) {
+	mx := DNSRecord{ID: "1", Name: "example.com", Type: "MX", Content: "mail.example.com", Priority: 10, TTL: 300}
+
+	tests := []struct {
+		name  string
+		other DNSRecord
+		want  bool
+	}{
+		{"same", mx, true},
+		{"without ID, comment and tags", DNSRecord{Name: "Example.com.", Type: "mx", Content: "mail.example.com", Priority: 10, TTL: 300, Comment: "mail"}, true},
+		{"priority in content", DNSRecord{Name: "example.com", Type: "MX", Content: "10 mail.example.com.", TTL: 300}, true},
+		{"other priority in content", DNSRecord{Name: "example.com", Type: "MX", Content: "20 mail.example.com", TTL: 300}, false},
+		{"other priority", DNSRecord{Name: "example.com", Type: "MX", Content: "mail.example.com", Priority: 20, TTL: 300}, false},
+		{"unset priority", DNSRecord{Name: "example.com", Type: "MX", Content: "mail.example.com", TTL: 300}, false},
+		{"other ttl", DNSRecord{Name: "example.com", Type: "MX", Content: "mail.example.com", Priority: 10, TTL: 600}, false},
+		{"proxied", DNSRecord{Name: "example.com", Type: "MX", Content: "mail.example.com", Priority: 10, TTL: 300, Proxied: true}, false},
+		{"other content", DNSRecord{Name: "example.com", Type: "MX", Content: "mx.example.com", Priority: 10, TTL: 300}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mx.Equal(tt.other))
+			assert.Equal(t, tt.want, tt.other.Equal(mx))
+		})
+	}
+
+	// Records without a priority are equal on the other fields alone
+	a := DNSRecord{Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 300}
+	assert.True(t, a.Equal(DNSRecord{Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 300}))
+}
+
+func TestPlanRecords(t *testing.T) {
+	current := []DNSRecord{
+		{ID: "1", Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 300},
+		{ID: "2", Name: "example.com", Type: "MX", Content: "mail.example.com", Priority: 10, TTL: 300},
+		{ID: "3", Name: "old.example.com", Type: "A", Content: "192.0.2.3", TTL: 300},
+		{ID: "4", Name: "api.example.com", Type: "A", Content: "192.0.2.4", TTL: 300, Comment: "api"},
+	}
+	desired := []DNSRecord{
+		{Name: "www.example.com.", Type: "A", Content: "192.0.2.1", TTL: 300},
+		{Name: "example.com", Type: "MX", Content: "mail.example.com", Priority: 20, TTL: 300},
+		{Name: "new.example.com", Type: "A", Content: "192.0.2.5", TTL: 300},
+		{Name: "api.example.com", Type: "A", Content: "192.0.2.4", TTL: 600},
+	}
+
+	plan := PlanRecords(current, desired)
+	assert.Equal(t, []DNSRecord{current[0]}, plan.Unchanged)
+	assert.Equal(t, []DNSRecord{desired[2]}, plan.Create)
+	assert.Equal(t, []DNSRecord{current[2]}, plan.Delete)
+
+	mx := current[1]
+	mx.Priority = 20
+	api := current[3]
+	api.TTL = 600
+	// Updates keep the ID and the comment of the record in the zone
+	assert.Equal(t, []RecordChange{{Old: current[1], New: mx}, {Old: current[3], New: api}}, plan.Update)
+	assert.False(t, plan.Empty())
+
+	plan = PlanRecords(current, current)
+	assert.True(t, plan.Empty())
+	assert.Equal(t, current, plan.Unchanged)
+}
+
+func TestPlanRecords_Normalized(t *testing.T) {
+	current := []DNSRecord{{ID: "1", Name: "blog.example.com", Type: "CNAME", Content: "example.github.io", TTL: 300}}
+	desired := []DNSRecord{{Name: "Blog.example.com.", Type: "cname", Content: "Example.GitHub.io.", TTL: 300}}
+
+	plan := PlanRecords(current, desired)
+	assert.True(t, plan.Empty())
+	assert.Equal(t, current, plan.Unchanged)
+
+	// Providers keeping the MX priority in the content hold the same record
+	current = []DNSRecord{{ID: "2", Name: "example.com", Type: "MX", Content: "10 mail.example.com.", TTL: 300}}
+	desired = []DNSRecord{{Name: "example.com", Type: "MX", Content: "mail.example.com", Priority: 10, TTL: 300}}
+	plan = PlanRecords(current, desired)
+	assert.True(t, plan.Empty())
+	assert.Equal(t, current, plan.Unchanged)
+
+	// A new priority updates the record with the content of the desired one
+	desired[0].Priority = 20
+	plan = PlanRecords(current, desired)
+	want := current[0]
+	want.Content = "mail.example.com"
+	want.Priority = 20
+	assert.Equal(t, []RecordChange{{Old: current[0], New: want}}, plan.Update)
+	assert.Equal(t, []FieldChange{{Field: "priority", Old: "0", New: "20"}}, DiffRecord(current[0], want))
+}
+
+func TestPlanRecords_Duplicates(t *testing.T) {
+	rr := DNSRecord{Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 300}
+	first, second := rr, rr
+	first.ID, second.ID = "1", "2"
+
+	plan := PlanRecords([]DNSRecord{first, second}, []DNSRecord{rr})
+	assert.Equal(t, []DNSRecord{first}, plan.Unchanged)
+	assert.Equal(t, []DNSRecord{second}, plan.Delete)
+
+	plan = PlanRecords([]DNSRecord{first}, []DNSRecord{rr, rr})
+	assert.Equal(t, []DNSRecord{first}, plan.Unchanged)
+	assert.Equal(t, []DNSRecord{rr}, plan.Create)
+}
+
+func TestDNSRecord_SameRecord(t *testing.T) {
+	rr := DNSRecord{ID: "1", Name: "www.example.com", Type: "CNAME", Content: "example.github.io", TTL: 300}
+	assert.True(t, rr.SameRecord(DNSRecord{Name: "WWW.example.com.", Type: "cname", Content: "Example.GitHub.io.", TTL: 600}))
+	assert.False(t, rr.SameRecord(DNSRecord{Name: "www.example.com", Type: "CNAME", Content: "example.gitlab.io"}))
+
+	mx := DNSRecord{Name: "example.com", Type: "MX", Content: "mail.example.com", Priority: 10}
+	assert.True(t, mx.SameRecord(DNSRecord{Name: "example.com", Type: "MX", Content: "10 mail.example.com."}))
+	// The priority is not compared
+	assert.True(t, mx.SameRecord(DNSRecord{Name: "example.com", Type: "MX", Content: "20 mail.example.com."}))
+	assert.False(t, mx.SameRecord(DNSRecord{Name: "example.com", Type: "MX", Content: "10 mx.example.com."}))
+
+	txt := DNSRecord{Name: "example.com", Type: "TXT", Content: "abcdef"}
+	assert.True(t, txt.SameRecord(DNSRecord{Name: "example.com", Type: "TXT", Content: `"abc" "def"`}))
+}
+
+func TestFieldChange_String(t *testing.T) {
+	assert.Equal(t, "ttl: 300 -> 600", FieldChange{Field: "ttl", Old: "300", New: "600"}.String())
+	assert.Equal(t, "comment:  -> web", FieldChange{Field: "comment", New: "web"}.String())
+}
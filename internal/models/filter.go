@@ -0,0 +1,77 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// RecordFilter reports whether a record should be kept.
+type RecordFilter func(rr DNSRecord) bool
+
+// FilterRecords returns the records of rrset matching all filters, keeping their order.
+// Filtering runs client-side, so it works the same for every provider.
+func FilterRecords(rrset []DNSRecord, filters ...RecordFilter) []DNSRecord {
+	filtered := make([]DNSRecord, 0, len(rrset))
+	for _, rr := range rrset {
+		if matchAll(rr, filters) {
+			filtered = append(filtered, rr)
+		}
+	}
+
+	return filtered
+}
+
+func matchAll(rr DNSRecord, filters []RecordFilter) bool {
+	for _, f := range filters {
+		if f != nil && !f(rr) {
+			return false
+		}
+	}
+	return true
+}
+
+// ByTypes keeps records of any of the given types, compared case-insensitively.
+// Without types all records are kept.
+func ByTypes(types ...string) RecordFilter {
+	if len(types) == 0 {
+		return nil
+	}
+
+	set := make(map[string]bool, len(types))
+	for _, t := range types {
+		set[strings.ToUpper(strings.TrimSpace(t))] = true
+	}
+
+	return func(rr DNSRecord) bool {
+		return set[strings.ToUpper(rr.Type)]
+	}
+}
+
+// ModifiedSince keeps records changed at or after t. Records of providers not
+// telling when they were changed have no modification time and are dropped.
+func ModifiedSince(t time.Time) RecordFilter {
+	return func(rr DNSRecord) bool {
+		return !rr.ModifiedOn.IsZero() && !rr.ModifiedOn.Before(t)
+	}
+}
+
+// ProxiedOnly keeps records proxied through the provider's CDN.
+func ProxiedOnly(rr DNSRecord) bool {
+	return rr.Proxied
+}
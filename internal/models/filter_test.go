@@ -0,0 +1,80 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterRecords(t *testing.T) {
+	rrset := []DNSRecord{
+		{ID: "1", Name: "www.example.com", Type: "A", Content: "192.0.2.1", Proxied: true},
+		{ID: "2", Name: "www.example.com", Type: "AAAA", Content: "2001:db8::1", Proxied: true},
+		{ID: "3", Name: "mail.example.com", Type: "A", Content: "192.0.2.2"},
+		{ID: "4", Name: "example.com", Type: "MX", Content: "10 mail.example.com"},
+		{ID: "5", Name: "example.com", Type: "TXT", Content: "v=spf1 -all"},
+	}
+	ids := func(rrset []DNSRecord) []string {
+		ids := []string{}
+		for _, rr := range rrset {
+			ids = append(ids, rr.ID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name    string
+		filters []RecordFilter
+		want    []string
+	}{
+		{name: "no filters", want: []string{"1", "2", "3", "4", "5"}},
+		{name: "no types", filters: []RecordFilter{ByTypes()}, want: []string{"1", "2", "3", "4", "5"}},
+		{name: "one type", filters: []RecordFilter{ByTypes("A")}, want: []string{"1", "3"}},
+		{name: "several types", filters: []RecordFilter{ByTypes("a", " aaaa ", "MX")}, want: []string{"1", "2", "3", "4"}},
+		{name: "unknown type", filters: []RecordFilter{ByTypes("SRV")}, want: []string{}},
+		{name: "proxied only", filters: []RecordFilter{ProxiedOnly}, want: []string{"1", "2"}},
+		{name: "type and proxied", filters: []RecordFilter{ByTypes("A", "MX"), ProxiedOnly}, want: []string{"1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ids(FilterRecords(rrset, tt.filters...)))
+		})
+	}
+}
+
+func TestModifiedSince(t *testing.T) {
+	since := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	keep := ModifiedSince(since)
+
+	assert.True(t, keep(DNSRecord{ModifiedOn: since.Add(time.Hour)}))
+	assert.True(t, keep(DNSRecord{ModifiedOn: since}))
+	assert.False(t, keep(DNSRecord{ModifiedOn: since.Add(-time.Second)}))
+	// Providers not telling when records changed match nothing
+	assert.False(t, keep(DNSRecord{}))
+
+	rrset := []DNSRecord{
+		{ID: "1", Type: "A", ModifiedOn: since.Add(-48 * time.Hour)},
+		{ID: "2", Type: "A", ModifiedOn: since.Add(2 * time.Hour)},
+		{ID: "3", Type: "TXT", ModifiedOn: since.Add(3 * time.Hour)},
+		{ID: "4", Type: "A"},
+	}
+	got := FilterRecords(rrset, ByTypes("A"), keep)
+	assert.Equal(t, []DNSRecord{rrset[1]}, got)
+}
@@ -0,0 +1,41 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "strings"
+
+// FQDN returns the fully qualified name of a record of zone, without the
+// trailing dot. An empty name or @ is the zone apex. A name ending with a dot
+// is already fully qualified, as is a name equal to the zone or ending with
+// it, compared case-insensitively; other names are relative to the zone.
+// Without a zone the name is returned as is.
+func FQDN(name, zone string) string {
+	name = strings.TrimSpace(name)
+	z := strings.TrimSuffix(strings.TrimSpace(zone), ".")
+	if name == "" || name == ApexName {
+		return z
+	}
+
+	n := strings.TrimSuffix(name, ".")
+	if z == "" || n != name {
+		return n
+	}
+	if strings.EqualFold(n, z) || strings.HasSuffix(strings.ToLower(n), "."+strings.ToLower(z)) {
+		return n
+	}
+	return n + "." + z
+}
@@ -0,0 +1,51 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFQDN(t *testing.T) {
+	tests := []struct {
+		name, zone, want string
+	}{
+		// Relative names
+		{"www", "example.com", "www.example.com"},
+		{"www", "example.com.", "www.example.com"},
+		{"a.b", "example.com", "a.b.example.com"},
+		{"notexample.com", "example.com", "notexample.com.example.com"},
+		// Apex
+		{"@", "example.com", "example.com"},
+		{"", "example.com.", "example.com"},
+		{"example.com", "example.com", "example.com"},
+		{"EXAMPLE.com", "example.com", "EXAMPLE.com"},
+		// Already qualified
+		{"www.example.com", "example.com", "www.example.com"},
+		{"WWW.Example.COM", "example.com", "WWW.Example.COM"},
+		// Trailing dots
+		{"www.example.com.", "example.com.", "www.example.com"},
+		{"www.example.org.", "example.com", "www.example.org"},
+		{"www.example.com.", "", "www.example.com"},
+		{"www", "", "www"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, FQDN(tt.name, tt.zone), "%q in %q", tt.name, tt.zone)
+	}
+}
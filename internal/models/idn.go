@@ -0,0 +1,73 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// idnProfile converts internationalized names for API calls. Labels are mapped
+// as for a lookup (e.g. lowercased), but underscores and wildcards used in DNS
+// record names are allowed.
+var idnProfile = idna.New(
+	idna.MapForLookup(),
+	idna.Transitional(false),
+	idna.StrictDomainName(false),
+)
+
+// NameToASCII converts a zone or record name with Unicode labels to its punycode
+// (xn--) form. ASCII names are returned unchanged.
+func NameToASCII(name string) (string, error) {
+	if isASCII(name) {
+		return name, nil
+	}
+
+	ascii, err := idnProfile.ToASCII(name)
+	if err != nil {
+		return "", fmt.Errorf("invalid internationalized name %q: %w", name, err)
+	}
+
+	return ascii, nil
+}
+
+// NameToUnicode converts a zone or record name with punycode (xn--) labels to its
+// Unicode form for display. Names that can't be decoded are returned unchanged.
+func NameToUnicode(name string) string {
+	if !strings.Contains(strings.ToLower(name), "xn--") {
+		return name
+	}
+
+	unicode, err := idnProfile.ToUnicode(name)
+	if err != nil {
+		return name
+	}
+
+	return unicode
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNameToASCII(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{name: "unicode zone", in: "münchen.de", want: "xn--mnchen-3ya.de"},
+		{name: "unicode record", in: "www.münchen.de", want: "www.xn--mnchen-3ya.de"},
+		{name: "uppercase unicode is mapped", in: "MÜNCHEN.de", want: "xn--mnchen-3ya.de"},
+		{name: "cyrillic", in: "пример.рф", want: "xn--e1afmkfd.xn--p1ai"},
+		{name: "unicode with underscore label", in: "_dmarc.münchen.de", want: "_dmarc.xn--mnchen-3ya.de"},
+		{name: "ascii unchanged", in: "_acme-challenge.Example.com.", want: "_acme-challenge.Example.com."},
+		{name: "empty", in: "", want: ""},
+		{name: "invalid", in: "a‍б.de", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NameToASCII(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNameToUnicode(t *testing.T) {
+	assert.Equal(t, "münchen.de", NameToUnicode("xn--mnchen-3ya.de"))
+	assert.Equal(t, "www.пример.рф", NameToUnicode("www.xn--e1afmkfd.xn--p1ai"))
+	assert.Equal(t, "example.com", NameToUnicode("example.com"))
+	assert.Equal(t, "xn--zz.de", NameToUnicode("xn--zz.de"))
+}
+
+func TestNameToASCII_RoundTrip(t *testing.T) {
+	for _, name := range []string{"münchen.de", "mail.bücher.example", "пример.рф"} {
+		ascii, err := NameToASCII(name)
+		require.NoError(t, err)
+		assert.Equal(t, name, NameToUnicode(ascii))
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"net"
+	"strings"
+)
+
+// NormalizeName returns name in the canonical form used to compare records:
+// lower case without the trailing dot.
+func NormalizeName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(name), "."))
+}
+
+// Normalize returns a copy of rr in a canonical form, so records that differ
+// only cosmetically compare equal: the name as by NormalizeName, the type in
+// upper case and the content by its type. Host names in the content of CNAME,
+// NS, PTR, DNAME, ALIAS, MX and SRV records are normalized as names, IPv6
+// addresses of AAAA records are compressed and TXT and SPF content is unquoted.
+func (rr DNSRecord) Normalize() DNSRecord {
+	rr.Name = NormalizeName(rr.Name)
+	rr.Type = strings.ToUpper(strings.TrimSpace(rr.Type))
+	rr.Content = normalizeContent(rr.Type, rr.Content)
+	return rr
+}
+
+// normalizeContent returns the canonical content of a record of type rrType.
+func normalizeContent(rrType, content string) string {
+	content = strings.TrimSpace(content)
+
+	switch rrType {
+	case "CNAME", "NS", "PTR", "DNAME", "ALIAS":
+		return NormalizeName(content)
+	case "MX", "SRV":
+		// The target is the last field, after the priority, weight and port
+		fields := strings.Fields(content)
+		if len(fields) == 0 {
+			return content
+		}
+		fields[len(fields)-1] = NormalizeName(fields[len(fields)-1])
+		return strings.Join(fields, " ")
+	case "AAAA":
+		if ip := net.ParseIP(content); ip != nil {
+			return ip.String()
+		}
+		return content
+	case "TXT", "SPF":
+		return JoinTXT(content)
+	default:
+		return content
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeName(t *testing.T) {
+	assert.Equal(t, "www.example.com", NormalizeName("www.example.com"))
+	assert.Equal(t, "www.example.com", NormalizeName(" WWW.Example.COM. "))
+	assert.Equal(t, "", NormalizeName("."))
+}
+
+func TestDNSRecord_Normalize(t *testing.T) {
+	tests := []struct {
+		name string
+		rr   DNSRecord
+		want DNSRecord
+	}{
+		{
+			name: "name and type",
+			rr:   DNSRecord{Name: "WWW.Example.com.", Type: "a", Content: " 192.0.2.1 "},
+			want: DNSRecord{Name: "www.example.com", Type: "A", Content: "192.0.2.1"},
+		},
+		{
+			name: "cname target",
+			rr:   DNSRecord{Name: "blog.example.com", Type: "CNAME", Content: "Example.GitHub.io."},
+			want: DNSRecord{Name: "blog.example.com", Type: "CNAME", Content: "example.github.io"},
+		},
+		{
+			name: "ns host",
+			rr:   DNSRecord{Name: "sub.example.com", Type: "ns", Content: "NS1.example.net."},
+			want: DNSRecord{Name: "sub.example.com", Type: "NS", Content: "ns1.example.net"},
+		},
+		{
+			name: "mx with priority",
+			rr:   DNSRecord{Name: "example.com", Type: "MX", Content: "10  Mail.Example.com."},
+			want: DNSRecord{Name: "example.com", Type: "MX", Content: "10 mail.example.com"},
+		},
+		{
+			name: "mx with separate priority",
+			rr:   DNSRecord{Name: "example.com", Type: "MX", Content: "Mail.Example.com.", Priority: 10},
+			want: DNSRecord{Name: "example.com", Type: "MX", Content: "mail.example.com", Priority: 10},
+		},
+		{
+			name: "srv target",
+			rr:   DNSRecord{Name: "_sip._tcp.example.com", Type: "SRV", Content: "10 5 5060 SIP.example.com."},
+			want: DNSRecord{Name: "_sip._tcp.example.com", Type: "SRV", Content: "10 5 5060 sip.example.com"},
+		},
+		{
+			name: "aaaa compressed",
+			rr:   DNSRecord{Name: "www.example.com", Type: "AAAA", Content: "2001:DB8:0:0::1"},
+			want: DNSRecord{Name: "www.example.com", Type: "AAAA", Content: "2001:db8::1"},
+		},
+		{
+			name: "txt quoted",
+			rr:   DNSRecord{Name: "example.com", Type: "TXT", Content: `"v=spf1 " "-all"`},
+			want: DNSRecord{Name: "example.com", Type: "TXT", Content: "v=spf1 -all"},
+		},
+		{
+			name: "txt case kept",
+			rr:   DNSRecord{Name: "example.com", Type: "TXT", Content: "Token=AbC"},
+			want: DNSRecord{Name: "example.com", Type: "TXT", Content: "Token=AbC"},
+		},
+		{
+			name: "other fields kept",
+			rr:   DNSRecord{ID: "1", Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 300, Proxied: true, Comment: "Web", Tags: []string{"env:prod"}},
+			want: DNSRecord{ID: "1", Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 300, Proxied: true, Comment: "Web", Tags: []string{"env:prod"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rr.Normalize())
+		})
+	}
+}
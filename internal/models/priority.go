@@ -0,0 +1,40 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// JoinPriority returns the content of an MX or SRV record with the priority as
+// its first field, as in zone files, for providers that keep the priority in
+// the content. Content that already holds it and other types are unchanged.
+func JoinPriority(rrtype string, priority int, content string) string {
+	n := len(strings.Fields(content))
+	switch strings.ToUpper(rrtype) {
+	case "MX":
+		if n == 1 {
+			return strconv.Itoa(priority) + " " + strings.TrimSpace(content)
+		}
+	case "SRV":
+		if n == 3 {
+			return strconv.Itoa(priority) + " " + strings.TrimSpace(content)
+		}
+	}
+	return content
+}
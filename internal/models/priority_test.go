@@ -0,0 +1,45 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoinPriority(t *testing.T) {
+	tests := []struct {
+		rrtype   string
+		priority int
+		content  string
+		want     string
+	}{
+		{rrtype: "MX", priority: 10, content: "mail.example.com", want: "10 mail.example.com"},
+		{rrtype: "mx", priority: 0, content: "mail.example.com", want: "0 mail.example.com"},
+		{rrtype: "MX", priority: 20, content: "10 mail.example.com", want: "10 mail.example.com"},
+		{rrtype: "SRV", priority: 10, content: "5 5060 sip.example.com", want: "10 5 5060 sip.example.com"},
+		{rrtype: "SRV", priority: 20, content: "10 5 5060 sip.example.com", want: "10 5 5060 sip.example.com"},
+		{rrtype: "A", priority: 10, content: "192.0.2.1", want: "192.0.2.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rrtype+" "+tt.content, func(t *testing.T) {
+			assert.Equal(t, tt.want, JoinPriority(tt.rrtype, tt.priority, tt.content))
+		})
+	}
+}
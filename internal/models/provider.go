@@ -0,0 +1,95 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ProviderInfo describes a configured DNS provider.
+type ProviderInfo struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	DisplayName string `json:"display_name"`
+	Default     bool   `json:"default"`
+	// Capabilities are only filled in on request, see providers list --capabilities
+	Capabilities *Capabilities `json:"capabilities,omitempty"`
+}
+
+// CommonRecordTypes are the record types every provider is expected to support.
+var CommonRecordTypes = []string{"A", "AAAA", "CNAME", "TXT", "MX", "NS", "SRV", "CAA"}
+
+// Capabilities describes the record types and features a DNS provider supports.
+// Empty RecordTypes mean that any record type is supported.
+type Capabilities struct {
+	RecordTypes []string `json:"record_types,omitempty"`
+	Proxied     bool     `json:"proxied"`
+	Comments    bool     `json:"comments"`
+	Tags        bool     `json:"tags"`
+	// MinTTL and MaxTTL bound the TTL of records in seconds, zero means no bound
+	MinTTL int `json:"min_ttl,omitempty"`
+	MaxTTL int `json:"max_ttl,omitempty"`
+	// AutoTTL is set when TTLAuto lets the provider choose the TTL, below MinTTL
+	AutoTTL bool `json:"auto_ttl,omitempty"`
+}
+
+// CheckTTL checks ttl against the TTL bounds of the provider. A TTL of 0 takes
+// the default TTL and is not checked. Out of range values are reported as a
+// *ValidationError of the "ttl" field.
+func (c Capabilities) CheckTTL(ttl int) error {
+	switch {
+	case ttl == 0, ttl == TTLAuto && c.AutoTTL:
+		return nil
+	case c.MinTTL > 0 && ttl < c.MinTTL, c.MaxTTL > 0 && ttl > c.MaxTTL:
+		return &ValidationError{Field: "ttl", Err: fmt.Errorf("TTL %d is out of range, the provider accepts %s", ttl, c.TTLRange())}
+	}
+	return nil
+}
+
+// TTLRange describes the TTL bounds, e.g. "60 to 86400 seconds or 1 (auto)".
+// It is "any" without bounds.
+func (c Capabilities) TTLRange() string {
+	var r string
+	switch {
+	case c.MinTTL > 0 && c.MaxTTL > 0:
+		r = fmt.Sprintf("%d to %d seconds", c.MinTTL, c.MaxTTL)
+	case c.MinTTL > 0:
+		r = fmt.Sprintf("at least %d seconds", c.MinTTL)
+	case c.MaxTTL > 0:
+		r = fmt.Sprintf("at most %d seconds", c.MaxTTL)
+	default:
+		return "any"
+	}
+	if c.AutoTTL {
+		r += fmt.Sprintf(" or %d (%s)", TTLAuto, TTLAutoString)
+	}
+	return r
+}
+
+// SupportsType reports whether records of type rrType can be managed, compared case-insensitively.
+func (c Capabilities) SupportsType(rrType string) bool {
+	if len(c.RecordTypes) == 0 {
+		return true
+	}
+	for _, t := range c.RecordTypes {
+		if strings.EqualFold(t, rrType) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapabilities_CheckTTL(t *testing.T) {
+	cf := Capabilities{MinTTL: 60, MaxTTL: 86400, AutoTTL: true}
+	for _, ttl := range []int{0, TTLAuto, 60, 86400} {
+		assert.NoError(t, cf.CheckTTL(ttl), ttl)
+	}
+	for _, ttl := range []int{2, 59, 86401} {
+		err := cf.CheckTTL(ttl)
+		var validationErr *ValidationError
+		if assert.ErrorAs(t, err, &validationErr, ttl) {
+			assert.Equal(t, "ttl", validationErr.Field)
+		}
+	}
+	assert.EqualError(t, cf.CheckTTL(30), "TTL 30 is out of range, the provider accepts 60 to 86400 seconds or 1 (auto)")
+
+	// Without AutoTTL, 1 is an ordinary TTL
+	regRu := Capabilities{MinTTL: 300, MaxTTL: 604800}
+	assert.EqualError(t, regRu.CheckTTL(TTLAuto), "TTL 1 is out of range, the provider accepts 300 to 604800 seconds")
+	assert.NoError(t, regRu.CheckTTL(300))
+	assert.NoError(t, regRu.CheckTTL(604800))
+	assert.Error(t, regRu.CheckTTL(299))
+	assert.Error(t, regRu.CheckTTL(604801))
+
+	// One sided and missing bounds
+	assert.EqualError(t, Capabilities{MinTTL: 30}.CheckTTL(10), "TTL 10 is out of range, the provider accepts at least 30 seconds")
+	assert.EqualError(t, Capabilities{MaxTTL: 3600}.CheckTTL(7200), "TTL 7200 is out of range, the provider accepts at most 3600 seconds")
+	assert.NoError(t, Capabilities{}.CheckTTL(MaxTTL))
+	assert.Equal(t, "any", Capabilities{}.TTLRange())
+}
@@ -17,38 +17,54 @@ limitations under the License.
 // Package models holds an internal structs for DNS zones and records and also queries params.
 package models
 
+import "time"
+
 // DNSRecord represents a DNS record in a zone.
 type DNSRecord struct {
-	Content string `json:"content,omitempty"`
-	ID      string `json:"id,omitempty"`
-	Name    string `json:"name,omitempty"`
-	Proxied bool   `json:"proxied,omitempty"`
-	TTL     int    `json:"ttl,omitempty"`
-	Type    string `json:"type,omitempty"`
+	Comment    string    `json:"comment,omitempty"`
+	Content    string    `json:"content,omitempty"`
+	ID         string    `json:"id,omitempty"`
+	ModifiedOn time.Time `json:"modified_on,omitzero"`
+	Name       string    `json:"name,omitempty"`
+	Priority   int       `json:"priority,omitempty"`
+	Proxied    bool      `json:"proxied,omitempty"`
+	Tags       []string  `json:"tags,omitempty"`
+	TTL        int       `json:"ttl,omitempty"`
+	Type       string    `json:"type,omitempty"`
+	ZoneID     string    `json:"zone_id,omitempty"`
 }
 
 // CreateDNSRecordParams params for creating DNS record.
 type CreateDNSRecordParams struct {
-	Content  string `json:"content,omitempty"`
-	ID       string `json:"id,omitempty"`
-	Name     string `json:"name,omitempty"`
-	Proxied  bool   `json:"proxied,omitempty"`
-	TTL      int    `json:"ttl,omitempty"`
-	Type     string `json:"type,omitempty"`
-	ZoneID   string `json:"zone_id,omitempty"`
-	ZoneName string `json:"zone_name,omitempty"`
+	Comment  string   `json:"comment,omitempty"`
+	Content  string   `json:"content,omitempty"`
+	ID       string   `json:"id,omitempty"`
+	Name     string   `json:"name,omitempty"`
+	Priority int      `json:"priority,omitempty"`
+	Proxied  bool     `json:"proxied,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	TTL      int      `json:"ttl,omitempty"`
+	Type     string   `json:"type,omitempty"`
+	ZoneID   string   `json:"zone_id,omitempty"`
+	ZoneName string   `json:"zone_name,omitempty"`
+	// FailOnDuplicate makes creating a record fail when the zone already holds
+	// a record with the same name, type and content.
+	FailOnDuplicate bool `json:"-"`
 }
 
 // UpdateDNSRecordParams params for updating DNS record.
 type UpdateDNSRecordParams struct {
-	Content  string `json:"content,omitempty"`
-	ID       string `json:"id,omitempty"`
-	Name     string `json:"name,omitempty"`
-	Proxied  bool   `json:"proxied,omitempty"`
-	TTL      int    `json:"ttl,omitempty"`
-	Type     string `json:"type,omitempty"`
-	ZoneID   string `json:"zone_id,omitempty"`
-	ZoneName string `json:"zone_name,omitempty"`
+	Comment  string   `json:"comment,omitempty"`
+	Content  string   `json:"content,omitempty"`
+	ID       string   `json:"id,omitempty"`
+	Name     string   `json:"name,omitempty"`
+	Priority int      `json:"priority,omitempty"`
+	Proxied  bool     `json:"proxied,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	TTL      int      `json:"ttl,omitempty"`
+	Type     string   `json:"type,omitempty"`
+	ZoneID   string   `json:"zone_id,omitempty"`
+	ZoneName string   `json:"zone_name,omitempty"`
 }
 
 // ListDNSRecordsParams params for list DNS records.
@@ -0,0 +1,64 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// Reverse DNS trees of IPv4 (RFC 1035) and IPv6 (RFC 3596) addresses.
+const (
+	ReverseZoneIPv4 = "in-addr.arpa"
+	ReverseZoneIPv6 = "ip6.arpa"
+)
+
+// ReverseName returns the name of the PTR record of an IP address, e.g.
+// 1.2.0.192.in-addr.arpa for 192.0.2.1. IPv6 addresses are expanded to one label
+// per nibble under ip6.arpa. IPv4-mapped IPv6 addresses are taken as IPv4.
+func ReverseName(ip string) (string, error) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return "", fmt.Errorf("invalid IP address %q", ip)
+	}
+	if addr.Zone() != "" {
+		return "", fmt.Errorf("IP address %q must not have a zone", ip)
+	}
+	addr = addr.Unmap()
+
+	var labels []string
+	if addr.Is4() {
+		b := addr.As4()
+		labels = make([]string, 0, len(b)+1)
+		for i := len(b) - 1; i >= 0; i-- {
+			labels = append(labels, strconv.Itoa(int(b[i])))
+		}
+		labels = append(labels, ReverseZoneIPv4)
+	} else {
+		const hex = "0123456789abcdef"
+		b := addr.As16()
+		labels = make([]string, 0, 2*len(b)+1)
+		for i := len(b) - 1; i >= 0; i-- {
+			labels = append(labels, string(hex[b[i]&0x0f]), string(hex[b[i]>>4]))
+		}
+		labels = append(labels, ReverseZoneIPv6)
+	}
+
+	return strings.Join(labels, "."), nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReverseName(t *testing.T) {
+	tests := []struct {
+		name    string
+		ip      string
+		want    string
+		wantErr string
+	}{
+		{name: "IPv4", ip: "192.0.2.1", want: "1.2.0.192.in-addr.arpa"},
+		{name: "IPv4 zero octets", ip: "10.0.0.0", want: "0.0.0.10.in-addr.arpa"},
+		{name: "IPv4 broadcast", ip: "255.255.255.255", want: "255.255.255.255.in-addr.arpa"},
+		{name: "IPv4 surrounding spaces", ip: " 198.51.100.42 ", want: "42.100.51.198.in-addr.arpa"},
+		{
+			name: "IPv6 compressed",
+			ip:   "2001:db8::1",
+			want: "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+		},
+		{
+			name: "IPv6 full form",
+			ip:   "2001:0db8:85a3:0000:0000:8a2e:0370:7334",
+			want: "4.3.3.7.0.7.3.0.e.2.a.8.0.0.0.0.0.0.0.0.3.a.5.8.8.b.d.0.1.0.0.2.ip6.arpa",
+		},
+		{
+			name: "IPv6 upper case",
+			ip:   "2001:DB8::ABCD",
+			want: "d.c.b.a.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+		},
+		{
+			name: "IPv6 loopback",
+			ip:   "::1",
+			want: "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.ip6.arpa",
+		},
+		{
+			name: "IPv6 unspecified",
+			ip:   "::",
+			want: "0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.ip6.arpa",
+		},
+		{name: "IPv4-mapped IPv6", ip: "::ffff:192.0.2.1", want: "1.2.0.192.in-addr.arpa"},
+		{name: "IPv6 with zone", ip: "fe80::1%eth0", wantErr: `IP address "fe80::1%eth0" must not have a zone`},
+		{name: "empty", ip: "", wantErr: `invalid IP address ""`},
+		{name: "host name", ip: "www.example.com", wantErr: `invalid IP address "www.example.com"`},
+		{name: "IPv4 octet out of range", ip: "192.0.2.256", wantErr: `invalid IP address "192.0.2.256"`},
+		{name: "IPv4 short form", ip: "10.1", wantErr: `invalid IP address "10.1"`},
+		{name: "CIDR", ip: "192.0.2.0/24", wantErr: `invalid IP address "192.0.2.0/24"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReverseName(tt.ip)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReverseName_Labels(t *testing.T) {
+	v4, err := ReverseName("203.0.113.7")
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSuffix(v4, "."+ReverseZoneIPv4), "."), 4)
+
+	v6, err := ReverseName("2001:db8:1234::5678")
+	require.NoError(t, err)
+	nibbles := strings.Split(strings.TrimSuffix(v6, "."+ReverseZoneIPv6), ".")
+	assert.Len(t, nibbles, 32)
+	for _, n := range nibbles {
+		assert.Len(t, n, 1)
+	}
+}
+
+// The names must match what resolvers query, as computed by the DNS library.
+func TestReverseName_MatchesReverseAddr(t *testing.T) {
+	for _, ip := range []string{
+		"0.0.0.0",
+		"127.0.0.1",
+		"192.0.2.1",
+		"100.64.0.255",
+		"::1",
+		"2001:db8::1",
+		"2001:db8:ffff:ffff:ffff:ffff:ffff:fffe",
+		"fe80::1ff:fe23:4567:890a",
+		"::ffff:198.51.100.1",
+	} {
+		want, err := dns.ReverseAddr(ip)
+		require.NoError(t, err)
+
+		got, err := ReverseName(ip)
+		require.NoError(t, err)
+		assert.Equal(t, strings.TrimSuffix(want, "."), got, ip)
+	}
+}
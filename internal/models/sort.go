@@ -0,0 +1,83 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// RecordSortFields are the record fields records can be sorted by.
+var RecordSortFields = []string{"name", "ttl", "type", "content"}
+
+// DefaultRecordSort is the field listings are sorted by when no other is asked for.
+const DefaultRecordSort = "name"
+
+// CompareRecords returns a comparator ordering records by the given field. TTLs
+// compare numerically, names case-insensitively. Records equal by the field are
+// ordered by name, type, content and ID, so the result does not depend on provider order.
+func CompareRecords(field string) (func(a, b DNSRecord) int, error) {
+	byName := func(a, b DNSRecord) int {
+		return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	}
+	byType := func(a, b DNSRecord) int { return cmp.Compare(a.Type, b.Type) }
+	byContent := func(a, b DNSRecord) int { return cmp.Compare(a.Content, b.Content) }
+	byID := func(a, b DNSRecord) int { return cmp.Compare(a.ID, b.ID) }
+
+	var first func(a, b DNSRecord) int
+	switch strings.ToLower(strings.TrimSpace(field)) {
+	case "name":
+		first = byName
+	case "ttl":
+		first = func(a, b DNSRecord) int { return cmp.Compare(a.TTL, b.TTL) }
+	case "type":
+		first = byType
+	case "content":
+		first = byContent
+	default:
+		return nil, fmt.Errorf("unknown sort field %q (available fields: %s)", field, strings.Join(RecordSortFields, ", "))
+	}
+
+	return func(a, b DNSRecord) int {
+		return cmp.Or(first(a, b), byName(a, b), byType(a, b), byContent(a, b), byID(a, b))
+	}, nil
+}
+
+// SortRecords sorts rrset in place by the given field, in descending order if reverse is set.
+func SortRecords(rrset []DNSRecord, field string, reverse bool) error {
+	compare, err := CompareRecords(field)
+	if err != nil {
+		return err
+	}
+
+	if reverse {
+		slices.SortStableFunc(rrset, func(a, b DNSRecord) int { return compare(b, a) })
+	} else {
+		slices.SortStableFunc(rrset, compare)
+	}
+
+	return nil
+}
+
+// SortZones sorts zones in place by name, case-insensitively, then by ID.
+func SortZones(zones []Zone) {
+	slices.SortStableFunc(zones, func(a, b Zone) int {
+		return cmp.Or(cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)), cmp.Compare(a.ID, b.ID))
+	})
+}
@@ -0,0 +1,92 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortRecords(t *testing.T) {
+	rrset := func() []DNSRecord {
+		return []DNSRecord{
+			{ID: "1", Name: "www.example.com", Type: "A", TTL: 300, Content: "192.0.2.2"},
+			{ID: "2", Name: "Mail.example.com", Type: "MX", TTL: 3600, Content: "10 mx.example.com"},
+			{ID: "3", Name: "www.example.com", Type: "A", TTL: 1800, Content: "192.0.2.1"},
+			{ID: "4", Name: "example.com", Type: "TXT", TTL: 60, Content: "v=spf1 -all"},
+			{ID: "5", Name: "www.example.com", Type: "AAAA", TTL: 300, Content: "2001:db8::1"},
+		}
+	}
+	ids := func(rrset []DNSRecord) []string {
+		var ids []string
+		for _, rr := range rrset {
+			ids = append(ids, rr.ID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		field   string
+		reverse bool
+		want    []string
+	}{
+		{field: "name", want: []string{"4", "2", "3", "1", "5"}},
+		{field: "name", reverse: true, want: []string{"5", "1", "3", "2", "4"}},
+		// 60 < 300 < 1800 < 3600, not the string order
+		{field: "ttl", want: []string{"4", "1", "5", "3", "2"}},
+		{field: "TTL", reverse: true, want: []string{"2", "3", "5", "1", "4"}},
+		{field: "type", want: []string{"3", "1", "5", "2", "4"}},
+		{field: "content", want: []string{"2", "3", "1", "5", "4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			got := rrset()
+			require.NoError(t, SortRecords(got, tt.field, tt.reverse))
+			assert.Equal(t, tt.want, ids(got))
+		})
+	}
+}
+
+func TestSortRecords_UnknownField(t *testing.T) {
+	err := SortRecords(nil, "priority", false)
+	assert.EqualError(t, err, `unknown sort field "priority" (available fields: name, ttl, type, content)`)
+}
+
+func TestSortRecords_ByID(t *testing.T) {
+	rrset := []DNSRecord{
+		{ID: "b", Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 600},
+		{ID: "a", Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 300},
+	}
+	require.NoError(t, SortRecords(rrset, "name", false))
+	assert.Equal(t, "a", rrset[0].ID)
+}
+
+func TestSortZones(t *testing.T) {
+	zones := []Zone{
+		{ID: "3", Name: "example.org"},
+		{ID: "2", Name: "Example.com"},
+		{ID: "1", Name: "example.net"},
+	}
+	SortZones(zones)
+	assert.Equal(t, []Zone{
+		{ID: "2", Name: "Example.com"},
+		{ID: "1", Name: "example.net"},
+		{ID: "3", Name: "example.org"},
+	}, zones)
+}
@@ -0,0 +1,50 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// TTLAuto is the TTL value meaning "automatic" (Cloudflare semantics).
+	TTLAuto = 1
+	// TTLAutoString is the user-facing spelling of TTLAuto.
+	TTLAutoString = "auto"
+)
+
+// ParseTTL parses a TTL given by the user.
+// An empty string yields 0 (use the default), "auto" yields TTLAuto,
+// anything else must be a non-negative number of seconds.
+func ParseTTL(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	if strings.EqualFold(s, TTLAutoString) {
+		return TTLAuto, nil
+	}
+
+	ttl, err := strconv.Atoi(s)
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("invalid TTL %q: must be a number of seconds or %q", s, TTLAutoString)
+	}
+
+	return ttl, nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// TXTMaxSegment is the maximum length in bytes of a single TXT character-string.
+	TXTMaxSegment = 255
+	// TXTMaxLength is the maximum length in bytes of a whole TXT value accepted for editing.
+	TXTMaxLength = 2048
+)
+
+// SplitTXT splits a TXT value into character-strings of at most TXTMaxSegment bytes.
+// UTF-8 encoded characters are never split between segments.
+func SplitTXT(value string) []string {
+	var segments []string
+
+	for len(value) > TXTMaxSegment {
+		cut := TXTMaxSegment
+		for cut > 0 && !utf8.RuneStart(value[cut]) {
+			cut--
+		}
+		segments = append(segments, value[:cut])
+		value = value[cut:]
+	}
+
+	return append(segments, value)
+}
+
+// QuoteTXT formats TXT character-strings as quoted strings separated by spaces,
+// e.g. "first" "second". Quotes and backslashes are escaped.
+func QuoteTXT(segments []string) string {
+	quoted := make([]string, len(segments))
+	for i, s := range segments {
+		s = strings.ReplaceAll(s, `\`, `\\`)
+		s = strings.ReplaceAll(s, `"`, `\"`)
+		quoted[i] = `"` + s + `"`
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+// EncodeTXT prepares a TXT value for a provider. Values longer than TXTMaxSegment
+// are split into quoted character-strings; shorter and already quoted values are
+// returned unchanged.
+func EncodeTXT(value string) string {
+	if len(value) <= TXTMaxSegment || strings.HasPrefix(strings.TrimSpace(value), `"`) {
+		return value
+	}
+
+	return QuoteTXT(SplitTXT(value))
+}
+
+// JoinTXT reassembles a TXT value made of quoted character-strings into a single string.
+// Values that are not a sequence of quoted strings are returned unchanged.
+func JoinTXT(content string) string {
+	s := strings.TrimSpace(content)
+	if !strings.HasPrefix(s, `"`) {
+		return content
+	}
+
+	var joined strings.Builder
+	for len(s) > 0 {
+		if s[0] != '"' {
+			return content
+		}
+
+		// Read one quoted string
+		closed := false
+		i := 1
+		for ; i < len(s); i++ {
+			c := s[i]
+			if c == '\\' && i+1 < len(s) {
+				i++
+				joined.WriteByte(s[i])
+				continue
+			}
+			if c == '"' {
+				closed = true
+				break
+			}
+			joined.WriteByte(c)
+		}
+		if !closed {
+			return content
+		}
+
+		s = strings.TrimLeft(s[i+1:], " \t")
+	}
+
+	return joined.String()
+}
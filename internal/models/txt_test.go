@@ -0,0 +1,84 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitTXT(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantLen []int
+	}{
+		{name: "empty", value: "", wantLen: []int{0}},
+		{name: "short", value: "v=spf1 -all", wantLen: []int{11}},
+		{name: "exactly 255 bytes", value: strings.Repeat("a", 255), wantLen: []int{255}},
+		{name: "256 bytes", value: strings.Repeat("a", 256), wantLen: []int{255, 1}},
+		{name: "600 bytes", value: strings.Repeat("a", 600), wantLen: []int{255, 255, 90}},
+		// 127 two-byte runes fill 254 bytes, the 128th rune does not fit into the first segment
+		{name: "multibyte boundary", value: strings.Repeat("ж", 130), wantLen: []int{254, 6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			segments := SplitTXT(tt.value)
+			lens := make([]int, len(segments))
+			for i, s := range segments {
+				lens[i] = len(s)
+			}
+			assert.Equal(t, tt.wantLen, lens)
+			assert.Equal(t, tt.value, strings.Join(segments, ""))
+		})
+	}
+}
+
+func TestEncodeTXT(t *testing.T) {
+	assert.Equal(t, "v=spf1 -all", EncodeTXT("v=spf1 -all"))
+	assert.Equal(t, `"already" "quoted"`, EncodeTXT(`"already" "quoted"`))
+
+	long := strings.Repeat("a", 255) + `b"c`
+	assert.Equal(t, `"`+strings.Repeat("a", 255)+`" "b\"c"`, EncodeTXT(long))
+}
+
+func TestJoinTXT(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "unquoted", content: "v=spf1 -all", want: "v=spf1 -all"},
+		{name: "single quoted string", content: `"v=spf1 -all"`, want: "v=spf1 -all"},
+		{name: "several strings", content: `"v=DKIM1; k=rsa; " "p=MIIB"`, want: "v=DKIM1; k=rsa; p=MIIB"},
+		{name: "escaped quote and backslash", content: `"a\"b" "c\\d"`, want: `a"bc\d`},
+		{name: "unbalanced quotes", content: `"abc`, want: `"abc`},
+		{name: "text after strings", content: `"abc" def`, want: `"abc" def`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, JoinTXT(tt.content))
+		})
+	}
+}
+
+func TestEncodeJoinTXT_RoundTrip(t *testing.T) {
+	value := strings.Repeat(`0123456789"\`, 60)
+	assert.Equal(t, value, JoinTXT(EncodeTXT(value)))
+}
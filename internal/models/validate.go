@@ -0,0 +1,332 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxNameLength is the maximum length of a domain name in presentation format (RFC 1035).
+	MaxNameLength = 253
+	// MaxLabelLength is the maximum length of a single domain name label (RFC 1035).
+	MaxLabelLength = 63
+	// ApexName is the shorthand for the zone apex in record names.
+	ApexName = "@"
+	// MinNameServers and MaxNameServers bound the number of name servers of a zone.
+	MinNameServers = 2
+	MaxNameServers = 4
+	// MaxTTL is the largest TTL in seconds, 2^31 - 1 (RFC 2181).
+	MaxTTL = 1<<31 - 1
+	// MaxPriority is the largest MX and SRV priority, as well as SRV weight and port.
+	MaxPriority = 65535
+)
+
+// ValidationError reports the field of a record that failed validation.
+type ValidationError struct {
+	// Field is the invalid field: "type", "name", "ttl", "priority", "proxied" or "content"
+	Field string
+	Err   error
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Validate checks the record before it is sent to a provider, see ValidateRecord.
+func (rr DNSRecord) Validate() error {
+	return ValidateRecord(rr.Type, rr.Name, rr.Content, rr.TTL, rr.Priority, rr.Proxied)
+}
+
+// Validate checks the record to create, see ValidateRecord.
+func (p CreateDNSRecordParams) Validate() error {
+	return ValidateRecord(p.Type, p.Name, p.Content, p.TTL, p.Priority, p.Proxied)
+}
+
+// Validate checks the updated record, see ValidateRecord.
+func (p UpdateDNSRecordParams) Validate() error {
+	return ValidateRecord(p.Type, p.Name, p.Content, p.TTL, p.Priority, p.Proxied)
+}
+
+// ValidateRecord checks a single record: its type is given, its name follows
+// ValidateName, the TTL is 0 (the default) up to MaxTTL, the priority is at
+// most MaxPriority, only ProxiedTypes are proxied, and the content is valid for
+// the type as checked by ValidateRecordContent. The first invalid field is
+// reported as a *ValidationError.
+func ValidateRecord(rrtype, name, content string, ttl, priority int, proxied bool) error {
+	if strings.TrimSpace(rrtype) == "" {
+		return &ValidationError{Field: "type", Err: fmt.Errorf("record type must not be empty")}
+	}
+	if err := ValidateName(name); err != nil {
+		return &ValidationError{Field: "name", Err: err}
+	}
+	if err := ValidateTTL(ttl); err != nil {
+		return &ValidationError{Field: "ttl", Err: err}
+	}
+	if priority < 0 || priority > MaxPriority {
+		return &ValidationError{Field: "priority", Err: fmt.Errorf("priority %d is out of range, it must be 0 to %d", priority, MaxPriority)}
+	}
+	if proxied {
+		if err := ValidateProxied(rrtype); err != nil {
+			return &ValidationError{Field: "proxied", Err: err}
+		}
+	}
+	if err := ValidateRecordContent(rrtype, content); err != nil {
+		return &ValidationError{Field: "content", Err: err}
+	}
+
+	return nil
+}
+
+// ValidateTTL checks that ttl is 0, meaning the default TTL, TTLAuto or a
+// number of seconds up to MaxTTL.
+func ValidateTTL(ttl int) error {
+	if ttl < 0 || ttl > MaxTTL {
+		return fmt.Errorf("TTL %d is out of range, it must be 0 to %d seconds", ttl, MaxTTL)
+	}
+	return nil
+}
+
+// ValidateName checks that a zone or record name follows the RFC 1035 limits:
+// at most 253 characters in total, labels of 1 to 63 characters made of letters,
+// digits, hyphens and underscores, not starting or ending with a hyphen.
+// A leading "*" label is allowed for wildcard records. Internationalized names
+// are checked in their punycode form.
+func ValidateName(name string) error {
+	ascii, err := NameToASCII(name)
+	if err != nil {
+		return err
+	}
+
+	n := strings.TrimSuffix(ascii, ".")
+	if n == "" {
+		return fmt.Errorf("name must not be empty")
+	}
+	if len(n) > MaxNameLength {
+		return fmt.Errorf("name %q is %d characters long, the limit is %d", name, len(n), MaxNameLength)
+	}
+
+	for i, label := range strings.Split(n, ".") {
+		if err := validateLabel(label, i == 0); err != nil {
+			return fmt.Errorf("invalid name %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateNameServers checks that a zone is given 2 to 4 distinct name servers
+// with valid host names. Wildcards are not host names and are rejected.
+func ValidateNameServers(ns []string) error {
+	if len(ns) < MinNameServers || len(ns) > MaxNameServers {
+		return fmt.Errorf("a zone needs %d to %d name servers, got %d", MinNameServers, MaxNameServers, len(ns))
+	}
+
+	seen := make(map[string]bool, len(ns))
+	for _, n := range ns {
+		if err := ValidateName(n); err != nil {
+			return err
+		}
+		if strings.HasPrefix(n, "*") {
+			return fmt.Errorf("name server %q must not be a wildcard", n)
+		}
+		key := strings.ToLower(strings.TrimSuffix(n, "."))
+		if seen[key] {
+			return fmt.Errorf("name server %q is given twice", n)
+		}
+		seen[key] = true
+	}
+
+	return nil
+}
+
+// validateLabel checks a single domain name label.
+func validateLabel(label string, first bool) error {
+	switch {
+	case label == "":
+		return fmt.Errorf("empty label")
+	case label == "*" && first:
+		return nil
+	case len(label) > MaxLabelLength:
+		return fmt.Errorf("label %q is %d characters long, the limit is %d", label, len(label), MaxLabelLength)
+	case label[0] == '-' || label[len(label)-1] == '-':
+		return fmt.Errorf("label %q must not start or end with a hyphen", label)
+	}
+
+	for _, r := range label {
+		if !isLabelChar(r) {
+			return fmt.Errorf("label %q contains invalid character %q", label, r)
+		}
+	}
+
+	return nil
+}
+
+func isLabelChar(r rune) bool {
+	return r >= 'a' && r <= 'z' ||
+		r >= 'A' && r <= 'Z' ||
+		r >= '0' && r <= '9' ||
+		r == '-' || r == '_'
+}
+
+// ValidateContent checks record content given by the user as ValidateRecordContent
+// does, except that A and AAAA content may hold several comma separated
+// addresses, each of which must belong to the record's address family.
+func ValidateContent(rrtype, content string) error {
+	switch strings.ToUpper(rrtype) {
+	case "A", "AAAA":
+		for _, v := range strings.Split(content, ",") {
+			if err := ValidateAddress(rrtype, strings.TrimSpace(v)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return ValidateRecordContent(rrtype, content)
+}
+
+// ValidateRecordContent checks that content is valid for a single record of rrtype:
+//
+//   - A and AAAA: an address of the record's family
+//   - CNAME, DNAME, NS and PTR: a host name
+//   - MX: "[priority] host", the host may be "." (null MX)
+//   - SRV: "[priority] weight port target", the target may be "."
+//   - CAA: "flags tag value"
+//   - TXT: at most TXTMaxLength bytes
+//
+// The MX and SRV priority may be kept apart from the content, as Cloudflare and
+// Vultr do. Content of other types must not be empty.
+func ValidateRecordContent(rrtype, content string) error {
+	rrtype = strings.ToUpper(rrtype)
+	parts := strings.Fields(content)
+
+	switch rrtype {
+	case "A", "AAAA":
+		return ValidateAddress(rrtype, content)
+	case "CNAME", "DNAME", "NS", "PTR":
+		if err := validateHostname(content); err != nil {
+			return fmt.Errorf("%s content must be a valid hostname: %w", rrtype, err)
+		}
+	case "MX":
+		if len(parts) == 2 {
+			if !isUint(parts[0], MaxPriority) {
+				return fmt.Errorf(`MX content must be "priority host", e.g. 10 mail.example.com`)
+			}
+			parts = parts[1:]
+		}
+		if len(parts) != 1 || parts[0] != "." && validateHostname(parts[0]) != nil {
+			return fmt.Errorf("MX content %q must name a valid mail exchanger hostname", content)
+		}
+	case "SRV":
+		if len(parts) == 4 {
+			if !isUint(parts[0], MaxPriority) {
+				return fmt.Errorf(`SRV content must be "priority weight port target", e.g. 10 5 5060 sip.example.com`)
+			}
+			parts = parts[1:]
+		}
+		if len(parts) != 3 || !isUint(parts[0], MaxPriority) || !isUint(parts[1], MaxPriority) {
+			return fmt.Errorf(`SRV content must be "priority weight port target", e.g. 10 5 5060 sip.example.com`)
+		}
+		if parts[2] != "." && validateHostname(parts[2]) != nil {
+			return fmt.Errorf("SRV target %q must be a valid hostname or .", parts[2])
+		}
+	case "CAA":
+		if len(parts) < 3 || !isUint(parts[0], 255) || !caaTagRe.MatchString(parts[1]) {
+			return fmt.Errorf(`CAA content must be "flags tag value", e.g. 0 issue "letsencrypt.org"`)
+		}
+	case "TXT":
+		if content == "" {
+			return fmt.Errorf("TXT content must not be empty")
+		}
+		if len(content) > TXTMaxLength {
+			return fmt.Errorf("TXT content is %d bytes long, the limit is %d", len(content), TXTMaxLength)
+		}
+	default:
+		if strings.TrimSpace(content) == "" {
+			return fmt.Errorf("%s content must not be empty", rrtype)
+		}
+	}
+
+	return nil
+}
+
+var caaTagRe = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+
+// validateHostname checks a host name a record points to. Unlike record
+// names, it must not be a wildcard.
+func validateHostname(host string) error {
+	if err := ValidateName(host); err != nil {
+		return err
+	}
+	if strings.HasPrefix(host, "*") {
+		return fmt.Errorf("%q is a wildcard", host)
+	}
+	return nil
+}
+
+// isUint reports whether s is a decimal number not greater than max.
+func isUint(s string, max int) bool {
+	if s == "" || len(s) > 10 || strings.TrimLeft(s, "0123456789") != "" {
+		return false
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n <= max
+}
+
+// ValidateAddress checks that a single value is an IPv4 address for A records
+// or an IPv6 address for AAAA records. Other types are not checked.
+func ValidateAddress(rrtype, value string) error {
+	rrtype = strings.ToUpper(rrtype)
+	if rrtype != "A" && rrtype != "AAAA" {
+		return nil
+	}
+
+	ip := net.ParseIP(value)
+	switch {
+	case ip == nil:
+		return fmt.Errorf("%q is not a valid IP address for %s record", value, rrtype)
+	case rrtype == "A" && ip.To4() == nil:
+		return fmt.Errorf("%q is an IPv6 address, use an AAAA record or an IPv4 address", value)
+	case rrtype == "AAAA" && ip.To4() != nil:
+		return fmt.Errorf("%q is an IPv4 address, use an A record or an IPv6 address", value)
+	}
+
+	return nil
+}
+
+// ProxiedTypes are the record types a CDN can proxy: the addresses and aliases of web hosts.
+var ProxiedTypes = []string{"A", "AAAA", "CNAME"}
+
+// ValidateProxied checks that records of rrtype can be proxied.
+func ValidateProxied(rrtype string) error {
+	if !slices.Contains(ProxiedTypes, strings.ToUpper(rrtype)) {
+		return fmt.Errorf("%s records cannot be proxied, only %s records", strings.ToUpper(rrtype), strings.Join(ProxiedTypes, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateName(t *testing.T) {
+	label63 := strings.Repeat("a", 63)
+	tests := []struct {
+		name    string
+		in      string
+		wantErr string
+	}{
+		{name: "simple", in: "www.example.com"},
+		{name: "trailing dot", in: "www.example.com."},
+		{name: "single label", in: "www"},
+		{name: "underscore", in: "_dmarc.example.com"},
+		{name: "wildcard", in: "*.example.com"},
+		{name: "unicode", in: "www.münchen.de"},
+		{name: "63 character label", in: label63 + ".example.com"},
+		{name: "253 characters", in: strings.Repeat(label63+".", 3) + strings.Repeat("a", 61)},
+		{name: "empty", in: "", wantErr: "must not be empty"},
+		{name: "64 character label", in: label63 + "a.example.com", wantErr: "is 64 characters long, the limit is 63"},
+		{name: "254 characters", in: strings.Repeat(label63+".", 3) + strings.Repeat("a", 62), wantErr: "is 254 characters long, the limit is 253"},
+		{name: "empty label", in: "www..example.com", wantErr: "empty label"},
+		{name: "space", in: "my host.example.com", wantErr: `invalid character ' '`},
+		{name: "slash", in: "a/b.example.com", wantErr: `invalid character '/'`},
+		{name: "leading hyphen", in: "-www.example.com", wantErr: "must not start or end with a hyphen"},
+		{name: "trailing hyphen", in: "www-.example.com", wantErr: "must not start or end with a hyphen"},
+		{name: "wildcard not first", in: "www.*.example.com", wantErr: `invalid character '*'`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateName(tt.in)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateNameServers(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      []string
+		wantErr string
+	}{
+		{name: "two", in: []string{"ns1.example.net", "ns2.example.net"}},
+		{name: "four", in: []string{"a.ns.example", "b.ns.example", "c.ns.example", "d.ns.example."}},
+		{name: "none", in: nil, wantErr: "needs 2 to 4 name servers, got 0"},
+		{name: "one", in: []string{"ns1.example.net"}, wantErr: "needs 2 to 4 name servers, got 1"},
+		{name: "five", in: []string{"a.ns", "b.ns", "c.ns", "d.ns", "e.ns"}, wantErr: "got 5"},
+		{name: "invalid", in: []string{"ns1.example.net", "ns 2.example.net"}, wantErr: "invalid character ' '"},
+		{name: "wildcard", in: []string{"ns1.example.net", "*.example.net"}, wantErr: "must not be a wildcard"},
+		{name: "duplicate", in: []string{"ns1.example.net", "NS1.example.net."}, wantErr: "is given twice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateNameServers(tt.in)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		rrtype  string
+		content string
+		wantErr string
+	}{
+		{name: "ipv4", rrtype: "A", content: "192.0.2.1"},
+		{name: "ipv6", rrtype: "AAAA", content: "2001:db8::1"},
+		{name: "lower case type", rrtype: "a", content: "192.0.2.1"},
+		{name: "multi value ipv4", rrtype: "A", content: "192.0.2.1, 192.0.2.2"},
+		{name: "multi value ipv6", rrtype: "AAAA", content: "2001:db8::1,2001:db8::2"},
+		{name: "ipv6 in A", rrtype: "A", content: "2001:db8::1", wantErr: "is an IPv6 address, use an AAAA record"},
+		{name: "ipv4 in AAAA", rrtype: "AAAA", content: "192.0.2.1", wantErr: "is an IPv4 address, use an A record"},
+		{name: "mixed multi value", rrtype: "A", content: "192.0.2.1,2001:db8::1", wantErr: `"2001:db8::1" is an IPv6 address`},
+		{name: "not an address", rrtype: "A", content: "www.example.com", wantErr: "is not a valid IP address for A record"},
+		{name: "empty value", rrtype: "A", content: "192.0.2.1,", wantErr: `"" is not a valid IP address`},
+		{name: "other type", rrtype: "CNAME", content: "www.example.com"},
+		{name: "other type not split", rrtype: "CNAME", content: "192.0.2.1,www.example.com", wantErr: "must be a valid hostname"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateContent(tt.rrtype, tt.content)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateProxied(t *testing.T) {
+	for _, rrtype := range []string{"A", "aaaa", "CNAME"} {
+		assert.NoError(t, ValidateProxied(rrtype))
+	}
+	for _, rrtype := range []string{"MX", "txt", "NS", "PTR"} {
+		assert.Error(t, ValidateProxied(rrtype))
+	}
+	assert.EqualError(t, ValidateProxied("mx"), "MX records cannot be proxied, only A, AAAA, CNAME records")
+}
+
+func TestValidateRecordContent(t *testing.T) {
+	tests := []struct {
+		rrtype  string
+		content string
+		wantErr string
+	}{
+		{"A", "192.0.2.1", ""},
+		{"A", "192.0.2.1,192.0.2.2", "is not a valid IP address"},
+		{"A", "2001:db8::1", "use an AAAA record"},
+		{"AAAA", "2001:db8::1", ""},
+		{"AAAA", "192.0.2.1", "use an A record"},
+		{"CNAME", "target.example.com", ""},
+		{"CNAME", "target.example.com.", ""},
+		{"cname", "_acme.example.com", ""},
+		{"CNAME", "mail.münchen.de", ""},
+		{"CNAME", "not a host", "CNAME content must be a valid hostname"},
+		{"CNAME", "*.example.com", "is a wildcard"},
+		{"CNAME", "", "must not be empty"},
+		{"NS", "ns1.example.com", ""},
+		{"NS", "ns1..example.com", "NS content must be a valid hostname"},
+		{"PTR", "host.example.com", ""},
+		{"PTR", "192.0.2.1/24", "PTR content must be a valid hostname"},
+		{"MX", "10 mail.example.com", ""},
+		{"MX", "mail.example.com", ""},
+		{"MX", "0 .", ""},
+		{"MX", "70000 mail.example.com", `"priority host"`},
+		{"MX", "ten mail.example.com", `"priority host"`},
+		{"MX", "10 mail example.com", "valid mail exchanger"},
+		{"MX", "", "valid mail exchanger"},
+		{"SRV", "10 5 5060 sip.example.com", ""},
+		{"SRV", "5 5060 sip.example.com", ""},
+		{"SRV", "0 0 0 .", ""},
+		{"SRV", "10 sip.example.com", `"priority weight port target"`},
+		{"SRV", "10 5 70000 sip.example.com", `"priority weight port target"`},
+		{"SRV", "10 5 5060 sip example", `"priority weight port target"`},
+		{"SRV", "10 5 5060 *.example.com", "SRV target"},
+		{"CAA", `0 issue "letsencrypt.org"`, ""},
+		{"CAA", `128 iodef "mailto:security@example.com"`, ""},
+		{"CAA", "issue letsencrypt.org", `"flags tag value"`},
+		{"CAA", "0 issue", `"flags tag value"`},
+		{"CAA", "256 issue letsencrypt.org", `"flags tag value"`},
+		{"CAA", "0 is-sue letsencrypt.org", `"flags tag value"`},
+		{"TXT", "v=spf1 -all", ""},
+		{"TXT", strings.Repeat("a", TXTMaxLength), ""},
+		{"TXT", strings.Repeat("a", TXTMaxLength+1), "the limit is 2048"},
+		{"TXT", "", "TXT content must not be empty"},
+		{"HTTPS", "1 . alpn=h2", ""},
+		{"HTTPS", " ", "HTTPS content must not be empty"},
+	}
+	for _, tt := range tests {
+		err := ValidateRecordContent(tt.rrtype, tt.content)
+		if tt.wantErr == "" {
+			assert.NoError(t, err, "%s %q", tt.rrtype, tt.content)
+			continue
+		}
+		if assert.Error(t, err, "%s %q", tt.rrtype, tt.content) {
+			assert.Contains(t, err.Error(), tt.wantErr, "%s %q", tt.rrtype, tt.content)
+		}
+	}
+}
+
+func TestValidateTTL(t *testing.T) {
+	for _, ttl := range []int{0, TTLAuto, 60, 86400, MaxTTL} {
+		assert.NoError(t, ValidateTTL(ttl))
+	}
+	for _, ttl := range []int{-1, MaxTTL + 1} {
+		assert.Error(t, ValidateTTL(ttl))
+	}
+}
+
+func TestValidateRecord(t *testing.T) {
+	valid := DNSRecord{Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 300}
+	assert.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name    string
+		rr      func(rr *DNSRecord)
+		field   string
+		wantErr string
+	}{
+		{"no type", func(rr *DNSRecord) { rr.Type = "" }, "type", "record type must not be empty"},
+		{"no name", func(rr *DNSRecord) { rr.Name = "" }, "name", "name must not be empty"},
+		{"invalid name", func(rr *DNSRecord) { rr.Name = "my host.example.com" }, "name", "invalid character"},
+		{"negative TTL", func(rr *DNSRecord) { rr.TTL = -1 }, "ttl", "TTL -1 is out of range"},
+		{"TTL too large", func(rr *DNSRecord) { rr.TTL = MaxTTL + 1 }, "ttl", "out of range"},
+		{"priority too large", func(rr *DNSRecord) { rr.Priority = 65536 }, "priority", "priority 65536 is out of range"},
+		{"proxied TXT", func(rr *DNSRecord) { rr.Type, rr.Content, rr.Proxied = "TXT", "hello", true }, "proxied", "TXT records cannot be proxied"},
+		{"content of another type", func(rr *DNSRecord) { rr.Type = "AAAA" }, "content", "use an A record"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := valid
+			tt.rr(&rr)
+
+			err := rr.Validate()
+			var validationErr *ValidationError
+			if assert.ErrorAs(t, err, &validationErr) {
+				assert.Equal(t, tt.field, validationErr.Field)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+
+	// The params types are checked the same way
+	assert.NoError(t, CreateDNSRecordParams{Name: "example.com", Type: "MX", Content: "mail.example.com", Priority: 10}.Validate())
+	assert.Error(t, CreateDNSRecordParams{Name: "example.com", Type: "MX", Content: "10 mail example.com"}.Validate())
+	assert.NoError(t, UpdateDNSRecordParams{Name: "_sip._tcp.example.com", Type: "SRV", Content: "10 5 5060 sip.example.com", TTL: TTLAuto}.Validate())
+	assert.Error(t, UpdateDNSRecordParams{Name: "example.com", Type: "CAA", Content: "0 issue"}.Validate())
+}
@@ -16,6 +16,8 @@ limitations under the License.
 
 package models
 
+import "time"
+
 // Zone describes a DNS zone.
 type Zone struct {
 	ID          string   `json:"id,omitempty"`
@@ -23,3 +25,45 @@ type Zone struct {
 	NameServers []string `json:"name_servers,omitempty"`
 	Status      string   `json:"status,omitempty"`
 }
+
+// SOA describes the start of authority record of a zone. Times are in seconds.
+type SOA struct {
+	Zone    string `json:"zone,omitempty"`
+	MName   string `json:"mname"`
+	RName   string `json:"rname"`
+	Serial  uint32 `json:"serial"`
+	Refresh uint32 `json:"refresh"`
+	Retry   uint32 `json:"retry"`
+	Expire  uint32 `json:"expire"`
+	Minimum uint32 `json:"minimum"`
+	TTL     uint32 `json:"ttl,omitempty"`
+}
+
+// DNSSEC states of a zone.
+const (
+	DNSSECActive          = "active"
+	DNSSECPending         = "pending"
+	DNSSECDisabled        = "disabled"
+	DNSSECPendingDisabled = "pending-disabled"
+)
+
+// DNSSECStatus describes the DNSSEC signing of a zone. Once the zone is signed,
+// the DS record is to be added at the registrar to complete the chain of trust.
+type DNSSECStatus struct {
+	Zone       string    `json:"zone,omitempty"`
+	Status     string    `json:"status"`
+	DS         string    `json:"ds,omitempty"`
+	KeyTag     int       `json:"key_tag,omitempty"`
+	Algorithm  string    `json:"algorithm,omitempty"`
+	DigestType string    `json:"digest_type,omitempty"`
+	Digest     string    `json:"digest,omitempty"`
+	Flags      int       `json:"flags,omitempty"`
+	PublicKey  string    `json:"public_key,omitempty"`
+	ModifiedOn time.Time `json:"modified_on,omitzero"`
+}
+
+// Signed reports whether the zone is signed or about to be, i.e. whether the DS
+// record belongs at the registrar.
+func (s DNSSECStatus) Signed() bool {
+	return s.Status == DNSSECActive || s.Status == DNSSECPending
+}
@@ -0,0 +1,169 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prettyprint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+)
+
+// defaultRecordFields is the order of record fields used when no fields are selected.
+var defaultRecordFields = []string{"id", "name", "ttl", "type", "proxied", "content"}
+
+// recordFieldTitles holds human-readable titles for record fields.
+var recordFieldTitles = map[string]string{
+	"id":          "ID",
+	"name":        "Name",
+	"ttl":         "TTL",
+	"type":        "Type",
+	"proxied":     "Proxied",
+	"content":     "Content",
+	"priority":    "Priority",
+	"comment":     "Comment",
+	"tags":        "Tags",
+	"modified_on": "Modified",
+}
+
+// recordFieldIndex maps JSON names of models.DNSRecord fields to their struct field index.
+var recordFieldIndex = func() map[string]int {
+	idx := make(map[string]int)
+	t := reflect.TypeOf(models.DNSRecord{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		idx[name] = i
+	}
+	return idx
+}()
+
+// NormalizeFields validates field names against models.DNSRecord and returns them
+// lowercased and trimmed, preserving the given order.
+func NormalizeFields(fields []string) ([]string, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	normalized := make([]string, 0, len(fields))
+	for _, f := range fields {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if f == "" {
+			continue
+		}
+		if _, ok := recordFieldIndex[f]; !ok {
+			return nil, fmt.Errorf("unknown field %q (available fields: %s)", f, strings.Join(defaultRecordFields, ", "))
+		}
+		normalized = append(normalized, f)
+	}
+
+	return normalized, nil
+}
+
+// fieldValue is a single named value of a projected record.
+type fieldValue struct {
+	name  string
+	value any
+}
+
+// projection is an ordered subset of record fields.
+type projection []fieldValue
+
+// MarshalJSON encodes projection as a JSON object keeping the fields order.
+func (p projection) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, fv := range p {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(fv.name)
+		if err != nil {
+			return nil, err
+		}
+		val, err := json.Marshal(fv.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// projectRecord returns values of the given fields of rr in the given order.
+// If fields is empty, the default set of fields is used.
+func projectRecord(rr models.DNSRecord, fields []string) projection {
+	if len(fields) == 0 {
+		fields = defaultRecordFields
+	}
+
+	v := reflect.ValueOf(rr)
+	p := make(projection, 0, len(fields))
+	for _, f := range fields {
+		i, ok := recordFieldIndex[f]
+		if !ok {
+			continue
+		}
+		p = append(p, fieldValue{name: f, value: v.Field(i).Interface()})
+	}
+
+	return p
+}
+
+// selectRecordFields returns a copy of rr with only the given fields set, the
+// others are zero. If fields is empty, rr is returned as is. Printers that need
+// a models.DNSRecord, such as templates, use it to honor the selected fields.
+func selectRecordFields(rr models.DNSRecord, fields []string) models.DNSRecord {
+	if len(fields) == 0 {
+		return rr
+	}
+
+	var out models.DNSRecord
+	src, dst := reflect.ValueOf(rr), reflect.ValueOf(&out).Elem()
+	for _, f := range fields {
+		if i, ok := recordFieldIndex[f]; ok {
+			dst.Field(i).Set(src.Field(i))
+		}
+	}
+
+	return out
+}
+
+// projectRecords applies projectRecord to every record of rrset.
+func projectRecords(rrset []models.DNSRecord, fields []string) []projection {
+	out := make([]projection, 0, len(rrset))
+	for _, rr := range rrset {
+		out = append(out, projectRecord(rr, fields))
+	}
+	return out
+}
+
+// fieldTitle returns a human-readable title for a record field.
+func fieldTitle(name string) string {
+	if title, ok := recordFieldTitles[name]; ok {
+		return title
+	}
+	return name
+}
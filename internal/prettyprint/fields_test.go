@@ -0,0 +1,211 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prettyprint
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  []string
+		want    []string
+		wantErr string
+	}{
+		{
+			name:   "empty",
+			fields: nil,
+			want:   nil,
+		},
+		{
+			name:   "keeps order",
+			fields: []string{"content", "name", "ttl"},
+			want:   []string{"content", "name", "ttl"},
+		},
+		{
+			name:   "case and spaces",
+			fields: []string{" Name", "TTL ", ""},
+			want:   []string{"name", "ttl"},
+		},
+		{
+			name:    "unknown field",
+			fields:  []string{"name", "weight"},
+			wantErr: `unknown field "weight" (available fields: id, name, ttl, type, proxied, content)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeFields(tt.fields)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestProjectRecord(t *testing.T) {
+	rr := models.DNSRecord{
+		ID:      "1",
+		Name:    "www.example.com",
+		TTL:     300,
+		Type:    "A",
+		Content: "192.0.2.1",
+	}
+
+	p := projectRecord(rr, []string{"content", "ttl", "name"})
+	require.Len(t, p, 3)
+	assert.Equal(t, fieldValue{name: "content", value: "192.0.2.1"}, p[0])
+	assert.Equal(t, fieldValue{name: "ttl", value: 300}, p[1])
+	assert.Equal(t, fieldValue{name: "name", value: "www.example.com"}, p[2])
+
+	// Default fields
+	p = projectRecord(rr, nil)
+	names := make([]string, 0, len(p))
+	for _, fv := range p {
+		names = append(names, fv.name)
+	}
+	assert.Equal(t, defaultRecordFields, names)
+}
+
+func TestProjection_MarshalJSON(t *testing.T) {
+	rr := models.DNSRecord{Name: "www.example.com", TTL: 300, Content: "192.0.2.1"}
+
+	j, err := json.Marshal(projectRecord(rr, []string{"ttl", "proxied", "name"}))
+	require.NoError(t, err)
+	// Zero values of selected fields are kept and the order is preserved.
+	assert.Equal(t, `{"ttl":300,"proxied":false,"name":"www.example.com"}`, string(j))
+}
+
+func TestPrinters_WithFields(t *testing.T) {
+	rrset := []models.DNSRecord{
+		{ID: "1", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1"},
+		{ID: "2", Name: "api.example.com", TTL: 60, Type: "A", Content: "192.0.2.2"},
+	}
+	fields := []string{"name", "content"}
+
+	out := captureStdout(t, func() {
+		New(FormatJSON, WithFields(fields)).RecordsList(rrset)
+	})
+	assert.Equal(t, `[{"name":"www.example.com","content":"192.0.2.1"},{"name":"api.example.com","content":"192.0.2.2"}]`+"\n", out)
+
+	out = captureStdout(t, func() {
+		New(FormatJSONL, WithFields(fields)).RecordsList(rrset)
+	})
+	assert.Equal(t, `{"name":"www.example.com","content":"192.0.2.1"}`+"\n"+`{"name":"api.example.com","content":"192.0.2.2"}`+"\n", out)
+
+	out = captureStdout(t, func() {
+		New(FormatText, WithFields(fields)).RecordsList(rrset)
+	})
+	assert.Equal(t, "Name             Content\n"+
+		"--------------------------\n"+
+		"www.example.com  192.0.2.1\n"+
+		"api.example.com  192.0.2.2\n", out)
+
+	out = captureStdout(t, func() {
+		New(FormatText, WithFields([]string{"ttl", "name"})).RecordInfo(rrset[0])
+	})
+	assert.Equal(t, "TTL: 300\nName: www.example.com\n", out)
+}
+
+func TestSelectRecordFields(t *testing.T) {
+	rr := models.DNSRecord{ID: "1", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1", Comment: "web"}
+
+	assert.Equal(t, rr, selectRecordFields(rr, nil))
+	assert.Equal(t, models.DNSRecord{Name: "www.example.com", TTL: 300}, selectRecordFields(rr, []string{"ttl", "name"}))
+}
+
+// Every printer prints the same fields of records for the same selection.
+func TestPrinters_FieldsConsistent(t *testing.T) {
+	rrset := []models.DNSRecord{
+		{ID: "1", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1", Comment: "web"},
+		{ID: "2", Name: "api.example.com", TTL: 60, Type: "AAAA", Content: "2001:db8::1"},
+	}
+	fields := []string{"ttl", "name"}
+
+	tmpl, err := ParseTemplate(`{{.Name}}|{{.TTL}}|{{.Type}}|{{.Content}}|{{.Comment}}`)
+	require.NoError(t, err)
+
+	tests := []struct {
+		format   OutputFormat
+		wantInfo string
+		wantList string
+	}{
+		{
+			format:   FormatText,
+			wantInfo: "TTL: 300\nName: www.example.com\n",
+			wantList: "TTL  Name\n" +
+				"--------------------\n" +
+				"300  www.example.com\n" +
+				"60   api.example.com\n",
+		},
+		{
+			format:   FormatJSON,
+			wantInfo: `{"ttl":300,"name":"www.example.com"}` + "\n",
+			wantList: `[{"ttl":300,"name":"www.example.com"},{"ttl":60,"name":"api.example.com"}]` + "\n",
+		},
+		{
+			format:   FormatJSONL,
+			wantInfo: `{"ttl":300,"name":"www.example.com"}` + "\n",
+			wantList: `{"ttl":300,"name":"www.example.com"}` + "\n" + `{"ttl":60,"name":"api.example.com"}` + "\n",
+		},
+		{
+			format: FormatMarkdown,
+			wantInfo: "| TTL | Name            |\n" +
+				"| --- | --------------- |\n" +
+				"| 300 | www.example.com |\n",
+			wantList: "| TTL | Name            |\n" +
+				"| --- | --------------- |\n" +
+				"| 300 | www.example.com |\n" +
+				"| 60  | api.example.com |\n",
+		},
+		{
+			format:   FormatTemplate,
+			wantInfo: "www.example.com|300|||\n",
+			wantList: "www.example.com|300|||\napi.example.com|60|||\n",
+		},
+		{format: FormatNone},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		p := New(tt.format, WithWriter(&buf), WithFields(fields), WithTemplate(tmpl))
+
+		p.RecordInfo(rrset[0])
+		assert.Equal(t, tt.wantInfo, buf.String(), "RecordInfo in format %d", tt.format)
+
+		buf.Reset()
+		p.RecordsList(rrset)
+		assert.Equal(t, tt.wantList, buf.String(), "RecordsList in format %d", tt.format)
+	}
+}
+
+func TestJSONPrinter_RecordsList_Empty(t *testing.T) {
+	for _, fields := range [][]string{nil, {"name"}} {
+		var buf bytes.Buffer
+		New(FormatJSON, WithWriter(&buf), WithFields(fields)).RecordsList(nil)
+		assert.Equal(t, "[]\n", buf.String(), "fields %v", fields)
+	}
+}
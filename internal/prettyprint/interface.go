@@ -35,4 +35,16 @@ type PrettyPrinter interface {
 	RecordDel(rr models.DNSRecord)
 	// RecordUpdate displays information about an updated DNS resource record.
 	RecordUpdate(rr models.DNSRecord)
+	// ProvidersList prints list of configured providers.
+	ProvidersList(providers []models.ProviderInfo)
+	// ProviderTypesList prints list of supported provider types.
+	ProviderTypesList(types []string)
+	// AccountInfo displays information about the account a provider is authenticated as.
+	AccountInfo(info models.AccountInfo)
+	// SOA displays the SOA record of a zone.
+	SOA(soa models.SOA)
+	// DNSSEC displays the DNSSEC status of a zone.
+	DNSSEC(status models.DNSSECStatus)
+	// BatchSummary displays the outcome of a bulk command.
+	BatchSummary(result models.BatchResult)
 }
@@ -19,12 +19,20 @@ package prettyprint
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 
 	"github.com/mixanemca/cdnscli/internal/models"
 )
 
+// Ensure that JSONPrinter fulfils the PrettyPrinter interface at compile time.
+var _ PrettyPrinter = (*JSONPrinter)(nil)
+
 // JSONPrinter prints in JSON format.
-type JSONPrinter struct{}
+type JSONPrinter struct {
+	fields []string
+	w      io.Writer
+	indent bool // indent the output instead of printing compact JSON
+}
 
 // ZonesList prints list of DNS zones.
 func (pp *JSONPrinter) ZonesList(zones []models.Zone, providerName string) {
@@ -40,32 +48,91 @@ func (pp *JSONPrinter) ZonesList(zones []models.Zone, providerName string) {
 			Provider: providerName,
 		}
 	}
-	fmt.Println(marshalJSON(zonesWithProvider))
+	fmt.Fprintln(pp.w, pp.marshal(zonesWithProvider))
 }
 
 // RecordsList prints list of DNS resource records.
 func (pp *JSONPrinter) RecordsList(rrset []models.DNSRecord) {
-	fmt.Println(marshalJSON(rrset))
+	fmt.Fprintln(pp.w, pp.marshal(pp.records(rrset)))
 }
 
 // RecordInfo displays information about a specified DNS resource record.
 func (pp *JSONPrinter) RecordInfo(rr models.DNSRecord) {
-	fmt.Println(marshalJSON(rr))
+	fmt.Fprintln(pp.w, pp.marshal(pp.record(rr)))
 }
 
 // RecordAdd displays information about a new DNS resource record.
 func (pp *JSONPrinter) RecordAdd(rr models.DNSRecord) {
-	fmt.Println(marshalJSON(rr))
+	fmt.Fprintln(pp.w, pp.marshal(pp.record(rr)))
 }
 
 // RecordDel displays information about a deleted DNS recource record.
 func (pp *JSONPrinter) RecordDel(rr models.DNSRecord) {
-	fmt.Println(marshalJSON(rr))
+	fmt.Fprintln(pp.w, pp.marshal(pp.record(rr)))
 }
 
 // RecordUpdate displays information about an updated DNS resource record.
 func (pp *JSONPrinter) RecordUpdate(rr models.DNSRecord) {
-	fmt.Println(marshalJSON(rr))
+	fmt.Fprintln(pp.w, pp.marshal(pp.record(rr)))
+}
+
+// ProvidersList prints list of configured providers.
+func (pp *JSONPrinter) ProvidersList(providers []models.ProviderInfo) {
+	fmt.Fprintln(pp.w, pp.marshal(providers))
+}
+
+// ProviderTypesList prints list of supported provider types.
+func (pp *JSONPrinter) ProviderTypesList(types []string) {
+	fmt.Fprintln(pp.w, pp.marshal(types))
+}
+
+// AccountInfo displays information about the account a provider is authenticated as.
+func (pp *JSONPrinter) AccountInfo(info models.AccountInfo) {
+	fmt.Fprintln(pp.w, pp.marshal(info))
+}
+
+// SOA displays the SOA record of a zone.
+func (pp *JSONPrinter) SOA(soa models.SOA) {
+	fmt.Fprintln(pp.w, pp.marshal(soa))
+}
+
+// DNSSEC displays the DNSSEC status of a zone.
+func (pp *JSONPrinter) DNSSEC(status models.DNSSECStatus) {
+	fmt.Fprintln(pp.w, pp.marshal(status))
+}
+
+// BatchSummary displays the outcome of a bulk command.
+func (pp *JSONPrinter) BatchSummary(result models.BatchResult) {
+	fmt.Fprintln(pp.w, pp.marshal(result))
+}
+
+// records returns rrset restricted to the selected fields, if any. No records
+// are an empty array rather than null, with or without selected fields.
+func (pp *JSONPrinter) records(rrset []models.DNSRecord) any {
+	if len(pp.fields) == 0 {
+		if rrset == nil {
+			return []models.DNSRecord{}
+		}
+		return rrset
+	}
+	return projectRecords(rrset, pp.fields)
+}
+
+// record returns rr restricted to the selected fields, if any.
+func (pp *JSONPrinter) record(rr models.DNSRecord) any {
+	if len(pp.fields) == 0 {
+		return rr
+	}
+	return projectRecord(rr, pp.fields)
+}
+
+// marshal returns v as indented or compact JSON, depending on the printer.
+func (pp *JSONPrinter) marshal(v any) string {
+	if !pp.indent {
+		return marshalJSON(v)
+	}
+	j, _ := json.MarshalIndent(v, "", "  ")
+	return string(j)
 }
 
 func marshalJSON(v any) string {
@@ -0,0 +1,117 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prettyprint
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+)
+
+// Ensure that JSONLPrinter fulfils the PrettyPrinter interface at compile time.
+var _ PrettyPrinter = (*JSONLPrinter)(nil)
+
+// JSONLPrinter prints in JSON lines format: one compact JSON object per line.
+// Useful for streaming large outputs into tools like jq or grep.
+type JSONLPrinter struct {
+	fields []string
+	w      io.Writer
+}
+
+// ZonesList prints list of DNS zones.
+func (pp *JSONLPrinter) ZonesList(zones []models.Zone, providerName string) {
+	type ZoneWithProvider struct {
+		models.Zone
+		Provider string `json:"provider"`
+	}
+	for _, z := range zones {
+		fmt.Fprintln(pp.w, marshalJSON(ZoneWithProvider{
+			Zone:     z,
+			Provider: providerName,
+		}))
+	}
+}
+
+// RecordsList prints list of DNS resource records.
+func (pp *JSONLPrinter) RecordsList(rrset []models.DNSRecord) {
+	for _, rr := range rrset {
+		fmt.Fprintln(pp.w, marshalJSON(pp.record(rr)))
+	}
+}
+
+// RecordInfo displays information about a specified DNS resource record.
+func (pp *JSONLPrinter) RecordInfo(rr models.DNSRecord) {
+	fmt.Fprintln(pp.w, marshalJSON(pp.record(rr)))
+}
+
+// RecordAdd displays information about a new DNS resource record.
+func (pp *JSONLPrinter) RecordAdd(rr models.DNSRecord) {
+	fmt.Fprintln(pp.w, marshalJSON(pp.record(rr)))
+}
+
+// RecordDel displays information about a deleted DNS recource record.
+func (pp *JSONLPrinter) RecordDel(rr models.DNSRecord) {
+	fmt.Fprintln(pp.w, marshalJSON(pp.record(rr)))
+}
+
+// RecordUpdate displays information about an updated DNS resource record.
+func (pp *JSONLPrinter) RecordUpdate(rr models.DNSRecord) {
+	fmt.Fprintln(pp.w, marshalJSON(pp.record(rr)))
+}
+
+// ProvidersList prints list of configured providers.
+func (pp *JSONLPrinter) ProvidersList(providers []models.ProviderInfo) {
+	for _, p := range providers {
+		fmt.Fprintln(pp.w, marshalJSON(p))
+	}
+}
+
+// ProviderTypesList prints list of supported provider types.
+func (pp *JSONLPrinter) ProviderTypesList(types []string) {
+	for _, t := range types {
+		fmt.Fprintln(pp.w, marshalJSON(t))
+	}
+}
+
+// AccountInfo displays information about the account a provider is authenticated as.
+func (pp *JSONLPrinter) AccountInfo(info models.AccountInfo) {
+	fmt.Fprintln(pp.w, marshalJSON(info))
+}
+
+// SOA displays the SOA record of a zone.
+func (pp *JSONLPrinter) SOA(soa models.SOA) {
+	fmt.Fprintln(pp.w, marshalJSON(soa))
+}
+
+// DNSSEC displays the DNSSEC status of a zone.
+func (pp *JSONLPrinter) DNSSEC(status models.DNSSECStatus) {
+	fmt.Fprintln(pp.w, marshalJSON(status))
+}
+
+// BatchSummary displays the outcome of a bulk command.
+func (pp *JSONLPrinter) BatchSummary(result models.BatchResult) {
+	fmt.Fprintln(pp.w, marshalJSON(result))
+}
+
+// record returns rr restricted to the selected fields, if any.
+func (pp *JSONLPrinter) record(rr models.DNSRecord) any {
+	if len(pp.fields) == 0 {
+		return rr
+	}
+	return projectRecord(rr, pp.fields)
+}
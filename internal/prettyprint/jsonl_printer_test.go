@@ -0,0 +1,81 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prettyprint
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONLPrinter_RecordsList(t *testing.T) {
+	rrset := []models.DNSRecord{
+		{ID: "1", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1"},
+		{ID: "2", Name: "api.example.com", TTL: 300, Type: "A", Content: "192.0.2.2"},
+		{ID: "3", Name: "blog.example.com", TTL: 1, Type: "CNAME", Content: "example.github.io", Proxied: true},
+	}
+
+	out := captureStdout(t, func() {
+		New(FormatJSONL).RecordsList(rrset)
+	})
+
+	require.True(t, strings.HasSuffix(out, "\n"))
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	require.Len(t, lines, len(rrset))
+
+	for i, line := range lines {
+		var rr models.DNSRecord
+		require.NoError(t, json.Unmarshal([]byte(line), &rr))
+		assert.Equal(t, rrset[i], rr)
+	}
+}
+
+func TestJSONLPrinter_RecordsList_Empty(t *testing.T) {
+	out := captureStdout(t, func() {
+		New(FormatJSONL).RecordsList(nil)
+	})
+
+	assert.Empty(t, out)
+}
+
+func TestJSONLPrinter_ZonesList(t *testing.T) {
+	zones := []models.Zone{
+		{ID: "1", Name: "example.com"},
+		{ID: "2", Name: "example.org"},
+	}
+
+	out := captureStdout(t, func() {
+		New(FormatJSONL).ZonesList(zones, "Cloudflare")
+	})
+
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	require.Len(t, lines, len(zones))
+
+	for i, line := range lines {
+		var z struct {
+			models.Zone
+			Provider string `json:"provider"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(line), &z))
+		assert.Equal(t, zones[i], z.Zone)
+		assert.Equal(t, "Cloudflare", z.Provider)
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prettyprint
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+)
+
+// Ensure that MarkdownPrinter fulfils the PrettyPrinter interface at compile time.
+var _ PrettyPrinter = (*MarkdownPrinter)(nil)
+
+// MarkdownPrinter prints zones and records as GitHub-flavored Markdown tables,
+// for pasting into documents and pull requests. Everything else is printed as
+// by TextPrinter.
+type MarkdownPrinter struct {
+	*TextPrinter
+	fields []string
+	w      io.Writer
+}
+
+// ZonesList prints list of DNS zones.
+func (pp *MarkdownPrinter) ZonesList(zones []models.Zone, providerName string) {
+	if len(zones) == 0 {
+		fmt.Fprintln(pp.w, "No zones found")
+		return
+	}
+
+	rows := make([][]string, len(zones))
+	for i, z := range zones {
+		rows[i] = []string{z.ID, models.NameToUnicode(z.Name), strings.Join(z.NameServers, ", "), z.Status, providerName}
+	}
+
+	printMarkdownTable(pp.w, []string{"ID", "Name", "NS", "Status", "Provider"}, rows)
+}
+
+// RecordsList prints list of DNS resource records. Unlike the text table, the
+// content is never truncated.
+func (pp *MarkdownPrinter) RecordsList(rrset []models.DNSRecord) {
+	if len(rrset) == 0 {
+		fmt.Fprintln(pp.w, "No records found")
+		return
+	}
+
+	pp.records(rrset)
+}
+
+// RecordInfo displays information about a specified DNS resource record as a table of one row.
+func (pp *MarkdownPrinter) RecordInfo(rr models.DNSRecord) {
+	pp.records([]models.DNSRecord{rr})
+}
+
+// records prints rrset as a table of the selected fields.
+func (pp *MarkdownPrinter) records(rrset []models.DNSRecord) {
+	rows := projectRecords(rrset, pp.fields)
+
+	titles := make([]string, len(rows[0]))
+	for i, fv := range rows[0] {
+		titles[i] = fieldTitle(fv.name)
+	}
+
+	cells := make([][]string, len(rows))
+	for r, row := range rows {
+		cells[r] = make([]string, len(row))
+		for i, fv := range row {
+			cells[r][i] = textValue(fv)
+		}
+	}
+
+	printMarkdownTable(pp.w, titles, cells)
+}
+
+// printMarkdownTable writes rows to w as a Markdown table. The columns are
+// padded to line up, so the table reads well before it is rendered too.
+func printMarkdownTable(w io.Writer, titles []string, rows [][]string) {
+	escaped := make([][]string, 0, len(rows)+1)
+	for _, row := range append([][]string{titles}, rows...) {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = escapeMarkdownCell(cell)
+		}
+		escaped = append(escaped, cells)
+	}
+
+	// The delimiter row needs at least three dashes
+	widths := make([]int, len(titles))
+	for i := range widths {
+		widths[i] = 3
+	}
+	for _, row := range escaped {
+		for i, cell := range row {
+			if l := utf8.RuneCountInString(cell); l > widths[i] {
+				widths[i] = l
+			}
+		}
+	}
+
+	delimiter := make([]string, len(widths))
+	for i, width := range widths {
+		delimiter[i] = strings.Repeat("-", width)
+	}
+
+	fmt.Fprint(w, markdownRow(escaped[0], widths))
+	fmt.Fprint(w, markdownRow(delimiter, widths))
+	for _, row := range escaped[1:] {
+		fmt.Fprint(w, markdownRow(row, widths))
+	}
+}
+
+// markdownRow formats cells as a table row, padded to the given widths.
+func markdownRow(cells []string, widths []int) string {
+	var b strings.Builder
+	b.WriteString("|")
+	for i, cell := range cells {
+		b.WriteString(" " + cell + strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)) + " |")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// escapeMarkdownCell escapes pipes, which would end the cell, and replaces
+// line breaks, which would end the row.
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, `|`, `\|`)
+	return strings.NewReplacer("\r\n", "<br>", "\n", "<br>", "\r", "<br>").Replace(s)
+}
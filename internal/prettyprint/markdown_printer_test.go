@@ -0,0 +1,67 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prettyprint
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownPrinter_RecordsList(t *testing.T) {
+	rrset := []models.DNSRecord{
+		{ID: "372e67954025e0ba6aaa6d586b9e0b59", Name: "example.com", TTL: 1, Type: "A", Content: "192.0.2.1", Proxied: true},
+		{ID: "5f8e2b1a", Name: "www.xn--mnchen-3ya.de", TTL: 300, Type: "CNAME", Content: "example.com"},
+		{ID: "9c0d", Name: "example.com", TTL: 3600, Type: "TXT", Content: "v=spf1 include:_spf.example.com include:_spf.example.net include:_spf.example.org ~all"},
+		{ID: "a1b2", Name: "_pipes.example.com", TTL: 300, Type: "TXT", Content: "a|b\nc"},
+	}
+
+	var buf bytes.Buffer
+	New(FormatMarkdown, WithWriter(&buf)).RecordsList(rrset)
+
+	assertGolden(t, "records_list_markdown", buf.String())
+}
+
+func TestMarkdownPrinter_ZonesList(t *testing.T) {
+	var buf bytes.Buffer
+	New(FormatMarkdown, WithWriter(&buf)).ZonesList([]models.Zone{
+		{ID: "1", Name: "xn--mnchen-3ya.de", Status: "active", NameServers: []string{"ns1.example.net", "ns2.example.net"}},
+		{ID: "23", Name: "example.com", Status: "pending"},
+	}, "Cloudflare")
+
+	assert.Equal(t, "| ID  | Name        | NS                               | Status  | Provider   |\n"+
+		"| --- | ----------- | -------------------------------- | ------- | ---------- |\n"+
+		"| 1   | münchen.de  | ns1.example.net, ns2.example.net | active  | Cloudflare |\n"+
+		"| 23  | example.com |                                  | pending | Cloudflare |\n", buf.String())
+}
+
+func TestMarkdownPrinter_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	p := New(FormatMarkdown, WithWriter(&buf))
+
+	p.ZonesList(nil, "Cloudflare")
+	p.RecordsList(nil)
+	assert.Equal(t, "No zones found\nNo records found\n", buf.String())
+}
+
+func TestEscapeMarkdownCell(t *testing.T) {
+	assert.Equal(t, "plain", escapeMarkdownCell("plain"))
+	assert.Equal(t, `a\|b\|c`, escapeMarkdownCell("a|b|c"))
+	assert.Equal(t, "one<br>two<br>three", escapeMarkdownCell("one\ntwo\r\nthree"))
+}
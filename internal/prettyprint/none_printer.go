@@ -20,6 +20,9 @@ import (
 	"github.com/mixanemca/cdnscli/internal/models"
 )
 
+// Ensure that NonePrinter fulfils the PrettyPrinter interface at compile time.
+var _ PrettyPrinter = (*NonePrinter)(nil)
+
 // NonePrinter don't print enythings. Use for scripts when output not needed.
 type NonePrinter struct{}
 
@@ -40,3 +43,21 @@ func (pp *NonePrinter) RecordDel(rr models.DNSRecord) {}
 
 // RecordUpdate displays information about an updated DNS resource record.
 func (pp *NonePrinter) RecordUpdate(rr models.DNSRecord) {}
+
+// ProvidersList prints list of configured providers.
+func (pp *NonePrinter) ProvidersList(providers []models.ProviderInfo) {}
+
+// ProviderTypesList prints list of supported provider types.
+func (pp *NonePrinter) ProviderTypesList(types []string) {}
+
+// AccountInfo displays information about the account a provider is authenticated as.
+func (pp *NonePrinter) AccountInfo(info models.AccountInfo) {}
+
+// SOA displays the SOA record of a zone.
+func (pp *NonePrinter) SOA(soa models.SOA) {}
+
+// DNSSEC displays the DNSSEC status of a zone.
+func (pp *NonePrinter) DNSSEC(status models.DNSSECStatus) {}
+
+// BatchSummary displays the outcome of a bulk command.
+func (pp *NonePrinter) BatchSummary(result models.BatchResult) {}
@@ -16,6 +16,12 @@ limitations under the License.
 
 package prettyprint
 
+import (
+	"io"
+	"os"
+	"text/template"
+)
+
 const (
 	// FormatText format for human-readable output.
 	FormatText OutputFormat = iota
@@ -23,20 +29,82 @@ const (
 	FormatJSON
 	// FormatNone format for discarding output.
 	FormatNone
+	// FormatJSONL format for output in JSON lines (one object per line).
+	FormatJSONL
+	// FormatTemplate format for output through a Go text/template, see WithTemplate.
+	FormatTemplate
+	// FormatMarkdown format for output as GitHub-flavored Markdown tables.
+	FormatMarkdown
 )
 
 // OutputFormat holds supported output formats.
 type OutputFormat uint
 
-// New constructs a new TextPrinter
-func New(output OutputFormat) PrettyPrinter {
+// Option configures a PrettyPrinter.
+type Option func(o *options)
+
+type options struct {
+	fields []string
+	w      io.Writer
+	indent bool
+	tmpl   *template.Template
+}
+
+// WithFields restricts records output to the given fields in the given order.
+// Field names must be normalized with NormalizeFields.
+func WithFields(fields []string) Option {
+	return func(o *options) {
+		o.fields = fields
+	}
+}
+
+// WithWriter makes the printer write to w instead of os.Stdout.
+// A nil writer is ignored.
+func WithWriter(w io.Writer) Option {
+	return func(o *options) {
+		if w != nil {
+			o.w = w
+		}
+	}
+}
+
+// WithIndent makes the JSON printer indent its output for humans.
+// Other formats, including JSON lines, stay compact.
+func WithIndent(indent bool) Option {
+	return func(o *options) {
+		o.indent = indent
+	}
+}
+
+// WithTemplate sets the template FormatTemplate prints with, see ParseTemplate.
+func WithTemplate(tmpl *template.Template) Option {
+	return func(o *options) {
+		o.tmpl = tmpl
+	}
+}
+
+// New constructs a new PrettyPrinter for the given output format.
+func New(output OutputFormat, opts ...Option) PrettyPrinter {
+	o := options{w: os.Stdout}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	switch output {
 	case FormatText:
-		return &TextPrinter{}
+		return &TextPrinter{fields: o.fields, w: o.w}
 	case FormatJSON:
-		return &JSONPrinter{}
+		return &JSONPrinter{fields: o.fields, w: o.w, indent: o.indent}
 	case FormatNone:
 		return &NonePrinter{}
+	case FormatJSONL:
+		return &JSONLPrinter{fields: o.fields, w: o.w}
+	case FormatMarkdown:
+		return &MarkdownPrinter{TextPrinter: &TextPrinter{fields: o.fields, w: o.w}, fields: o.fields, w: o.w}
+	case FormatTemplate:
+		if o.tmpl != nil {
+			return &TemplatePrinter{fields: o.fields, tmpl: o.tmpl, w: o.w}
+		}
 	}
 
 	// This code should not be executed, but we’re keeping it just in case.
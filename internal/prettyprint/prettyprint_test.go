@@ -0,0 +1,159 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prettyprint
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureStdout runs f and returns everything it wrote to os.Stdout.
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	f()
+
+	require.NoError(t, w.Close())
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, r)
+	require.NoError(t, err)
+
+	return buf.String()
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		format OutputFormat
+		want   PrettyPrinter
+	}{
+		{format: FormatText, want: &TextPrinter{}},
+		{format: FormatJSON, want: &JSONPrinter{}},
+		{format: FormatNone, want: &NonePrinter{}},
+		{format: FormatJSONL, want: &JSONLPrinter{}},
+		{format: FormatMarkdown, want: &MarkdownPrinter{}},
+		{format: OutputFormat(255), want: &NonePrinter{}},
+	}
+	for _, tt := range tests {
+		p := New(tt.format)
+		assert.Implements(t, (*PrettyPrinter)(nil), p)
+		assert.IsType(t, tt.want, p)
+	}
+}
+
+func TestWithWriter(t *testing.T) {
+	rr := models.DNSRecord{ID: "1", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1"}
+
+	tests := []struct {
+		format OutputFormat
+		want   string
+	}{
+		{format: FormatText, want: "DNS resource record www.example.com successfully updated\n"},
+		{format: FormatJSON, want: `{"content":"192.0.2.1","id":"1","name":"www.example.com","ttl":300,"type":"A"}` + "\n"},
+		{format: FormatJSONL, want: `{"content":"192.0.2.1","id":"1","name":"www.example.com","ttl":300,"type":"A"}` + "\n"},
+		{format: FormatNone, want: ""},
+		{format: FormatMarkdown, want: "DNS resource record www.example.com successfully updated\n"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		out := captureStdout(t, func() {
+			New(tt.format, WithWriter(&buf)).RecordUpdate(rr)
+		})
+
+		assert.Equal(t, tt.want, buf.String())
+		assert.Empty(t, out)
+	}
+}
+
+func TestWithWriter_Table(t *testing.T) {
+	var buf bytes.Buffer
+	New(FormatText, WithWriter(&buf)).ProvidersList([]models.ProviderInfo{
+		{Name: "cloudflare", Type: "cloudflare", DisplayName: "Cloudflare", Default: true},
+	})
+
+	assert.Equal(t, "Name        Type        Display Name  Default\n"+
+		strings.Repeat("-", 45)+"\n"+
+		"cloudflare  cloudflare  Cloudflare    yes\n", buf.String())
+}
+
+func TestPrinters_BatchSummary(t *testing.T) {
+	result := models.BatchResult{Created: 2, Failed: 1, Elapsed: 1500 * time.Millisecond}
+
+	tests := []struct {
+		format OutputFormat
+		want   string
+	}{
+		{format: FormatText, want: "2 created, 0 updated, 0 deleted, 1 failed in 1.5s\n"},
+		{format: FormatJSON, want: `{"created":2,"updated":0,"deleted":0,"not_found":0,"failed":1,"elapsed_seconds":1.5}` + "\n"},
+		{format: FormatJSONL, want: `{"created":2,"updated":0,"deleted":0,"not_found":0,"failed":1,"elapsed_seconds":1.5}` + "\n"},
+		{format: FormatNone, want: ""},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		New(tt.format, WithWriter(&buf)).BatchSummary(result)
+		assert.Equal(t, tt.want, buf.String())
+	}
+}
+
+func TestWithIndent(t *testing.T) {
+	rr := models.DNSRecord{ID: "1", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1"}
+
+	tests := []struct {
+		format OutputFormat
+		indent bool
+		want   string
+	}{
+		{format: FormatJSON, indent: false, want: `{"content":"192.0.2.1","id":"1","name":"www.example.com","ttl":300,"type":"A"}` + "\n"},
+		{format: FormatJSON, indent: true, want: "{\n" +
+			`  "content": "192.0.2.1",` + "\n" +
+			`  "id": "1",` + "\n" +
+			`  "name": "www.example.com",` + "\n" +
+			`  "ttl": 300,` + "\n" +
+			`  "type": "A"` + "\n" +
+			"}\n"},
+		// JSON lines need one object per line, so they are never indented
+		{format: FormatJSONL, indent: true, want: `{"content":"192.0.2.1","id":"1","name":"www.example.com","ttl":300,"type":"A"}` + "\n"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		New(tt.format, WithWriter(&buf), WithIndent(tt.indent)).RecordInfo(rr)
+		assert.Equal(t, tt.want, buf.String())
+	}
+}
+
+func TestWithIndent_Fields(t *testing.T) {
+	rr := models.DNSRecord{ID: "1", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1"}
+
+	var buf bytes.Buffer
+	New(FormatJSON, WithWriter(&buf), WithFields([]string{"name", "ttl"}), WithIndent(true)).RecordsList([]models.DNSRecord{rr})
+
+	assert.Equal(t, "[\n  {\n    \"name\": \"www.example.com\",\n    \"ttl\": 300\n  }\n]\n", buf.String())
+}
@@ -0,0 +1,132 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prettyprint
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+)
+
+// Ensure that TemplatePrinter fulfils the PrettyPrinter interface at compile time.
+var _ PrettyPrinter = (*TemplatePrinter)(nil)
+
+// TemplatePrinter prints every item through a user given Go text/template,
+// one execution per line. Records are passed as models.DNSRecord, zones as
+// models.Zone and so on, so {{.Name}} {{.Content}} prints name and content.
+// With selected fields the other fields of records are zero.
+type TemplatePrinter struct {
+	fields []string
+	tmpl   *template.Template
+	w      io.Writer
+}
+
+// ParseTemplate parses the text of a --template output template.
+func ParseTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		return nil, fmt.Errorf("output format template needs a --template")
+	}
+	tmpl, err := template.New("output").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// ZonesList prints list of DNS zones.
+func (pp *TemplatePrinter) ZonesList(zones []models.Zone, providerName string) {
+	for _, z := range zones {
+		pp.execute(z)
+	}
+}
+
+// RecordsList prints list of DNS resource records.
+func (pp *TemplatePrinter) RecordsList(rrset []models.DNSRecord) {
+	for _, rr := range rrset {
+		pp.execute(selectRecordFields(rr, pp.fields))
+	}
+}
+
+// RecordInfo displays information about a specified DNS resource record.
+func (pp *TemplatePrinter) RecordInfo(rr models.DNSRecord) {
+	pp.execute(selectRecordFields(rr, pp.fields))
+}
+
+// RecordAdd displays information about a new DNS resource record.
+func (pp *TemplatePrinter) RecordAdd(rr models.DNSRecord) {
+	pp.execute(selectRecordFields(rr, pp.fields))
+}
+
+// RecordDel displays information about a deleted DNS recource record.
+func (pp *TemplatePrinter) RecordDel(rr models.DNSRecord) {
+	pp.execute(selectRecordFields(rr, pp.fields))
+}
+
+// RecordUpdate displays information about an updated DNS resource record.
+func (pp *TemplatePrinter) RecordUpdate(rr models.DNSRecord) {
+	pp.execute(selectRecordFields(rr, pp.fields))
+}
+
+// ProvidersList prints list of configured providers.
+func (pp *TemplatePrinter) ProvidersList(providers []models.ProviderInfo) {
+	for _, p := range providers {
+		pp.execute(p)
+	}
+}
+
+// ProviderTypesList prints list of supported provider types.
+func (pp *TemplatePrinter) ProviderTypesList(types []string) {
+	for _, t := range types {
+		pp.execute(t)
+	}
+}
+
+// AccountInfo displays information about the account a provider is authenticated as.
+func (pp *TemplatePrinter) AccountInfo(info models.AccountInfo) {
+	pp.execute(info)
+}
+
+// SOA displays the SOA record of a zone.
+func (pp *TemplatePrinter) SOA(soa models.SOA) {
+	pp.execute(soa)
+}
+
+// DNSSEC displays the DNSSEC status of a zone.
+func (pp *TemplatePrinter) DNSSEC(status models.DNSSECStatus) {
+	pp.execute(status)
+}
+
+// BatchSummary displays the outcome of a bulk command.
+func (pp *TemplatePrinter) BatchSummary(result models.BatchResult) {
+	pp.execute(result)
+}
+
+// execute prints v through the template followed by a newline. A template
+// that does not fit v, e.g. {{.Content}} for a zone, is reported on STDERR
+// and nothing is printed for v.
+func (pp *TemplatePrinter) execute(v any) {
+	var buf bytes.Buffer
+	if err := pp.tmpl.Execute(&buf, v); err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: failed to execute output template: %v\n", err)
+		return
+	}
+	fmt.Fprintln(pp.w, buf.String())
+}
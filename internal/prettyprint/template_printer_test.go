@@ -0,0 +1,78 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prettyprint
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplatePrinter(t *testing.T) {
+	tmpl, err := ParseTemplate(`{{.Name}} {{.TTL}} {{.Content}}`)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	p := New(FormatTemplate, WithWriter(&buf), WithTemplate(tmpl))
+	assert.IsType(t, &TemplatePrinter{}, p)
+
+	p.RecordsList([]models.DNSRecord{
+		{ID: "1", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1"},
+		{ID: "2", Name: "mail.example.com", TTL: 600, Type: "A", Content: "192.0.2.2"},
+	})
+	p.RecordAdd(models.DNSRecord{ID: "3", Name: "api.example.com", TTL: 60, Type: "A", Content: "192.0.2.3"})
+
+	assert.Equal(t, "www.example.com 300 192.0.2.1\n"+
+		"mail.example.com 600 192.0.2.2\n"+
+		"api.example.com 60 192.0.2.3\n", buf.String())
+}
+
+func TestTemplatePrinter_Zones(t *testing.T) {
+	tmpl, err := ParseTemplate(`{{.ID}}	{{.Name}}`)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	New(FormatTemplate, WithWriter(&buf), WithTemplate(tmpl)).ZonesList([]models.Zone{
+		{ID: "42", Name: "example.com"},
+	}, "cloudflare")
+
+	assert.Equal(t, "42\texample.com\n", buf.String())
+}
+
+func TestTemplatePrinter_ExecuteError(t *testing.T) {
+	tmpl, err := ParseTemplate(`{{.Name}} {{.Content}}`)
+	require.NoError(t, err)
+
+	// Zones have no content, the error goes to STDERR and nothing is printed
+	var buf bytes.Buffer
+	New(FormatTemplate, WithWriter(&buf), WithTemplate(tmpl)).ZonesList([]models.Zone{{ID: "42", Name: "example.com"}}, "")
+	assert.Empty(t, buf.String())
+}
+
+func TestParseTemplate_Invalid(t *testing.T) {
+	_, err := ParseTemplate(`{{.Name`)
+	assert.ErrorContains(t, err, "invalid output template")
+
+	_, err = ParseTemplate("")
+	assert.ErrorContains(t, err, "needs a --template")
+
+	// Without a template there is nothing to print with
+	assert.IsType(t, &NonePrinter{}, New(FormatTemplate))
+}
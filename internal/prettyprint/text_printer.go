@@ -18,34 +18,44 @@ package prettyprint
 
 import (
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/mixanemca/cdnscli/internal/models"
 )
 
+// Ensure that TextPrinter fulfils the PrettyPrinter interface at compile time.
+var _ PrettyPrinter = (*TextPrinter)(nil)
+
 // TextPrinter prints in human-readable format.
-type TextPrinter struct{}
+type TextPrinter struct {
+	fields []string
+	w      io.Writer
+}
 
 // ZonesList prints list of DNS zones.
 func (pp *TextPrinter) ZonesList(zones []models.Zone, providerName string) {
 	if len(zones) == 0 {
-		fmt.Println("No zones found")
+		fmt.Fprintln(pp.w, "No zones found")
 		return
 	}
 
 	// Calculate column widths
-	maxIDLen := 3 // "ID"
-	maxNameLen := 4 // "Name"
-	maxNSLen := 2 // "NS"
-	maxStatusLen := 6 // "Status"
+	maxIDLen := 3       // "ID"
+	maxNameLen := 4     // "Name"
+	maxNSLen := 2       // "NS"
+	maxStatusLen := 6   // "Status"
 	maxProviderLen := 8 // "Provider"
 
 	for _, z := range zones {
 		if len(z.ID) > maxIDLen {
 			maxIDLen = len(z.ID)
 		}
-		if len(z.Name) > maxNameLen {
-			maxNameLen = len(z.Name)
+		if l := utf8.RuneCountInString(models.NameToUnicode(z.Name)); l > maxNameLen {
+			maxNameLen = l
 		}
 		nsStr := strings.Join(z.NameServers, ", ")
 		if len(nsStr) > maxNSLen {
@@ -66,72 +76,334 @@ func (pp *TextPrinter) ZonesList(zones []models.Zone, providerName string) {
 		maxNSLen, "NS",
 		maxStatusLen, "Status",
 		maxProviderLen, "Provider")
-	fmt.Print(header)
-	
+	fmt.Fprint(pp.w, header)
+
 	// Print separator
 	separator := strings.Repeat("-", len(header)-1) + "\n"
-	fmt.Print(separator)
+	fmt.Fprint(pp.w, separator)
 
 	// Print rows
 	for _, z := range zones {
 		nsStr := strings.Join(z.NameServers, ", ")
 		row := fmt.Sprintf("%-*s  %-*s  %-*s  %-*s  %-*s\n",
 			maxIDLen, z.ID,
-			maxNameLen, z.Name,
+			maxNameLen, models.NameToUnicode(z.Name),
 			maxNSLen, nsStr,
 			maxStatusLen, z.Status,
 			maxProviderLen, providerName)
-		fmt.Print(row)
+		fmt.Fprint(pp.w, row)
 	}
 }
 
 // RecordsList prints list of DNS resource records.
 func (pp *TextPrinter) RecordsList(rrset []models.DNSRecord) {
-	var fields strings.Builder
-	for _, rr := range rrset {
-		fields.WriteString(fmt.Sprintf("ID: %s\n", rr.ID))
-		fields.WriteString(fmt.Sprintf("Name: %s\n", rr.Name))
-		fields.WriteString(fmt.Sprintf("TTL: %d\n", rr.TTL))
-		fields.WriteString(fmt.Sprintf("Type: %s\n", rr.Type))
-		fields.WriteString(fmt.Sprintf("Proxied: %t\n", rr.Proxied))
-		fields.WriteString(fmt.Sprintf("Content: %s\n", rr.Content))
+	if len(rrset) == 0 {
+		fmt.Fprintln(pp.w, "No records found")
+		return
 	}
-	fmt.Print(fields.String())
+
+	rows := projectRecords(rrset, pp.fields)
+	columns := rows[0]
+
+	titles := make([]string, len(columns))
+	for i, col := range columns {
+		titles[i] = fieldTitle(col.name)
+	}
+
+	cells := make([][]string, len(rows))
+	for r, row := range rows {
+		cells[r] = make([]string, len(row))
+		for i, fv := range row {
+			cells[r][i] = formatField(fv)
+		}
+	}
+
+	printTable(pp.w, titles, cells)
 }
 
 // RecordInfo displays information about a specified DNS resource record.
 func (pp *TextPrinter) RecordInfo(rr models.DNSRecord) {
 	var fields strings.Builder
 
-	fields.WriteString(fmt.Sprintf("ID: %s\n", rr.ID))
-	fields.WriteString(fmt.Sprintf("Name: %s\n", rr.Name))
-	fields.WriteString(fmt.Sprintf("TTL: %d\n", rr.TTL))
-	fields.WriteString(fmt.Sprintf("Type: %s\n", rr.Type))
-	fields.WriteString(fmt.Sprintf("Proxied: %t\n", rr.Proxied))
-	fields.WriteString(fmt.Sprintf("Content: %s\n", rr.Content))
-
-	fmt.Print(fields.String())
+	writeRecordFields(&fields, projectRecord(rr, pp.fields))
 
+	fmt.Fprint(pp.w, fields.String())
 }
 
-// RecordAdd displays information about a new DNS resource record.
+// RecordAdd displays information about a new DNS resource record: a summary line
+// followed by the record as the provider returned it, so the TTL, content and
+// proxying that took effect can be checked. Priority, comment and tags are
+// shown when set, unless fields are selected.
 func (pp *TextPrinter) RecordAdd(rr models.DNSRecord) {
 	var fields strings.Builder
 
 	fields.WriteString(fmt.Sprintf("New resource record %q was been added with ID %q\n",
-		rr.Name,
+		models.NameToUnicode(rr.Name),
 		rr.ID,
 	))
 
-	fmt.Print(fields.String())
+	selected := pp.fields
+	if len(selected) == 0 {
+		selected = append(selected, defaultRecordFields...)
+		if rr.Priority != 0 {
+			selected = append(selected, "priority")
+		}
+		if rr.Comment != "" {
+			selected = append(selected, "comment")
+		}
+		if len(rr.Tags) > 0 {
+			selected = append(selected, "tags")
+		}
+	}
+	writeRecordFields(&fields, projectRecord(rr, selected))
+
+	fmt.Fprint(pp.w, fields.String())
+}
+
+// writeRecordFields writes the projected fields to b, one "Title: value" per line.
+func writeRecordFields(b *strings.Builder, p projection) {
+	for _, fv := range p {
+		b.WriteString(fmt.Sprintf("%s: %s\n", fieldTitle(fv.name), textValue(fv)))
+	}
 }
 
 // RecordDel displays information about a deleted DNS recource record.
 func (pp *TextPrinter) RecordDel(rr models.DNSRecord) {
-	fmt.Printf("DNS resource record %s successfully deleted\n", rr.Name)
+	fmt.Fprintf(pp.w, "DNS resource record %s successfully deleted\n", models.NameToUnicode(rr.Name))
 }
 
 // RecordUpdate displays information about an updated DNS resource record.
 func (pp *TextPrinter) RecordUpdate(rr models.DNSRecord) {
-	fmt.Printf("DNS resource record %s successfully updated\n", rr.Name)
+	fmt.Fprintf(pp.w, "DNS resource record %s successfully updated\n", models.NameToUnicode(rr.Name))
+}
+
+// AccountInfo displays information about the account a provider is authenticated as.
+func (pp *TextPrinter) AccountInfo(info models.AccountInfo) {
+	var fields strings.Builder
+
+	fields.WriteString(fmt.Sprintf("Provider: %s (%s)\n", info.Provider, info.ProviderType))
+	for _, f := range []struct{ title, value string }{
+		{"ID", info.ID},
+		{"Name", info.Name},
+		{"Email", info.Email},
+		{"Accounts", strings.Join(info.Accounts, ", ")},
+		{"Scopes", strings.Join(info.Scopes, ", ")},
+	} {
+		if f.value != "" {
+			fields.WriteString(fmt.Sprintf("%s: %s\n", f.title, f.value))
+		}
+	}
+
+	fmt.Fprint(pp.w, fields.String())
+}
+
+// SOA displays the SOA record of a zone, the times in seconds.
+func (pp *TextPrinter) SOA(soa models.SOA) {
+	var fields strings.Builder
+
+	for _, f := range []struct {
+		title string
+		value any
+	}{
+		{"Zone", models.NameToUnicode(soa.Zone)},
+		{"Primary NS", models.NameToUnicode(soa.MName)},
+		{"Responsible", soa.RName},
+		{"Serial", soa.Serial},
+		{"Refresh", soa.Refresh},
+		{"Retry", soa.Retry},
+		{"Expire", soa.Expire},
+		{"Minimum", soa.Minimum},
+		{"TTL", soa.TTL},
+	} {
+		fields.WriteString(fmt.Sprintf("%s: %v\n", f.title, f.value))
+	}
+
+	fmt.Fprint(pp.w, fields.String())
+}
+
+// DNSSEC displays the DNSSEC status of a zone and, while the zone is signed,
+// the DS record to add at the registrar.
+func (pp *TextPrinter) DNSSEC(status models.DNSSECStatus) {
+	var fields strings.Builder
+
+	fields.WriteString(fmt.Sprintf("Zone: %s\n", models.NameToUnicode(status.Zone)))
+	fields.WriteString(fmt.Sprintf("Status: %s\n", status.Status))
+	for _, f := range []struct{ title, value string }{
+		{"DS", status.DS},
+		{"Key Tag", nonZero(status.KeyTag)},
+		{"Algorithm", status.Algorithm},
+		{"Digest Type", status.DigestType},
+		{"Digest", status.Digest},
+		{"Flags", nonZero(status.Flags)},
+		{"Public Key", status.PublicKey},
+	} {
+		if f.value != "" {
+			fields.WriteString(fmt.Sprintf("%s: %s\n", f.title, f.value))
+		}
+	}
+	if !status.ModifiedOn.IsZero() {
+		fields.WriteString(fmt.Sprintf("Modified: %s\n", status.ModifiedOn.Format(time.RFC3339)))
+	}
+
+	fmt.Fprint(pp.w, fields.String())
+}
+
+// nonZero formats n, or returns an empty string for zero.
+func nonZero(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+// BatchSummary displays the outcome of a bulk command.
+func (pp *TextPrinter) BatchSummary(result models.BatchResult) {
+	fmt.Fprintln(pp.w, result)
+}
+
+// ProvidersList prints list of configured providers.
+func (pp *TextPrinter) ProvidersList(providers []models.ProviderInfo) {
+	if len(providers) == 0 {
+		fmt.Fprintln(pp.w, "No providers configured")
+		return
+	}
+
+	headers := []string{"Name", "Type", "Display Name", "Default"}
+	withCaps := providers[0].Capabilities != nil
+	if withCaps {
+		headers = append(headers, "Proxied", "Comments", "Tags", "TTL", "Record Types")
+	}
+
+	rows := make([][]string, len(providers))
+	for i, p := range providers {
+		rows[i] = []string{p.Name, p.Type, p.DisplayName, yesNo(p.Default, "")}
+		if withCaps && p.Capabilities != nil {
+			c := p.Capabilities
+			types := strings.Join(c.RecordTypes, ", ")
+			if types == "" {
+				types = "any"
+			}
+			rows[i] = append(rows[i], yesNo(c.Proxied, "no"), yesNo(c.Comments, "no"), yesNo(c.Tags, "no"), ttlBounds(*c), types)
+		}
+	}
+
+	printTable(pp.w, headers, rows)
+}
+
+// ttlBounds returns the TTL bounds of c for a table cell, e.g. 60-86400, auto.
+func ttlBounds(c models.Capabilities) string {
+	if c.MinTTL == 0 && c.MaxTTL == 0 {
+		return "any"
+	}
+
+	bounds := "0-"
+	if c.MinTTL > 0 {
+		bounds = strconv.Itoa(c.MinTTL) + "-"
+	}
+	if c.MaxTTL > 0 {
+		bounds += strconv.Itoa(c.MaxTTL)
+	}
+	if c.AutoTTL {
+		bounds += ", " + models.TTLAutoString
+	}
+	return bounds
+}
+
+// yesNo returns "yes" for true and no otherwise.
+func yesNo(b bool, no string) string {
+	if b {
+		return "yes"
+	}
+	return no
+}
+
+// ProviderTypesList prints list of supported provider types.
+func (pp *TextPrinter) ProviderTypesList(types []string) {
+	for _, t := range types {
+		fmt.Fprintln(pp.w, t)
+	}
+}
+
+// truncate shortens s to at most width runes, replacing the tail with an ellipsis.
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + "…"
+}
+
+// maxContentWidth is the maximum width of the Content column in RecordsList.
+// Longer values are truncated with an ellipsis.
+const maxContentWidth = 60
+
+// textValue formats a projected field value for display.
+// Punycode names are shown in their Unicode form, times as RFC 3339 and empty
+// when unknown.
+func textValue(fv fieldValue) string {
+	if fv.name == "name" {
+		return models.NameToUnicode(fmt.Sprint(fv.value))
+	}
+	if t, ok := fv.value.(time.Time); ok {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+	return fmt.Sprint(fv.value)
+}
+
+// formatField formats a projected field value for a table cell.
+func formatField(fv fieldValue) string {
+	s := textValue(fv)
+	if fv.name == "content" {
+		return truncate(s, maxContentWidth)
+	}
+	return s
+}
+
+// printTable writes rows to w as aligned columns under a header and a separator line.
+func printTable(w io.Writer, titles []string, rows [][]string) {
+	// Calculate column widths
+	widths := make([]int, len(titles))
+	for i, title := range titles {
+		widths[i] = utf8.RuneCountInString(title)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if l := utf8.RuneCountInString(cell); l > widths[i] {
+				widths[i] = l
+			}
+		}
+	}
+
+	// Print header
+	fmt.Fprint(w, formatRow(titles, widths))
+
+	// Print separator
+	total := 2 * (len(widths) - 1)
+	for _, w := range widths {
+		total += w
+	}
+	fmt.Fprint(w, strings.Repeat("-", total)+"\n")
+
+	// Print rows
+	for _, row := range rows {
+		fmt.Fprint(w, formatRow(row, widths))
+	}
+}
+
+// formatRow pads cells to the given widths, leaving the last cell unpadded.
+func formatRow(cells []string, widths []int) string {
+	var row strings.Builder
+	for i, cell := range cells {
+		if i == len(cells)-1 {
+			row.WriteString(cell)
+			break
+		}
+		row.WriteString(cell)
+		row.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)+2))
+	}
+	row.WriteString("\n")
+	return row.String()
 }
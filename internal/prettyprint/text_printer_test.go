@@ -0,0 +1,246 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prettyprint
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// assertGolden compares got with the content of testdata/<name>.golden.
+// Run tests with -update to rewrite golden files.
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		require.NoError(t, os.WriteFile(path, []byte(got), 0644))
+	}
+
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), got)
+}
+
+func TestTextPrinter_RecordsList(t *testing.T) {
+	rrset := []models.DNSRecord{
+		{ID: "372e67954025e0ba6aaa6d586b9e0b59", Name: "example.com", TTL: 1, Type: "A", Content: "192.0.2.1", Proxied: true},
+		{ID: "5f8e2b1a", Name: "www.example.com", TTL: 300, Type: "CNAME", Content: "example.com"},
+		{ID: "9c0d", Name: "example.com", TTL: 3600, Type: "TXT", Content: "v=spf1 include:_spf.example.com include:_spf.example.net include:_spf.example.org ~all"},
+	}
+
+	out := captureStdout(t, func() {
+		New(FormatText).RecordsList(rrset)
+	})
+
+	assertGolden(t, "records_list", out)
+}
+
+func TestTextPrinter_RecordAdd(t *testing.T) {
+	var buf bytes.Buffer
+	p := New(FormatText, WithWriter(&buf))
+	p.RecordAdd(models.DNSRecord{ID: "372e67954025e0ba6aaa6d586b9e0b59", Name: "xn--e1afmkfd.example.com", TTL: 1, Type: "A", Content: "192.0.2.1", Proxied: true})
+	p.RecordAdd(models.DNSRecord{ID: "5f8e2b1a", Name: "example.com", TTL: 3600, Type: "MX", Content: "mail.example.com", Priority: 10, Comment: "primary mail", Tags: []string{"env:prod"}})
+
+	assertGolden(t, "record_add", buf.String())
+
+	// Selected fields limit the details
+	buf.Reset()
+	New(FormatText, WithWriter(&buf), WithFields([]string{"name", "ttl"})).RecordAdd(models.DNSRecord{ID: "1", Name: "www.example.com", TTL: 300, Comment: "web"})
+	assert.Equal(t, "New resource record \"www.example.com\" was been added with ID \"1\"\nName: www.example.com\nTTL: 300\n", buf.String())
+
+	// JSON prints the whole record as returned
+	buf.Reset()
+	New(FormatJSON, WithWriter(&buf)).RecordAdd(models.DNSRecord{ID: "1", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1", Proxied: true, Comment: "web"})
+	assert.JSONEq(t, `{"id":"1","name":"www.example.com","ttl":300,"type":"A","content":"192.0.2.1","proxied":true,"comment":"web"}`, buf.String())
+}
+
+func TestTextPrinter_RecordsList_Empty(t *testing.T) {
+	out := captureStdout(t, func() {
+		New(FormatText).RecordsList(nil)
+	})
+
+	assert.Equal(t, "No records found\n", out)
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		width int
+		want  string
+	}{
+		{name: "shorter than width", in: "abc", width: 5, want: "abc"},
+		{name: "equal to width", in: "abcde", width: 5, want: "abcde"},
+		{name: "longer than width", in: "abcdef", width: 5, want: "abcd…"},
+		{name: "multibyte", in: "пример.рф", width: 4, want: "при…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, truncate(tt.in, tt.width))
+		})
+	}
+}
+
+func TestPrinters_ZonesList_ProviderName(t *testing.T) {
+	zones := []models.Zone{
+		{ID: "1", Name: "example.com", NameServers: []string{"ns1.example.net", "ns2.example.net"}, Status: "active"},
+	}
+
+	for _, format := range []OutputFormat{FormatText, FormatJSON, FormatJSONL} {
+		out := captureStdout(t, func() {
+			New(format).ZonesList(zones, "Cloudflare Production")
+		})
+		assert.Contains(t, out, "example.com")
+		assert.Contains(t, out, "Cloudflare Production")
+	}
+
+	out := captureStdout(t, func() {
+		New(FormatNone).ZonesList(zones, "Cloudflare Production")
+	})
+	assert.Empty(t, out)
+}
+
+func TestTextPrinter_AccountInfo(t *testing.T) {
+	out := captureStdout(t, func() {
+		New(FormatText).AccountInfo(models.AccountInfo{
+			Provider:     "Cloudflare",
+			ProviderType: "cloudflare",
+			Email:        "user@example.com",
+			Accounts:     []string{"Example Ltd"},
+			Scopes:       []string{"DNS Write", "Zone Read"},
+		})
+	})
+
+	assert.Equal(t, "Provider: Cloudflare (cloudflare)\n"+
+		"Email: user@example.com\n"+
+		"Accounts: Example Ltd\n"+
+		"Scopes: DNS Write, Zone Read\n", out)
+}
+
+func TestTextPrinter_SOA(t *testing.T) {
+	var buf bytes.Buffer
+	New(FormatText, WithWriter(&buf)).SOA(models.SOA{
+		Zone:    "xn--e1afmkfd.example",
+		MName:   "kristina.ns.cloudflare.com",
+		RName:   "dns.cloudflare.com",
+		Serial:  2051896520,
+		Refresh: 10000,
+		Retry:   2400,
+		Expire:  604800,
+		Minimum: 3600,
+		TTL:     3600,
+	})
+
+	assert.Equal(t, "Zone: пример.example\n"+
+		"Primary NS: kristina.ns.cloudflare.com\n"+
+		"Responsible: dns.cloudflare.com\n"+
+		"Serial: 2051896520\n"+
+		"Refresh: 10000\n"+
+		"Retry: 2400\n"+
+		"Expire: 604800\n"+
+		"Minimum: 3600\n"+
+		"TTL: 3600\n", buf.String())
+}
+
+func TestTextPrinter_DNSSEC(t *testing.T) {
+	var buf bytes.Buffer
+	p := New(FormatText, WithWriter(&buf))
+	p.DNSSEC(models.DNSSECStatus{
+		Zone:       "example.com",
+		Status:     "active",
+		DS:         "example.com. 3600 IN DS 16953 13 2 48E939042E82C22542CB377B580DFDC52A361CEFDC72E7F9107E2B6BD9306A45",
+		KeyTag:     16953,
+		Algorithm:  "13",
+		DigestType: "2",
+		Digest:     "48E939042E82C22542CB377B580DFDC52A361CEFDC72E7F9107E2B6BD9306A45",
+		Flags:      257,
+		ModifiedOn: time.Date(2026, 10, 15, 9, 41, 2, 0, time.UTC),
+	})
+	assert.Equal(t, "Zone: example.com\n"+
+		"Status: active\n"+
+		"DS: example.com. 3600 IN DS 16953 13 2 48E939042E82C22542CB377B580DFDC52A361CEFDC72E7F9107E2B6BD9306A45\n"+
+		"Key Tag: 16953\n"+
+		"Algorithm: 13\n"+
+		"Digest Type: 2\n"+
+		"Digest: 48E939042E82C22542CB377B580DFDC52A361CEFDC72E7F9107E2B6BD9306A45\n"+
+		"Flags: 257\n"+
+		"Modified: 2026-10-15T09:41:02Z\n", buf.String())
+
+	buf.Reset()
+	p.DNSSEC(models.DNSSECStatus{Zone: "example.com", Status: "disabled"})
+	assert.Equal(t, "Zone: example.com\nStatus: disabled\n", buf.String())
+}
+
+func TestTextPrinter_ProvidersList(t *testing.T) {
+	out := captureStdout(t, func() {
+		New(FormatText).ProvidersList([]models.ProviderInfo{
+			{Name: "cf-production", Type: "cloudflare", DisplayName: "Cloudflare Production", Default: true},
+			{Name: "pdns", Type: "powerdns", DisplayName: "PowerDNS"},
+		})
+	})
+
+	assert.Equal(t, "Name           Type        Display Name           Default\n"+
+		strings.Repeat("-", 57)+"\n"+
+		"cf-production  cloudflare  Cloudflare Production  yes\n"+
+		"pdns           powerdns    PowerDNS               \n", out)
+}
+
+func TestTextPrinter_ProvidersList_Capabilities(t *testing.T) {
+	var buf bytes.Buffer
+	New(FormatText, WithWriter(&buf)).ProvidersList([]models.ProviderInfo{
+		{Name: "cf", Type: "cloudflare", DisplayName: "Cloudflare", Default: true,
+			Capabilities: &models.Capabilities{RecordTypes: []string{"A", "TXT"}, Proxied: true, Comments: true, Tags: true,
+				MinTTL: 60, MaxTTL: 86400, AutoTTL: true}},
+		{Name: "pdns", Type: "powerdns", DisplayName: "PowerDNS", Capabilities: &models.Capabilities{}},
+	})
+
+	assert.Equal(t, "Name  Type        Display Name  Default  Proxied  Comments  Tags  TTL             Record Types\n"+
+		strings.Repeat("-", 94)+"\n"+
+		"cf    cloudflare  Cloudflare    yes      yes      yes       yes   60-86400, auto  A, TXT\n"+
+		"pdns  powerdns    PowerDNS               no       no        no    any             any\n", buf.String())
+}
+
+func TestTextPrinter_IDN(t *testing.T) {
+	rr := models.DNSRecord{ID: "1", Name: "www.xn--mnchen-3ya.de", TTL: 300, Type: "A", Content: "192.0.2.1"}
+
+	out := captureStdout(t, func() {
+		New(FormatText).RecordsList([]models.DNSRecord{rr})
+	})
+	assert.Contains(t, out, "www.münchen.de")
+
+	out = captureStdout(t, func() {
+		New(FormatText).ZonesList([]models.Zone{{ID: "1", Name: "xn--mnchen-3ya.de"}}, "Cloudflare")
+	})
+	assert.Contains(t, out, "münchen.de")
+
+	out = captureStdout(t, func() {
+		New(FormatJSON).RecordInfo(rr)
+	})
+	assert.Contains(t, out, "www.xn--mnchen-3ya.de")
+}
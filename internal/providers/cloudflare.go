@@ -18,21 +18,101 @@ package providers
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	"github.com/cloudflare/cloudflare-go"
 	"github.com/mixanemca/cdnscli/internal/models"
 )
 
+// accountInfoRepo is implemented by repositories able to describe the account in use.
+type accountInfoRepo interface {
+	AccountInfo(ctx context.Context) (models.AccountInfo, error)
+}
+
+// pingRepo is implemented by repositories with a cheaper health check than listing zones.
+type pingRepo interface {
+	Ping(ctx context.Context) error
+}
+
+// Ping checks that the API is reachable and accepts the credentials. Unlike the
+// verification done when the provider is created, it can be repeated cheaply.
+// Repositories without a health check of their own list the zones.
+func (p *provider) Ping(ctx context.Context) error {
+	if r, ok := p.repo.(pingRepo); ok {
+		return r.Ping(ctx)
+	}
+
+	_, err := p.repo.ListZones(ctx)
+	return err
+}
+
+// capabilitiesRepo is implemented by repositories describing what they support.
+type capabilitiesRepo interface {
+	Capabilities() models.Capabilities
+}
+
+// Capabilities describes the record types and features the provider supports.
+// Repositories that do not describe themselves support the common record types
+// and none of the optional features.
+func (p *provider) Capabilities() models.Capabilities {
+	if r, ok := p.repo.(capabilitiesRepo); ok {
+		return r.Capabilities()
+	}
+	return models.Capabilities{RecordTypes: models.CommonRecordTypes}
+}
+
+// AccountInfo returns details about the account the provider is authenticated as.
+// Repositories without account details report only the provider type and display name.
+func (p *provider) AccountInfo(ctx context.Context) (models.AccountInfo, error) {
+	var info models.AccountInfo
+
+	if r, ok := p.repo.(accountInfoRepo); ok {
+		var err error
+		info, err = r.AccountInfo(ctx)
+		if err != nil {
+			return models.AccountInfo{}, err
+		}
+	}
+	info.Provider = p.displayName
+	info.ProviderType = p.providerType
+
+	return info, nil
+}
+
 // AddRR creates a new DNS resource record for a zone.
 func (p *provider) AddRR(ctx context.Context, zone string, params models.CreateDNSRecordParams) (models.DNSRecord, error) {
 	var rr models.DNSRecord
 
-	zoneID, err := p.repo.ZoneIDByName(zone)
+	if err := namesToASCII(&zone, &params.Name, &params.ZoneName); err != nil {
+		return rr, err
+	}
+	if err := params.Validate(); err != nil {
+		return rr, err
+	}
+
+	if params.TTL == 0 {
+		params.TTL = p.defaultTTL
+	}
+	if err := p.Capabilities().CheckTTL(params.TTL); err != nil {
+		return rr, err
+	}
+
+	if params.ZoneName == "" {
+		params.ZoneName = zone
+	}
+	zoneID, err := p.zoneIDFor(zone, params.ZoneID)
 	if err != nil {
 		return rr, err
 	}
 	params.ZoneID = zoneID
 
+	if params.FailOnDuplicate {
+		if err := p.checkDuplicate(ctx, params); err != nil {
+			return rr, err
+		}
+	}
+
 	rr, err = p.repo.CreateDNSRecord(ctx, params)
 	if err != nil {
 		return rr, err
@@ -41,9 +121,83 @@ func (p *provider) AddRR(ctx context.Context, zone string, params models.CreateD
 	return rr, nil
 }
 
+// UpsertRR makes creating a record idempotent. A record of the zone matching params
+// by name, type and content, see models.DNSRecord.SameRecord, is updated with the TTL, proxying, priority, comment
+// and tags of params, unset TTL, priority, comment and tags keep their values.
+// Without a matching record, params is created as by AddRR.
+func (p *provider) UpsertRR(ctx context.Context, zone string, params models.CreateDNSRecordParams) (models.DNSRecord, error) {
+	if err := namesToASCII(&zone, &params.Name, &params.ZoneName); err != nil {
+		return models.DNSRecord{}, err
+	}
+	if err := params.Validate(); err != nil {
+		return models.DNSRecord{}, err
+	}
+	if err := p.Capabilities().CheckTTL(params.TTL); err != nil {
+		return models.DNSRecord{}, err
+	}
+
+	if params.ZoneName == "" {
+		params.ZoneName = zone
+	}
+	rrset, err := p.ListRecords(ctx, models.ListDNSRecordsParams{ZoneID: params.ZoneID, ZoneName: params.ZoneName})
+	if err != nil {
+		return models.DNSRecord{}, err
+	}
+
+	want := models.DNSRecord{Name: params.Name, Type: params.Type, Content: params.Content, Priority: params.Priority}
+	for _, rr := range rrset {
+		if !rr.SameRecord(want) {
+			continue
+		}
+
+		if params.TTL != 0 {
+			rr.TTL = params.TTL
+		}
+		if params.Priority != 0 {
+			rr.Priority = params.Priority
+		}
+		if params.Comment != "" {
+			rr.Comment = params.Comment
+		}
+		if params.Tags != nil {
+			rr.Tags = params.Tags
+		}
+		rr.Proxied = params.Proxied
+		if rr.ZoneID == "" {
+			rr.ZoneID = params.ZoneID
+		}
+
+		return p.UpdateRR(ctx, zone, rr)
+	}
+
+	return p.AddRR(ctx, zone, params)
+}
+
+// checkDuplicate returns a DuplicateRecordError if the zone of params already
+// holds a record with its name, type and content, see models.DNSRecord.SameRecord.
+func (p *provider) checkDuplicate(ctx context.Context, params models.CreateDNSRecordParams) error {
+	rrset, err := p.ListRecords(ctx, models.ListDNSRecordsParams{ZoneID: params.ZoneID, ZoneName: params.ZoneName})
+	if err != nil {
+		return err
+	}
+
+	want := models.DNSRecord{Name: params.Name, Type: params.Type, Content: params.Content, Priority: params.Priority}
+	for _, rr := range rrset {
+		if rr.SameRecord(want) {
+			return &DuplicateRecordError{Record: rr}
+		}
+	}
+
+	return nil
+}
+
 // DeleteRR deletes a DNS resource record from a given zone.
 func (p *provider) DeleteRR(ctx context.Context, zone string, rr models.DNSRecord) error {
-	zoneID, err := p.repo.ZoneIDByName(zone)
+	if err := namesToASCII(&zone); err != nil {
+		return err
+	}
+
+	zoneID, err := p.zoneID(zone, rr.ZoneID)
 	if err != nil {
 		return err
 	}
@@ -58,53 +212,93 @@ func (p *provider) DeleteRR(ctx context.Context, zone string, rr models.DNSRecor
 
 // UpdateRR updates an existing DNS resource record
 func (p *provider) UpdateRR(ctx context.Context, zone string, rr models.DNSRecord) (models.DNSRecord, error) {
-	zoneID, err := p.repo.ZoneIDByName(zone)
+	if err := namesToASCII(&zone, &rr.Name); err != nil {
+		return models.DNSRecord{}, err
+	}
+	if err := rr.Validate(); err != nil {
+		return models.DNSRecord{}, err
+	}
+	if err := p.Capabilities().CheckTTL(rr.TTL); err != nil {
+		return models.DNSRecord{}, err
+	}
+
+	zoneID, err := p.zoneIDFor(zone, rr.ZoneID)
 	if err != nil {
 		return models.DNSRecord{}, err
 	}
 
 	updateParams := models.UpdateDNSRecordParams{
-		Content: rr.Content,
-		ID:      rr.ID,
-		Name:    rr.Name,
-		Proxied: rr.Proxied,
-		TTL:     rr.TTL,
-		Type:    rr.Type,
-		ZoneID:  zoneID,
+		Comment:  rr.Comment,
+		Content:  rr.Content,
+		ID:       rr.ID,
+		Name:     rr.Name,
+		Priority: rr.Priority,
+		Tags:     rr.Tags,
+		Proxied:  rr.Proxied,
+		TTL:      rr.TTL,
+		Type:     rr.Type,
+		ZoneID:   zoneID,
+		ZoneName: zone,
 	}
 
 	return p.repo.UpdateDNSRecord(ctx, updateParams)
 }
 
-// GetRRByName returns a single DNS record for the given zone & record identifiers.
+// GetRRByID returns a single DNS record for the given zone & record ID.
+func (p *provider) GetRRByID(ctx context.Context, params models.ListDNSRecordsParams) (models.DNSRecord, error) {
+	if err := namesToASCII(&params.ZoneName); err != nil {
+		return models.DNSRecord{}, err
+	}
+
+	zoneID, err := p.zoneID(params.ZoneName, params.ZoneID)
+	if err != nil {
+		return models.DNSRecord{}, err
+	}
+
+	return p.repo.GetDNSRecord(ctx, zoneID, params.ID)
+}
+
+// GetRRByName returns the DNS record of the zone with the given name, which may
+// be relative to the zone. Of several records with the name, e.g. A and AAAA,
+// the first one listed is returned.
+// A missing zone is reported as ErrZoneNotFound and no record with the name as
+// ErrRecordNotFound, so callers can tell them apart.
 func (p *provider) GetRRByName(ctx context.Context, zone, name string) (models.DNSRecord, error) {
-	var rr models.DNSRecord
+	if err := namesToASCII(&zone, &name); err != nil {
+		return models.DNSRecord{}, err
+	}
 
-	zoneID, err := p.repo.ZoneIDByName(zone)
+	zoneID, err := p.zoneID(zone, "")
 	if err != nil {
-		return rr, err
+		if errors.Is(err, ErrNotFound) && !errors.Is(err, ErrZoneNotFound) {
+			err = NewNotFoundError("zone", zone, err)
+		}
+		return models.DNSRecord{}, err
 	}
 
 	rrset, err := p.repo.ListDNSRecords(ctx, zoneID)
 	if err != nil {
-		return rr, err
+		return models.DNSRecord{}, err
 	}
 
-	// TODO: this code need to refactoring
-	for _, rec := range rrset {
-		rr, err = p.repo.GetDNSRecord(ctx, zoneID, rec.ID)
-		if err != nil {
-			return rr, err
+	fqdn := models.FQDN(name, zone)
+	for _, rr := range rrset {
+		if strings.EqualFold(strings.TrimSuffix(rr.Name, "."), fqdn) {
+			return p.repo.GetDNSRecord(ctx, zoneID, rr.ID)
 		}
 	}
 
-	return rr, nil
+	return models.DNSRecord{}, NewNotFoundError("record", models.NameToUnicode(fqdn), nil)
 }
 
-// ListZones return lists zones on an account.
+// ListZones return lists zones on an account. Credentials scoped to the single
+// zone of the provider list that zone when they may not list zones.
 func (p *provider) ListZones(ctx context.Context) ([]models.Zone, error) {
 	zones, err := p.repo.ListZones(ctx)
 	if err != nil {
+		if p.singleZone.Name != "" && errors.Is(err, ErrForbidden) {
+			return p.singleZoneList(err)
+		}
 		return []models.Zone{}, err
 	}
 
@@ -113,8 +307,15 @@ func (p *provider) ListZones(ctx context.Context) ([]models.Zone, error) {
 
 // ListZonesByName return lists zones on an account using the zone name for filtering.
 func (p *provider) ListZonesByName(ctx context.Context, name string) ([]models.Zone, error) {
+	if err := namesToASCII(&name); err != nil {
+		return []models.Zone{}, err
+	}
+
 	zones, err := p.repo.ListZones(ctx, name)
 	if err != nil {
+		if p.isSingleZone(name) && errors.Is(err, ErrForbidden) {
+			return p.singleZoneList(err)
+		}
 		return []models.Zone{}, err
 	}
 
@@ -124,7 +325,7 @@ func (p *provider) ListZonesByName(ctx context.Context, name string) ([]models.Z
 // ListRecordsByZoneID returns a slice of DNS records for the given zone identifier and parameters.
 func (p *provider) ListRecordsByZoneID(ctx context.Context, id string, params models.ListDNSRecordsParams) ([]models.DNSRecord, error) {
 	// Fetch all records for a zone
-	rrset, err := p.repo.ListDNSRecords(context.Background(), id)
+	rrset, err := p.repo.ListDNSRecords(ctx, id)
 	if err != nil {
 		return []models.DNSRecord{}, err
 	}
@@ -134,7 +335,11 @@ func (p *provider) ListRecordsByZoneID(ctx context.Context, id string, params mo
 
 // ListRecords returns a slice of DNS records for the given zone name.
 func (p *provider) ListRecords(ctx context.Context, params models.ListDNSRecordsParams) ([]models.DNSRecord, error) {
-	id, err := p.repo.ZoneIDByName(params.ZoneName)
+	if err := namesToASCII(&params.ZoneName, &params.Name); err != nil {
+		return []models.DNSRecord{}, err
+	}
+
+	id, err := p.zoneID(params.ZoneName, params.ZoneID)
 	if err != nil {
 		return []models.DNSRecord{}, err
 	}
@@ -142,14 +347,230 @@ func (p *provider) ListRecords(ctx context.Context, params models.ListDNSRecords
 	return p.ListRecordsByZoneID(ctx, id, params)
 }
 
+// recordCounter is implemented by repositories able to count records without listing them.
+type recordCounter interface {
+	CountDNSRecords(ctx context.Context, zoneID string) (int, error)
+}
+
+// CountRecords returns the number of DNS records in the given zone. Repositories
+// unable to count records cheaply list them all.
+func (p *provider) CountRecords(ctx context.Context, zone string) (int, error) {
+	if err := namesToASCII(&zone); err != nil {
+		return 0, err
+	}
+
+	id, err := p.zoneID(zone, "")
+	if err != nil {
+		return 0, err
+	}
+
+	if r, ok := p.repo.(recordCounter); ok {
+		return r.CountDNSRecords(ctx, id)
+	}
+
+	rrset, err := p.repo.ListDNSRecords(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	return len(rrset), nil
+}
+
+// nameServersRepo is implemented by repositories able to change the name servers of a zone.
+type nameServersRepo interface {
+	UpdateNameServers(ctx context.Context, zoneID string, ns []string) error
+}
+
+// UpdateNameServers sets the name servers of the given zone after checking that
+// there are 2 to 4 valid ones. Providers assigning the name servers themselves
+// return an error of category ErrUnsupported.
+func (p *provider) UpdateNameServers(ctx context.Context, zone string, ns []string) error {
+	r, ok := p.repo.(nameServersRepo)
+	if !ok {
+		return &UnsupportedFeatureError{Feature: "changing name servers"}
+	}
+
+	ns = append([]string{}, ns...)
+	names := []*string{&zone}
+	for i := range ns {
+		names = append(names, &ns[i])
+	}
+	if err := namesToASCII(names...); err != nil {
+		return err
+	}
+	if err := models.ValidateNameServers(ns); err != nil {
+		return err
+	}
+
+	id, err := p.zoneID(zone, "")
+	if err != nil {
+		return err
+	}
+
+	return r.UpdateNameServers(ctx, id, ns)
+}
+
+// soaRepo is implemented by repositories able to read the SOA record of a zone.
+type soaRepo interface {
+	GetSOA(ctx context.Context, zoneID string) (models.SOA, error)
+}
+
+// GetSOA returns the SOA record of the given zone. Providers not exposing it
+// return an error of category ErrUnsupported.
+func (p *provider) GetSOA(ctx context.Context, zone string) (models.SOA, error) {
+	r, ok := p.repo.(soaRepo)
+	if !ok {
+		return models.SOA{}, &UnsupportedFeatureError{Feature: "reading the SOA record"}
+	}
+
+	if err := namesToASCII(&zone); err != nil {
+		return models.SOA{}, err
+	}
+	zone = strings.TrimSuffix(zone, ".")
+	id, err := p.zoneID(zone, "")
+	if err != nil {
+		return models.SOA{}, err
+	}
+
+	soa, err := r.GetSOA(ctx, id)
+	if err != nil {
+		return models.SOA{}, err
+	}
+	soa.Zone = zone
+	return soa, nil
+}
+
+// dnssecRepo is implemented by repositories able to sign zones with DNSSEC.
+type dnssecRepo interface {
+	GetDNSSEC(ctx context.Context, zoneID string) (models.DNSSECStatus, error)
+	SetDNSSEC(ctx context.Context, zoneID string, enable bool) (models.DNSSECStatus, error)
+}
+
+// GetDNSSEC returns the DNSSEC status of the given zone. Providers without
+// DNSSEC management return an error of category ErrUnsupported.
+func (p *provider) GetDNSSEC(ctx context.Context, zone string) (models.DNSSECStatus, error) {
+	return p.dnssec(ctx, zone, func(r dnssecRepo, id string) (models.DNSSECStatus, error) {
+		return r.GetDNSSEC(ctx, id)
+	})
+}
+
+// SetDNSSEC enables or disables DNSSEC signing of the given zone and returns the
+// new status. Providers without DNSSEC management return an error of category
+// ErrUnsupported.
+func (p *provider) SetDNSSEC(ctx context.Context, zone string, enable bool) (models.DNSSECStatus, error) {
+	return p.dnssec(ctx, zone, func(r dnssecRepo, id string) (models.DNSSECStatus, error) {
+		return r.SetDNSSEC(ctx, id, enable)
+	})
+}
+
+// dnssec looks the zone up and calls f with the repository and the zone ID.
+func (p *provider) dnssec(ctx context.Context, zone string, f func(r dnssecRepo, id string) (models.DNSSECStatus, error)) (models.DNSSECStatus, error) {
+	r, ok := p.repo.(dnssecRepo)
+	if !ok {
+		return models.DNSSECStatus{}, &UnsupportedFeatureError{Feature: "DNSSEC"}
+	}
+
+	if err := namesToASCII(&zone); err != nil {
+		return models.DNSSECStatus{}, err
+	}
+	zone = strings.TrimSuffix(zone, ".")
+	id, err := p.zoneID(zone, "")
+	if err != nil {
+		return models.DNSSECStatus{}, err
+	}
+
+	status, err := f(r, id)
+	if err != nil {
+		return models.DNSSECStatus{}, err
+	}
+	status.Zone = zone
+	return status, nil
+}
+
+// zoneID returns the zone identifier when it is already known and looks it up
+// by the zone name otherwise, saving an API call for callers that have the ID.
+// Looked up IDs are kept in memory for the life of the provider.
+func (p *provider) zoneID(zone, id string) (string, error) {
+	if id != "" {
+		return id, nil
+	}
+	if p.isSingleZone(zone) && p.singleZone.ID != "" {
+		return p.singleZone.ID, nil
+	}
+
+	return p.zones.resolve(zone)
+}
+
+// lookupZoneID looks the ID of zone up in the zone cache, if the provider has
+// one, and through the API otherwise, keeping the result in the cache.
+func (p *provider) lookupZoneID(zone string) (string, error) {
+	if p.zoneCache != nil {
+		if id, ok := p.zoneCache.Get(zone); ok {
+			return id, nil
+		}
+	}
+
+	id, err := p.repo.ZoneIDByName(zone)
+	if err != nil {
+		return "", err
+	}
+
+	if p.zoneCache != nil {
+		// The cache only saves API calls, failing to write it is not an error
+		_ = p.zoneCache.Set(zone, id)
+	}
+
+	return id, nil
+}
+
+// isSingleZone reports whether zone is the single zone of the provider.
+func (p *provider) isSingleZone(zone string) bool {
+	return p.singleZone.Name != "" && zoneCacheKey(zone) == zoneCacheKey(p.singleZone.Name)
+}
+
+// singleZoneList returns the single zone of the provider as the zone list, for
+// credentials forbidden to list zones. The zone ID is looked up by name unless
+// configured. If that fails too, listErr is returned.
+func (p *provider) singleZoneList(listErr error) ([]models.Zone, error) {
+	zone := p.singleZone
+	if zone.ID == "" {
+		id, err := p.zoneID(zone.Name, "")
+		if err != nil {
+			return []models.Zone{}, listErr
+		}
+		zone.ID = id
+	}
+
+	return []models.Zone{zone}, nil
+}
+
+// zoneNameAddressed is implemented by repositories addressing zones by name,
+// which need no zone ID to create or update records.
+type zoneNameAddressed interface {
+	addressesZonesByName()
+}
+
+// zoneIDFor is zoneID for operations that are given the zone name too. It skips
+// the lookup when the repository addresses zones by name and the name is known.
+func (p *provider) zoneIDFor(zone, id string) (string, error) {
+	if _, ok := p.repo.(zoneNameAddressed); ok && zone != "" {
+		return id, nil
+	}
+
+	return p.zoneID(zone, id)
+}
+
 func convFromDNSRecord(cfrr cloudflare.DNSRecord) models.DNSRecord {
 	return models.DNSRecord{
-		ID:      cfrr.ID,
-		Name:    cfrr.Name,
-		TTL:     cfrr.TTL,
-		Type:    cfrr.Type,
-		Proxied: cloudflare.Bool(cfrr.Proxied),
-		Content: cfrr.Content,
+		ID:         cfrr.ID,
+		Name:       cfrr.Name,
+		TTL:        cfrr.TTL,
+		Type:       cfrr.Type,
+		Proxied:    cloudflare.Bool(cfrr.Proxied),
+		Content:    convFromContent(cfrr.Type, cfrr.Content),
+		Priority:   int(cloudflare.Uint16(cfrr.Priority)),
+		Comment:    cfrr.Comment,
+		Tags:       cfrr.Tags,
+		ModifiedOn: cfrr.ModifiedOn,
 	}
 }
 
@@ -157,12 +578,16 @@ func convFromDNSRecords(cfrrset []cloudflare.DNSRecord) []models.DNSRecord {
 	rrset := make([]models.DNSRecord, 0, len(cfrrset))
 	for _, cfrr := range cfrrset {
 		rr := models.DNSRecord{
-			ID:      cfrr.ID,
-			Name:    cfrr.Name,
-			TTL:     cfrr.TTL,
-			Type:    cfrr.Type,
-			Proxied: cloudflare.Bool(cfrr.Proxied),
-			Content: cfrr.Content,
+			ID:         cfrr.ID,
+			Name:       cfrr.Name,
+			TTL:        cfrr.TTL,
+			Type:       cfrr.Type,
+			Proxied:    cloudflare.Bool(cfrr.Proxied),
+			Content:    convFromContent(cfrr.Type, cfrr.Content),
+			Priority:   int(cloudflare.Uint16(cfrr.Priority)),
+			Comment:    cfrr.Comment,
+			Tags:       cfrr.Tags,
+			ModifiedOn: cfrr.ModifiedOn,
 		}
 		rrset = append(rrset, rr)
 	}
@@ -187,9 +612,12 @@ func convFromDNSZones(cfzones []cloudflare.Zone) []models.Zone {
 
 func convToCreateDNSRecordParams(p models.CreateDNSRecordParams) cloudflare.CreateDNSRecordParams {
 	return cloudflare.CreateDNSRecordParams{
-		Content:  p.Content,
+		Comment:  p.Comment,
+		Content:  convToContent(p.Type, p.Content),
 		Name:     p.Name,
+		Priority: convToPriority(p.Type, p.Priority),
 		Proxied:  cloudflare.BoolPtr(p.Proxied),
+		Tags:     p.Tags,
 		TTL:      p.TTL,
 		Type:     p.Type,
 		ZoneName: p.ZoneName,
@@ -197,11 +625,54 @@ func convToCreateDNSRecordParams(p models.CreateDNSRecordParams) cloudflare.Crea
 	}
 }
 
+// namesToASCII converts zone and record names to punycode in place.
+func namesToASCII(names ...*string) error {
+	for _, n := range names {
+		ascii, err := models.NameToASCII(*n)
+		if err != nil {
+			return err
+		}
+		*n = ascii
+	}
+	return nil
+}
+
+// convToContent converts record content to the form expected by the API:
+// long TXT values are split into quoted character-strings.
+func convToContent(rrtype, content string) string {
+	if strings.EqualFold(rrtype, "TXT") {
+		return models.EncodeTXT(content)
+	}
+	return content
+}
+
+// convToPriority returns the priority to send for records that have one,
+// MX, SRV and URI, and nil for the other types.
+func convToPriority(rrtype string, priority int) *uint16 {
+	switch strings.ToUpper(rrtype) {
+	case "MX", "SRV", "URI":
+		return cloudflare.Uint16Ptr(uint16(priority))
+	}
+	return nil
+}
+
+// convFromContent converts record content returned by the API for display:
+// TXT character-strings are reassembled into a single value.
+func convFromContent(rrtype, content string) string {
+	if strings.EqualFold(rrtype, "TXT") {
+		return models.JoinTXT(content)
+	}
+	return content
+}
+
 func convFromCreateDNSRecordParams(p cloudflare.CreateDNSRecordParams) models.CreateDNSRecordParams {
 	return models.CreateDNSRecordParams{
+		Comment:  p.Comment,
 		Content:  p.Content,
 		Name:     p.Name,
+		Priority: int(cloudflare.Uint16(p.Priority)),
 		Proxied:  cloudflare.Bool(p.Proxied),
+		Tags:     p.Tags,
 		TTL:      p.TTL,
 		Type:     p.Type,
 		ZoneName: p.ZoneName,
@@ -210,12 +681,17 @@ func convFromCreateDNSRecordParams(p cloudflare.CreateDNSRecordParams) models.Cr
 }
 
 func convToUpdateDNSRecordParams(p models.UpdateDNSRecordParams) cloudflare.UpdateDNSRecordParams {
+	// Comment and Tags are always sent: the caller passes the record's
+	// current values when it does not change them.
 	return cloudflare.UpdateDNSRecordParams{
-		Content: p.Content,
-		ID:      p.ID,
-		Name:    p.Name,
-		Proxied: cloudflare.BoolPtr(p.Proxied),
-		TTL:     p.TTL,
-		Type:    p.Type,
+		Comment:  cloudflare.StringPtr(p.Comment),
+		Content:  convToContent(p.Type, p.Content),
+		ID:       p.ID,
+		Name:     p.Name,
+		Priority: convToPriority(p.Type, p.Priority),
+		Proxied:  cloudflare.BoolPtr(p.Proxied),
+		Tags:     p.Tags,
+		TTL:      p.TTL,
+		Type:     p.Type,
 	}
 }
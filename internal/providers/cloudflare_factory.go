@@ -22,6 +22,7 @@ import (
 
 	"github.com/cloudflare/cloudflare-go"
 	"github.com/mixanemca/cdnscli/internal/config"
+	"github.com/mixanemca/cdnscli/internal/models"
 )
 
 // cloudflareFactory creates Cloudflare providers.
@@ -55,16 +56,37 @@ func (f *cloudflareFactory) CreateProvider(cfg *config.ProviderConfig) (Provider
 			"incomplete credentials: need either api_token or (api_key + email)", nil)
 	}
 
+	opts, err := providerOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tokens scoped to a single zone may not list zones, they work with the configured zone
+	zone := models.Zone{ID: cfg.GetZoneID(), Name: cfg.GetZoneName()}
+	if zone.ID != "" && zone.Name == "" {
+		return nil, NewProviderConfigError("", TypeCloudflare, "options.zone_name",
+			"zone_id is set without zone_name, the name of the zone", nil)
+	}
+	if err := namesToASCII(&zone.Name); err != nil {
+		return nil, NewProviderConfigError("", TypeCloudflare, "options.zone_name", "invalid zone name", err)
+	}
+	opts = append(opts, WithSingleZone(zone))
+
+	httpClient, err := newHTTPClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	var api *cloudflare.API
 
 	if creds.APIToken != "" {
-		api, err = cloudflare.NewWithAPIToken(creds.APIToken)
+		api, err = cloudflare.NewWithAPIToken(creds.APIToken, cloudflare.HTTPClient(httpClient))
 		if err != nil {
 			return nil, NewProviderCredentialsError("cloudflare", 
 				"failed to create API client with token", err)
 		}
 	} else if creds.APIKey != "" && creds.Email != "" {
-		api, err = cloudflare.New(creds.APIKey, creds.Email)
+		api, err = cloudflare.New(creds.APIKey, creds.Email, cloudflare.HTTPClient(httpClient))
 		if err != nil {
 			return nil, NewProviderCredentialsError("cloudflare", 
 				"failed to create API client with API key and email", err)
@@ -79,5 +101,5 @@ func (f *cloudflareFactory) CreateProvider(cfg *config.ProviderConfig) (Provider
 	}
 
 	repo := NewRepoCloudFlare(api)
-	return NewProvider(repo), nil
+	return NewProvider(repo, opts...), nil
 }
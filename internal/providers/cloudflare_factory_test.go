@@ -115,6 +115,27 @@ func TestCloudflareFactory_CreateProvider_EmptyAPIToken(t *testing.T) {
 	assert.Contains(t, credsErr.Error(), "incomplete credentials")
 }
 
+func TestCloudflareFactory_CreateProvider_ZoneIDWithoutName(t *testing.T) {
+	factory := NewCloudflareFactory()
+	cfg := &config.ProviderConfig{
+		Type: "cloudflare",
+		Credentials: map[string]interface{}{
+			"api_token": "token",
+		},
+		Options: map[string]interface{}{
+			"zone_id": "023e105f4ecef8ad9ca31a8372d0c353",
+		},
+	}
+
+	provider, err := factory.CreateProvider(cfg)
+	assert.Nil(t, provider)
+
+	var cfgErr *ProviderConfigError
+	if assert.ErrorAs(t, err, &cfgErr) {
+		assert.Equal(t, "options.zone_name", cfgErr.Field)
+	}
+}
+
 // Note: Testing actual provider creation with real Cloudflare API would require
 // either integration tests with a test token or more sophisticated mocking.
 // These tests focus on validation and error handling which can be tested
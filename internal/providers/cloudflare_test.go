@@ -19,12 +19,20 @@ package providers
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/cloudflare/cloudflare-go"
+	"github.com/mixanemca/cdnscli/internal/config"
 	"github.com/mixanemca/cdnscli/internal/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockClient mock client for zones.
@@ -149,11 +157,12 @@ func TestListRecordsByZoneID(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockClient := new(MockClient)
 
-			mockClient.On("ListDNSRecords", mock.Anything, tt.mockParams.ZoneID).
+			// The context of the caller reaches the API call, so it can be canceled
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			mockClient.On("ListDNSRecords", ctx, tt.mockParams.ZoneID).
 				Return(tt.mockResp, tt.expectedErr)
 
-			ctx := context.Background()
-
 			client := NewProvider(mockClient)
 
 			result, err := client.ListRecordsByZoneID(ctx, tt.mockParams.ZoneID, tt.mockParams)
@@ -219,7 +228,10 @@ func TestListRecords(t *testing.T) {
 
 			client := NewProvider(mockClient)
 
-			result, err := client.ListRecords(ctx, tt.mockParams)
+			// The zone is looked up by name, ZoneID is what the lookup returns
+			params := tt.mockParams
+			params.ZoneID = ""
+			result, err := client.ListRecords(ctx, params)
 
 			if tt.wantErr {
 				assert.EqualError(t, err, tt.expectedErr.Error())
@@ -561,6 +573,7 @@ func TestGetRRByName(t *testing.T) {
 			name:     "empty zone id",
 			zone:     "example.com",
 			zoneID:   "12345",
+			record:   "test.example.com",
 			recordID: "67890",
 			mockResp: models.DNSRecord{},
 			mockRespRRSet: []models.DNSRecord{
@@ -602,6 +615,80 @@ func TestGetRRByName(t *testing.T) {
 
 }
 
+func TestGetRRByName_Errors(t *testing.T) {
+	rrset := []models.DNSRecord{
+		{ID: "1", Name: "www.example.com", Type: "A"},
+		{ID: "2", Name: "www.example.com", Type: "AAAA"},
+		{ID: "3", Name: "mail.example.com", Type: "A"},
+	}
+
+	t.Run("first record with the name", func(t *testing.T) {
+		mockClient := new(MockClient)
+		mockClient.On("ZoneIDByName", "example.com").Return("12345", nil)
+		mockClient.On("ListDNSRecords", mock.Anything, "12345").Return(rrset, nil)
+		mockClient.On("GetDNSRecord", mock.Anything, "12345", "1").Return(rrset[0], nil)
+
+		rr, err := NewProvider(mockClient).GetRRByName(context.Background(), "example.com", "WWW.example.com.")
+		require.NoError(t, err)
+		assert.Equal(t, rrset[0], rr)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("name relative to the zone", func(t *testing.T) {
+		mockClient := new(MockClient)
+		mockClient.On("ZoneIDByName", "example.com").Return("12345", nil)
+		mockClient.On("ListDNSRecords", mock.Anything, "12345").Return(rrset, nil)
+		mockClient.On("GetDNSRecord", mock.Anything, "12345", "3").Return(rrset[2], nil)
+
+		rr, err := NewProvider(mockClient).GetRRByName(context.Background(), "example.com", "mail")
+		require.NoError(t, err)
+		assert.Equal(t, rrset[2], rr)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("zone not found", func(t *testing.T) {
+		mockClient := new(MockClient)
+		mockClient.On("ZoneIDByName", "example.org").Return("", NewNotFoundError("zone", "example.org", errors.New(errCloudflareZoneNotFound)))
+
+		_, err := NewProvider(mockClient).GetRRByName(context.Background(), "example.org", "www.example.org")
+		assert.ErrorIs(t, err, ErrZoneNotFound)
+		assert.NotErrorIs(t, err, ErrRecordNotFound)
+		assert.EqualError(t, err, "zone example.org not found")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("zone lookup 404", func(t *testing.T) {
+		mockClient := new(MockClient)
+		mockClient.On("ZoneIDByName", "example.org").Return("", NewNotFoundError("resource", "/zones", nil))
+
+		_, err := NewProvider(mockClient).GetRRByName(context.Background(), "example.org", "www.example.org")
+		assert.ErrorIs(t, err, ErrZoneNotFound)
+		assert.EqualError(t, err, "zone example.org not found")
+	})
+
+	t.Run("record not found", func(t *testing.T) {
+		mockClient := new(MockClient)
+		mockClient.On("ZoneIDByName", "example.com").Return("12345", nil)
+		mockClient.On("ListDNSRecords", mock.Anything, "12345").Return(rrset, nil)
+
+		_, err := NewProvider(mockClient).GetRRByName(context.Background(), "example.com", "ftp.example.com")
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+		assert.NotErrorIs(t, err, ErrZoneNotFound)
+		assert.EqualError(t, err, "record ftp.example.com not found")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("other zone lookup failure", func(t *testing.T) {
+		credsErr := NewProviderCredentialsError(TypeCloudflare, "request rejected", errors.New("403 Forbidden"))
+		mockClient := new(MockClient)
+		mockClient.On("ZoneIDByName", "example.com").Return("", credsErr)
+
+		_, err := NewProvider(mockClient).GetRRByName(context.Background(), "example.com", "www.example.com")
+		assert.ErrorIs(t, err, ErrCredentials)
+		assert.NotErrorIs(t, err, ErrNotFound)
+	})
+}
+
 func TestCreateDNSRecord(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -648,7 +735,9 @@ func TestCreateDNSRecord(t *testing.T) {
 			ctx := context.Background()
 			provider := NewProvider(mockClient)
 
-			result, err := provider.AddRR(ctx, tt.zone, tt.mockParams)
+			params := tt.mockParams
+			params.ZoneID = ""
+			result, err := provider.AddRR(ctx, tt.zone, params)
 			if tt.wantErr {
 				assert.EqualError(t, err, tt.expectedErr.Error())
 			} else {
@@ -696,7 +785,9 @@ func TestCreateDNSRecord(t *testing.T) {
 			ctx := context.Background()
 			provider := NewProvider(mockClient)
 
-			result, err := provider.AddRR(ctx, tt.zone, tt.mockParams)
+			params := tt.mockParams
+			params.ZoneID = ""
+			result, err := provider.AddRR(ctx, tt.zone, params)
 			if tt.wantErr {
 				assert.EqualError(t, err, tt.expectedErr.Error())
 			} else {
@@ -747,7 +838,9 @@ func TestCreateDNSRecord(t *testing.T) {
 			ctx := context.Background()
 			provider := NewProvider(mockClient)
 
-			result, err := provider.AddRR(ctx, tt.zone, tt.mockParams)
+			params := tt.mockParams
+			params.ZoneID = ""
+			result, err := provider.AddRR(ctx, tt.zone, params)
 			if tt.wantErr {
 				assert.EqualError(t, err, tt.expectedErr.Error())
 			} else {
@@ -908,12 +1001,13 @@ func TestUpdateteDNSRecord(t *testing.T) {
 			zone:   "example.com",
 			zoneID: "12345",
 			mockParams: models.UpdateDNSRecordParams{
-				Name:    "test.example.com",
-				Proxied: true,
-				TTL:     60,
-				Type:    "A",
-				Content: "192.0.2.1",
-				ZoneID:  "12345",
+				Name:     "test.example.com",
+				Proxied:  true,
+				TTL:      60,
+				Type:     "A",
+				Content:  "192.0.2.1",
+				ZoneID:   "12345",
+				ZoneName: "example.com",
 			},
 			mockResp: models.DNSRecord{
 				Name:    "test.example.com",
@@ -954,6 +1048,7 @@ func TestUpdateteDNSRecord(t *testing.T) {
 		name        string
 		zone        string
 		zoneID      string
+		rr          models.DNSRecord
 		mockParams  models.UpdateDNSRecordParams
 		mockResp    models.DNSRecord
 		wantErr     bool
@@ -963,6 +1058,7 @@ func TestUpdateteDNSRecord(t *testing.T) {
 			name:        "update a DNS resource record without zone name error",
 			zone:        "",
 			zoneID:      "12345",
+			rr:          models.DNSRecord{Name: "test.example.com", Type: "A", Content: "192.0.2.1"},
 			mockParams:  models.UpdateDNSRecordParams{},
 			mockResp:    models.DNSRecord{},
 			wantErr:     true,
@@ -979,7 +1075,7 @@ func TestUpdateteDNSRecord(t *testing.T) {
 			ctx := context.Background()
 			provider := NewProvider(mockClient)
 
-			result, err := provider.UpdateRR(ctx, tt.zone, tt.mockResp)
+			result, err := provider.UpdateRR(ctx, tt.zone, tt.rr)
 			if tt.wantErr {
 				assert.EqualError(t, err, tt.expectedErr.Error())
 			} else {
@@ -1007,6 +1103,10 @@ func TestConvFromDNSRecord(t *testing.T) {
 				Type:    "A",
 				Proxied: cloudflare.BoolPtr(true),
 				Content: "192.168.0.1",
+				Comment: "web frontend",
+				Tags:    []string{"env:prod", "team:web"},
+
+				ModifiedOn: time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC),
 			},
 			expected: models.DNSRecord{
 				ID:      "record-id",
@@ -1015,6 +1115,10 @@ func TestConvFromDNSRecord(t *testing.T) {
 				Type:    "A",
 				Proxied: true,
 				Content: "192.168.0.1",
+				Comment: "web frontend",
+				Tags:    []string{"env:prod", "team:web"},
+
+				ModifiedOn: time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC),
 			},
 		},
 		{
@@ -1055,6 +1159,10 @@ func TestConvFromDNSRecords(t *testing.T) {
 					Type:    "A",
 					Proxied: cloudflare.BoolPtr(true),
 					Content: "192.168.0.1",
+					Comment: "web frontend",
+					Tags:    []string{"env:prod", "team:web"},
+
+					ModifiedOn: time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC),
 				},
 			},
 			expected: []models.DNSRecord{
@@ -1065,6 +1173,10 @@ func TestConvFromDNSRecords(t *testing.T) {
 					Type:    "A",
 					Proxied: true,
 					Content: "192.168.0.1",
+					Comment: "web frontend",
+					Tags:    []string{"env:prod", "team:web"},
+
+					ModifiedOn: time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC),
 				},
 			},
 		},
@@ -1132,18 +1244,22 @@ func TestConvToCreateDNSRecordParams(t *testing.T) {
 		{
 			name: "Valid input",
 			input: models.CreateDNSRecordParams{
+				Comment:  "web frontend",
 				Content:  "192.168.0.1",
 				Name:     "example.com",
 				Proxied:  true,
+				Tags:     []string{"env:prod", "team:web"},
 				TTL:      3600,
 				Type:     "A",
 				ZoneName: "example-zone",
 				ZoneID:   "zone-id",
 			},
 			expected: cloudflare.CreateDNSRecordParams{
+				Comment:  "web frontend",
 				Content:  "192.168.0.1",
 				Name:     "example.com",
 				Proxied:  cloudflare.BoolPtr(true),
+				Tags:     []string{"env:prod", "team:web"},
 				TTL:      3600,
 				Type:     "A",
 				ZoneName: "example-zone",
@@ -1182,18 +1298,22 @@ func TestConvFromCreateDNSRecordParams(t *testing.T) {
 		{
 			name: "Valid input",
 			input: cloudflare.CreateDNSRecordParams{
+				Comment:  "web frontend",
 				Content:  "192.168.0.1",
 				Name:     "example.com",
 				Proxied:  cloudflare.BoolPtr(true),
+				Tags:     []string{"env:prod", "team:web"},
 				TTL:      3600,
 				Type:     "A",
 				ZoneName: "example-zone",
 				ZoneID:   "zone-id",
 			},
 			expected: models.CreateDNSRecordParams{
+				Comment:  "web frontend",
 				Content:  "192.168.0.1",
 				Name:     "example.com",
 				Proxied:  true,
+				Tags:     []string{"env:prod", "team:web"},
 				TTL:      3600,
 				Type:     "A",
 				ZoneName: "example-zone",
@@ -1232,18 +1352,22 @@ func TestConvToUpdateDNSRecordParams(t *testing.T) {
 		{
 			name: "Valid input",
 			input: models.UpdateDNSRecordParams{
+				Comment: "web frontend",
 				Content: "192.168.0.1",
 				ID:      "record-id",
 				Name:    "example.com",
 				Proxied: true,
+				Tags:    []string{"env:prod", "team:web"},
 				TTL:     3600,
 				Type:    "A",
 			},
 			expected: cloudflare.UpdateDNSRecordParams{
+				Comment: cloudflare.StringPtr("web frontend"),
 				Content: "192.168.0.1",
 				ID:      "record-id",
 				Name:    "example.com",
 				Proxied: cloudflare.BoolPtr(true),
+				Tags:    []string{"env:prod", "team:web"},
 				TTL:     3600,
 				Type:    "A",
 			},
@@ -1252,6 +1376,7 @@ func TestConvToUpdateDNSRecordParams(t *testing.T) {
 			name:  "Empty input",
 			input: models.UpdateDNSRecordParams{},
 			expected: cloudflare.UpdateDNSRecordParams{
+				Comment: cloudflare.StringPtr(""),
 				Content: "",
 				ID:      "",
 				Name:    "",
@@ -1269,3 +1394,967 @@ func TestConvToUpdateDNSRecordParams(t *testing.T) {
 		})
 	}
 }
+
+func TestAddRR_DefaultTTL(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []ProviderOption
+		ttl     int
+		wantTTL int
+	}{
+		{name: "no TTL uses built-in default", ttl: 0, wantTTL: DefaultTTL},
+		{name: "no TTL uses configured default", opts: []ProviderOption{WithDefaultTTL(300)}, ttl: 0, wantTTL: 300},
+		{name: "explicit TTL wins", opts: []ProviderOption{WithDefaultTTL(300)}, ttl: 60, wantTTL: 60},
+		{name: "auto TTL is kept", opts: []ProviderOption{WithDefaultTTL(300)}, ttl: models.TTLAuto, wantTTL: models.TTLAuto},
+		{name: "non-positive default is ignored", opts: []ProviderOption{WithDefaultTTL(0)}, ttl: 0, wantTTL: DefaultTTL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(MockClient)
+
+			params := models.CreateDNSRecordParams{
+				Content:  "192.0.2.1",
+				Name:     "test.example.com",
+				TTL:      tt.ttl,
+				Type:     "A",
+				ZoneName: "example.com",
+			}
+			want := params
+			want.ZoneID = "12345"
+			want.TTL = tt.wantTTL
+
+			mockClient.On("ZoneIDByName", "example.com").
+				Return("12345", nil)
+			mockClient.On("CreateDNSRecord", mock.Anything, want).
+				Return(models.DNSRecord{TTL: tt.wantTTL}, nil)
+
+			provider := NewProvider(mockClient, tt.opts...)
+
+			rr, err := provider.AddRR(context.Background(), "example.com", params)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantTTL, rr.TTL)
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAddRR_FailOnDuplicate(t *testing.T) {
+	existing := models.DNSRecord{ID: "rr1", Name: "www.example.com", Type: "A", Content: "192.0.2.1", ZoneID: "12345"}
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{name: "duplicate is detected", content: "192.0.2.1", wantErr: true},
+		{name: "other content is created", content: "192.0.2.2", wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := models.CreateDNSRecordParams{
+				Content:         tt.content,
+				Name:            "www.example.com",
+				TTL:             300,
+				Type:            "A",
+				ZoneID:          "12345",
+				FailOnDuplicate: true,
+			}
+
+			mockClient := new(MockClient)
+			mockClient.On("ListDNSRecords", mock.Anything, "12345").
+				Return([]models.DNSRecord{existing}, nil)
+			mockClient.On("CreateDNSRecord", mock.Anything, mock.Anything).
+				Return(models.DNSRecord{ID: "rr2"}, nil)
+
+			provider := NewProvider(mockClient)
+
+			rr, err := provider.AddRR(context.Background(), "example.com", params)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrDuplicate)
+				var dupErr *DuplicateRecordError
+				if assert.ErrorAs(t, err, &dupErr) {
+					assert.Equal(t, "rr1", dupErr.Record.ID)
+				}
+				mockClient.AssertNotCalled(t, "CreateDNSRecord", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "rr2", rr.ID)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpsertRR(t *testing.T) {
+	existing := models.DNSRecord{ID: "rr1", Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 3600, Comment: "web", ZoneID: "12345"}
+
+	t.Run("update existing record", func(t *testing.T) {
+		mockClient := new(MockClient)
+		mockClient.On("ZoneIDByName", "example.com").
+			Return("12345", nil)
+		mockClient.On("ListDNSRecords", mock.Anything, "12345").
+			Return([]models.DNSRecord{
+				{ID: "rr0", Name: "www.example.com", Type: "A", Content: "192.0.2.2", ZoneID: "12345"},
+				existing,
+			}, nil)
+		mockClient.On("UpdateDNSRecord", mock.Anything, models.UpdateDNSRecordParams{
+			Comment:  "web",
+			Content:  "192.0.2.1",
+			ID:       "rr1",
+			Name:     "www.example.com",
+			TTL:      300,
+			Type:     "A",
+			ZoneID:   "12345",
+			ZoneName: "example.com",
+		}).Return(models.DNSRecord{ID: "rr1", TTL: 300}, nil)
+
+		provider := NewProvider(mockClient)
+
+		rr, err := provider.UpsertRR(context.Background(), "example.com", models.CreateDNSRecordParams{
+			Content: "192.0.2.1",
+			Name:    "WWW.example.com.",
+			TTL:     300,
+			Type:    "a",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "rr1", rr.ID)
+
+		mockClient.AssertExpectations(t)
+		mockClient.AssertNotCalled(t, "CreateDNSRecord", mock.Anything, mock.Anything)
+	})
+
+	t.Run("create missing record", func(t *testing.T) {
+		params := models.CreateDNSRecordParams{
+			Content: "192.0.2.3",
+			Name:    "www.example.com",
+			Type:    "A",
+			ZoneID:  "12345",
+		}
+		want := params
+		want.TTL = DefaultTTL
+		want.ZoneName = "example.com"
+
+		mockClient := new(MockClient)
+		mockClient.On("ListDNSRecords", mock.Anything, "12345").
+			Return([]models.DNSRecord{existing}, nil)
+		mockClient.On("CreateDNSRecord", mock.Anything, want).
+			Return(models.DNSRecord{ID: "rr2"}, nil)
+
+		provider := NewProvider(mockClient)
+
+		rr, err := provider.UpsertRR(context.Background(), "example.com", params)
+		assert.NoError(t, err)
+		assert.Equal(t, "rr2", rr.ID)
+
+		mockClient.AssertExpectations(t)
+		mockClient.AssertNotCalled(t, "UpdateDNSRecord", mock.Anything, mock.Anything)
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		mockClient := new(MockClient)
+		mockClient.On("ListDNSRecords", mock.Anything, "12345").
+			Return([]models.DNSRecord{}, errors.New("API error"))
+
+		provider := NewProvider(mockClient)
+
+		_, err := provider.UpsertRR(context.Background(), "example.com", models.CreateDNSRecordParams{Name: "www.example.com", Type: "A", Content: "192.0.2.1", ZoneID: "12345"})
+		assert.EqualError(t, err, "API error")
+		mockClient.AssertNotCalled(t, "CreateDNSRecord", mock.Anything, mock.Anything)
+	})
+}
+
+func TestProviderOptions_DefaultTTL(t *testing.T) {
+	cfg := &config.ProviderConfig{
+		Type:    TypeCloudflare,
+		Options: map[string]interface{}{"default_ttl": "auto"},
+	}
+	opts, err := providerOptions(cfg)
+	assert.NoError(t, err)
+	p := NewProvider(new(MockClient), opts...).(*provider)
+	assert.Equal(t, models.TTLAuto, p.defaultTTL)
+
+	cfg.Options["default_ttl"] = 600
+	opts, err = providerOptions(cfg)
+	assert.NoError(t, err)
+	p = NewProvider(new(MockClient), opts...).(*provider)
+	assert.Equal(t, 600, p.defaultTTL)
+
+	cfg.Options["default_ttl"] = "ten minutes"
+	_, err = providerOptions(cfg)
+	assert.Error(t, err)
+	_, ok := err.(*ProviderConfigError)
+	assert.True(t, ok)
+}
+
+// MockAccountClient is a mock repository that also reports account details.
+type MockAccountClient struct {
+	MockClient
+}
+
+func (m *MockAccountClient) AccountInfo(ctx context.Context) (models.AccountInfo, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(models.AccountInfo), args.Error(1)
+}
+
+func TestAccountInfo(t *testing.T) {
+	t.Run("default reports provider type and display name", func(t *testing.T) {
+		provider := NewProvider(new(MockClient), WithProviderType(TypePowerDNS, "PowerDNS"))
+
+		info, err := provider.AccountInfo(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, models.AccountInfo{Provider: "PowerDNS", ProviderType: TypePowerDNS}, info)
+	})
+
+	t.Run("repository details are returned", func(t *testing.T) {
+		mockClient := new(MockAccountClient)
+		mockClient.On("AccountInfo", mock.Anything).
+			Return(models.AccountInfo{Email: "user@example.com", Scopes: []string{"DNS Write"}}, nil)
+
+		provider := NewProvider(mockClient, WithProviderType(TypeCloudflare, "Cloudflare Production"))
+
+		info, err := provider.AccountInfo(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, models.AccountInfo{
+			Provider:     "Cloudflare Production",
+			ProviderType: TypeCloudflare,
+			Email:        "user@example.com",
+			Scopes:       []string{"DNS Write"},
+		}, info)
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockClient := new(MockAccountClient)
+		mockClient.On("AccountInfo", mock.Anything).
+			Return(models.AccountInfo{}, errors.New("invalid token"))
+
+		provider := NewProvider(mockClient, WithProviderType(TypeCloudflare, "Cloudflare"))
+
+		_, err := provider.AccountInfo(context.Background())
+		assert.EqualError(t, err, "invalid token")
+	})
+}
+
+// MockPingClient is a mock repository with a health check of its own.
+type MockPingClient struct {
+	MockClient
+}
+
+func (m *MockPingClient) Ping(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func TestPing(t *testing.T) {
+	t.Run("default lists zones", func(t *testing.T) {
+		mockClient := new(MockClient)
+		mockClient.On("ListZones", mock.Anything, mock.Anything).
+			Return([]models.Zone{}, nil).Once()
+		mockClient.On("ListZones", mock.Anything, mock.Anything).
+			Return([]models.Zone{}, errors.New("connection refused")).Once()
+
+		provider := NewProvider(mockClient)
+
+		assert.NoError(t, provider.Ping(context.Background()))
+		assert.EqualError(t, provider.Ping(context.Background()), "connection refused")
+	})
+
+	t.Run("repository health check", func(t *testing.T) {
+		mockClient := new(MockPingClient)
+		mockClient.On("Ping", mock.Anything).Return(nil).Once()
+		mockClient.On("Ping", mock.Anything).Return(NewProviderCredentialsError(TypeCloudflare, "request rejected", nil)).Once()
+
+		provider := NewProvider(mockClient)
+
+		assert.NoError(t, provider.Ping(context.Background()))
+		assert.ErrorIs(t, provider.Ping(context.Background()), ErrCredentials)
+		mockClient.AssertNotCalled(t, "ListZones", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRepoCloudFlarePing(t *testing.T) {
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/user/tokens/verify", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if status != http.StatusOK {
+			fmt.Fprint(w, `{"success":false,"errors":[{"code":1000,"message":"Invalid API Token"}],"messages":[],"result":null}`)
+			return
+		}
+		fmt.Fprint(w, `{"success":true,"errors":[],"messages":[],"result":{"id":"token","status":"active"}}`)
+	}))
+	defer srv.Close()
+
+	api, err := cloudflare.NewWithAPIToken("token", cloudflare.BaseURL(srv.URL))
+	require.NoError(t, err)
+	repo := NewRepoCloudFlare(api).(pingRepo)
+
+	assert.NoError(t, repo.Ping(context.Background()))
+
+	status = http.StatusUnauthorized
+	assert.ErrorIs(t, repo.Ping(context.Background()), ErrCredentials)
+}
+
+// MockCountClient is a mock repository able to count records.
+type MockCountClient struct {
+	MockClient
+}
+
+func (m *MockCountClient) CountDNSRecords(ctx context.Context, zoneID string) (int, error) {
+	args := m.Called(ctx, zoneID)
+	return args.Int(0), args.Error(1)
+}
+
+func TestCountRecords(t *testing.T) {
+	t.Run("default lists records", func(t *testing.T) {
+		mockClient := new(MockClient)
+		mockClient.On("ZoneIDByName", "example.com").Return("12345", nil)
+		mockClient.On("ListDNSRecords", mock.Anything, "12345").
+			Return([]models.DNSRecord{{ID: "1"}, {ID: "2"}}, nil)
+
+		n, err := NewProvider(mockClient).CountRecords(context.Background(), "example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, n)
+	})
+
+	t.Run("repository count", func(t *testing.T) {
+		mockClient := new(MockCountClient)
+		mockClient.On("ZoneIDByName", "example.com").Return("12345", nil)
+		mockClient.On("CountDNSRecords", mock.Anything, "12345").Return(1500, nil)
+
+		n, err := NewProvider(mockClient).CountRecords(context.Background(), "example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, 1500, n)
+		mockClient.AssertNotCalled(t, "ListDNSRecords", mock.Anything, mock.Anything)
+	})
+
+	t.Run("zone lookup error", func(t *testing.T) {
+		mockClient := new(MockCountClient)
+		mockClient.On("ZoneIDByName", "missing.com").Return("", NewNotFoundError("zone", "missing.com", nil))
+
+		_, err := NewProvider(mockClient).CountRecords(context.Background(), "missing.com")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestRepoCloudFlareCountDNSRecords(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/zones/12345/dns_records", r.URL.Path)
+		// A single record is requested, the total comes with the result info
+		assert.Equal(t, "1", r.URL.Query().Get("per_page"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"success":true,"errors":[],"messages":[],"result":[{"id":"1","type":"A","name":"www.example.com","content":"192.0.2.1"}],`+
+			`"result_info":{"page":1,"per_page":1,"count":1,"total_count":1500,"total_pages":1500}}`)
+	}))
+	defer srv.Close()
+
+	api, err := cloudflare.NewWithAPIToken("token", cloudflare.BaseURL(srv.URL))
+	require.NoError(t, err)
+	repo := NewRepoCloudFlare(api).(recordCounter)
+
+	n, err := repo.CountDNSRecords(context.Background(), "12345")
+	require.NoError(t, err)
+	assert.Equal(t, 1500, n)
+}
+
+func TestCloudflareTokenScopes(t *testing.T) {
+	token := cloudflare.APIToken{
+		Policies: []cloudflare.APITokenPolicies{
+			{PermissionGroups: []cloudflare.APITokenPermissionGroups{{Name: "DNS Write"}, {Name: "Zone Read"}}},
+			{PermissionGroups: []cloudflare.APITokenPermissionGroups{{Name: "Zone Read"}, {ID: "no-name"}}},
+		},
+	}
+	assert.Equal(t, []string{"DNS Write", "Zone Read"}, cloudflareTokenScopes(token))
+	assert.Nil(t, cloudflareTokenScopes(cloudflare.APIToken{}))
+}
+
+func TestConvTXTContent(t *testing.T) {
+	long := strings.Repeat("a", 300)
+	quoted := `"` + strings.Repeat("a", 255) + `" "` + strings.Repeat("a", 45) + `"`
+
+	params := convToCreateDNSRecordParams(models.CreateDNSRecordParams{Type: "TXT", Content: long})
+	assert.Equal(t, quoted, params.Content)
+
+	update := convToUpdateDNSRecordParams(models.UpdateDNSRecordParams{Type: "TXT", Content: long})
+	assert.Equal(t, quoted, update.Content)
+
+	rr := convFromDNSRecord(cloudflare.DNSRecord{Type: "TXT", Content: quoted})
+	assert.Equal(t, long, rr.Content)
+
+	rrset := convFromDNSRecords([]cloudflare.DNSRecord{{Type: "TXT", Content: quoted}, {Type: "A", Content: "192.0.2.1"}})
+	assert.Equal(t, long, rrset[0].Content)
+	assert.Equal(t, "192.0.2.1", rrset[1].Content)
+}
+
+func TestConvPriority(t *testing.T) {
+	params := convToCreateDNSRecordParams(models.CreateDNSRecordParams{Type: "MX", Content: "mail.example.com", Priority: 10})
+	assert.Equal(t, cloudflare.Uint16Ptr(10), params.Priority)
+
+	// A zero MX priority is sent, other types have none
+	update := convToUpdateDNSRecordParams(models.UpdateDNSRecordParams{Type: "MX", Content: "mail.example.com"})
+	assert.Equal(t, cloudflare.Uint16Ptr(0), update.Priority)
+	params = convToCreateDNSRecordParams(models.CreateDNSRecordParams{Type: "A", Content: "192.0.2.1", Priority: 10})
+	assert.Nil(t, params.Priority)
+
+	rr := convFromDNSRecord(cloudflare.DNSRecord{Type: "SRV", Content: "5 5060 sip.example.com", Priority: cloudflare.Uint16Ptr(20)})
+	assert.Equal(t, 20, rr.Priority)
+
+	rrset := convFromDNSRecords([]cloudflare.DNSRecord{{Type: "MX", Content: "mail.example.com", Priority: cloudflare.Uint16Ptr(10)}, {Type: "A", Content: "192.0.2.1"}})
+	assert.Equal(t, 10, rrset[0].Priority)
+	assert.Equal(t, 0, rrset[1].Priority)
+}
+
+func TestProvider_IDN(t *testing.T) {
+	mockClient := new(MockClient)
+	mockClient.On("ZoneIDByName", "xn--mnchen-3ya.de").
+		Return("12345", nil)
+	mockClient.On("CreateDNSRecord", mock.Anything, models.CreateDNSRecordParams{
+		Content:  "192.0.2.1",
+		Name:     "www.xn--mnchen-3ya.de",
+		TTL:      300,
+		Type:     "A",
+		ZoneID:   "12345",
+		ZoneName: "xn--mnchen-3ya.de",
+	}).Return(models.DNSRecord{ID: "1", Name: "www.xn--mnchen-3ya.de"}, nil)
+
+	provider := NewProvider(mockClient)
+	rr, err := provider.AddRR(context.Background(), "münchen.de", models.CreateDNSRecordParams{
+		Content:  "192.0.2.1",
+		Name:     "www.münchen.de",
+		TTL:      300,
+		Type:     "A",
+		ZoneName: "münchen.de",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "www.xn--mnchen-3ya.de", rr.Name)
+	mockClient.AssertExpectations(t)
+
+	_, err = provider.ListZonesByName(context.Background(), "a‍б.de")
+	assert.Error(t, err)
+}
+
+func TestGetRRByID(t *testing.T) {
+	want := models.DNSRecord{ID: "rr2", Name: "www.example.com", Type: "AAAA", Content: "2001:db8::1"}
+
+	mockClient := new(MockClient)
+	mockClient.On("ZoneIDByName", "example.com").
+		Return("12345", nil)
+	mockClient.On("GetDNSRecord", mock.Anything, "12345", "rr2").
+		Return(want, nil)
+
+	provider := NewProvider(mockClient)
+	rr, err := provider.GetRRByID(context.Background(), models.ListDNSRecordsParams{ZoneName: "example.com", ID: "rr2"})
+	assert.NoError(t, err)
+	assert.Equal(t, want, rr)
+	mockClient.AssertExpectations(t)
+
+	mockClient = new(MockClient)
+	mockClient.On("ZoneIDByName", "missing.com").
+		Return("", errors.New("zone not found"))
+
+	provider = NewProvider(mockClient)
+	_, err = provider.GetRRByID(context.Background(), models.ListDNSRecordsParams{ZoneName: "missing.com", ID: "rr2"})
+	assert.EqualError(t, err, "zone not found")
+}
+
+func TestProvider_ZoneIDSkipsLookup(t *testing.T) {
+	ctx := context.Background()
+	rr := models.DNSRecord{ID: "rr1", Name: "www.example.com", Type: "A", Content: "192.0.2.1", ZoneID: "12345"}
+
+	mockClient := new(MockClient)
+	mockClient.On("CreateDNSRecord", mock.Anything, mock.MatchedBy(func(p models.CreateDNSRecordParams) bool {
+		return p.ZoneID == "12345"
+	})).Return(rr, nil)
+	mockClient.On("ListDNSRecords", mock.Anything, "12345").
+		Return([]models.DNSRecord{rr}, nil)
+	mockClient.On("GetDNSRecord", mock.Anything, "12345", "rr1").
+		Return(rr, nil)
+	mockClient.On("UpdateDNSRecord", mock.Anything, mock.MatchedBy(func(p models.UpdateDNSRecordParams) bool {
+		return p.ZoneID == "12345"
+	})).Return(rr, nil)
+	mockClient.On("DeleteDNSRecord", mock.Anything, "12345", "rr1").
+		Return(nil)
+
+	provider := NewProvider(mockClient)
+
+	_, err := provider.AddRR(ctx, "", models.CreateDNSRecordParams{Name: "www.example.com", Type: "A", Content: "192.0.2.1", ZoneID: "12345"})
+	assert.NoError(t, err)
+	_, err = provider.ListRecords(ctx, models.ListDNSRecordsParams{ZoneID: "12345"})
+	assert.NoError(t, err)
+	_, err = provider.GetRRByID(ctx, models.ListDNSRecordsParams{ZoneID: "12345", ID: "rr1"})
+	assert.NoError(t, err)
+	_, err = provider.UpdateRR(ctx, "", rr)
+	assert.NoError(t, err)
+	assert.NoError(t, provider.DeleteRR(ctx, "", rr))
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "ZoneIDByName", mock.Anything)
+}
+
+func TestConvCloudflareError(t *testing.T) {
+	cfErr := &cloudflare.Error{StatusCode: 403}
+
+	err := convCloudflareError(fmt.Errorf("ListZonesContext command failed: %w", &cloudflare.AuthenticationError{}), "", "")
+	var credsErr *ProviderCredentialsError
+	assert.ErrorAs(t, err, &credsErr)
+
+	assert.False(t, errors.Is(err, ErrForbidden))
+
+	err = convCloudflareError(&cloudflare.AuthorizationError{}, "record with ID", "1")
+	assert.ErrorAs(t, err, &credsErr)
+	assert.ErrorIs(t, err, ErrForbidden)
+	assert.ErrorIs(t, err, ErrCredentials)
+
+	err = convCloudflareError(&cloudflare.NotFoundError{}, "record with ID", "1")
+	var notFoundErr *NotFoundError
+	if assert.ErrorAs(t, err, &notFoundErr) {
+		assert.Equal(t, "record with ID 1 not found", notFoundErr.Error())
+	}
+
+	err = convCloudflareError(&cloudflare.NotFoundError{}, "", "")
+	assert.False(t, errors.As(err, &notFoundErr))
+
+	assert.Equal(t, cfErr, convCloudflareError(cfErr, "zone", "example.com"))
+	assert.NoError(t, convCloudflareError(nil, "zone", "example.com"))
+}
+
+func TestRecordValidation(t *testing.T) {
+	ctx := context.Background()
+	// The mock has no expectations: invalid records must not reach the API
+	p := NewProvider(new(MockClient))
+
+	_, err := p.AddRR(ctx, "example.com", models.CreateDNSRecordParams{Name: "www.example.com", Type: "A", Content: "2001:db8::1"})
+	var validationErr *models.ValidationError
+	if assert.ErrorAs(t, err, &validationErr) {
+		assert.Equal(t, "content", validationErr.Field)
+	}
+
+	_, err = p.UpsertRR(ctx, "example.com", models.CreateDNSRecordParams{Name: "www.example.com", Type: "MX", Content: "mail.example.com", TTL: -1})
+	if assert.ErrorAs(t, err, &validationErr) {
+		assert.Equal(t, "ttl", validationErr.Field)
+	}
+
+	_, err = p.UpdateRR(ctx, "example.com", models.DNSRecord{ID: "1", Name: "bad name.example.com", Type: "A", Content: "192.0.2.1"})
+	if assert.ErrorAs(t, err, &validationErr) {
+		assert.Equal(t, "name", validationErr.Field)
+	}
+}
+
+// capsClient is a MockClient with the capabilities of a real repository.
+type capsClient struct {
+	*MockClient
+	caps models.Capabilities
+}
+
+func (c capsClient) Capabilities() models.Capabilities {
+	return c.caps
+}
+
+func TestTTLBounds(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		caps   models.Capabilities
+		accept []int
+		reject []int
+	}{
+		{"cloudflare", NewProvider(NewRepoCloudFlare(&cloudflare.API{})).Capabilities(), []int{models.TTLAuto, 60, 86400}, []int{2, 59, 86401}},
+		{"regru", NewProvider(newRepoRegRu(nil)).Capabilities(), []int{300, 604800}, []int{models.TTLAuto, 299, 604801}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, ttl := range tt.accept {
+				mockClient := new(MockClient)
+				params := models.CreateDNSRecordParams{Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: ttl, ZoneID: "1", ZoneName: "example.com"}
+				rr := models.DNSRecord{ID: "2", Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: ttl, ZoneID: "1"}
+				mockClient.On("CreateDNSRecord", mock.Anything, params).Return(rr, nil)
+				mockClient.On("UpdateDNSRecord", mock.Anything, mock.Anything).Return(rr, nil)
+				p := NewProvider(capsClient{mockClient, tt.caps})
+
+				_, err := p.AddRR(ctx, "example.com", params)
+				assert.NoError(t, err, ttl)
+				_, err = p.UpdateRR(ctx, "example.com", rr)
+				assert.NoError(t, err, ttl)
+			}
+
+			for _, ttl := range tt.reject {
+				// Rejected before any API call, the mock has no expectations
+				p := NewProvider(capsClient{new(MockClient), tt.caps})
+				var validationErr *models.ValidationError
+
+				_, err := p.AddRR(ctx, "example.com", models.CreateDNSRecordParams{Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: ttl})
+				if assert.ErrorAs(t, err, &validationErr, ttl) {
+					assert.Equal(t, "ttl", validationErr.Field)
+				}
+				_, err = p.UpsertRR(ctx, "example.com", models.CreateDNSRecordParams{Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: ttl})
+				assert.ErrorAs(t, err, &validationErr, ttl)
+				_, err = p.UpdateRR(ctx, "example.com", models.DNSRecord{ID: "2", Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: ttl, ZoneID: "1"})
+				assert.ErrorAs(t, err, &validationErr, ttl)
+			}
+		})
+	}
+
+	// The default TTL applies to records without one and must be in range too
+	p := NewProvider(capsClient{new(MockClient), models.Capabilities{MinTTL: 60, MaxTTL: 86400}}, WithDefaultTTL(100000))
+	_, err := p.AddRR(ctx, "example.com", models.CreateDNSRecordParams{Name: "www.example.com", Type: "A", Content: "192.0.2.1"})
+	assert.ErrorContains(t, err, "TTL 100000 is out of range")
+}
+
+func TestSingleZone(t *testing.T) {
+	ctx := context.Background()
+	forbidden := NewProviderForbiddenError(TypeCloudflare, "request rejected", &cloudflare.AuthorizationError{})
+	rrset := []models.DNSRecord{{ID: "1", Name: "www.example.com", Type: "A", Content: "192.0.2.1"}}
+
+	t.Run("configured zone ID", func(t *testing.T) {
+		mockClient := new(MockClient)
+		mockClient.On("ListZones", mock.Anything).Return([]models.Zone{}, forbidden)
+		mockClient.On("ListDNSRecords", mock.Anything, "zone-id").Return(rrset, nil)
+		p := NewProvider(mockClient, WithSingleZone(models.Zone{ID: "zone-id", Name: "example.com"}))
+
+		zones, err := p.ListZones(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []models.Zone{{ID: "zone-id", Name: "example.com"}}, zones)
+
+		zones, err = p.ListZonesByName(ctx, "Example.COM.")
+		require.NoError(t, err)
+		assert.Equal(t, []models.Zone{{ID: "zone-id", Name: "example.com"}}, zones)
+
+		// Other zones are not listed
+		_, err = p.ListZonesByName(ctx, "example.org")
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		// Records are reached without looking the zone up
+		got, err := p.ListRecords(ctx, models.ListDNSRecordsParams{ZoneName: "example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, rrset, got)
+		mockClient.AssertNotCalled(t, "ZoneIDByName", mock.Anything)
+	})
+
+	t.Run("zone ID looked up", func(t *testing.T) {
+		mockClient := new(MockClient)
+		mockClient.On("ListZones", mock.Anything).Return([]models.Zone{}, forbidden)
+		mockClient.On("ZoneIDByName", "example.com").Return("zone-id", nil)
+		p := NewProvider(mockClient, WithSingleZone(models.Zone{Name: "example.com"}))
+
+		zones, err := p.ListZones(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []models.Zone{{ID: "zone-id", Name: "example.com"}}, zones)
+	})
+
+	t.Run("lookup fails", func(t *testing.T) {
+		mockClient := new(MockClient)
+		mockClient.On("ListZones", mock.Anything).Return([]models.Zone{}, forbidden)
+		mockClient.On("ZoneIDByName", "example.com").Return("", forbidden)
+		p := NewProvider(mockClient, WithSingleZone(models.Zone{Name: "example.com"}))
+
+		_, err := p.ListZones(ctx)
+		assert.Equal(t, forbidden, err)
+	})
+
+	t.Run("other errors", func(t *testing.T) {
+		mockClient := new(MockClient)
+		mockClient.On("ListZones", mock.Anything).Return([]models.Zone{}, errors.New("timeout"))
+		p := NewProvider(mockClient, WithSingleZone(models.Zone{ID: "zone-id", Name: "example.com"}))
+
+		_, err := p.ListZones(ctx)
+		assert.EqualError(t, err, "timeout")
+	})
+
+	t.Run("no single zone", func(t *testing.T) {
+		mockClient := new(MockClient)
+		mockClient.On("ListZones", mock.Anything).Return([]models.Zone{}, forbidden)
+		p := NewProvider(mockClient, WithSingleZone(models.Zone{ID: "zone-id"}))
+
+		_, err := p.ListZones(ctx)
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
+}
+
+func TestCapabilities(t *testing.T) {
+	cf := NewProvider(NewRepoCloudFlare(&cloudflare.API{})).Capabilities()
+	assert.True(t, cf.Proxied)
+	assert.True(t, cf.Comments)
+	assert.True(t, cf.Tags)
+	assert.True(t, cf.SupportsType("ptr"))
+
+	regRu := NewProvider(newRepoRegRu(nil)).Capabilities()
+	assert.False(t, regRu.Proxied)
+	assert.False(t, regRu.Comments)
+	assert.False(t, regRu.Tags)
+	assert.True(t, regRu.SupportsType("SRV"))
+	assert.False(t, regRu.SupportsType("CAA"))
+
+	// Repositories that do not describe themselves get the common types only
+	def := NewProvider(new(MockClient)).Capabilities()
+	assert.Equal(t, models.Capabilities{RecordTypes: models.CommonRecordTypes}, def)
+}
+
+func TestCheckCapabilities(t *testing.T) {
+	caps := models.Capabilities{RecordTypes: []string{"A", "TXT"}}
+
+	assert.NoError(t, CheckCapabilities(caps, models.CreateDNSRecordParams{Type: "a"}))
+
+	tests := []struct {
+		params models.CreateDNSRecordParams
+		want   string
+	}{
+		{params: models.CreateDNSRecordParams{Type: "caa"}, want: "the provider does not support record type CAA"},
+		{params: models.CreateDNSRecordParams{Type: "A", Proxied: true}, want: "the provider does not support proxying"},
+		{params: models.CreateDNSRecordParams{Type: "A", Comment: "web"}, want: "the provider does not support comments"},
+		{params: models.CreateDNSRecordParams{Type: "TXT", Tags: []string{"env:prod"}}, want: "the provider does not support tags"},
+	}
+	for _, tt := range tests {
+		err := CheckCapabilities(caps, tt.params)
+		assert.EqualError(t, err, tt.want)
+		assert.ErrorIs(t, err, ErrUnsupported)
+	}
+
+	all := models.Capabilities{Proxied: true, Comments: true, Tags: true}
+	assert.NoError(t, CheckCapabilities(all, models.CreateDNSRecordParams{Type: "PTR", Proxied: true, Comment: "web", Tags: []string{"env:prod"}}))
+}
+
+// MockNameServersClient is a mock repository able to change name servers.
+type MockNameServersClient struct {
+	MockClient
+}
+
+func (m *MockNameServersClient) UpdateNameServers(ctx context.Context, zoneID string, ns []string) error {
+	args := m.Called(ctx, zoneID, ns)
+	return args.Error(0)
+}
+
+func TestUpdateNameServers(t *testing.T) {
+	t.Run("unsupported", func(t *testing.T) {
+		err := NewProvider(new(MockClient)).UpdateNameServers(context.Background(), "example.com", []string{"ns1.example.net", "ns2.example.net"})
+		assert.ErrorIs(t, err, ErrUnsupported)
+	})
+
+	t.Run("invalid name servers", func(t *testing.T) {
+		mockClient := new(MockNameServersClient)
+
+		err := NewProvider(mockClient).UpdateNameServers(context.Background(), "example.com", []string{"ns1.example.net"})
+		assert.ErrorContains(t, err, "needs 2 to 4 name servers")
+		mockClient.AssertNotCalled(t, "UpdateNameServers", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("updates the zone", func(t *testing.T) {
+		mockClient := new(MockNameServersClient)
+		mockClient.On("ZoneIDByName", "xn--mnchen-3ya.de").Return("12345", nil)
+		mockClient.On("UpdateNameServers", mock.Anything, "12345", []string{"ns1.xn--mnchen-3ya.de", "ns2.example.net"}).Return(nil)
+
+		ns := []string{"ns1.münchen.de", "ns2.example.net"}
+		err := NewProvider(mockClient).UpdateNameServers(context.Background(), "münchen.de", ns)
+		assert.NoError(t, err)
+		assert.Equal(t, "ns1.münchen.de", ns[0], "the caller's slice is left alone")
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestRepoCloudFlareUpdateNameServers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/zones/12345", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"vanity_name_servers":["ns1.example.net","ns2.example.net"]}`, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"success":true,"errors":[],"messages":[],"result":{"id":"12345","name":"example.com"}}`)
+	}))
+	defer srv.Close()
+
+	api, err := cloudflare.NewWithAPIToken("token", cloudflare.BaseURL(srv.URL))
+	require.NoError(t, err)
+	repo := NewRepoCloudFlare(api).(nameServersRepo)
+
+	assert.NoError(t, repo.UpdateNameServers(context.Background(), "12345", []string{"ns1.example.net", "ns2.example.net"}))
+}
+
+// cloudflareTestExport is a zone file as exported by Cloudflare.
+const cloudflareTestExport = `;;
+;; Domain:     example.com.
+;; Exported:   2026-10-15 09:41:02
+;;
+;; This file is intended for use for informational and archival
+;; purposes ONLY and MUST be edited before use on a production
+;; DNS server.
+;;
+
+;; SOA Record
+example.com	3600	IN	SOA	kristina.ns.cloudflare.com. dns.cloudflare.com. 2051896520 10000 2400 604800 3600
+
+;; NS Records
+example.com.	86400	IN	NS	kristina.ns.cloudflare.com.
+example.com.	86400	IN	NS	rob.ns.cloudflare.com.
+
+;; A Records
+www.example.com.	1	IN	A	192.0.2.1 ; cf_tags=cf-proxied:true
+`
+
+func TestConvFromCloudflareExportSOA(t *testing.T) {
+	soa, err := convFromCloudflareExportSOA(cloudflareTestExport)
+	require.NoError(t, err)
+	assert.Equal(t, models.SOA{
+		MName:   "kristina.ns.cloudflare.com",
+		RName:   "dns.cloudflare.com",
+		Serial:  2051896520,
+		Refresh: 10000,
+		Retry:   2400,
+		Expire:  604800,
+		Minimum: 3600,
+		TTL:     3600,
+	}, soa)
+
+	_, err = convFromCloudflareExportSOA("www.example.com. 1 IN A 192.0.2.1\n")
+	assert.EqualError(t, err, "the zone export holds no SOA record")
+
+	_, err = convFromCloudflareExportSOA("example.com. 3600 IN SOA broken\n")
+	assert.ErrorContains(t, err, "failed to parse the zone export")
+}
+
+// MockSOAClient is a mock repository able to read the SOA record.
+type MockSOAClient struct {
+	MockClient
+}
+
+func (m *MockSOAClient) GetSOA(ctx context.Context, zoneID string) (models.SOA, error) {
+	args := m.Called(ctx, zoneID)
+	return args.Get(0).(models.SOA), args.Error(1)
+}
+
+func TestGetSOA(t *testing.T) {
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := NewProvider(new(MockClient)).GetSOA(context.Background(), "example.com")
+		assert.ErrorIs(t, err, ErrUnsupported)
+	})
+
+	t.Run("reads the zone", func(t *testing.T) {
+		mockClient := new(MockSOAClient)
+		mockClient.On("ZoneIDByName", "xn--mnchen-3ya.de").Return("12345", nil)
+		mockClient.On("GetSOA", mock.Anything, "12345").Return(models.SOA{MName: "ns1.example.net", Serial: 7}, nil)
+
+		soa, err := NewProvider(mockClient).GetSOA(context.Background(), "münchen.de.")
+		require.NoError(t, err)
+		assert.Equal(t, models.SOA{Zone: "xn--mnchen-3ya.de", MName: "ns1.example.net", Serial: 7}, soa)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestRepoCloudFlareGetSOA(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/zones/12345/dns_records/export", r.URL.Path)
+		fmt.Fprint(w, cloudflareTestExport)
+	}))
+	defer srv.Close()
+
+	api, err := cloudflare.NewWithAPIToken("token", cloudflare.BaseURL(srv.URL))
+	require.NoError(t, err)
+	repo := NewRepoCloudFlare(api).(soaRepo)
+
+	soa, err := repo.GetSOA(context.Background(), "12345")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2051896520), soa.Serial)
+}
+
+func TestConvFromZoneDNSSEC(t *testing.T) {
+	modified := time.Date(2026, 10, 15, 9, 41, 2, 0, time.UTC)
+	dnssec := cloudflare.ZoneDNSSEC{
+		Status:          "active",
+		Flags:           257,
+		Algorithm:       "13",
+		KeyType:         "ECDSAP256SHA256",
+		DigestType:      "2",
+		DigestAlgorithm: "SHA256",
+		Digest:          "48E939042E82C22542CB377B580DFDC52A361CEFDC72E7F9107E2B6BD9306A45",
+		DS:              "example.com. 3600 IN DS 16953 13 2 48E939042E82C22542CB377B580DFDC52A361CEFDC72E7F9107E2B6BD9306A45",
+		KeyTag:          16953,
+		PublicKey:       "oXiGYrSTO+LSCJ3mohc8EP+CzF9KxBj8/ydXJ22pKuZP3VAC3/Md/k7xZfz470CoRyZJ6gV6vml07IC3d8xqhA==",
+		ModifiedOn:      modified,
+	}
+
+	assert.Equal(t, models.DNSSECStatus{
+		Status:     "active",
+		DS:         dnssec.DS,
+		KeyTag:     16953,
+		Algorithm:  "13",
+		DigestType: "2",
+		Digest:     dnssec.Digest,
+		Flags:      257,
+		PublicKey:  dnssec.PublicKey,
+		ModifiedOn: modified,
+	}, convFromZoneDNSSEC(dnssec))
+
+	// Pending zones are about to be signed, the DS record is already valid
+	dnssec.Status = "pending"
+	assert.Equal(t, dnssec.DS, convFromZoneDNSSEC(dnssec).DS)
+
+	// Once signing stops the DS record must not be added anymore
+	for _, status := range []string{"disabled", "pending-disabled"} {
+		dnssec.Status = status
+		assert.Equal(t, models.DNSSECStatus{Status: status, ModifiedOn: modified}, convFromZoneDNSSEC(dnssec))
+	}
+}
+
+// MockDNSSECClient is a mock repository able to sign zones.
+type MockDNSSECClient struct {
+	MockClient
+}
+
+func (m *MockDNSSECClient) GetDNSSEC(ctx context.Context, zoneID string) (models.DNSSECStatus, error) {
+	args := m.Called(ctx, zoneID)
+	return args.Get(0).(models.DNSSECStatus), args.Error(1)
+}
+
+func (m *MockDNSSECClient) SetDNSSEC(ctx context.Context, zoneID string, enable bool) (models.DNSSECStatus, error) {
+	args := m.Called(ctx, zoneID, enable)
+	return args.Get(0).(models.DNSSECStatus), args.Error(1)
+}
+
+func TestDNSSEC(t *testing.T) {
+	t.Run("unsupported", func(t *testing.T) {
+		p := NewProvider(new(MockClient))
+		_, err := p.GetDNSSEC(context.Background(), "example.com")
+		assert.ErrorIs(t, err, ErrUnsupported)
+		_, err = p.SetDNSSEC(context.Background(), "example.com", true)
+		assert.ErrorIs(t, err, ErrUnsupported)
+	})
+
+	t.Run("status and enable", func(t *testing.T) {
+		mockClient := new(MockDNSSECClient)
+		mockClient.On("ZoneIDByName", "example.com").Return("12345", nil)
+		mockClient.On("GetDNSSEC", mock.Anything, "12345").Return(models.DNSSECStatus{Status: "disabled"}, nil)
+		mockClient.On("SetDNSSEC", mock.Anything, "12345", true).Return(models.DNSSECStatus{Status: "pending", KeyTag: 16953}, nil)
+
+		p := NewProvider(mockClient)
+		status, err := p.GetDNSSEC(context.Background(), "example.com.")
+		require.NoError(t, err)
+		assert.Equal(t, models.DNSSECStatus{Zone: "example.com", Status: "disabled"}, status)
+
+		status, err = p.SetDNSSEC(context.Background(), "example.com", true)
+		require.NoError(t, err)
+		assert.Equal(t, models.DNSSECStatus{Zone: "example.com", Status: "pending", KeyTag: 16953}, status)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestRepoCloudFlareSetDNSSEC(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/zones/12345/dnssec", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"status":"disabled"}`, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"success":true,"errors":[],"messages":[],"result":{"status":"pending-disabled","key_tag":16953,"ds":"example.com. 3600 IN DS 16953 13 2 48E9"}}`)
+	}))
+	defer srv.Close()
+
+	api, err := cloudflare.NewWithAPIToken("token", cloudflare.BaseURL(srv.URL))
+	require.NoError(t, err)
+	repo := NewRepoCloudFlare(api).(dnssecRepo)
+
+	status, err := repo.SetDNSSEC(context.Background(), "12345", false)
+	require.NoError(t, err)
+	assert.Equal(t, models.DNSSECStatus{Status: "pending-disabled"}, status)
+}
@@ -20,12 +20,18 @@ package providers
 const (
 	TypeCloudflare = "cloudflare"
 	TypeRegRu      = "regru"
+	TypePowerDNS   = "powerdns"
+	TypeVultr      = "vultr"
+	TypeRFC2136    = "rfc2136"
 )
 
 // DefaultDisplayNames contains default display names for provider types.
 var DefaultDisplayNames = map[string]string{
 	TypeCloudflare: "Cloudflare",
 	TypeRegRu:      "RegRu",
+	TypePowerDNS:   "PowerDNS",
+	TypeVultr:      "Vultr",
+	TypeRFC2136:    "RFC 2136",
 }
 
 // GetDisplayName returns the display name for a provider type.
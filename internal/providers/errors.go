@@ -17,7 +17,36 @@ limitations under the License.
 package providers
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+)
+
+// Error categories shared by all providers. The concrete error types below report
+// their category through Is, so callers can branch with errors.Is instead of
+// asserting each type:
+//
+//	if errors.Is(err, providers.ErrNotFound) { ... }
+var (
+	// ErrDuplicate is the category of records that would duplicate an existing one.
+	ErrDuplicate = errors.New("duplicate record")
+	// ErrCredentials is the category of missing, invalid or rejected credentials.
+	ErrCredentials = errors.New("credentials error")
+	// ErrForbidden is the category of valid credentials lacking the permission
+	// for a request, a kind of ErrCredentials.
+	ErrForbidden = fmt.Errorf("permission denied: %w", ErrCredentials)
+	// ErrNotFound is the category of zones and records that do not exist.
+	// A provider missing from the config is a configuration error, not ErrNotFound.
+	ErrNotFound = errors.New("not found")
+	// ErrUnsupported is the category of provider types and operations that are not supported.
+	ErrUnsupported = errors.New("not supported")
+	// ErrZoneNotFound is the category of zones that do not exist, a kind of ErrNotFound.
+	ErrZoneNotFound = fmt.Errorf("zone %w", ErrNotFound)
+	// ErrRecordNotFound is the category of records that do not exist in an existing
+	// zone, a kind of ErrNotFound.
+	ErrRecordNotFound = fmt.Errorf("record %w", ErrNotFound)
 )
 
 // ProviderError represents a provider-related error.
@@ -69,6 +98,11 @@ func (e *ProviderTypeNotSupportedError) Error() string {
 	return fmt.Sprintf("unsupported provider type: %q", e.ProviderType)
 }
 
+// Is reports whether target is ErrUnsupported.
+func (e *ProviderTypeNotSupportedError) Is(target error) bool {
+	return target == ErrUnsupported
+}
+
 // ProviderCreationError indicates that a provider could not be created.
 type ProviderCreationError struct {
 	ProviderName string
@@ -124,6 +158,8 @@ type ProviderCredentialsError struct {
 	ProviderType string
 	Message      string
 	Cause        error
+	// Forbidden is set when the credentials are valid but lack a permission
+	Forbidden bool
 }
 
 // Error implements the error interface.
@@ -139,6 +175,84 @@ func (e *ProviderCredentialsError) Unwrap() error {
 	return e.Cause
 }
 
+// Is reports whether target is ErrCredentials, or ErrForbidden as Forbidden is set.
+func (e *ProviderCredentialsError) Is(target error) bool {
+	return target == ErrCredentials || e.Forbidden && target == ErrForbidden
+}
+
+// NotFoundError indicates that a zone or record does not exist.
+type NotFoundError struct {
+	// Resource describes what was looked up, e.g. "zone" or "record with ID"
+	Resource string
+	Name     string
+	Cause    error
+}
+
+// Error implements the error interface.
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s %s not found", e.Resource, e.Name)
+}
+
+// Unwrap returns the underlying error.
+func (e *NotFoundError) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether target is ErrNotFound, or ErrZoneNotFound or ErrRecordNotFound
+// as Resource names a zone or a record.
+func (e *NotFoundError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return true
+	case ErrZoneNotFound:
+		return e.kind() == "zone"
+	case ErrRecordNotFound:
+		return e.kind() == "record"
+	}
+	return false
+}
+
+// kind returns "zone" or "record" when Resource names one, e.g. "record with ID"
+// or "reverse zone", otherwise an empty string.
+func (e *NotFoundError) kind() string {
+	for _, w := range strings.Fields(e.Resource) {
+		if w == "zone" || w == "record" {
+			return w
+		}
+	}
+	return ""
+}
+
+// DuplicateRecordError indicates that a record with the same name, type and content already exists.
+type DuplicateRecordError struct {
+	Record models.DNSRecord
+}
+
+// Error implements the error interface.
+func (e *DuplicateRecordError) Error() string {
+	return fmt.Sprintf("record %s %s %s already exists (ID %s)", e.Record.Name, e.Record.Type, e.Record.Content, e.Record.ID)
+}
+
+// Is reports whether target is ErrDuplicate.
+func (e *DuplicateRecordError) Is(target error) bool {
+	return target == ErrDuplicate
+}
+
+// UnsupportedFeatureError indicates that a provider does not support a record type or feature.
+type UnsupportedFeatureError struct {
+	Feature string
+}
+
+// Error implements the error interface.
+func (e *UnsupportedFeatureError) Error() string {
+	return fmt.Sprintf("the provider does not support %s", e.Feature)
+}
+
+// Is reports whether target is ErrUnsupported.
+func (e *UnsupportedFeatureError) Is(target error) bool {
+	return target == ErrUnsupported
+}
+
 // Helper functions to create errors
 
 // NewProviderNotFoundError creates a new ProviderNotFoundError.
@@ -186,3 +300,20 @@ func NewProviderCredentialsError(providerType, message string, cause error) *Pro
 		Cause:        cause,
 	}
 }
+
+// NewProviderForbiddenError creates a new ProviderCredentialsError for valid
+// credentials lacking a permission.
+func NewProviderForbiddenError(providerType, message string, cause error) *ProviderCredentialsError {
+	err := NewProviderCredentialsError(providerType, message, cause)
+	err.Forbidden = true
+	return err
+}
+
+// NewNotFoundError creates a new NotFoundError.
+func NewNotFoundError(resource, name string, cause error) *NotFoundError {
+	return &NotFoundError{
+		Resource: resource,
+		Name:     name,
+		Cause:    cause,
+	}
+}
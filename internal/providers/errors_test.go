@@ -18,8 +18,10 @@ package providers
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/mixanemca/cdnscli/internal/models"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -283,3 +285,77 @@ func TestNewProviderCredentialsError(t *testing.T) {
 	assert.Equal(t, "test message", err.Message)
 	assert.Equal(t, cause, err.Cause)
 }
+
+func TestNewNotFoundError(t *testing.T) {
+	cause := errors.New("test cause")
+	err := NewNotFoundError("zone", "example.com", cause)
+	assert.NotNil(t, err)
+	assert.Equal(t, "zone example.com not found", err.Error())
+	assert.ErrorIs(t, err, cause)
+}
+
+func TestNotFoundError_Kinds(t *testing.T) {
+	tests := []struct {
+		resource  string
+		zoneErr   bool
+		recordErr bool
+	}{
+		{resource: "zone", zoneErr: true},
+		{resource: "zone with ID", zoneErr: true},
+		{resource: "reverse zone", zoneErr: true},
+		{resource: "record", recordErr: true},
+		{resource: "record with ID", recordErr: true},
+		{resource: "PTR record", recordErr: true},
+		{resource: "provider"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.resource, func(t *testing.T) {
+			err := fmt.Errorf("lookup: %w", NewNotFoundError(tt.resource, "example.com", nil))
+			assert.ErrorIs(t, err, ErrNotFound)
+			assert.Equal(t, tt.zoneErr, errors.Is(err, ErrZoneNotFound))
+			assert.Equal(t, tt.recordErr, errors.Is(err, ErrRecordNotFound))
+		})
+	}
+
+	// Both kinds are ErrNotFound, so they share its exit code
+	assert.ErrorIs(t, ErrZoneNotFound, ErrNotFound)
+	assert.ErrorIs(t, ErrRecordNotFound, ErrNotFound)
+	assert.NotErrorIs(t, ErrZoneNotFound, ErrRecordNotFound)
+}
+
+func TestErrorCategories(t *testing.T) {
+	credsErr := NewProviderCredentialsError("cloudflare", "request rejected", errors.New("403 Forbidden"))
+	notFoundErr := NewNotFoundError("record", "www.example.com", errors.New("404"))
+	unsupportedErr := NewProviderTypeNotSupportedError("route53", []string{"cloudflare"})
+	duplicateErr := &DuplicateRecordError{Record: models.DNSRecord{ID: "rr1", Name: "www.example.com", Type: "A", Content: "192.0.2.1"}}
+
+	tests := []struct {
+		name     string
+		err      error
+		category error
+	}{
+		{name: "credentials", err: credsErr, category: ErrCredentials},
+		{name: "not found", err: notFoundErr, category: ErrNotFound},
+		{name: "unsupported type", err: unsupportedErr, category: ErrUnsupported},
+		{name: "duplicate record", err: duplicateErr, category: ErrDuplicate},
+		{name: "wrapped by fmt", err: fmt.Errorf("list records: %w", notFoundErr), category: ErrNotFound},
+		{name: "wrapped by creation error", err: NewProviderCreationError("cf", "cloudflare", "factory creation failed", credsErr), category: ErrCredentials},
+		{name: "wrapped by provider error", err: &ProviderError{ProviderName: "cf", ProviderType: "cloudflare", Message: "list", Cause: notFoundErr}, category: ErrNotFound},
+	}
+
+	categories := []error{ErrCredentials, ErrDuplicate, ErrNotFound, ErrUnsupported}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, c := range categories {
+				assert.Equal(t, c == tt.category, errors.Is(tt.err, c), "errors.Is(%v, %v)", tt.err, c)
+			}
+		})
+	}
+
+	// A provider missing from the config is not a missing zone or record
+	assert.NotErrorIs(t, NewProviderNotFoundError("cf", nil), ErrNotFound)
+	assert.NotErrorIs(t, NewProviderConfigError("cf", "cloudflare", "api_token", "missing", nil), ErrCredentials)
+	assert.EqualError(t, duplicateErr, "record www.example.com A 192.0.2.1 already exists (ID rr1)")
+	// The cause is still reachable
+	assert.ErrorIs(t, fmt.Errorf("x: %w", credsErr), credsErr)
+}
@@ -70,17 +70,22 @@ func (r *providerRegistry) CreateProvider(name string, cfg *config.Config) (Prov
 		return nil, NewProviderConfigError(name, "", "", "failed to get provider configuration", err)
 	}
 
-	// Get factory for provider type
-	factory, exists := r.factories[providerCfg.Type]
+	// Get factory for provider type, aliases such as cf resolve to the canonical type
+	providerType := providerCfg.CanonicalType()
+	factory, exists := r.factories[providerType]
 	if !exists {
 		supported := r.GetSupportedTypes()
 		return nil, NewProviderTypeNotSupportedError(providerCfg.Type, supported)
 	}
 
+	// Factories see the canonical type
+	canonicalCfg := *providerCfg
+	canonicalCfg.Type = providerType
+
 	// Create provider using factory
-	provider, err := factory.CreateProvider(providerCfg)
+	provider, err := factory.CreateProvider(&canonicalCfg)
 	if err != nil {
-		return nil, NewProviderCreationError(name, providerCfg.Type, "factory creation failed", err)
+		return nil, NewProviderCreationError(name, providerType, "factory creation failed", err)
 	}
 
 	return provider, nil
@@ -25,6 +25,7 @@ import (
 	"github.com/mixanemca/cdnscli/internal/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockProviderFactory is a mock implementation of ProviderFactory.
@@ -50,6 +51,11 @@ type MockProvider struct {
 	mock.Mock
 }
 
+func (m *MockProvider) AccountInfo(ctx context.Context) (models.AccountInfo, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(models.AccountInfo), args.Error(1)
+}
+
 func (m *MockProvider) AddRR(ctx context.Context, zone string, params models.CreateDNSRecordParams) (models.DNSRecord, error) {
 	args := m.Called(ctx, zone, params)
 	return args.Get(0).(models.DNSRecord), args.Error(1)
@@ -60,6 +66,11 @@ func (m *MockProvider) DeleteRR(ctx context.Context, zone string, rr models.DNSR
 	return args.Error(0)
 }
 
+func (m *MockProvider) GetRRByID(ctx context.Context, params models.ListDNSRecordsParams) (models.DNSRecord, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(models.DNSRecord), args.Error(1)
+}
+
 func (m *MockProvider) GetRRByName(ctx context.Context, zone, name string) (models.DNSRecord, error) {
 	args := m.Called(ctx, zone, name)
 	return args.Get(0).(models.DNSRecord), args.Error(1)
@@ -90,6 +101,46 @@ func (m *MockProvider) UpdateRR(ctx context.Context, zone string, rr models.DNSR
 	return args.Get(0).(models.DNSRecord), args.Error(1)
 }
 
+func (m *MockProvider) UpsertRR(ctx context.Context, zone string, params models.CreateDNSRecordParams) (models.DNSRecord, error) {
+	args := m.Called(ctx, zone, params)
+	return args.Get(0).(models.DNSRecord), args.Error(1)
+}
+
+func (m *MockProvider) Capabilities() models.Capabilities {
+	args := m.Called()
+	return args.Get(0).(models.Capabilities)
+}
+
+func (m *MockProvider) UpdateNameServers(ctx context.Context, zone string, ns []string) error {
+	args := m.Called(ctx, zone, ns)
+	return args.Error(0)
+}
+
+func (m *MockProvider) GetSOA(ctx context.Context, zone string) (models.SOA, error) {
+	args := m.Called(ctx, zone)
+	return args.Get(0).(models.SOA), args.Error(1)
+}
+
+func (m *MockProvider) GetDNSSEC(ctx context.Context, zone string) (models.DNSSECStatus, error) {
+	args := m.Called(ctx, zone)
+	return args.Get(0).(models.DNSSECStatus), args.Error(1)
+}
+
+func (m *MockProvider) SetDNSSEC(ctx context.Context, zone string, enable bool) (models.DNSSECStatus, error) {
+	args := m.Called(ctx, zone, enable)
+	return args.Get(0).(models.DNSSECStatus), args.Error(1)
+}
+
+func (m *MockProvider) Ping(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockProvider) CountRecords(ctx context.Context, zone string) (int, error) {
+	args := m.Called(ctx, zone)
+	return args.Int(0), args.Error(1)
+}
+
 func TestNewProviderRegistry(t *testing.T) {
 	registry := NewProviderRegistry()
 	assert.NotNil(t, registry)
@@ -144,7 +195,10 @@ func TestProviderRegistry_CreateProvider_Success(t *testing.T) {
 			"token": "test-token",
 		},
 	}
-	factory.On("CreateProvider", providerCfg).Return(mockProvider, nil)
+	// The registry passes the config under the name it is configured with
+	factory.On("CreateProvider", mock.MatchedBy(func(c *config.ProviderConfig) bool {
+		return c.Type == providerCfg.Type && c.Name() == "test-provider"
+	})).Return(mockProvider, nil)
 
 	registry.Register(factory)
 
@@ -196,6 +250,49 @@ func TestProviderRegistry_CreateProvider_UnsupportedType(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestProviderRegistry_CreateProvider_Alias(t *testing.T) {
+	registry := NewProviderRegistry()
+
+	cloudflare := new(MockProviderFactory)
+	cloudflare.On("Type").Return(TypeCloudflare)
+	powerdns := new(MockProviderFactory)
+	powerdns.On("Type").Return(TypePowerDNS)
+
+	mockProvider := new(MockProvider)
+	// The factory gets the canonical type, whatever the spelling in the config
+	cloudflare.On("CreateProvider", mock.MatchedBy(func(c *config.ProviderConfig) bool {
+		return c.Type == TypeCloudflare && c.Name() == "cf"
+	})).Return(mockProvider, nil).Twice()
+
+	registry.Register(cloudflare)
+	registry.Register(powerdns)
+
+	for _, typ := range []string{"cf", " CloudFlare "} {
+		cfg := &config.Config{
+			Providers: map[string]config.ProviderConfig{
+				"cf": {Type: typ},
+			},
+		}
+		provider, err := registry.CreateProvider("cf", cfg)
+		require.NoError(t, err, typ)
+		assert.Equal(t, mockProvider, provider)
+	}
+	cloudflare.AssertExpectations(t)
+	powerdns.AssertNotCalled(t, "CreateProvider", mock.Anything)
+
+	// Unknown types still fail with the supported types
+	cfg := &config.Config{
+		Providers: map[string]config.ProviderConfig{
+			"r53": {Type: "Route53"},
+		},
+	}
+	_, err := registry.CreateProvider("r53", cfg)
+	var typeErr *ProviderTypeNotSupportedError
+	require.ErrorAs(t, err, &typeErr)
+	assert.Equal(t, "Route53", typeErr.ProviderType)
+	assert.ElementsMatch(t, []string{TypeCloudflare, TypePowerDNS}, typeErr.Supported)
+}
+
 func TestProviderRegistry_CreateProvider_FactoryError(t *testing.T) {
 	registry := NewProviderRegistry()
 	
@@ -206,7 +303,9 @@ func TestProviderRegistry_CreateProvider_FactoryError(t *testing.T) {
 		Type: "test-type",
 	}
 	factoryError := errors.New("factory creation failed")
-	factory.On("CreateProvider", providerCfg).Return(nil, factoryError)
+	factory.On("CreateProvider", mock.MatchedBy(func(c *config.ProviderConfig) bool {
+		return c.Type == providerCfg.Type && c.Name() == "test-provider"
+	})).Return(nil, factoryError)
 
 	registry.Register(factory)
 
@@ -0,0 +1,99 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/mixanemca/cdnscli/internal/config"
+)
+
+// traceOutput receives the request traces of providers created with debug on.
+var traceOutput io.Writer = os.Stderr
+
+// newHTTPClient builds the HTTP client of the provider API from the provider
+// options: http_proxy sets the proxy, otherwise HTTP_PROXY and HTTPS_PROXY
+// apply; ca_bundle adds the CA certificates of a PEM file to the system ones,
+// e.g. of a TLS-intercepting corporate proxy; insecure_skip_verify disables
+// the verification of the server certificate. Every request is bounded by the
+// client timeout. With debug on every request is traced to STDERR.
+func newHTTPClient(cfg *config.ProviderConfig) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if proxy := cfg.GetHTTPProxy(); proxy != "" {
+		u, err := url.Parse(proxy)
+		if err == nil && (u.Host == "" || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5")) {
+			err = fmt.Errorf("proxy URL must be http://, https:// or socks5:// with a host, got %q", proxy)
+		}
+		if err != nil {
+			return nil, NewProviderConfigError("", cfg.Type, "options.http_proxy", "invalid proxy URL", err)
+		}
+		transport.Proxy = http.ProxyURL(u)
+	}
+
+	skipVerify, err := cfg.GetInsecureSkipVerify()
+	if err != nil {
+		return nil, NewProviderConfigError("", cfg.Type, "options.insecure_skip_verify", "invalid value", err)
+	}
+	caBundle, err := cfg.GetCABundle()
+	if err != nil {
+		return nil, NewProviderConfigError("", cfg.Type, "options.ca_bundle", "invalid path", err)
+	}
+
+	if skipVerify || caBundle != "" {
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		// #nosec G402 -- opted in by the user, e.g. for a test installation
+		transport.TLSClientConfig.InsecureSkipVerify = skipVerify
+		if caBundle != "" {
+			pool, err := certPool(caBundle)
+			if err != nil {
+				return nil, NewProviderConfigError("", cfg.Type, "options.ca_bundle", "failed to load CA certificates", err)
+			}
+			transport.TLSClientConfig.RootCAs = pool
+		}
+	}
+
+	if cfg.Debug() {
+		return &http.Client{Transport: newTraceTransport(transport, traceOutput, cfg.Type), Timeout: cfg.ClientTimeout()}, nil
+	}
+	return &http.Client{Transport: transport, Timeout: cfg.ClientTimeout()}, nil
+}
+
+// certPool returns the system CA certificates with those of the PEM file at path added.
+func certPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no PEM certificates found in %s", path)
+	}
+	return pool, nil
+}
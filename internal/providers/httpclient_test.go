@@ -0,0 +1,127 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"encoding/pem"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHTTPClient_Default(t *testing.T) {
+	client, err := newHTTPClient(&config.ProviderConfig{Type: TypePowerDNS})
+	require.NoError(t, err)
+	assert.Equal(t, config.DefaultClientTimeout, client.Timeout)
+
+	transport := client.Transport.(*http.Transport)
+	assert.NotNil(t, transport.Proxy, "the proxy environment variables apply")
+	if transport.TLSClientConfig != nil {
+		assert.False(t, transport.TLSClientConfig.InsecureSkipVerify)
+		assert.Nil(t, transport.TLSClientConfig.RootCAs)
+	}
+}
+
+func TestNewHTTPClient_Proxy(t *testing.T) {
+	client, err := newHTTPClient(&config.ProviderConfig{
+		Type:    TypePowerDNS,
+		Options: map[string]interface{}{"http_proxy": " http://proxy.example.com:3128 "},
+	})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "https://api.example.com", nil)
+	require.NoError(t, err)
+	proxy, err := client.Transport.(*http.Transport).Proxy(req)
+	require.NoError(t, err)
+	assert.Equal(t, "http://proxy.example.com:3128", proxy.String())
+
+	for _, bad := range []string{"proxy.example.com:3128", "ftp://proxy.example.com", "http://", "http://%zz"} {
+		_, err := newHTTPClient(&config.ProviderConfig{
+			Type:    TypePowerDNS,
+			Options: map[string]interface{}{"http_proxy": bad},
+		})
+		var cfgErr *ProviderConfigError
+		require.ErrorAs(t, err, &cfgErr, bad)
+		assert.Equal(t, "options.http_proxy", cfgErr.Field)
+	}
+}
+
+func TestNewHTTPClient_InsecureSkipVerify(t *testing.T) {
+	for _, v := range []interface{}{true, "true"} {
+		client, err := newHTTPClient(&config.ProviderConfig{
+			Type:    TypeVultr,
+			Options: map[string]interface{}{"insecure_skip_verify": v},
+		})
+		require.NoError(t, err)
+		assert.True(t, client.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify)
+	}
+
+	_, err := newHTTPClient(&config.ProviderConfig{
+		Type:    TypeVultr,
+		Options: map[string]interface{}{"insecure_skip_verify": "maybe"},
+	})
+	var cfgErr *ProviderConfigError
+	require.ErrorAs(t, err, &cfgErr)
+	assert.Equal(t, "options.insecure_skip_verify", cfgErr.Field)
+}
+
+func TestNewHTTPClient_CABundle(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	srv.StartTLS()
+	defer srv.Close()
+
+	dir := t.TempDir()
+	bundle := filepath.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0o600))
+
+	// Without the bundle the test server certificate is not trusted
+	client, err := newHTTPClient(&config.ProviderConfig{Type: TypeCloudflare})
+	require.NoError(t, err)
+	_, err = client.Get(srv.URL)
+	assert.Error(t, err)
+
+	client, err = newHTTPClient(&config.ProviderConfig{
+		Type:    TypeCloudflare,
+		Options: map[string]interface{}{"ca_bundle": bundle},
+	})
+	require.NoError(t, err)
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	notPEM := filepath.Join(dir, "not.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0o600))
+	for _, path := range []string{notPEM, filepath.Join(dir, "missing.pem")} {
+		_, err := newHTTPClient(&config.ProviderConfig{
+			Type:    TypeCloudflare,
+			Options: map[string]interface{}{"ca_bundle": path},
+		})
+		var cfgErr *ProviderConfigError
+		require.ErrorAs(t, err, &cfgErr, path)
+		assert.Equal(t, "options.ca_bundle", cfgErr.Field)
+	}
+}
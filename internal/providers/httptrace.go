@@ -0,0 +1,87 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// redactedParams are the substrings of query parameter names whose values are
+// replaced by *** in traces, compared in lower case.
+var redactedParams = []string{"key", "token", "secret", "pass", "auth", "signature"}
+
+// traceTransport is an http.RoundTripper writing a line per request to out: the
+// method, the URL with secrets redacted, the status and the latency. Headers
+// and bodies, which carry the credentials, are never written.
+type traceTransport struct {
+	next     http.RoundTripper
+	out      io.Writer
+	provider string
+}
+
+// newTraceTransport returns a transport tracing the requests of next to out,
+// prefixed with the provider type.
+func newTraceTransport(next http.RoundTripper, out io.Writer, provider string) *traceTransport {
+	return &traceTransport{next: next, out: out, provider: provider}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+
+	if err != nil {
+		fmt.Fprintf(t.out, "DEBUG: %s %s %s: error after %s: %v\n", t.provider, req.Method, redactURL(req.URL), elapsed, err)
+	} else {
+		fmt.Fprintf(t.out, "DEBUG: %s %s %s: %s in %s\n", t.provider, req.Method, redactURL(req.URL), resp.Status, elapsed)
+	}
+	return resp, err
+}
+
+// redactURL returns u as a string with the password of the user info and the
+// values of query parameters named like secrets replaced by ***.
+func redactURL(u *url.URL) string {
+	r := *u
+	if r.RawQuery != "" {
+		query := r.Query()
+		for name, values := range query {
+			if isSecretParam(name) {
+				for i := range values {
+					values[i] = "***"
+				}
+			}
+		}
+		r.RawQuery = strings.ReplaceAll(query.Encode(), "%2A%2A%2A", "***")
+	}
+	return r.Redacted()
+}
+
+func isSecretParam(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range redactedParams {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
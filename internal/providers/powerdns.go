@@ -0,0 +1,496 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/mixanemca/cdnscli/internal/config"
+	"github.com/mixanemca/cdnscli/internal/models"
+)
+
+const (
+	// powerDNSDefaultServerID is the server ID used when none is configured.
+	powerDNSDefaultServerID = "localhost"
+
+	powerDNSChangeTypeReplace = "REPLACE"
+	powerDNSChangeTypeDelete  = "DELETE"
+)
+
+// pdnsZone is a zone as returned by the PowerDNS HTTP API.
+type pdnsZone struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Kind        string      `json:"kind,omitempty"`
+	Nameservers []string    `json:"nameservers,omitempty"`
+	RRSets      []pdnsRRSet `json:"rrsets,omitempty"`
+}
+
+// pdnsRRSet is a set of records sharing the same name and type.
+type pdnsRRSet struct {
+	Name       string       `json:"name"`
+	Type       string       `json:"type"`
+	TTL        int          `json:"ttl,omitempty"`
+	ChangeType string       `json:"changetype,omitempty"`
+	Records    []pdnsRecord `json:"records"`
+}
+
+// pdnsRecord is a single record of a RRSet.
+type pdnsRecord struct {
+	Content  string `json:"content"`
+	Disabled bool   `json:"disabled"`
+}
+
+// pdnsPatch is the body of a zone PATCH request.
+type pdnsPatch struct {
+	RRSets []pdnsRRSet `json:"rrsets"`
+}
+
+// pdnsError is an error response of the PowerDNS HTTP API.
+type pdnsError struct {
+	Error string `json:"error"`
+}
+
+type repoPowerDNS struct {
+	apiURL   string
+	apiKey   string
+	serverID string
+	client   *http.Client
+}
+
+// NewRepoPowerDNS creates a repository for PowerDNS authoritative server provider.
+func NewRepoPowerDNS(apiURL, apiKey, serverID string, client *http.Client) Repo {
+	if serverID == "" {
+		serverID = powerDNSDefaultServerID
+	}
+	if client == nil {
+		client = &http.Client{Timeout: config.DefaultClientTimeout}
+	}
+	return &repoPowerDNS{
+		apiURL:   strings.TrimSuffix(apiURL, "/"),
+		apiKey:   apiKey,
+		serverID: serverID,
+		client:   client,
+	}
+}
+
+// Capabilities reports that PowerDNS accepts any record type it knows the format of.
+func (r *repoPowerDNS) Capabilities() models.Capabilities {
+	return models.Capabilities{}
+}
+
+func (r *repoPowerDNS) GetDNSRecord(ctx context.Context, zoneID, recordID string) (models.DNSRecord, error) {
+	rrset, err := r.ListDNSRecords(ctx, zoneID)
+	if err != nil {
+		return models.DNSRecord{}, err
+	}
+
+	for _, rr := range rrset {
+		if rr.ID == recordID {
+			return rr, nil
+		}
+	}
+
+	return models.DNSRecord{}, NewNotFoundError("record with ID", recordID, nil)
+}
+
+func (r *repoPowerDNS) CreateDNSRecord(ctx context.Context, params models.CreateDNSRecordParams) (models.DNSRecord, error) {
+	zoneID := params.ZoneID
+	if zoneID == "" {
+		if params.ZoneName == "" {
+			return models.DNSRecord{}, fmt.Errorf("zone name or zone ID must be provided")
+		}
+		id, err := r.zoneIDByName(ctx, params.ZoneName)
+		if err != nil {
+			return models.DNSRecord{}, err
+		}
+		zoneID = id
+	}
+
+	zone, err := r.getZone(ctx, zoneID)
+	if err != nil {
+		return models.DNSRecord{}, err
+	}
+
+	name := pdnsCanonical(params.Name)
+	rrType := strings.ToUpper(params.Type)
+	record := pdnsRecord{Content: convToPDNSContent(rrType, models.JoinPriority(rrType, params.Priority, params.Content))}
+
+	// PowerDNS replaces a whole RRSet, so keep the existing records of the set.
+	rrset := pdnsRRSet{Name: name, Type: rrType, TTL: params.TTL}
+	if existing, ok := findPDNSRRSet(zone.RRSets, name, rrType); ok {
+		for _, rec := range existing.Records {
+			if rec.Content == record.Content {
+				return models.DNSRecord{}, fmt.Errorf("record %s %s %s already exists", params.Name, rrType, params.Content)
+			}
+		}
+		rrset.Records = append(rrset.Records, existing.Records...)
+	}
+	rrset.Records = append(rrset.Records, record)
+
+	if err := r.patchRRSets(ctx, zoneID, replacePDNSRRSet(rrset)); err != nil {
+		return models.DNSRecord{}, err
+	}
+
+	return convFromPDNSRecord(rrset, record), nil
+}
+
+func (r *repoPowerDNS) DeleteDNSRecord(ctx context.Context, zoneID, recordID string) error {
+	name, rrType, content, err := parsePDNSRecordID(recordID)
+	if err != nil {
+		return err
+	}
+
+	zone, err := r.getZone(ctx, zoneID)
+	if err != nil {
+		return err
+	}
+
+	existing, ok := findPDNSRRSet(zone.RRSets, name, rrType)
+	if !ok {
+		return NewNotFoundError("record with ID", recordID, nil)
+	}
+
+	rrset, found := removePDNSRecord(existing, content)
+	if !found {
+		return NewNotFoundError("record with ID", recordID, nil)
+	}
+
+	if len(rrset.Records) == 0 {
+		return r.patchRRSets(ctx, zoneID, pdnsRRSet{
+			Name:       name,
+			Type:       rrType,
+			ChangeType: powerDNSChangeTypeDelete,
+			Records:    []pdnsRecord{},
+		})
+	}
+
+	return r.patchRRSets(ctx, zoneID, replacePDNSRRSet(rrset))
+}
+
+func (r *repoPowerDNS) ListDNSRecords(ctx context.Context, id string) ([]models.DNSRecord, error) {
+	zone, err := r.getZone(ctx, id)
+	if err != nil {
+		return []models.DNSRecord{}, err
+	}
+
+	return convFromPDNSRRSets(zone.RRSets), nil
+}
+
+func (r *repoPowerDNS) ListZones(ctx context.Context, z ...string) ([]models.Zone, error) {
+	query := url.Values{}
+	if len(z) > 0 && z[0] != "" {
+		query.Set("zone", pdnsCanonical(z[0]))
+	}
+
+	var zones []pdnsZone
+	if err := r.do(ctx, http.MethodGet, r.serverPath("zones"), query, nil, &zones); err != nil {
+		return []models.Zone{}, err
+	}
+
+	return convFromPDNSZones(zones), nil
+}
+
+func (r *repoPowerDNS) UpdateDNSRecord(ctx context.Context, params models.UpdateDNSRecordParams) (models.DNSRecord, error) {
+	oldName, oldType, oldContent, err := parsePDNSRecordID(params.ID)
+	if err != nil {
+		return models.DNSRecord{}, err
+	}
+
+	zoneID := params.ZoneID
+	if zoneID == "" {
+		if params.ZoneName == "" {
+			return models.DNSRecord{}, fmt.Errorf("zone name or zone ID must be provided")
+		}
+		id, err := r.zoneIDByName(ctx, params.ZoneName)
+		if err != nil {
+			return models.DNSRecord{}, err
+		}
+		zoneID = id
+	}
+
+	zone, err := r.getZone(ctx, zoneID)
+	if err != nil {
+		return models.DNSRecord{}, err
+	}
+
+	existing, ok := findPDNSRRSet(zone.RRSets, oldName, oldType)
+	if !ok {
+		return models.DNSRecord{}, NewNotFoundError("record with ID", params.ID, nil)
+	}
+	oldSet, found := removePDNSRecord(existing, oldContent)
+	if !found {
+		return models.DNSRecord{}, NewNotFoundError("record with ID", params.ID, nil)
+	}
+
+	name := pdnsCanonical(params.Name)
+	if params.Name == "" {
+		name = oldName
+	}
+	rrType := strings.ToUpper(params.Type)
+	if rrType == "" {
+		rrType = oldType
+	}
+	ttl := params.TTL
+	if ttl == 0 {
+		ttl = existing.TTL
+	}
+	record := pdnsRecord{Content: convToPDNSContent(rrType, models.JoinPriority(rrType, params.Priority, params.Content))}
+
+	var changes []pdnsRRSet
+	if name == oldName && rrType == oldType {
+		// The record stays in the same RRSet, just replace its content.
+		oldSet.TTL = ttl
+		oldSet.Records = append(oldSet.Records, record)
+		changes = append(changes, replacePDNSRRSet(oldSet))
+	} else {
+		// The record moves to another RRSet.
+		if len(oldSet.Records) == 0 {
+			changes = append(changes, pdnsRRSet{
+				Name:       oldName,
+				Type:       oldType,
+				ChangeType: powerDNSChangeTypeDelete,
+				Records:    []pdnsRecord{},
+			})
+		} else {
+			changes = append(changes, replacePDNSRRSet(oldSet))
+		}
+		newSet := pdnsRRSet{Name: name, Type: rrType, TTL: ttl}
+		if target, ok := findPDNSRRSet(zone.RRSets, name, rrType); ok {
+			newSet.Records = append(newSet.Records, target.Records...)
+		}
+		newSet.Records = append(newSet.Records, record)
+		changes = append(changes, replacePDNSRRSet(newSet))
+	}
+
+	if err := r.patchRRSets(ctx, zoneID, changes...); err != nil {
+		return models.DNSRecord{}, err
+	}
+
+	return convFromPDNSRecord(pdnsRRSet{Name: name, Type: rrType, TTL: ttl}, record), nil
+}
+
+func (r *repoPowerDNS) ZoneIDByName(zoneName string) (string, error) {
+	return r.zoneIDByName(context.Background(), zoneName)
+}
+
+// zoneIDByName looks the zone up within the deadline of ctx.
+func (r *repoPowerDNS) zoneIDByName(ctx context.Context, zoneName string) (string, error) {
+	zones, err := r.ListZones(ctx, zoneName)
+	if err != nil {
+		return "", err
+	}
+
+	if len(zones) == 0 {
+		return "", NewNotFoundError("zone", zoneName, nil)
+	}
+
+	return zones[0].ID, nil
+}
+
+// getZone returns a zone with its RRSets.
+func (r *repoPowerDNS) getZone(ctx context.Context, zoneID string) (pdnsZone, error) {
+	var zone pdnsZone
+	if err := r.do(ctx, http.MethodGet, r.serverPath("zones", zoneID), nil, nil, &zone); err != nil {
+		return pdnsZone{}, err
+	}
+	return zone, nil
+}
+
+// patchRRSets applies RRSet changes to a zone.
+func (r *repoPowerDNS) patchRRSets(ctx context.Context, zoneID string, rrsets ...pdnsRRSet) error {
+	return r.do(ctx, http.MethodPatch, r.serverPath("zones", zoneID), nil, pdnsPatch{RRSets: rrsets}, nil)
+}
+
+// serverPath builds an API path under the configured server.
+func (r *repoPowerDNS) serverPath(elem ...string) string {
+	parts := []string{"api", "v1", "servers", url.PathEscape(r.serverID)}
+	for _, e := range elem {
+		parts = append(parts, url.PathEscape(e))
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+// do performs an API request, encoding body and decoding the response into out if not nil.
+func (r *repoPowerDNS) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	u := r.apiURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-API-Key", r.apiKey)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr pdnsError
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Error != "" {
+			return fmt.Errorf("powerdns API error (HTTP %d): %s", resp.StatusCode, apiErr.Error)
+		}
+		return fmt.Errorf("powerdns API error (HTTP %d)", resp.StatusCode)
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// pdnsCanonical returns the name with a trailing dot as required by PowerDNS.
+func pdnsCanonical(name string) string {
+	if name == "" || strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// pdnsRecordID builds a synthetic record ID, PowerDNS records have no IDs of their own.
+func pdnsRecordID(name, rrType, content string) string {
+	return strings.Join([]string{pdnsCanonical(name), rrType, content}, "/")
+}
+
+// parsePDNSRecordID splits a synthetic record ID built by pdnsRecordID.
+func parsePDNSRecordID(id string) (name, rrType, content string, err error) {
+	parts := strings.SplitN(id, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", fmt.Errorf("invalid PowerDNS record ID %q", id)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+// findPDNSRRSet returns the RRSet with the given name and type.
+func findPDNSRRSet(rrsets []pdnsRRSet, name, rrType string) (pdnsRRSet, bool) {
+	for _, s := range rrsets {
+		if strings.EqualFold(s.Name, name) && strings.EqualFold(s.Type, rrType) {
+			return s, true
+		}
+	}
+	return pdnsRRSet{}, false
+}
+
+// removePDNSRecord returns a copy of rrset without the record with the given content.
+func removePDNSRecord(rrset pdnsRRSet, content string) (pdnsRRSet, bool) {
+	found := false
+	records := make([]pdnsRecord, 0, len(rrset.Records))
+	for _, rec := range rrset.Records {
+		if !found && rec.Content == content {
+			found = true
+			continue
+		}
+		records = append(records, rec)
+	}
+	rrset.Records = records
+	return rrset, found
+}
+
+// replacePDNSRRSet marks rrset to replace the existing one.
+func replacePDNSRRSet(rrset pdnsRRSet) pdnsRRSet {
+	rrset.ChangeType = powerDNSChangeTypeReplace
+	return rrset
+}
+
+// Conversion functions
+
+// convToPDNSContent converts record content to the presentation format expected
+// by the API: TXT values are sent as quoted character-strings.
+func convToPDNSContent(rrType, content string) string {
+	if strings.EqualFold(rrType, "TXT") && !strings.HasPrefix(strings.TrimSpace(content), `"`) {
+		return models.QuoteTXT(models.SplitTXT(content))
+	}
+	return content
+}
+
+// convFromPDNSContent converts record content returned by the API for display:
+// TXT character-strings are reassembled into a single value.
+func convFromPDNSContent(rrType, content string) string {
+	if strings.EqualFold(rrType, "TXT") {
+		return models.JoinTXT(content)
+	}
+	return content
+}
+
+func convFromPDNSRecord(rrset pdnsRRSet, rec pdnsRecord) models.DNSRecord {
+	return models.DNSRecord{
+		ID:      pdnsRecordID(rrset.Name, rrset.Type, rec.Content),
+		Name:    strings.TrimSuffix(rrset.Name, "."),
+		TTL:     rrset.TTL,
+		Type:    rrset.Type,
+		Content: convFromPDNSContent(rrset.Type, rec.Content),
+		Proxied: false, // PowerDNS doesn't support proxying
+	}
+}
+
+func convFromPDNSRRSets(rrsets []pdnsRRSet) []models.DNSRecord {
+	records := make([]models.DNSRecord, 0, len(rrsets))
+	for _, s := range rrsets {
+		for _, rec := range s.Records {
+			records = append(records, convFromPDNSRecord(s, rec))
+		}
+	}
+	return records
+}
+
+func convFromPDNSZones(zones []pdnsZone) []models.Zone {
+	result := make([]models.Zone, 0, len(zones))
+	for _, z := range zones {
+		zone := models.Zone{
+			ID:          z.ID,
+			Name:        strings.TrimSuffix(z.Name, "."),
+			NameServers: z.Nameservers,
+			Status:      z.Kind,
+		}
+		result = append(result, zone)
+	}
+	return result
+}
@@ -0,0 +1,104 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/mixanemca/cdnscli/internal/config"
+)
+
+// powerDNSFactory creates PowerDNS providers.
+type powerDNSFactory struct{}
+
+// NewPowerDNSFactory creates a new PowerDNS provider factory.
+func NewPowerDNSFactory() ProviderFactory {
+	return &powerDNSFactory{}
+}
+
+// Type returns the provider type name.
+func (f *powerDNSFactory) Type() string {
+	return TypePowerDNS
+}
+
+// CreateProvider creates a PowerDNS provider from configuration.
+func (f *powerDNSFactory) CreateProvider(cfg *config.ProviderConfig) (Provider, error) {
+	if cfg.Type != TypePowerDNS {
+		return nil, NewProviderConfigError("", TypePowerDNS, "type",
+			fmt.Sprintf("invalid provider type for PowerDNS factory: %q", cfg.Type), nil)
+	}
+
+	creds, err := cfg.GetPowerDNSCredentials()
+	if err != nil {
+		return nil, NewProviderCredentialsError("powerdns", "failed to get credentials", err)
+	}
+
+	apiURL := strings.TrimSpace(creds.APIURL)
+	apiKey := strings.TrimSpace(creds.APIKey)
+	serverID := strings.TrimSpace(creds.ServerID)
+
+	if apiURL == "" {
+		return nil, NewProviderCredentialsError("powerdns",
+			"api_url is required but not provided in credentials (check config file)", nil)
+	}
+	if err := validateAPIURL(apiURL); err != nil {
+		return nil, NewProviderCredentialsError("powerdns", "invalid api_url", err)
+	}
+	if apiKey == "" {
+		return nil, NewProviderCredentialsError("powerdns",
+			"api_key is required but not provided in credentials (check config file)", nil)
+	}
+
+	opts, err := providerOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient, err := newHTTPClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := NewRepoPowerDNS(apiURL, apiKey, serverID, httpClient)
+
+	// Verify credentials by trying to list zones
+	_, err = repo.ListZones(context.Background())
+	if err != nil {
+		return nil, NewProviderCredentialsError("powerdns",
+			"failed to verify credentials (api_url/api_key may be invalid)", err)
+	}
+
+	return NewProvider(repo, opts...), nil
+}
+
+// validateAPIURL checks that the API URL is an absolute http(s) URL.
+func validateAPIURL(apiURL string) error {
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("scheme must be http or https, got %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("host is required")
+	}
+	return nil
+}
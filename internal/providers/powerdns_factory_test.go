@@ -0,0 +1,199 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPowerDNSFactory_Type(t *testing.T) {
+	factory := NewPowerDNSFactory()
+	assert.Equal(t, "powerdns", factory.Type())
+}
+
+func TestPowerDNSFactory_CreateProvider_InvalidType(t *testing.T) {
+	factory := NewPowerDNSFactory()
+	cfg := &config.ProviderConfig{
+		Type: "invalid-type",
+	}
+
+	provider, err := factory.CreateProvider(cfg)
+	assert.Nil(t, provider)
+	assert.Error(t, err)
+
+	configErr, ok := err.(*ProviderConfigError)
+	assert.True(t, ok)
+	assert.Contains(t, configErr.Error(), "invalid provider type")
+}
+
+func TestPowerDNSFactory_CreateProvider_InvalidCredentials(t *testing.T) {
+	tests := []struct {
+		name        string
+		credentials map[string]interface{}
+		wantErr     string
+	}{
+		{
+			name:        "missing credentials",
+			credentials: map[string]interface{}{},
+			wantErr:     "api_url is required",
+		},
+		{
+			name: "missing api key",
+			credentials: map[string]interface{}{
+				"api_url": "http://127.0.0.1:8081",
+			},
+			wantErr: "api_key is required",
+		},
+		{
+			name: "empty api key",
+			credentials: map[string]interface{}{
+				"api_url": "http://127.0.0.1:8081",
+				"api_key": "   ",
+			},
+			wantErr: "api_key is required",
+		},
+		{
+			name: "url without scheme",
+			credentials: map[string]interface{}{
+				"api_url": "127.0.0.1:8081",
+				"api_key": "secret",
+			},
+			wantErr: "invalid api_url",
+		},
+		{
+			name: "unsupported scheme",
+			credentials: map[string]interface{}{
+				"api_url": "ftp://dns.example.com",
+				"api_key": "secret",
+			},
+			wantErr: "scheme must be http or https",
+		},
+		{
+			name: "url without host",
+			credentials: map[string]interface{}{
+				"api_url": "http://",
+				"api_key": "secret",
+			},
+			wantErr: "host is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := NewPowerDNSFactory()
+			cfg := &config.ProviderConfig{
+				Type:        "powerdns",
+				Credentials: tt.credentials,
+			}
+
+			provider, err := factory.CreateProvider(cfg)
+			assert.Nil(t, provider)
+			assert.Error(t, err)
+
+			credsErr, ok := err.(*ProviderCredentialsError)
+			assert.True(t, ok)
+			assert.Contains(t, credsErr.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestPowerDNSFactory_CreateProvider_Unauthorized(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"Unauthorized"}`))
+	}))
+	defer srv.Close()
+
+	factory := NewPowerDNSFactory()
+	cfg := &config.ProviderConfig{
+		Type: "powerdns",
+		Credentials: map[string]interface{}{
+			"api_url": srv.URL,
+			"api_key": "wrong",
+		},
+	}
+
+	provider, err := factory.CreateProvider(cfg)
+	assert.Nil(t, provider)
+	assert.Error(t, err)
+
+	credsErr, ok := err.(*ProviderCredentialsError)
+	assert.True(t, ok)
+	assert.Contains(t, credsErr.Error(), "failed to verify credentials")
+	assert.Contains(t, credsErr.Error(), "Unauthorized")
+}
+
+func TestPowerDNSFactory_CreateProvider_Success(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/servers/ns1/zones", r.URL.Path)
+		assert.Equal(t, "secret", r.Header.Get("X-API-Key"))
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	factory := NewPowerDNSFactory()
+	cfg := &config.ProviderConfig{
+		Type: "powerdns",
+		Credentials: map[string]interface{}{
+			"api_url":   srv.URL + "/",
+			"api_key":   "secret",
+			"server_id": "ns1",
+		},
+	}
+
+	provider, err := factory.CreateProvider(cfg)
+	assert.NoError(t, err)
+	assert.NotNil(t, provider)
+}
+
+func TestPowerDNSFactory_CreateProvider_Proxy(t *testing.T) {
+	// The proxy gets the requests for the API, which is not reachable otherwise
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer proxy.Close()
+
+	factory := NewPowerDNSFactory()
+	cfg := &config.ProviderConfig{
+		Type: "powerdns",
+		Credentials: map[string]interface{}{
+			"api_url": "http://pdns.invalid:8081",
+			"api_key": "secret",
+		},
+		Options: map[string]interface{}{
+			"http_proxy": proxy.URL,
+		},
+	}
+
+	provider, err := factory.CreateProvider(cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, provider)
+	assert.Equal(t, []string{"http://pdns.invalid:8081/api/v1/servers/localhost/zones"}, proxied)
+
+	cfg.Options["http_proxy"] = "proxy.invalid:3128"
+	_, err = factory.CreateProvider(cfg)
+	var cfgErr *ProviderConfigError
+	require.ErrorAs(t, err, &cfgErr)
+	assert.Equal(t, "options.http_proxy", cfgErr.Field)
+}
@@ -0,0 +1,265 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/config"
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pdnsTestZone is a zone served by newPDNSTestServer.
+const pdnsTestZone = `{
+	"id": "example.com.",
+	"name": "example.com.",
+	"kind": "Native",
+	"rrsets": [
+		{"name": "www.example.com.", "type": "A", "ttl": 300, "records": [
+			{"content": "192.0.2.1", "disabled": false},
+			{"content": "192.0.2.2", "disabled": false}
+		]},
+		{"name": "mail.example.com.", "type": "A", "ttl": 3600, "records": [
+			{"content": "192.0.2.25", "disabled": false}
+		]}
+	]
+}`
+
+// newPDNSTestServer returns a PowerDNS API stub that records PATCH bodies.
+func newPDNSTestServer(t *testing.T, patches *[]pdnsPatch) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("X-API-Key"))
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/servers/localhost/zones":
+			_, _ = w.Write([]byte(`[{"id": "example.com.", "name": "example.com.", "kind": "Native"}]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/servers/localhost/zones/example.com.":
+			_, _ = w.Write([]byte(pdnsTestZone))
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v1/servers/localhost/zones/example.com.":
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			var patch pdnsPatch
+			require.NoError(t, json.Unmarshal(body, &patch))
+			*patches = append(*patches, patch)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"Not Found"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestRepoPowerDNS_ListZones(t *testing.T) {
+	var patches []pdnsPatch
+	srv := newPDNSTestServer(t, &patches)
+	repo := NewRepoPowerDNS(srv.URL, "secret", "", nil)
+
+	zones, err := repo.ListZones(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []models.Zone{{ID: "example.com.", Name: "example.com", Status: "Native"}}, zones)
+
+	id, err := repo.ZoneIDByName("example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com.", id)
+}
+
+func TestRepoPowerDNS_ListDNSRecords(t *testing.T) {
+	var patches []pdnsPatch
+	srv := newPDNSTestServer(t, &patches)
+	repo := NewRepoPowerDNS(srv.URL, "secret", "", nil)
+
+	rrset, err := repo.ListDNSRecords(context.Background(), "example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, []models.DNSRecord{
+		{ID: "www.example.com./A/192.0.2.1", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1"},
+		{ID: "www.example.com./A/192.0.2.2", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.2"},
+		{ID: "mail.example.com./A/192.0.2.25", Name: "mail.example.com", TTL: 3600, Type: "A", Content: "192.0.2.25"},
+	}, rrset)
+}
+
+func TestRepoPowerDNS_CreateDNSRecord(t *testing.T) {
+	var patches []pdnsPatch
+	srv := newPDNSTestServer(t, &patches)
+	repo := NewRepoPowerDNS(srv.URL, "secret", "", nil)
+
+	rr, err := repo.CreateDNSRecord(context.Background(), models.CreateDNSRecordParams{
+		Name:    "www.example.com",
+		Type:    "a",
+		Content: "192.0.2.3",
+		TTL:     300,
+		ZoneID:  "example.com.",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "www.example.com./A/192.0.2.3", rr.ID)
+	assert.False(t, rr.Proxied)
+
+	// The existing records of the RRSet must be kept.
+	require.Len(t, patches, 1)
+	assert.Equal(t, []pdnsRRSet{{
+		Name:       "www.example.com.",
+		Type:       "A",
+		TTL:        300,
+		ChangeType: "REPLACE",
+		Records: []pdnsRecord{
+			{Content: "192.0.2.1"},
+			{Content: "192.0.2.2"},
+			{Content: "192.0.2.3"},
+		},
+	}}, patches[0].RRSets)
+
+	// Creating a duplicate fails without patching.
+	_, err = repo.CreateDNSRecord(context.Background(), models.CreateDNSRecordParams{
+		Name:     "www.example.com",
+		Type:     "A",
+		Content:  "192.0.2.1",
+		ZoneName: "example.com",
+	})
+	assert.EqualError(t, err, "record www.example.com A 192.0.2.1 already exists")
+	assert.Len(t, patches, 1)
+}
+
+func TestRepoPowerDNS_DeleteDNSRecord(t *testing.T) {
+	var patches []pdnsPatch
+	srv := newPDNSTestServer(t, &patches)
+	repo := NewRepoPowerDNS(srv.URL, "secret", "", nil)
+
+	// Deleting one of several records replaces the RRSet.
+	err := repo.DeleteDNSRecord(context.Background(), "example.com.", "www.example.com./A/192.0.2.1")
+	require.NoError(t, err)
+	// Deleting the last record deletes the RRSet.
+	err = repo.DeleteDNSRecord(context.Background(), "example.com.", "mail.example.com./A/192.0.2.25")
+	require.NoError(t, err)
+
+	require.Len(t, patches, 2)
+	assert.Equal(t, []pdnsRRSet{{
+		Name:       "www.example.com.",
+		Type:       "A",
+		TTL:        300,
+		ChangeType: "REPLACE",
+		Records:    []pdnsRecord{{Content: "192.0.2.2"}},
+	}}, patches[0].RRSets)
+	assert.Equal(t, []pdnsRRSet{{
+		Name:       "mail.example.com.",
+		Type:       "A",
+		ChangeType: "DELETE",
+		Records:    []pdnsRecord{},
+	}}, patches[1].RRSets)
+
+	err = repo.DeleteDNSRecord(context.Background(), "example.com.", "www.example.com./A/198.51.100.1")
+	assert.EqualError(t, err, "record with ID www.example.com./A/198.51.100.1 not found")
+}
+
+func TestRepoPowerDNS_UpdateDNSRecord(t *testing.T) {
+	var patches []pdnsPatch
+	srv := newPDNSTestServer(t, &patches)
+	repo := NewRepoPowerDNS(srv.URL, "secret", "", nil)
+
+	rr, err := repo.UpdateDNSRecord(context.Background(), models.UpdateDNSRecordParams{
+		ID:      "www.example.com./A/192.0.2.1",
+		Name:    "www.example.com",
+		Type:    "A",
+		Content: "192.0.2.10",
+		TTL:     600,
+		ZoneID:  "example.com.",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.DNSRecord{
+		ID:      "www.example.com./A/192.0.2.10",
+		Name:    "www.example.com",
+		TTL:     600,
+		Type:    "A",
+		Content: "192.0.2.10",
+	}, rr)
+
+	require.Len(t, patches, 1)
+	assert.Equal(t, []pdnsRRSet{{
+		Name:       "www.example.com.",
+		Type:       "A",
+		TTL:        600,
+		ChangeType: "REPLACE",
+		Records:    []pdnsRecord{{Content: "192.0.2.2"}, {Content: "192.0.2.10"}},
+	}}, patches[0].RRSets)
+}
+
+func TestRepoPowerDNS_APIError(t *testing.T) {
+	var patches []pdnsPatch
+	srv := newPDNSTestServer(t, &patches)
+	repo := NewRepoPowerDNS(srv.URL, "secret", "", nil)
+
+	_, err := repo.ListDNSRecords(context.Background(), "unknown.com.")
+	assert.EqualError(t, err, "powerdns API error (HTTP 404): Not Found")
+}
+
+func TestParsePDNSRecordID(t *testing.T) {
+	name, rrType, content, err := parsePDNSRecordID("example.com./TXT/v=spf1 a/b ~all")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com.", name)
+	assert.Equal(t, "TXT", rrType)
+	assert.Equal(t, "v=spf1 a/b ~all", content)
+
+	_, _, _, err = parsePDNSRecordID("12345")
+	assert.EqualError(t, err, `invalid PowerDNS record ID "12345"`)
+}
+
+func TestRepoPowerDNS_CreateDNSRecord_TXT(t *testing.T) {
+	var patches []pdnsPatch
+	srv := newPDNSTestServer(t, &patches)
+	repo := NewRepoPowerDNS(srv.URL, "secret", "", nil)
+
+	rr, err := repo.CreateDNSRecord(context.Background(), models.CreateDNSRecordParams{
+		Name:     "example.com",
+		Type:     "TXT",
+		Content:  `v=spf1 include:_spf.example.net ~all`,
+		TTL:      300,
+		ZoneName: "example.com",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `example.com./TXT/"v=spf1 include:_spf.example.net ~all"`, rr.ID)
+	assert.Equal(t, "v=spf1 include:_spf.example.net ~all", rr.Content)
+
+	// PowerDNS expects TXT content in presentation format.
+	require.Len(t, patches, 1)
+	assert.Equal(t, []pdnsRecord{{Content: `"v=spf1 include:_spf.example.net ~all"`}}, patches[0].RRSets[0].Records)
+
+	// Already quoted values are sent unchanged.
+	_, err = repo.CreateDNSRecord(context.Background(), models.CreateDNSRecordParams{
+		Name:    "_dmarc.example.com",
+		Type:    "TXT",
+		Content: `"v=DMARC1; p=none"`,
+		ZoneID:  "example.com.",
+	})
+	require.NoError(t, err)
+	require.Len(t, patches, 2)
+	assert.Equal(t, []pdnsRecord{{Content: `"v=DMARC1; p=none"`}}, patches[1].RRSets[0].Records)
+}
+
+func TestNewRepoPowerDNS_Timeout(t *testing.T) {
+	repo := NewRepoPowerDNS("http://localhost:8081", "secret", "", nil).(*repoPowerDNS)
+	assert.Equal(t, config.DefaultClientTimeout, repo.client.Timeout)
+}
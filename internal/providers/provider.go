@@ -19,37 +19,146 @@ package providers
 
 import (
 	"context"
+	"strings"
 
+	"github.com/mixanemca/cdnscli/internal/config"
 	"github.com/mixanemca/cdnscli/internal/models"
 )
 
 // Provider exposes methods for manage DNS.
 type Provider interface {
+	// AccountInfo returns details about the account the provider is authenticated as.
+	AccountInfo(ctx context.Context) (models.AccountInfo, error)
+	// Capabilities describes the record types and features the provider supports.
+	Capabilities() models.Capabilities
 	// AddRR creates a new DNS resource record for a given zone.
 	AddRR(ctx context.Context, zone string, params models.CreateDNSRecordParams) (models.DNSRecord, error)
 	// DeleteRR deletes a DNS resource record from a given zone.
 	DeleteRR(ctx context.Context, zone string, rr models.DNSRecord) error
+	// GetRRByID returns a single DNS resource record by its identifier (params.ID) in the given zone.
+	GetRRByID(ctx context.Context, params models.ListDNSRecordsParams) (models.DNSRecord, error)
 	// GetRRByName returns a single DNS resource record for the given zone & record identifiers.
 	GetRRByName(ctx context.Context, zone, name string) (models.DNSRecord, error)
+	// GetSOA returns the SOA record of the given zone.
+	GetSOA(ctx context.Context, zone string) (models.SOA, error)
+	// GetDNSSEC returns the DNSSEC status of the given zone.
+	GetDNSSEC(ctx context.Context, zone string) (models.DNSSECStatus, error)
+	// SetDNSSEC enables or disables DNSSEC signing of the given zone.
+	SetDNSSEC(ctx context.Context, zone string, enable bool) (models.DNSSECStatus, error)
+	// Ping makes a lightweight API call to check that the provider is reachable and accepts the credentials.
+	Ping(ctx context.Context) error
 	// ListZones lists the zones on an account.
 	ListZones(ctx context.Context) ([]models.Zone, error)
 	// ListZonesByName lists the zone in an account using the zone name for filtering.
 	ListZonesByName(ctx context.Context, name string) ([]models.Zone, error)
+	// CountRecords returns the number of DNS records in the given zone.
+	CountRecords(ctx context.Context, zone string) (int, error)
 	// ListRecords returns a slice of DNS records for the given zone name.
 	ListRecords(ctx context.Context, params models.ListDNSRecordsParams) ([]models.DNSRecord, error)
 	// ListRecordsByZoneID returns a slice of DNS records for the given zone identifier.
 	ListRecordsByZoneID(ctx context.Context, id string, params models.ListDNSRecordsParams) ([]models.DNSRecord, error)
+	// UpdateNameServers sets the name servers of the given zone.
+	UpdateNameServers(ctx context.Context, zone string, ns []string) error
 	// UpdateRR updates and returns an existing DNS resource record.
 	UpdateRR(ctx context.Context, zone string, rr models.DNSRecord) (models.DNSRecord, error)
+	// UpsertRR updates the record matching params by name, type and content, or creates it if there is none.
+	UpsertRR(ctx context.Context, zone string, params models.CreateDNSRecordParams) (models.DNSRecord, error)
 }
 
+// DefaultTTL is the TTL used for new records when none is given.
+const DefaultTTL = 1800
+
 type provider struct {
-	repo Repo
+	repo         Repo
+	defaultTTL   int
+	providerType string
+	displayName  string
+	zoneCache    *ZoneCache
+	zones        *zoneResolver
+	singleZone   models.Zone
+}
+
+// ProviderOption configures a provider.
+type ProviderOption func(p *provider)
+
+// WithDefaultTTL sets the TTL used by AddRR when the record has no TTL.
+// Non-positive values are ignored.
+func WithDefaultTTL(ttl int) ProviderOption {
+	return func(p *provider) {
+		if ttl > 0 {
+			p.defaultTTL = ttl
+		}
+	}
+}
+
+// WithProviderType sets the provider type and display name reported by AccountInfo.
+func WithProviderType(providerType, displayName string) ProviderOption {
+	return func(p *provider) {
+		p.providerType = providerType
+		p.displayName = displayName
+	}
+}
+
+// WithZoneCache makes the provider look zone IDs up in cache before asking the API.
+// A nil cache disables caching.
+func WithZoneCache(cache *ZoneCache) ProviderOption {
+	return func(p *provider) {
+		p.zoneCache = cache
+	}
+}
+
+// WithSingleZone gives the provider the zone of credentials scoped to it, such
+// as a Cloudflare token for a single zone. Such credentials may not list zones:
+// when listing is forbidden, the zone is listed alone. A zone ID saves looking
+// the zone up by its name. A zone without a name is ignored.
+func WithSingleZone(zone models.Zone) ProviderOption {
+	return func(p *provider) {
+		if zone.Name != "" {
+			p.singleZone = zone
+		}
+	}
 }
 
 // NewProvider creates a new provider.
-func NewProvider(repo Repo) Provider {
-	return &provider{
-		repo: repo,
+func NewProvider(repo Repo, opts ...ProviderOption) Provider {
+	p := &provider{
+		repo:       repo,
+		defaultTTL: DefaultTTL,
+	}
+	p.zones = newZoneResolver(p.lookupZoneID)
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// providerOptions builds provider options from the provider configuration.
+func providerOptions(cfg *config.ProviderConfig) ([]ProviderOption, error) {
+	ttl, err := cfg.GetDefaultTTL()
+	if err != nil {
+		return nil, NewProviderConfigError("", cfg.Type, "options.default_ttl", "invalid default TTL", err)
+	}
+
+	return []ProviderOption{
+		WithDefaultTTL(ttl),
+		WithProviderType(cfg.Type, GetDisplayName(cfg.Type, cfg.DisplayName)),
+		WithZoneCache(ZoneCacheFromConfig(cfg)),
+	}, nil
+}
+
+// CheckCapabilities returns an UnsupportedFeatureError when params use a record
+// type or a feature the provider does not support, which it would otherwise
+// reject or silently drop.
+func CheckCapabilities(caps models.Capabilities, params models.CreateDNSRecordParams) error {
+	switch {
+	case !caps.SupportsType(params.Type):
+		return &UnsupportedFeatureError{Feature: "record type " + strings.ToUpper(params.Type)}
+	case params.Proxied && !caps.Proxied:
+		return &UnsupportedFeatureError{Feature: "proxying"}
+	case params.Comment != "" && !caps.Comments:
+		return &UnsupportedFeatureError{Feature: "comments"}
+	case len(params.Tags) > 0 && !caps.Tags:
+		return &UnsupportedFeatureError{Feature: "tags"}
 	}
+	return nil
 }
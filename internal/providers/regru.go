@@ -20,39 +20,122 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/mixanemca/cdnscli/internal/models"
 	"github.com/mixanemca/regru-go"
 )
 
+// regruClient is the part of the RegRu API client used by the repository.
+type regruClient interface {
+	AddRR(ctx context.Context, zone string, params regru.CreateDNSRecordParams) (regru.DNSRecord, error)
+	DeleteRR(ctx context.Context, zone string, rr regru.DNSRecord) error
+	ListRecords(ctx context.Context, params regru.ListDNSRecordsParams) ([]regru.DNSRecord, error)
+	ListZones(ctx context.Context) ([]regru.Zone, error)
+	ListZonesByName(ctx context.Context, name string) ([]regru.Zone, error)
+	UpdateRR(ctx context.Context, zone string, rr regru.DNSRecord) (regru.DNSRecord, error)
+}
+
 type repoRegRu struct {
-	client *regru.Client
+	client regruClient
+
+	// RegRu addresses zones by name. Names of zone IDs seen so far are kept,
+	// so operations given a zone ID do not list all zones to find its name.
+	mu        sync.Mutex
+	zoneNames map[string]string
 }
 
 // NewRepoRegRu creates a repository for RegRu provider.
 func NewRepoRegRu(client *regru.Client) Repo {
+	return newRepoRegRu(client)
+}
+
+func newRepoRegRu(client regruClient) *repoRegRu {
 	return &repoRegRu{
-		client: client,
+		client:    client,
+		zoneNames: make(map[string]string),
 	}
 }
 
-func (r *repoRegRu) GetDNSRecord(ctx context.Context, zoneID, recordID string) (models.DNSRecord, error) {
-	// Get zone name by ID
+// addressesZonesByName marks RegRu as addressing zones by name, see zoneNameAddressed.
+func (r *repoRegRu) addressesZonesByName() {}
+
+// zoneName returns name if it is set and otherwise the name of the zone with the given ID.
+// Only an ID not seen before costs a ListZones call.
+func (r *repoRegRu) zoneName(ctx context.Context, name, id string) (string, error) {
+	if name != "" {
+		return name, nil
+	}
+	if id == "" {
+		return "", fmt.Errorf("zone name or zone ID must be provided")
+	}
+
+	r.mu.Lock()
+	name, ok := r.zoneNames[id]
+	r.mu.Unlock()
+	if ok {
+		return name, nil
+	}
+
 	zones, err := r.client.ListZones(ctx)
 	if err != nil {
-		return models.DNSRecord{}, err
+		return "", err
 	}
+	r.rememberZones(zones)
 
-	var zoneName string
-	for _, zone := range zones {
-		if zone.ID == zoneID {
-			zoneName = zone.Name
-			break
-		}
+	r.mu.Lock()
+	name, ok = r.zoneNames[id]
+	r.mu.Unlock()
+	if !ok {
+		return "", NewNotFoundError("zone with ID", id, nil)
 	}
 
-	if zoneName == "" {
-		return models.DNSRecord{}, fmt.Errorf("zone with ID %s not found", zoneID)
+	return name, nil
+}
+
+// rememberZones keeps the names of zones by their IDs.
+func (r *repoRegRu) rememberZones(zones []regru.Zone) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, z := range zones {
+		r.zoneNames[z.ID] = z.Name
+	}
+}
+
+// RegRu accepts TTLs of 5 minutes to a week.
+const (
+	regRuMinTTL = 300
+	regRuMaxTTL = 604800
+)
+
+// Capabilities reports the record types and TTL bounds of RegRu, it has no optional features.
+func (r *repoRegRu) Capabilities() models.Capabilities {
+	return models.Capabilities{
+		RecordTypes: []string{
+			regru.RecordTypeA, regru.RecordTypeAAAA, regru.RecordTypeCNAME, regru.RecordTypeMX,
+			regru.RecordTypeNS, regru.RecordTypeSRV, regru.RecordTypeTXT,
+		},
+		MinTTL: regRuMinTTL,
+		MaxTTL: regRuMaxTTL,
+	}
+}
+
+// Ping lists the zones, RegRu has no cheaper call. The zone names are kept for later operations.
+func (r *repoRegRu) Ping(ctx context.Context) error {
+	zones, err := r.client.ListZones(ctx)
+	if err != nil {
+		return err
+	}
+	r.rememberZones(zones)
+
+	return nil
+}
+
+func (r *repoRegRu) GetDNSRecord(ctx context.Context, zoneID, recordID string) (models.DNSRecord, error) {
+	zoneName, err := r.zoneName(ctx, "", zoneID)
+	if err != nil {
+		return models.DNSRecord{}, err
 	}
 
 	// List all records and find the one with matching ID
@@ -70,32 +153,19 @@ func (r *repoRegRu) GetDNSRecord(ctx context.Context, zoneID, recordID string) (
 		}
 	}
 
-	return models.DNSRecord{}, fmt.Errorf("record with ID %s not found", recordID)
+	return models.DNSRecord{}, NewNotFoundError("record with ID", recordID, nil)
 }
 
 func (r *repoRegRu) CreateDNSRecord(ctx context.Context, params models.CreateDNSRecordParams) (models.DNSRecord, error) {
-	zoneName := params.ZoneName
-	if zoneName == "" {
-		// Try to get zone name from zone ID
-		zones, err := r.client.ListZones(ctx)
-		if err != nil {
-			return models.DNSRecord{}, err
-		}
-		for _, zone := range zones {
-			if zone.ID == params.ZoneID {
-				zoneName = zone.Name
-				break
-			}
-		}
-		if zoneName == "" {
-			return models.DNSRecord{}, fmt.Errorf("zone name or zone ID must be provided")
-		}
+	zoneName, err := r.zoneName(ctx, params.ZoneName, params.ZoneID)
+	if err != nil {
+		return models.DNSRecord{}, err
 	}
 
 	createParams := regru.CreateDNSRecordParams{
 		Name:    params.Name,
 		Type:    params.Type,
-		Content: params.Content,
+		Content: models.JoinPriority(params.Type, params.Priority, params.Content),
 		TTL:     params.TTL,
 	}
 
@@ -108,24 +178,11 @@ func (r *repoRegRu) CreateDNSRecord(ctx context.Context, params models.CreateDNS
 }
 
 func (r *repoRegRu) DeleteDNSRecord(ctx context.Context, zoneID, recordID string) error {
-	// Get zone name by ID
-	zones, err := r.client.ListZones(ctx)
+	zoneName, err := r.zoneName(ctx, "", zoneID)
 	if err != nil {
 		return err
 	}
 
-	var zoneName string
-	for _, zone := range zones {
-		if zone.ID == zoneID {
-			zoneName = zone.Name
-			break
-		}
-	}
-
-	if zoneName == "" {
-		return fmt.Errorf("zone with ID %s not found", zoneID)
-	}
-
 	// Get record to delete
 	record, err := r.GetDNSRecord(ctx, zoneID, recordID)
 	if err != nil {
@@ -139,24 +196,11 @@ func (r *repoRegRu) DeleteDNSRecord(ctx context.Context, zoneID, recordID string
 }
 
 func (r *repoRegRu) ListDNSRecords(ctx context.Context, id string) ([]models.DNSRecord, error) {
-	// Get zone name by ID
-	zones, err := r.client.ListZones(ctx)
+	zoneName, err := r.zoneName(ctx, "", id)
 	if err != nil {
 		return []models.DNSRecord{}, err
 	}
 
-	var zoneName string
-	for _, zone := range zones {
-		if zone.ID == id {
-			zoneName = zone.Name
-			break
-		}
-	}
-
-	if zoneName == "" {
-		return []models.DNSRecord{}, fmt.Errorf("zone with ID %s not found", id)
-	}
-
 	params := regru.ListDNSRecordsParams{
 		ZoneName: zoneName,
 	}
@@ -183,27 +227,15 @@ func (r *repoRegRu) ListZones(ctx context.Context, z ...string) ([]models.Zone,
 	if err != nil {
 		return []models.Zone{}, err
 	}
+	r.rememberZones(zones)
 
 	return convFromRegRuZones(zones), nil
 }
 
 func (r *repoRegRu) UpdateDNSRecord(ctx context.Context, params models.UpdateDNSRecordParams) (models.DNSRecord, error) {
-	zoneName := params.ZoneName
-	if zoneName == "" {
-		// Try to get zone name from zone ID
-		zones, err := r.client.ListZones(ctx)
-		if err != nil {
-			return models.DNSRecord{}, err
-		}
-		for _, zone := range zones {
-			if zone.ID == params.ZoneID {
-				zoneName = zone.Name
-				break
-			}
-		}
-		if zoneName == "" {
-			return models.DNSRecord{}, fmt.Errorf("zone name or zone ID must be provided")
-		}
+	zoneName, err := r.zoneName(ctx, params.ZoneName, params.ZoneID)
+	if err != nil {
+		return models.DNSRecord{}, err
 	}
 
 	// Convert to regru DNSRecord format
@@ -211,7 +243,7 @@ func (r *repoRegRu) UpdateDNSRecord(ctx context.Context, params models.UpdateDNS
 		ID:      params.ID,
 		Name:    params.Name,
 		Type:    params.Type,
-		Content: params.Content,
+		Content: models.JoinPriority(params.Type, params.Priority, params.Content),
 		TTL:     params.TTL,
 	}
 
@@ -231,8 +263,9 @@ func (r *repoRegRu) ZoneIDByName(zoneName string) (string, error) {
 	}
 
 	if len(zones) == 0 {
-		return "", fmt.Errorf("zone %s not found", zoneName)
+		return "", NewNotFoundError("zone", zoneName, nil)
 	}
+	r.rememberZones(zones[:1])
 
 	// Return the first matching zone ID
 	return zones[0].ID, nil
@@ -63,8 +63,18 @@ func (f *regruFactory) CreateProvider(cfg *config.ProviderConfig) (Provider, err
 			"password is required but not provided in credentials (check config file)", nil)
 	}
 
+	opts, err := providerOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient, err := newHTTPClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create RegRu client with trimmed credentials
-	client := regru.NewClient(username, password)
+	client := regru.NewClient(username, password, regru.WithHTTPClient(httpClient), regru.WithTimeout(regru.DefaultTimeout))
 
 	// Verify credentials by trying to list zones
 	ctx := context.Background()
@@ -75,5 +85,5 @@ func (f *regruFactory) CreateProvider(cfg *config.ProviderConfig) (Provider, err
 	}
 
 	repo := NewRepoRegRu(client)
-	return NewProvider(repo), nil
+	return NewProvider(repo, opts...), nil
 }
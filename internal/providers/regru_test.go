@@ -17,11 +17,15 @@ limitations under the License.
 package providers
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/mixanemca/cdnscli/internal/models"
 	"github.com/mixanemca/regru-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConvFromRegRuDNSRecord(t *testing.T) {
@@ -327,6 +331,23 @@ func TestConvToRegRuDNSRecord(t *testing.T) {
 				TTL:     3600,
 			},
 		},
+		{
+			name: "Name keeps its case",
+			input: models.DNSRecord{
+				ID:      "record-id",
+				Name:    "WWW.Example.com.",
+				TTL:     3600,
+				Type:    "A",
+				Content: "192.168.0.1",
+			},
+			expected: regru.DNSRecord{
+				ID:      "record-id",
+				Name:    "WWW.Example.com", // Only the trailing dot is removed
+				Type:    "A",
+				Content: "192.168.0.1",
+				TTL:     3600,
+			},
+		},
 		{
 			name: "CNAME record",
 			input: models.DNSRecord{
@@ -353,3 +374,145 @@ func TestConvToRegRuDNSRecord(t *testing.T) {
 		})
 	}
 }
+
+// MockRegRuClient mock client for the RegRu API.
+type MockRegRuClient struct {
+	mock.Mock
+}
+
+func (m *MockRegRuClient) AddRR(ctx context.Context, zone string, params regru.CreateDNSRecordParams) (regru.DNSRecord, error) {
+	args := m.Called(ctx, zone, params)
+	return args.Get(0).(regru.DNSRecord), args.Error(1)
+}
+
+func (m *MockRegRuClient) DeleteRR(ctx context.Context, zone string, rr regru.DNSRecord) error {
+	args := m.Called(ctx, zone, rr)
+	return args.Error(0)
+}
+
+func (m *MockRegRuClient) ListRecords(ctx context.Context, params regru.ListDNSRecordsParams) ([]regru.DNSRecord, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]regru.DNSRecord), args.Error(1)
+}
+
+func (m *MockRegRuClient) ListZones(ctx context.Context) ([]regru.Zone, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]regru.Zone), args.Error(1)
+}
+
+func (m *MockRegRuClient) ListZonesByName(ctx context.Context, name string) ([]regru.Zone, error) {
+	args := m.Called(ctx, name)
+	return args.Get(0).([]regru.Zone), args.Error(1)
+}
+
+func (m *MockRegRuClient) UpdateRR(ctx context.Context, zone string, rr regru.DNSRecord) (regru.DNSRecord, error) {
+	args := m.Called(ctx, zone, rr)
+	return args.Get(0).(regru.DNSRecord), args.Error(1)
+}
+
+func TestRegRuAddRRSkipsZoneListing(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockRegRuClient)
+	p := NewProvider(newRepoRegRu(client))
+
+	params := regru.CreateDNSRecordParams{Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 3600}
+	client.On("AddRR", ctx, "example.com", params).Return(regru.DNSRecord{ID: "1", Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 3600}, nil)
+
+	rr, err := p.AddRR(ctx, "example.com", models.CreateDNSRecordParams{Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 3600})
+	require.NoError(t, err)
+	assert.Equal(t, "1", rr.ID)
+
+	client.AssertExpectations(t)
+	client.AssertNotCalled(t, "ListZones", mock.Anything)
+	client.AssertNotCalled(t, "ListZonesByName", mock.Anything, mock.Anything)
+}
+
+func TestRegRuUpdateRRSkipsZoneListing(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockRegRuClient)
+	p := NewProvider(newRepoRegRu(client))
+
+	record := regru.DNSRecord{ID: "1", Name: "www.example.com", Type: "A", Content: "192.0.2.2", TTL: 600}
+	client.On("UpdateRR", ctx, "example.com", record).Return(record, nil)
+
+	rr, err := p.UpdateRR(ctx, "example.com", models.DNSRecord{ID: "1", Name: "www.example.com", Type: "A", Content: "192.0.2.2", TTL: 600})
+	require.NoError(t, err)
+	assert.Equal(t, "192.0.2.2", rr.Content)
+
+	client.AssertExpectations(t)
+	client.AssertNotCalled(t, "ListZones", mock.Anything)
+	client.AssertNotCalled(t, "ListZonesByName", mock.Anything, mock.Anything)
+}
+
+func TestRegRuZoneNameFromResolvedID(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockRegRuClient)
+	repo := newRepoRegRu(client)
+
+	client.On("ListZonesByName", mock.Anything, "example.com").Return([]regru.Zone{{ID: "42", Name: "example.com"}}, nil)
+	client.On("ListRecords", ctx, regru.ListDNSRecordsParams{ZoneName: "example.com"}).Return([]regru.DNSRecord{{ID: "1", Name: "www.example.com", Type: "A"}}, nil)
+
+	id, err := repo.ZoneIDByName("example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "42", id)
+
+	rrset, err := repo.ListDNSRecords(ctx, id)
+	require.NoError(t, err)
+	assert.Len(t, rrset, 1)
+
+	rr, err := repo.GetDNSRecord(ctx, id, "1")
+	require.NoError(t, err)
+	assert.Equal(t, "www.example.com", rr.Name)
+
+	client.AssertNotCalled(t, "ListZones", mock.Anything)
+}
+
+func TestRegRuZoneNameByIDListsOnce(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockRegRuClient)
+	repo := newRepoRegRu(client)
+
+	client.On("ListZones", ctx).Return([]regru.Zone{{ID: "42", Name: "example.com"}}, nil).Once()
+	client.On("ListRecords", ctx, regru.ListDNSRecordsParams{ZoneName: "example.com"}).Return([]regru.DNSRecord{}, nil)
+
+	for i := 0; i < 2; i++ {
+		_, err := repo.ListDNSRecords(ctx, "42")
+		require.NoError(t, err)
+	}
+	client.AssertNumberOfCalls(t, "ListZones", 1)
+
+	_, err := repo.ListDNSRecords(ctx, "")
+	assert.Error(t, err)
+}
+
+func TestRegRuUnknownZoneID(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockRegRuClient)
+	repo := newRepoRegRu(client)
+
+	client.On("ListZones", ctx).Return([]regru.Zone{{ID: "42", Name: "example.com"}}, nil)
+
+	_, err := repo.ListDNSRecords(ctx, "7")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	client.AssertNotCalled(t, "ListRecords", mock.Anything, mock.Anything)
+}
+
+func TestRegRuPing(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockRegRuClient)
+	repo := newRepoRegRu(client)
+	p := NewProvider(repo)
+
+	client.On("ListZones", ctx).Return([]regru.Zone{{ID: "42", Name: "example.com"}}, nil).Once()
+	client.On("ListZones", ctx).Return([]regru.Zone{}, errors.New("connection refused")).Once()
+	client.On("ListRecords", ctx, regru.ListDNSRecordsParams{ZoneName: "example.com"}).Return([]regru.DNSRecord{}, nil)
+
+	require.NoError(t, p.Ping(ctx))
+	// The zones listed by the ping are remembered
+	_, err := repo.ListDNSRecords(ctx, "42")
+	require.NoError(t, err)
+	client.AssertNumberOfCalls(t, "ListZones", 1)
+
+	assert.EqualError(t, p.Ping(ctx), "connection refused")
+}
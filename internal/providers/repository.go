@@ -18,8 +18,12 @@ package providers
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/cloudflare/cloudflare-go"
+	"github.com/miekg/dns"
 	"github.com/mixanemca/cdnscli/internal/models"
 )
 
@@ -55,7 +59,7 @@ func (r *repoCloudFlare) GetDNSRecord(ctx context.Context, zoneID, recordID stri
 
 	record, err := r.api.GetDNSRecord(ctx, &rc, recordID)
 	if err != nil {
-		return models.DNSRecord{}, err
+		return models.DNSRecord{}, convCloudflareError(err, "record with ID", recordID)
 	}
 
 	return convFromDNSRecord(record), nil
@@ -71,7 +75,7 @@ func (r *repoCloudFlare) CreateDNSRecord(ctx context.Context, params models.Crea
 
 	rr, err := r.api.CreateDNSRecord(ctx, &rc, convToCreateDNSRecordParams(params))
 	if err != nil {
-		return models.DNSRecord{}, err
+		return models.DNSRecord{}, convCloudflareError(err, "", "")
 	}
 
 	return convFromDNSRecord(rr), nil
@@ -85,22 +89,34 @@ func (r *repoCloudFlare) DeleteDNSRecord(ctx context.Context, zoneID, recordID s
 		Type:       cloudflare.ZoneType,
 	}
 
-	return r.api.DeleteDNSRecord(ctx, &rc, recordID)
+	return convCloudflareError(r.api.DeleteDNSRecord(ctx, &rc, recordID), "record with ID", recordID)
 }
 
 func (r *repoCloudFlare) ListDNSRecords(ctx context.Context, id string) ([]models.DNSRecord, error) {
 	rrset, _, err := r.api.ListDNSRecords(ctx, cloudflare.ZoneIdentifier(id), cloudflare.ListDNSRecordsParams{})
 	if err != nil {
-		return []models.DNSRecord{}, err
+		return []models.DNSRecord{}, convCloudflareError(err, "zone with ID", id)
 	}
 
 	return convFromDNSRecords(rrset), nil
 }
 
+// CountDNSRecords requests a single record and returns the total count reported with it.
+func (r *repoCloudFlare) CountDNSRecords(ctx context.Context, id string) (int, error) {
+	_, info, err := r.api.ListDNSRecords(ctx, cloudflare.ZoneIdentifier(id), cloudflare.ListDNSRecordsParams{
+		ResultInfo: cloudflare.ResultInfo{Page: 1, PerPage: 1},
+	})
+	if err != nil {
+		return 0, convCloudflareError(err, "zone with ID", id)
+	}
+
+	return info.Total, nil
+}
+
 func (r *repoCloudFlare) ListZones(ctx context.Context, z ...string) ([]models.Zone, error) {
 	zones, err := r.api.ListZones(ctx, z...)
 	if err != nil {
-		return []models.Zone{}, err
+		return []models.Zone{}, convCloudflareError(err, "", "")
 	}
 
 	return convFromDNSZones(zones), nil
@@ -116,12 +132,214 @@ func (r *repoCloudFlare) UpdateDNSRecord(ctx context.Context, params models.Upda
 
 	rr, err := r.api.UpdateDNSRecord(ctx, &rc, convToUpdateDNSRecordParams(params))
 	if err != nil {
-		return models.DNSRecord{}, err
+		return models.DNSRecord{}, convCloudflareError(err, "record with ID", params.ID)
 	}
 
 	return convFromDNSRecord(rr), nil
 }
 
 func (r *repoCloudFlare) ZoneIDByName(zoneName string) (string, error) {
-	return r.api.ZoneIDByName(zoneName)
+	id, err := r.api.ZoneIDByName(zoneName)
+	if err != nil {
+		// cloudflare-go reports a missing zone with a plain error
+		if err.Error() == errCloudflareZoneNotFound {
+			return "", NewNotFoundError("zone", zoneName, err)
+		}
+		return "", convCloudflareError(err, "", "")
+	}
+
+	return id, nil
+}
+
+// errCloudflareZoneNotFound is the message of the error cloudflare-go returns
+// from ZoneIDByName when no zone matches.
+const errCloudflareZoneNotFound = "zone could not be found"
+
+// convCloudflareError converts Cloudflare API errors to provider errors.
+// Rejected credentials become a ProviderCredentialsError. A 404 becomes a
+// NotFoundError for the given resource, unless resource is empty.
+func convCloudflareError(err error, resource, name string) error {
+	if err == nil {
+		return nil
+	}
+
+	var (
+		authnErr    *cloudflare.AuthenticationError
+		authzErr    *cloudflare.AuthorizationError
+		notFoundErr *cloudflare.NotFoundError
+	)
+	switch {
+	case errors.As(err, &authnErr):
+		return NewProviderCredentialsError(TypeCloudflare, "request rejected", err)
+	case errors.As(err, &authzErr):
+		return NewProviderForbiddenError(TypeCloudflare, "request rejected", err)
+	case resource != "" && errors.As(err, &notFoundErr):
+		return NewNotFoundError(resource, name, err)
+	}
+
+	return err
+}
+
+// convFromCloudflareExportSOA returns the SOA record of a zone file exported by
+// Cloudflare. Owner names without the trailing dot are taken as absolute.
+func convFromCloudflareExportSOA(export string) (models.SOA, error) {
+	zp := dns.NewZoneParser(strings.NewReader(export), ".", "")
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		if soa, ok := rr.(*dns.SOA); ok {
+			return convFromSOA(soa), nil
+		}
+	}
+	if err := zp.Err(); err != nil {
+		return models.SOA{}, fmt.Errorf("failed to parse the zone export: %w", err)
+	}
+	return models.SOA{}, errors.New("the zone export holds no SOA record")
+}
+
+// convFromZoneDNSSEC converts the DNSSEC setting of a zone. The DS record
+// details are only kept while they are meaningful, i.e. the zone is signed.
+func convFromZoneDNSSEC(dnssec cloudflare.ZoneDNSSEC) models.DNSSECStatus {
+	status := models.DNSSECStatus{
+		Status:     dnssec.Status,
+		ModifiedOn: dnssec.ModifiedOn,
+	}
+	if status.Signed() {
+		status.DS = dnssec.DS
+		status.KeyTag = dnssec.KeyTag
+		status.Algorithm = dnssec.Algorithm
+		status.DigestType = dnssec.DigestType
+		status.Digest = dnssec.Digest
+		status.Flags = dnssec.Flags
+		status.PublicKey = dnssec.PublicKey
+	}
+	return status
+}
+
+// cloudflareRecordTypes are the record types the Cloudflare DNS API manages.
+var cloudflareRecordTypes = []string{
+	"A", "AAAA", "CAA", "CERT", "CNAME", "DNSKEY", "DS", "HTTPS", "LOC", "MX",
+	"NAPTR", "NS", "PTR", "SMIMEA", "SRV", "SSHFP", "SVCB", "TLSA", "TXT", "URI",
+}
+
+// Cloudflare accepts TTLs of 60 seconds to a day, or 1 for automatic.
+const (
+	cloudflareMinTTL = 60
+	cloudflareMaxTTL = 86400
+)
+
+// Capabilities reports that Cloudflare proxies records and keeps their comments and tags.
+func (r *repoCloudFlare) Capabilities() models.Capabilities {
+	return models.Capabilities{
+		RecordTypes: cloudflareRecordTypes,
+		Proxied:     true,
+		Comments:    true,
+		Tags:        true,
+		MinTTL:      cloudflareMinTTL,
+		MaxTTL:      cloudflareMaxTTL,
+		AutoTTL:     true,
+	}
+}
+
+// UpdateNameServers sets custom (vanity) name servers of the zone, which needs a
+// Cloudflare plan that includes them.
+func (r *repoCloudFlare) UpdateNameServers(ctx context.Context, zoneID string, ns []string) error {
+	if _, err := r.api.ZoneSetVanityNS(ctx, zoneID, ns); err != nil {
+		return convCloudflareError(err, "zone with ID", zoneID)
+	}
+	return nil
+}
+
+// GetSOA reads the SOA record from the BIND zone file export, the API does not
+// list it with the other records.
+func (r *repoCloudFlare) GetSOA(ctx context.Context, zoneID string) (models.SOA, error) {
+	export, err := r.api.ExportDNSRecords(ctx, cloudflare.ZoneIdentifier(zoneID), cloudflare.ExportDNSRecordsParams{})
+	if err != nil {
+		return models.SOA{}, convCloudflareError(err, "zone with ID", zoneID)
+	}
+	return convFromCloudflareExportSOA(export)
+}
+
+func (r *repoCloudFlare) GetDNSSEC(ctx context.Context, zoneID string) (models.DNSSECStatus, error) {
+	dnssec, err := r.api.ZoneDNSSECSetting(ctx, zoneID)
+	if err != nil {
+		return models.DNSSECStatus{}, convCloudflareError(err, "zone with ID", zoneID)
+	}
+	return convFromZoneDNSSEC(dnssec), nil
+}
+
+// SetDNSSEC turns signing on or off. Enabling leaves the zone pending until
+// Cloudflare has signed it, disabling keeps signing until the DS record is gone.
+func (r *repoCloudFlare) SetDNSSEC(ctx context.Context, zoneID string, enable bool) (models.DNSSECStatus, error) {
+	status := models.DNSSECDisabled
+	if enable {
+		status = models.DNSSECActive
+	}
+	dnssec, err := r.api.UpdateZoneDNSSEC(ctx, zoneID, cloudflare.ZoneDNSSECUpdateOptions{Status: status})
+	if err != nil {
+		return models.DNSSECStatus{}, convCloudflareError(err, "zone with ID", zoneID)
+	}
+	return convFromZoneDNSSEC(dnssec), nil
+}
+
+// Ping verifies the API token, or reads the user details with key based credentials.
+func (r *repoCloudFlare) Ping(ctx context.Context) error {
+	if r.api.APIToken != "" {
+		_, err := r.api.VerifyAPIToken(ctx)
+		return convCloudflareError(err, "", "")
+	}
+
+	_, err := r.api.UserDetails(ctx)
+	return convCloudflareError(err, "", "")
+}
+
+// AccountInfo returns the user, accounts and token permissions visible to the API credentials.
+// Only the credential check is mandatory; details the credentials are not allowed to read are omitted.
+func (r *repoCloudFlare) AccountInfo(ctx context.Context) (models.AccountInfo, error) {
+	var info models.AccountInfo
+
+	if r.api.APIToken != "" {
+		token, err := r.api.VerifyAPIToken(ctx)
+		if err != nil {
+			return models.AccountInfo{}, err
+		}
+		if details, err := r.api.GetAPIToken(ctx, token.ID); err == nil {
+			info.Scopes = cloudflareTokenScopes(details)
+		}
+	}
+
+	user, err := r.api.UserDetails(ctx)
+	switch {
+	case err == nil:
+		info.ID = user.ID
+		info.Email = user.Email
+		info.Name = strings.TrimSpace(user.FirstName + " " + user.LastName)
+	case r.api.APIToken == "":
+		// Key based credentials always have access to the user details.
+		return models.AccountInfo{}, err
+	}
+
+	if accounts, _, err := r.api.Accounts(ctx, cloudflare.AccountsListParams{}); err == nil {
+		for _, account := range accounts {
+			info.Accounts = append(info.Accounts, account.Name)
+		}
+	}
+
+	return info, nil
+}
+
+// cloudflareTokenScopes returns the unique permission group names of an API token.
+func cloudflareTokenScopes(token cloudflare.APIToken) []string {
+	var scopes []string
+	seen := make(map[string]bool)
+
+	for _, policy := range token.Policies {
+		for _, group := range policy.PermissionGroups {
+			if group.Name == "" || seen[group.Name] {
+				continue
+			}
+			seen[group.Name] = true
+			scopes = append(scopes, group.Name)
+		}
+	}
+
+	return scopes
 }
@@ -0,0 +1,400 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/mixanemca/cdnscli/internal/models"
+)
+
+const (
+	// rfc2136DefaultPort is the name server port used when the server has none.
+	rfc2136DefaultPort = "53"
+
+	// rfc2136DefaultAlgo is the TSIG algorithm used when none is configured.
+	rfc2136DefaultAlgo = dns.HmacSHA256
+
+	// rfc2136Fudge is the allowed clock skew of TSIG signatures, in seconds.
+	rfc2136Fudge = 300
+
+	// rfc2136Timeout limits a single exchange or zone transfer.
+	rfc2136Timeout = 10 * time.Second
+)
+
+// rfc2136Key is a TSIG key used to sign updates and zone transfers.
+type rfc2136Key struct {
+	Name   string
+	Secret string
+	Algo   string
+}
+
+type repoRFC2136 struct {
+	server string
+	key    rfc2136Key
+	zones  []string
+}
+
+// NewRepoRFC2136 creates a repository performing dynamic updates (RFC 2136) and zone
+// transfers against a name server such as BIND or Knot. Zones have no IDs, the zone
+// name is used as zone ID. The zones are only listed from configuration, since the
+// protocol has no way to enumerate them.
+func NewRepoRFC2136(server, keyName, keySecret, keyAlgo string, zones []string) Repo {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, rfc2136DefaultPort)
+	}
+	if keyAlgo == "" {
+		keyAlgo = rfc2136DefaultAlgo
+	}
+	return &repoRFC2136{
+		server: server,
+		key: rfc2136Key{
+			Name:   dns.Fqdn(strings.ToLower(keyName)),
+			Secret: keySecret,
+			Algo:   dns.Fqdn(strings.ToLower(keyAlgo)),
+		},
+		zones: zones,
+	}
+}
+
+// Capabilities reports that any record type can be sent in a dynamic update.
+func (r *repoRFC2136) Capabilities() models.Capabilities {
+	return models.Capabilities{}
+}
+
+func (r *repoRFC2136) GetDNSRecord(ctx context.Context, zoneID, recordID string) (models.DNSRecord, error) {
+	rrset, err := r.ListDNSRecords(ctx, zoneID)
+	if err != nil {
+		return models.DNSRecord{}, err
+	}
+
+	for _, rr := range rrset {
+		if rr.ID == recordID {
+			return rr, nil
+		}
+	}
+
+	return models.DNSRecord{}, NewNotFoundError("record with ID", recordID, nil)
+}
+
+func (r *repoRFC2136) CreateDNSRecord(ctx context.Context, params models.CreateDNSRecordParams) (models.DNSRecord, error) {
+	zone := params.ZoneID
+	if zone == "" {
+		zone = params.ZoneName
+	}
+	if zone == "" {
+		return models.DNSRecord{}, fmt.Errorf("zone name or zone ID must be provided")
+	}
+
+	rr, err := rfc2136RR(params.Name, params.Type, params.TTL, models.JoinPriority(params.Type, params.Priority, params.Content))
+	if err != nil {
+		return models.DNSRecord{}, err
+	}
+
+	if err := r.exchange(ctx, rfc2136InsertMsg(zone, rr)); err != nil {
+		return models.DNSRecord{}, err
+	}
+
+	return convFromRFC2136RR(rr), nil
+}
+
+func (r *repoRFC2136) DeleteDNSRecord(ctx context.Context, zoneID, recordID string) error {
+	rr, err := parseRFC2136RecordID(recordID)
+	if err != nil {
+		return err
+	}
+
+	return r.exchange(ctx, rfc2136RemoveMsg(zoneID, rr))
+}
+
+func (r *repoRFC2136) ListDNSRecords(ctx context.Context, id string) ([]models.DNSRecord, error) {
+	m := new(dns.Msg)
+	m.SetAxfr(dns.Fqdn(id))
+	r.sign(m)
+
+	t := &dns.Transfer{
+		DialTimeout:  rfc2136Timeout,
+		ReadTimeout:  rfc2136Timeout,
+		WriteTimeout: rfc2136Timeout,
+		TsigSecret:   r.tsigSecret(),
+	}
+	ch, err := t.In(m, r.server)
+	if err != nil {
+		return []models.DNSRecord{}, fmt.Errorf("zone transfer of %s failed: %w", id, err)
+	}
+
+	var rrs []dns.RR
+	for env := range ch {
+		if env.Error != nil {
+			return []models.DNSRecord{}, fmt.Errorf("zone transfer of %s failed: %w", id, env.Error)
+		}
+		rrs = append(rrs, env.RR...)
+	}
+
+	// A transfer starts and ends with the SOA record, keep it once
+	if n := len(rrs); n > 1 && rrs[n-1].Header().Rrtype == dns.TypeSOA {
+		rrs = rrs[:n-1]
+	}
+
+	return convFromRFC2136RRs(rrs), nil
+}
+
+func (r *repoRFC2136) ListZones(ctx context.Context, z ...string) ([]models.Zone, error) {
+	if len(z) > 0 && z[0] != "" {
+		return convFromRFC2136Zones(z[:1]), nil
+	}
+
+	return convFromRFC2136Zones(r.zones), nil
+}
+
+func (r *repoRFC2136) UpdateDNSRecord(ctx context.Context, params models.UpdateDNSRecordParams) (models.DNSRecord, error) {
+	zone := params.ZoneID
+	if zone == "" {
+		zone = params.ZoneName
+	}
+	if zone == "" {
+		return models.DNSRecord{}, fmt.Errorf("zone name or zone ID must be provided")
+	}
+
+	old, err := parseRFC2136RecordID(params.ID)
+	if err != nil {
+		return models.DNSRecord{}, err
+	}
+
+	name := params.Name
+	if name == "" {
+		name = old.Header().Name
+	}
+	rrType := params.Type
+	if rrType == "" {
+		rrType = dns.TypeToString[old.Header().Rrtype]
+	}
+	ttl := params.TTL
+	if ttl == 0 {
+		ttl = int(old.Header().Ttl)
+	}
+
+	rr, err := rfc2136RR(name, rrType, ttl, models.JoinPriority(rrType, params.Priority, params.Content))
+	if err != nil {
+		return models.DNSRecord{}, err
+	}
+
+	// Remove and insert in one message, so the update is atomic
+	if err := r.exchange(ctx, rfc2136ReplaceMsg(zone, old, rr)); err != nil {
+		return models.DNSRecord{}, err
+	}
+
+	return convFromRFC2136RR(rr), nil
+}
+
+// GetSOA queries the name server for the SOA record of the zone. The query is
+// signed like updates, for servers answering only to the key.
+func (r *repoRFC2136) GetSOA(ctx context.Context, zoneID string) (models.SOA, error) {
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(zoneID), dns.TypeSOA)
+	r.sign(m)
+
+	c := &dns.Client{
+		Net:        "tcp",
+		Timeout:    rfc2136Timeout,
+		TsigSecret: r.tsigSecret(),
+	}
+	resp, _, err := c.ExchangeContext(ctx, m, r.server)
+	if err != nil {
+		return models.SOA{}, fmt.Errorf("SOA query failed: %w", err)
+	}
+	if resp.Rcode != dns.RcodeSuccess {
+		return models.SOA{}, fmt.Errorf("SOA query failed: server answered %s", dns.RcodeToString[resp.Rcode])
+	}
+	for _, rr := range resp.Answer {
+		if soa, ok := rr.(*dns.SOA); ok {
+			return convFromSOA(soa), nil
+		}
+	}
+	return models.SOA{}, NewNotFoundError("zone", strings.TrimSuffix(zoneID, "."), nil)
+}
+
+func (r *repoRFC2136) ZoneIDByName(zoneName string) (string, error) {
+	return dns.Fqdn(zoneName), nil
+}
+
+// exchange signs and sends an UPDATE message and checks the response code.
+func (r *repoRFC2136) exchange(ctx context.Context, m *dns.Msg) error {
+	r.sign(m)
+
+	c := &dns.Client{
+		Net:        "tcp",
+		Timeout:    rfc2136Timeout,
+		TsigSecret: r.tsigSecret(),
+	}
+	resp, _, err := c.ExchangeContext(ctx, m, r.server)
+	if err != nil {
+		return fmt.Errorf("dynamic update failed: %w", err)
+	}
+
+	switch resp.Rcode {
+	case dns.RcodeSuccess:
+		return nil
+	case dns.RcodeNotAuth, dns.RcodeRefused, dns.RcodeBadSig:
+		return NewProviderCredentialsError(TypeRFC2136, "update rejected",
+			fmt.Errorf("server answered %s", dns.RcodeToString[resp.Rcode]))
+	case dns.RcodeNotZone, dns.RcodeNameError:
+		return NewNotFoundError("zone", strings.TrimSuffix(m.Question[0].Name, "."), nil)
+	default:
+		return fmt.Errorf("dynamic update failed: server answered %s", dns.RcodeToString[resp.Rcode])
+	}
+}
+
+// sign adds a TSIG record to m when a key is configured.
+func (r *repoRFC2136) sign(m *dns.Msg) {
+	if r.key.Secret == "" {
+		return
+	}
+	m.SetTsig(r.key.Name, r.key.Algo, rfc2136Fudge, time.Now().Unix())
+}
+
+// tsigSecret returns the TSIG secrets for dns.Client and dns.Transfer.
+func (r *repoRFC2136) tsigSecret() map[string]string {
+	if r.key.Secret == "" {
+		return nil
+	}
+	return map[string]string{r.key.Name: r.key.Secret}
+}
+
+// rfc2136RR builds a resource record from its presentation format parts.
+func rfc2136RR(name, rrType string, ttl int, content string) (dns.RR, error) {
+	rrType = strings.ToUpper(rrType)
+	rr, err := dns.NewRR(fmt.Sprintf("%s %d IN %s %s", dns.Fqdn(name), ttl, rrType, convToRFC2136Content(rrType, content)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s record %s: %w", rrType, name, err)
+	}
+	if rr == nil {
+		return nil, fmt.Errorf("invalid %s record %s: empty record", rrType, name)
+	}
+	return rr, nil
+}
+
+// rfc2136InsertMsg builds an UPDATE message adding rr to the zone.
+func rfc2136InsertMsg(zone string, rr dns.RR) *dns.Msg {
+	m := new(dns.Msg)
+	m.SetUpdate(dns.Fqdn(zone))
+	m.Insert([]dns.RR{rr})
+	return m
+}
+
+// rfc2136RemoveMsg builds an UPDATE message deleting rr from the zone.
+func rfc2136RemoveMsg(zone string, rr dns.RR) *dns.Msg {
+	m := new(dns.Msg)
+	m.SetUpdate(dns.Fqdn(zone))
+	m.Remove([]dns.RR{rr})
+	return m
+}
+
+// rfc2136ReplaceMsg builds an UPDATE message replacing old with rr.
+func rfc2136ReplaceMsg(zone string, old, rr dns.RR) *dns.Msg {
+	m := new(dns.Msg)
+	m.SetUpdate(dns.Fqdn(zone))
+	m.Remove([]dns.RR{old})
+	m.Insert([]dns.RR{rr})
+	return m
+}
+
+// rfc2136Content returns the record data in presentation format.
+func rfc2136Content(rr dns.RR) string {
+	return strings.TrimPrefix(rr.String(), rr.Header().String())
+}
+
+// rfc2136RecordID builds a synthetic record ID, records in a zone have no IDs of their own.
+func rfc2136RecordID(rr dns.RR) string {
+	return strings.Join([]string{rr.Header().Name, dns.TypeToString[rr.Header().Rrtype], rfc2136Content(rr)}, "/")
+}
+
+// parseRFC2136RecordID parses a synthetic record ID built by rfc2136RecordID.
+func parseRFC2136RecordID(id string) (dns.RR, error) {
+	parts := strings.SplitN(id, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid RFC 2136 record ID %q", id)
+	}
+	rr, err := dns.NewRR(fmt.Sprintf("%s 0 IN %s %s", parts[0], parts[1], parts[2]))
+	if err != nil || rr == nil {
+		return nil, fmt.Errorf("invalid RFC 2136 record ID %q", id)
+	}
+	return rr, nil
+}
+
+// Conversion functions
+
+// convToRFC2136Content converts record content to presentation format: TXT
+// values are quoted, so values with spaces or semicolons are kept whole.
+func convToRFC2136Content(rrType, content string) string {
+	if rrType == "TXT" && !strings.HasPrefix(strings.TrimSpace(content), `"`) {
+		return models.QuoteTXT(models.SplitTXT(content))
+	}
+	return content
+}
+
+func convFromRFC2136RR(rr dns.RR) models.DNSRecord {
+	rrType := dns.TypeToString[rr.Header().Rrtype]
+	return models.DNSRecord{
+		ID:      rfc2136RecordID(rr),
+		Name:    strings.TrimSuffix(rr.Header().Name, "."),
+		TTL:     int(rr.Header().Ttl),
+		Type:    rrType,
+		Content: convFromContent(rrType, rfc2136Content(rr)),
+		Proxied: false, // plain name servers don't proxy
+	}
+}
+
+func convFromRFC2136RRs(rrs []dns.RR) []models.DNSRecord {
+	records := make([]models.DNSRecord, 0, len(rrs))
+	for _, rr := range rrs {
+		records = append(records, convFromRFC2136RR(rr))
+	}
+	return records
+}
+
+// convFromSOA converts a SOA record. Names lose the trailing dot, as in records
+// read from providers.
+func convFromSOA(soa *dns.SOA) models.SOA {
+	return models.SOA{
+		MName:   strings.TrimSuffix(soa.Ns, "."),
+		RName:   strings.TrimSuffix(soa.Mbox, "."),
+		Serial:  soa.Serial,
+		Refresh: soa.Refresh,
+		Retry:   soa.Retry,
+		Expire:  soa.Expire,
+		Minimum: soa.Minttl,
+		TTL:     soa.Hdr.Ttl,
+	}
+}
+
+func convFromRFC2136Zones(zones []string) []models.Zone {
+	result := make([]models.Zone, 0, len(zones))
+	for _, z := range zones {
+		zone := models.Zone{
+			ID:   dns.Fqdn(z),
+			Name: strings.TrimSuffix(z, "."),
+		}
+		result = append(result, zone)
+	}
+	return result
+}
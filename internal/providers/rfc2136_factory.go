@@ -0,0 +1,94 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/miekg/dns"
+	"github.com/mixanemca/cdnscli/internal/config"
+)
+
+// rfc2136Factory creates RFC 2136 dynamic DNS providers.
+type rfc2136Factory struct{}
+
+// NewRFC2136Factory creates a new RFC 2136 provider factory.
+func NewRFC2136Factory() ProviderFactory {
+	return &rfc2136Factory{}
+}
+
+// Type returns the provider type name.
+func (f *rfc2136Factory) Type() string {
+	return TypeRFC2136
+}
+
+// CreateProvider creates an RFC 2136 provider from configuration.
+// Credentials are not verified here: the protocol has no cheap authenticated
+// request, a wrong key is reported by the first update or zone transfer.
+func (f *rfc2136Factory) CreateProvider(cfg *config.ProviderConfig) (Provider, error) {
+	if cfg.Type != TypeRFC2136 {
+		return nil, NewProviderConfigError("", TypeRFC2136, "type",
+			fmt.Sprintf("invalid provider type for RFC 2136 factory: %q", cfg.Type), nil)
+	}
+
+	creds, err := cfg.GetRFC2136Credentials()
+	if err != nil {
+		return nil, NewProviderCredentialsError(TypeRFC2136, "failed to get credentials", err)
+	}
+
+	server := strings.TrimSpace(creds.Server)
+	keyName := strings.TrimSpace(creds.KeyName)
+	keySecret := strings.TrimSpace(creds.KeySecret)
+	keyAlgo := strings.TrimSpace(creds.KeyAlgo)
+
+	if server == "" {
+		return nil, NewProviderCredentialsError(TypeRFC2136,
+			"server is required but not provided in credentials (check config file)", nil)
+	}
+	if keyName == "" || keySecret == "" {
+		return nil, NewProviderCredentialsError(TypeRFC2136,
+			"key_name and key_secret are required but not provided in credentials (check config file)", nil)
+	}
+	if _, err := base64.StdEncoding.DecodeString(keySecret); err != nil {
+		return nil, NewProviderCredentialsError(TypeRFC2136, "key_secret must be base64 encoded", err)
+	}
+	if keyAlgo != "" && !isTSIGAlgorithm(keyAlgo) {
+		return nil, NewProviderCredentialsError(TypeRFC2136,
+			fmt.Sprintf("unsupported key_algo %q", keyAlgo), nil)
+	}
+
+	opts, err := providerOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := NewRepoRFC2136(server, keyName, keySecret, keyAlgo, cfg.GetZones())
+
+	return NewProvider(repo, opts...), nil
+}
+
+// isTSIGAlgorithm reports whether algo is a TSIG algorithm supported by the dns package.
+func isTSIGAlgorithm(algo string) bool {
+	switch dns.Fqdn(strings.ToLower(algo)) {
+	case dns.HmacSHA1, dns.HmacSHA224, dns.HmacSHA256, dns.HmacSHA384, dns.HmacSHA512:
+		return true
+	default:
+		return false
+	}
+}
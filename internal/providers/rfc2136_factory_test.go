@@ -0,0 +1,68 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/config"
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRFC2136Factory(t *testing.T) {
+	factory := NewRFC2136Factory()
+	assert.Equal(t, "rfc2136", factory.Type())
+
+	tests := []struct {
+		name        string
+		credentials map[string]interface{}
+		wantErr     string
+	}{
+		{name: "missing server", credentials: map[string]interface{}{}, wantErr: "server is required"},
+		{name: "missing key", credentials: map[string]interface{}{"server": "127.0.0.1"}, wantErr: "key_name and key_secret are required"},
+		{name: "secret not base64", credentials: map[string]interface{}{"server": "127.0.0.1", "key_name": "k", "key_secret": "%%%"}, wantErr: "base64"},
+		{name: "unknown algorithm", credentials: map[string]interface{}{"server": "127.0.0.1", "key_name": "k", "key_secret": rfc2136TestKeySecret, "key_algo": "hmac-md4"}, wantErr: "unsupported key_algo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := factory.CreateProvider(&config.ProviderConfig{Type: "rfc2136", Credentials: tt.credentials})
+			assert.Nil(t, provider)
+
+			credsErr, ok := err.(*ProviderCredentialsError)
+			assert.True(t, ok)
+			assert.Contains(t, credsErr.Error(), tt.wantErr)
+		})
+	}
+
+	provider, err := factory.CreateProvider(&config.ProviderConfig{
+		Type: "rfc2136",
+		Credentials: map[string]interface{}{
+			"server":     "127.0.0.1:5353",
+			"key_name":   "cdnscli",
+			"key_secret": rfc2136TestKeySecret,
+			"key_algo":   "hmac-sha512",
+		},
+		Options: map[string]interface{}{"zones": []interface{}{"example.com"}},
+	})
+	require.NoError(t, err)
+	zones, err := provider.ListZones(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []models.Zone{{ID: "example.com.", Name: "example.com"}}, zones)
+}
@@ -0,0 +1,334 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	rfc2136TestKeyName   = "cdnscli."
+	rfc2136TestKeySecret = "c2VjcmV0LXNlY3JldC1zZWNyZXQ="
+)
+
+// rfc2136TestZone is the zone served by newRFC2136TestServer.
+var rfc2136TestZone = []string{
+	"example.com. 3600 IN SOA ns1.example.com. admin.example.com. 1 7200 3600 1209600 3600",
+	"www.example.com. 300 IN A 192.0.2.1",
+	"example.com. 3600 IN MX 10 mail.example.com.",
+	`example.com. 3600 IN TXT "v=spf1 -all"`,
+}
+
+// rfc2136TestServer is a fake name server accepting TSIG signed updates and zone transfers.
+type rfc2136TestServer struct {
+	addr    string
+	mu      sync.Mutex
+	updates []*dns.Msg
+	rcode   int
+}
+
+// newRFC2136TestServer starts a fake name server on a random local TCP port.
+func newRFC2136TestServer(t *testing.T) *rfc2136TestServer {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ts := &rfc2136TestServer{addr: ln.Addr().String(), rcode: dns.RcodeSuccess}
+	started := make(chan struct{})
+	srv := &dns.Server{
+		Listener:          ln,
+		TsigSecret:        map[string]string{rfc2136TestKeyName: rfc2136TestKeySecret},
+		Handler:           dns.HandlerFunc(ts.serveDNS),
+		NotifyStartedFunc: func() { close(started) },
+		// The default accept func answers NOTIMP to UPDATE messages
+		MsgAcceptFunc: func(dns.Header) dns.MsgAcceptAction { return dns.MsgAccept },
+	}
+	go func() { _ = srv.ActivateAndServe() }()
+	t.Cleanup(func() { _ = srv.Shutdown() })
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("fake name server did not start")
+	}
+
+	return ts
+}
+
+func (ts *rfc2136TestServer) serveDNS(w dns.ResponseWriter, req *dns.Msg) {
+	m := new(dns.Msg)
+	m.SetReply(req)
+	if req.IsTsig() == nil || w.TsigStatus() != nil {
+		m.Rcode = dns.RcodeNotAuth
+		_ = w.WriteMsg(m)
+		return
+	}
+	m.SetTsig(rfc2136TestKeyName, dns.HmacSHA256, 300, time.Now().Unix())
+
+	if req.Opcode == dns.OpcodeUpdate {
+		ts.mu.Lock()
+		ts.updates = append(ts.updates, req)
+		m.Rcode = ts.rcode
+		ts.mu.Unlock()
+		_ = w.WriteMsg(m)
+		return
+	}
+
+	if len(req.Question) == 1 && req.Question[0].Qtype == dns.TypeAXFR {
+		var rrs []dns.RR
+		for _, s := range rfc2136TestZone {
+			rr, _ := dns.NewRR(s)
+			rrs = append(rrs, rr)
+		}
+		rrs = append(rrs, rrs[0])
+
+		ch := make(chan *dns.Envelope, 1)
+		ch <- &dns.Envelope{RR: rrs}
+		close(ch)
+		tr := new(dns.Transfer)
+		_ = tr.Out(w, req, ch)
+		w.Hijack()
+		return
+	}
+
+	if len(req.Question) == 1 && req.Question[0].Qtype == dns.TypeSOA {
+		if req.Question[0].Name == "example.com." {
+			rr, _ := dns.NewRR(rfc2136TestZone[0])
+			m.Answer = append(m.Answer, rr)
+		}
+		_ = w.WriteMsg(m)
+		return
+	}
+
+	m.Rcode = dns.RcodeRefused
+	_ = w.WriteMsg(m)
+}
+
+func (ts *rfc2136TestServer) lastUpdate(t *testing.T) *dns.Msg {
+	t.Helper()
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	require.NotEmpty(t, ts.updates)
+	return ts.updates[len(ts.updates)-1]
+}
+
+func newRFC2136TestRepo(addr string) Repo {
+	return NewRepoRFC2136(addr, rfc2136TestKeyName, rfc2136TestKeySecret, "", []string{"example.com"})
+}
+
+func TestRepoRFC2136_ListDNSRecords(t *testing.T) {
+	ts := newRFC2136TestServer(t)
+	repo := newRFC2136TestRepo(ts.addr)
+
+	rrset, err := repo.ListDNSRecords(context.Background(), "example.com.")
+	require.NoError(t, err)
+	require.Len(t, rrset, 4)
+	assert.Equal(t, "SOA", rrset[0].Type)
+	assert.Equal(t, models.DNSRecord{
+		ID:      "www.example.com./A/192.0.2.1",
+		Name:    "www.example.com",
+		TTL:     300,
+		Type:    "A",
+		Content: "192.0.2.1",
+	}, rrset[1])
+	assert.Equal(t, "10 mail.example.com.", rrset[2].Content)
+	assert.Equal(t, "v=spf1 -all", rrset[3].Content)
+
+	rr, err := repo.GetDNSRecord(context.Background(), "example.com.", "www.example.com./A/192.0.2.1")
+	require.NoError(t, err)
+	assert.Equal(t, rrset[1], rr)
+}
+
+func TestRepoRFC2136_GetSOA(t *testing.T) {
+	ts := newRFC2136TestServer(t)
+	repo := newRFC2136TestRepo(ts.addr).(soaRepo)
+
+	soa, err := repo.GetSOA(context.Background(), "example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, models.SOA{
+		MName:   "ns1.example.com",
+		RName:   "admin.example.com",
+		Serial:  1,
+		Refresh: 7200,
+		Retry:   3600,
+		Expire:  1209600,
+		Minimum: 3600,
+		TTL:     3600,
+	}, soa)
+
+	_, err = repo.GetSOA(context.Background(), "example.org.")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestRepoRFC2136_WrongKey(t *testing.T) {
+	ts := newRFC2136TestServer(t)
+	repo := NewRepoRFC2136(ts.addr, rfc2136TestKeyName, "d3Jvbmc=", "", nil)
+
+	_, err := repo.CreateDNSRecord(context.Background(), models.CreateDNSRecordParams{
+		Name: "www.example.com", Type: "A", TTL: 300, Content: "192.0.2.2", ZoneName: "example.com",
+	})
+	assert.Error(t, err)
+}
+
+func TestRepoRFC2136_CreateDNSRecord(t *testing.T) {
+	ts := newRFC2136TestServer(t)
+	repo := newRFC2136TestRepo(ts.addr)
+
+	rr, err := repo.CreateDNSRecord(context.Background(), models.CreateDNSRecordParams{
+		Name: "www.example.com", Type: "a", TTL: 300, Content: "192.0.2.2", ZoneName: "example.com",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "www.example.com./A/192.0.2.2", rr.ID)
+
+	m := ts.lastUpdate(t)
+	assert.Equal(t, "example.com.", m.Question[0].Name)
+	assert.Equal(t, dns.TypeSOA, m.Question[0].Qtype)
+	require.Len(t, m.Ns, 1)
+	assert.Equal(t, "www.example.com.\t300\tIN\tA\t192.0.2.2", m.Ns[0].String())
+}
+
+func TestRepoRFC2136_CreateDNSRecord_TXT(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "spf with spaces",
+			content: "v=spf1 include:x ~all",
+			want:    `"v=spf1 include:x ~all"`,
+		},
+		{
+			name:    "dkim with semicolons",
+			content: "v=DKIM1; k=rsa; p=MIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQC",
+			want:    `"v=DKIM1; k=rsa; p=MIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQC"`,
+		},
+		{
+			name:    "already quoted",
+			content: `"v=spf1" " -all"`,
+			want:    `"v=spf1" " -all"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newRFC2136TestServer(t)
+			repo := newRFC2136TestRepo(ts.addr)
+
+			rr, err := repo.CreateDNSRecord(context.Background(), models.CreateDNSRecordParams{
+				Name: "example.com", Type: "TXT", TTL: 300, Content: tt.content, ZoneName: "example.com",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, "example.com./TXT/"+tt.want, rr.ID)
+			assert.Equal(t, models.JoinTXT(tt.want), rr.Content)
+
+			m := ts.lastUpdate(t)
+			require.Len(t, m.Ns, 1)
+			assert.Equal(t, tt.want, rfc2136Content(m.Ns[0]))
+		})
+	}
+}
+
+func TestRepoRFC2136_CreateDNSRecord_Priority(t *testing.T) {
+	ts := newRFC2136TestServer(t)
+	repo := newRFC2136TestRepo(ts.addr)
+
+	// The priority kept apart from the content is added back
+	rr, err := repo.CreateDNSRecord(context.Background(), models.CreateDNSRecordParams{
+		Name: "example.com", Type: "MX", TTL: 300, Content: "mail.example.com", Priority: 10, ZoneName: "example.com",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "10 mail.example.com.", rr.Content)
+
+	m := ts.lastUpdate(t)
+	require.Len(t, m.Ns, 1)
+	assert.Equal(t, "10 mail.example.com.", rfc2136Content(m.Ns[0]))
+}
+
+func TestRepoRFC2136_UpdateDNSRecord(t *testing.T) {
+	ts := newRFC2136TestServer(t)
+	repo := newRFC2136TestRepo(ts.addr)
+
+	rr, err := repo.UpdateDNSRecord(context.Background(), models.UpdateDNSRecordParams{
+		ID: "www.example.com./A/192.0.2.1", Content: "192.0.2.3", ZoneID: "example.com.",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.DNSRecord{
+		ID:      "www.example.com./A/192.0.2.3",
+		Name:    "www.example.com",
+		Type:    "A",
+		Content: "192.0.2.3",
+	}, rr)
+
+	m := ts.lastUpdate(t)
+	require.Len(t, m.Ns, 2)
+	assert.Equal(t, uint16(dns.ClassNONE), m.Ns[0].Header().Class)
+	assert.Equal(t, "192.0.2.1", rfc2136Content(m.Ns[0]))
+	assert.Equal(t, uint16(dns.ClassINET), m.Ns[1].Header().Class)
+	assert.Equal(t, "192.0.2.3", rfc2136Content(m.Ns[1]))
+}
+
+func TestRepoRFC2136_DeleteDNSRecord(t *testing.T) {
+	ts := newRFC2136TestServer(t)
+	repo := newRFC2136TestRepo(ts.addr)
+
+	require.NoError(t, repo.DeleteDNSRecord(context.Background(), "example.com.", `example.com./TXT/"v=spf1 -all"`))
+
+	m := ts.lastUpdate(t)
+	require.Len(t, m.Ns, 1)
+	assert.Equal(t, uint16(dns.ClassNONE), m.Ns[0].Header().Class)
+	assert.Equal(t, dns.TypeTXT, m.Ns[0].Header().Rrtype)
+
+	assert.Error(t, repo.DeleteDNSRecord(context.Background(), "example.com.", "bogus"))
+
+	ts.mu.Lock()
+	ts.rcode = dns.RcodeNotZone
+	ts.mu.Unlock()
+	err := repo.DeleteDNSRecord(context.Background(), "example.net.", "www.example.net./A/192.0.2.1")
+	var notFoundErr *NotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+}
+
+func TestRepoRFC2136_Zones(t *testing.T) {
+	repo := NewRepoRFC2136("ns1.example.com", rfc2136TestKeyName, rfc2136TestKeySecret, "", []string{"example.com", "example.net"})
+
+	id, err := repo.ZoneIDByName("example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com.", id)
+
+	zones, err := repo.ListZones(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []models.Zone{
+		{ID: "example.com.", Name: "example.com"},
+		{ID: "example.net.", Name: "example.net"},
+	}, zones)
+
+	zones, err = repo.ListZones(context.Background(), "example.org")
+	require.NoError(t, err)
+	assert.Equal(t, []models.Zone{{ID: "example.org.", Name: "example.org"}}, zones)
+}
@@ -0,0 +1,453 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mixanemca/cdnscli/internal/config"
+	"github.com/mixanemca/cdnscli/internal/models"
+)
+
+const (
+	// vultrDefaultAPIURL is the Vultr API base URL used when none is configured.
+	vultrDefaultAPIURL = "https://api.vultr.com"
+
+	// vultrPageSize is the number of items requested per page.
+	vultrPageSize = 500
+)
+
+// vultrDomain is a DNS domain as returned by the Vultr API.
+type vultrDomain struct {
+	Domain      string `json:"domain"`
+	DateCreated string `json:"date_created,omitempty"`
+	DNSSEC      string `json:"dns_sec,omitempty"`
+}
+
+// vultrRecord is a DNS record as returned by the Vultr API.
+// Names are relative to the domain, the apex is an empty name.
+type vultrRecord struct {
+	ID       string `json:"id,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Name     string `json:"name"`
+	Data     string `json:"data"`
+	Priority *int   `json:"priority,omitempty"`
+	TTL      int    `json:"ttl,omitempty"`
+}
+
+// vultrMeta holds the pagination details of a list response.
+type vultrMeta struct {
+	Total int `json:"total"`
+	Links struct {
+		Next string `json:"next"`
+	} `json:"links"`
+}
+
+type vultrDomainsResponse struct {
+	Domains []vultrDomain `json:"domains"`
+	Meta    vultrMeta     `json:"meta"`
+}
+
+type vultrDomainResponse struct {
+	Domain vultrDomain `json:"domain"`
+}
+
+type vultrRecordsResponse struct {
+	Records []vultrRecord `json:"records"`
+	Meta    vultrMeta     `json:"meta"`
+}
+
+type vultrRecordResponse struct {
+	Record vultrRecord `json:"record"`
+}
+
+// vultrError is an error response of the Vultr API.
+type vultrError struct {
+	Error string `json:"error"`
+}
+
+// vultrAPIError is returned for non-2xx responses of the Vultr API.
+type vultrAPIError struct {
+	StatusCode int
+	Message    string
+}
+
+// Error implements the error interface.
+func (e *vultrAPIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("vultr API error (HTTP %d): %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("vultr API error (HTTP %d)", e.StatusCode)
+}
+
+type repoVultr struct {
+	apiURL string
+	apiKey string
+	client *http.Client
+}
+
+// NewRepoVultr creates a repository for Vultr DNS provider.
+// Vultr domains have no IDs of their own, the domain name is used as zone ID.
+func NewRepoVultr(apiURL, apiKey string, client *http.Client) Repo {
+	if apiURL == "" {
+		apiURL = vultrDefaultAPIURL
+	}
+	if client == nil {
+		client = &http.Client{Timeout: config.DefaultClientTimeout}
+	}
+	return &repoVultr{
+		apiURL: strings.TrimSuffix(apiURL, "/"),
+		apiKey: apiKey,
+		client: client,
+	}
+}
+
+// Capabilities reports the record types Vultr manages, it has no optional features.
+func (r *repoVultr) Capabilities() models.Capabilities {
+	return models.Capabilities{RecordTypes: []string{"A", "AAAA", "CNAME", "NS", "MX", "SRV", "TXT", "CAA", "SSHFP"}}
+}
+
+func (r *repoVultr) GetDNSRecord(ctx context.Context, zoneID, recordID string) (models.DNSRecord, error) {
+	var resp vultrRecordResponse
+	if err := r.do(ctx, http.MethodGet, vultrPath("domains", zoneID, "records", recordID), nil, nil, &resp); err != nil {
+		return models.DNSRecord{}, vultrNotFound(err, "record with ID", recordID)
+	}
+
+	return convFromVultrRecord(zoneID, resp.Record), nil
+}
+
+func (r *repoVultr) CreateDNSRecord(ctx context.Context, params models.CreateDNSRecordParams) (models.DNSRecord, error) {
+	zoneID := params.ZoneID
+	if zoneID == "" {
+		if params.ZoneName == "" {
+			return models.DNSRecord{}, fmt.Errorf("zone name or zone ID must be provided")
+		}
+		zoneID = vultrDomainName(params.ZoneName)
+	}
+
+	priority, content := vultrSplitPriority(params.Type, params.Priority, params.Content)
+	req := vultrRecord{
+		Type:     strings.ToUpper(params.Type),
+		Name:     vultrRelativeName(params.Name, zoneID),
+		Data:     content,
+		Priority: priority,
+		TTL:      params.TTL,
+	}
+
+	var resp vultrRecordResponse
+	if err := r.do(ctx, http.MethodPost, vultrPath("domains", zoneID, "records"), nil, req, &resp); err != nil {
+		return models.DNSRecord{}, vultrNotFound(err, "zone", zoneID)
+	}
+
+	return convFromVultrRecord(zoneID, resp.Record), nil
+}
+
+func (r *repoVultr) DeleteDNSRecord(ctx context.Context, zoneID, recordID string) error {
+	err := r.do(ctx, http.MethodDelete, vultrPath("domains", zoneID, "records", recordID), nil, nil, nil)
+	return vultrNotFound(err, "record with ID", recordID)
+}
+
+func (r *repoVultr) ListDNSRecords(ctx context.Context, id string) ([]models.DNSRecord, error) {
+	var records []vultrRecord
+
+	query := url.Values{"per_page": {strconv.Itoa(vultrPageSize)}}
+	for {
+		var resp vultrRecordsResponse
+		if err := r.do(ctx, http.MethodGet, vultrPath("domains", id, "records"), query, nil, &resp); err != nil {
+			return []models.DNSRecord{}, vultrNotFound(err, "zone", id)
+		}
+		records = append(records, resp.Records...)
+
+		if resp.Meta.Links.Next == "" {
+			break
+		}
+		query.Set("cursor", resp.Meta.Links.Next)
+	}
+
+	return convFromVultrRecords(id, records), nil
+}
+
+func (r *repoVultr) ListZones(ctx context.Context, z ...string) ([]models.Zone, error) {
+	if len(z) > 0 && z[0] != "" {
+		var resp vultrDomainResponse
+		err := r.do(ctx, http.MethodGet, vultrPath("domains", vultrDomainName(z[0])), nil, nil, &resp)
+		if isVultrNotFound(err) {
+			return []models.Zone{}, nil
+		}
+		if err != nil {
+			return []models.Zone{}, err
+		}
+		return convFromVultrDomains([]vultrDomain{resp.Domain}), nil
+	}
+
+	var domains []vultrDomain
+
+	query := url.Values{"per_page": {strconv.Itoa(vultrPageSize)}}
+	for {
+		var resp vultrDomainsResponse
+		if err := r.do(ctx, http.MethodGet, vultrPath("domains"), query, nil, &resp); err != nil {
+			return []models.Zone{}, err
+		}
+		domains = append(domains, resp.Domains...)
+
+		if resp.Meta.Links.Next == "" {
+			break
+		}
+		query.Set("cursor", resp.Meta.Links.Next)
+	}
+
+	return convFromVultrDomains(domains), nil
+}
+
+func (r *repoVultr) UpdateDNSRecord(ctx context.Context, params models.UpdateDNSRecordParams) (models.DNSRecord, error) {
+	zoneID := params.ZoneID
+	if zoneID == "" {
+		if params.ZoneName == "" {
+			return models.DNSRecord{}, fmt.Errorf("zone name or zone ID must be provided")
+		}
+		zoneID = vultrDomainName(params.ZoneName)
+	}
+
+	existing, err := r.GetDNSRecord(ctx, zoneID, params.ID)
+	if err != nil {
+		return models.DNSRecord{}, err
+	}
+	// Vultr keeps the record type, a different type needs a new record
+	if params.Type != "" && !strings.EqualFold(params.Type, existing.Type) {
+		return models.DNSRecord{}, fmt.Errorf("vultr cannot change the type of record %s from %s to %s, delete and add it instead",
+			existing.Name, existing.Type, strings.ToUpper(params.Type))
+	}
+
+	if params.Name != "" {
+		existing.Name = params.Name
+	}
+	if params.TTL != 0 {
+		existing.TTL = params.TTL
+	}
+	// Without new content the record keeps its content and priority
+	var priority *int
+	if params.Content != "" {
+		var content string
+		priority, content = vultrSplitPriority(existing.Type, params.Priority, params.Content)
+		if priority != nil {
+			existing.Priority = *priority
+		}
+		existing.Content = content
+	}
+
+	req := vultrRecord{
+		Name:     vultrRelativeName(existing.Name, zoneID),
+		Data:     existing.Content,
+		Priority: priority,
+		TTL:      existing.TTL,
+	}
+	if err := r.do(ctx, http.MethodPatch, vultrPath("domains", zoneID, "records", params.ID), nil, req, nil); err != nil {
+		return models.DNSRecord{}, vultrNotFound(err, "record with ID", params.ID)
+	}
+
+	return existing, nil
+}
+
+func (r *repoVultr) ZoneIDByName(zoneName string) (string, error) {
+	zones, err := r.ListZones(context.Background(), zoneName)
+	if err != nil {
+		return "", err
+	}
+
+	if len(zones) == 0 {
+		return "", NewNotFoundError("zone", zoneName, nil)
+	}
+
+	return zones[0].ID, nil
+}
+
+// do performs an API request, encoding body and decoding the response into out if not nil.
+func (r *repoVultr) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	u := r.apiURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+r.apiKey)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &vultrAPIError{StatusCode: resp.StatusCode}
+		var e vultrError
+		if json.Unmarshal(data, &e) == nil {
+			apiErr.Message = e.Error
+		}
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			return NewProviderCredentialsError(TypeVultr, "request rejected", apiErr)
+		}
+		return apiErr
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// vultrPath builds an API path from escaped elements.
+func vultrPath(elem ...string) string {
+	parts := []string{"v2"}
+	for _, e := range elem {
+		parts = append(parts, url.PathEscape(e))
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+// isVultrNotFound reports whether err is a 404 response of the Vultr API.
+func isVultrNotFound(err error) bool {
+	apiErr, ok := err.(*vultrAPIError)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// vultrNotFound converts a 404 response into a NotFoundError for the given resource.
+func vultrNotFound(err error, resource, name string) error {
+	if isVultrNotFound(err) {
+		return NewNotFoundError(resource, name, err)
+	}
+	return err
+}
+
+// vultrDomainName returns the zone name without a trailing dot, as Vultr expects it.
+func vultrDomainName(zone string) string {
+	return strings.TrimSuffix(zone, ".")
+}
+
+// vultrRelativeName returns name relative to the domain, the apex becomes an empty name.
+func vultrRelativeName(name, domain string) string {
+	name = strings.TrimSuffix(name, ".")
+	switch {
+	case strings.EqualFold(name, domain):
+		return ""
+	case strings.HasSuffix(strings.ToLower(name), "."+strings.ToLower(domain)):
+		return name[:len(name)-len(domain)-1]
+	default:
+		return name
+	}
+}
+
+// vultrFQDN returns the fully qualified name of a record relative to the domain.
+func vultrFQDN(name, domain string) string {
+	if name == "" || name == "@" {
+		return domain
+	}
+	return name + "." + domain
+}
+
+// vultrSplitPriority returns the priority and the content to send for a record.
+// Vultr keeps the MX and SRV priority apart from the content, so a priority
+// given as the first word of the content ("10 mail.example.com") is split off
+// unless an explicit priority is set.
+func vultrSplitPriority(rrtype string, priority int, content string) (*int, string) {
+	switch strings.ToUpper(rrtype) {
+	case "MX", "SRV":
+	default:
+		return nil, content
+	}
+
+	if priority != 0 {
+		return &priority, content
+	}
+
+	if first, rest, ok := strings.Cut(strings.TrimSpace(content), " "); ok {
+		if p, err := strconv.Atoi(first); err == nil {
+			return &p, strings.TrimSpace(rest)
+		}
+	}
+
+	return nil, content
+}
+
+// Conversion functions
+
+func convFromVultrRecord(domain string, rec vultrRecord) models.DNSRecord {
+	rr := models.DNSRecord{
+		ID:      rec.ID,
+		Name:    vultrFQDN(rec.Name, domain),
+		TTL:     rec.TTL,
+		Type:    rec.Type,
+		Content: rec.Data,
+		Proxied: false, // Vultr doesn't support proxying
+	}
+	if rec.Priority != nil {
+		rr.Priority = *rec.Priority
+	}
+	return rr
+}
+
+func convFromVultrRecords(domain string, records []vultrRecord) []models.DNSRecord {
+	result := make([]models.DNSRecord, 0, len(records))
+	for _, rec := range records {
+		result = append(result, convFromVultrRecord(domain, rec))
+	}
+	return result
+}
+
+func convFromVultrDomains(domains []vultrDomain) []models.Zone {
+	result := make([]models.Zone, 0, len(domains))
+	for _, d := range domains {
+		zone := models.Zone{
+			ID:   d.Domain,
+			Name: d.Domain,
+		}
+		result = append(result, zone)
+	}
+	return result
+}
@@ -0,0 +1,85 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mixanemca/cdnscli/internal/config"
+)
+
+// vultrFactory creates Vultr providers.
+type vultrFactory struct{}
+
+// NewVultrFactory creates a new Vultr provider factory.
+func NewVultrFactory() ProviderFactory {
+	return &vultrFactory{}
+}
+
+// Type returns the provider type name.
+func (f *vultrFactory) Type() string {
+	return TypeVultr
+}
+
+// CreateProvider creates a Vultr provider from configuration.
+func (f *vultrFactory) CreateProvider(cfg *config.ProviderConfig) (Provider, error) {
+	if cfg.Type != TypeVultr {
+		return nil, NewProviderConfigError("", TypeVultr, "type",
+			fmt.Sprintf("invalid provider type for Vultr factory: %q", cfg.Type), nil)
+	}
+
+	creds, err := cfg.GetVultrCredentials()
+	if err != nil {
+		return nil, NewProviderCredentialsError(TypeVultr, "failed to get credentials", err)
+	}
+
+	apiKey := strings.TrimSpace(creds.APIKey)
+	apiURL := strings.TrimSpace(creds.APIURL)
+
+	if apiKey == "" {
+		return nil, NewProviderCredentialsError(TypeVultr,
+			"api_key is required but not provided in credentials (check config file)", nil)
+	}
+	if apiURL != "" {
+		if err := validateAPIURL(apiURL); err != nil {
+			return nil, NewProviderCredentialsError(TypeVultr, "invalid api_url", err)
+		}
+	}
+
+	opts, err := providerOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient, err := newHTTPClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := NewRepoVultr(apiURL, apiKey, httpClient)
+
+	// Verify credentials by trying to list zones
+	_, err = repo.ListZones(context.Background())
+	if err != nil {
+		return nil, NewProviderCredentialsError(TypeVultr,
+			"failed to verify credentials (api_key may be invalid)", err)
+	}
+
+	return NewProvider(repo, opts...), nil
+}
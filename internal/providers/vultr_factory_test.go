@@ -0,0 +1,140 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVultrFactory_Type(t *testing.T) {
+	factory := NewVultrFactory()
+	assert.Equal(t, "vultr", factory.Type())
+}
+
+func TestVultrFactory_CreateProvider_InvalidType(t *testing.T) {
+	factory := NewVultrFactory()
+	cfg := &config.ProviderConfig{
+		Type: "invalid-type",
+	}
+
+	provider, err := factory.CreateProvider(cfg)
+	assert.Nil(t, provider)
+	assert.Error(t, err)
+
+	configErr, ok := err.(*ProviderConfigError)
+	assert.True(t, ok)
+	assert.Contains(t, configErr.Error(), "invalid provider type")
+}
+
+func TestVultrFactory_CreateProvider_InvalidCredentials(t *testing.T) {
+	tests := []struct {
+		name        string
+		credentials map[string]interface{}
+		wantErr     string
+	}{
+		{
+			name:        "missing credentials",
+			credentials: map[string]interface{}{},
+			wantErr:     "api_key is required",
+		},
+		{
+			name: "empty api key",
+			credentials: map[string]interface{}{
+				"api_key": "   ",
+			},
+			wantErr: "api_key is required",
+		},
+		{
+			name: "invalid api url",
+			credentials: map[string]interface{}{
+				"api_key": "secret",
+				"api_url": "ftp://api.vultr.com",
+			},
+			wantErr: "scheme must be http or https",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := NewVultrFactory()
+			cfg := &config.ProviderConfig{
+				Type:        "vultr",
+				Credentials: tt.credentials,
+			}
+
+			provider, err := factory.CreateProvider(cfg)
+			assert.Nil(t, provider)
+			assert.Error(t, err)
+
+			credsErr, ok := err.(*ProviderCredentialsError)
+			assert.True(t, ok)
+			assert.Contains(t, credsErr.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestVultrFactory_CreateProvider_Unauthorized(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"Invalid API token.","status":401}`))
+	}))
+	defer srv.Close()
+
+	factory := NewVultrFactory()
+	cfg := &config.ProviderConfig{
+		Type: "vultr",
+		Credentials: map[string]interface{}{
+			"api_key": "wrong",
+			"api_url": srv.URL,
+		},
+	}
+
+	provider, err := factory.CreateProvider(cfg)
+	assert.Nil(t, provider)
+	assert.Error(t, err)
+
+	credsErr, ok := err.(*ProviderCredentialsError)
+	assert.True(t, ok)
+	assert.Contains(t, credsErr.Error(), "failed to verify credentials")
+	assert.Contains(t, credsErr.Error(), "Invalid API token.")
+}
+
+func TestVultrFactory_CreateProvider_Success(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/domains", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"domains":[],"meta":{"total":0,"links":{"next":"","prev":""}}}`))
+	}))
+	defer srv.Close()
+
+	factory := NewVultrFactory()
+	cfg := &config.ProviderConfig{
+		Type: "vultr",
+		Credentials: map[string]interface{}{
+			"api_key": "secret",
+			"api_url": srv.URL + "/",
+		},
+	}
+
+	provider, err := factory.CreateProvider(cfg)
+	assert.NoError(t, err)
+	assert.NotNil(t, provider)
+}
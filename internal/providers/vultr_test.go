@@ -0,0 +1,242 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/config"
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// vultrRequest is a request with a body received by newVultrTestServer.
+type vultrRequest struct {
+	Method string
+	Path   string
+	Body   map[string]any
+}
+
+// newVultrTestServer returns a Vultr API stub serving example.com and recording write requests.
+func newVultrTestServer(t *testing.T, requests *[]vultrRequest) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		if r.Method != http.MethodGet {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			req := vultrRequest{Method: r.Method, Path: r.URL.Path}
+			if len(body) > 0 {
+				require.NoError(t, json.Unmarshal(body, &req.Body))
+			}
+			*requests = append(*requests, req)
+		}
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/domains":
+			_, _ = w.Write([]byte(`{"domains":[{"domain":"example.com"}],"meta":{"total":1,"links":{"next":"","prev":""}}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/domains/example.com":
+			_, _ = w.Write([]byte(`{"domain":{"domain":"example.com"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/domains/example.com/records":
+			if r.URL.Query().Get("cursor") == "" {
+				_, _ = w.Write([]byte(`{"records":[
+					{"id":"r1","type":"A","name":"www","data":"192.0.2.1","ttl":300},
+					{"id":"r2","type":"MX","name":"","data":"mail.example.com","priority":10,"ttl":3600}
+				],"meta":{"total":3,"links":{"next":"page2","prev":""}}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"records":[
+				{"id":"r3","type":"TXT","name":"","data":"\"v=spf1 -all\"","ttl":3600}
+			],"meta":{"total":3,"links":{"next":"","prev":"page1"}}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/domains/example.com/records/r2":
+			_, _ = w.Write([]byte(`{"record":{"id":"r2","type":"MX","name":"","data":"mail.example.com","priority":10,"ttl":3600}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/domains/example.com/records":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"record":{"id":"r4","type":"MX","name":"","data":"mx2.example.com","priority":20,"ttl":300}}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/v2/domains/example.com/records/r2":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && r.URL.Path == "/v2/domains/example.com/records/r1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"Not found","status":404}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestRepoVultr_ListZones(t *testing.T) {
+	var requests []vultrRequest
+	srv := newVultrTestServer(t, &requests)
+	repo := NewRepoVultr(srv.URL, "secret", nil)
+
+	zones, err := repo.ListZones(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []models.Zone{{ID: "example.com", Name: "example.com"}}, zones)
+
+	zones, err = repo.ListZones(context.Background(), "missing.com")
+	require.NoError(t, err)
+	assert.Empty(t, zones)
+}
+
+func TestRepoVultr_ZoneIDByName(t *testing.T) {
+	var requests []vultrRequest
+	srv := newVultrTestServer(t, &requests)
+	repo := NewRepoVultr(srv.URL, "secret", nil)
+
+	id, err := repo.ZoneIDByName("example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", id)
+
+	_, err = repo.ZoneIDByName("missing.com")
+	var notFoundErr *NotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+}
+
+func TestNewRepoVultr_Timeout(t *testing.T) {
+	repo := NewRepoVultr("", "secret", nil).(*repoVultr)
+	assert.Equal(t, config.DefaultClientTimeout, repo.client.Timeout)
+}
+
+func TestRepoVultr_ListDNSRecords(t *testing.T) {
+	var requests []vultrRequest
+	srv := newVultrTestServer(t, &requests)
+	repo := NewRepoVultr(srv.URL, "secret", nil)
+
+	rrset, err := repo.ListDNSRecords(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []models.DNSRecord{
+		{ID: "r1", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1"},
+		{ID: "r2", Name: "example.com", TTL: 3600, Type: "MX", Content: "mail.example.com", Priority: 10},
+		{ID: "r3", Name: "example.com", TTL: 3600, Type: "TXT", Content: `"v=spf1 -all"`},
+	}, rrset)
+}
+
+func TestRepoVultr_CreateDNSRecord(t *testing.T) {
+	var requests []vultrRequest
+	srv := newVultrTestServer(t, &requests)
+	repo := NewRepoVultr(srv.URL, "secret", nil)
+
+	rr, err := repo.CreateDNSRecord(context.Background(), models.CreateDNSRecordParams{
+		Name:     "example.com",
+		Type:     "mx",
+		Content:  "20 mx2.example.com",
+		TTL:      300,
+		ZoneName: "example.com",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.DNSRecord{ID: "r4", Name: "example.com", TTL: 300, Type: "MX", Content: "mx2.example.com", Priority: 20}, rr)
+
+	require.Len(t, requests, 1)
+	assert.Equal(t, map[string]any{
+		"type":     "MX",
+		"name":     "",
+		"data":     "mx2.example.com",
+		"priority": float64(20),
+		"ttl":      float64(300),
+	}, requests[0].Body)
+}
+
+func TestRepoVultr_UpdateDNSRecord(t *testing.T) {
+	var requests []vultrRequest
+	srv := newVultrTestServer(t, &requests)
+	repo := NewRepoVultr(srv.URL, "secret", nil)
+
+	rr, err := repo.UpdateDNSRecord(context.Background(), models.UpdateDNSRecordParams{
+		ID:       "r2",
+		Name:     "example.com",
+		Type:     "MX",
+		Content:  "mx3.example.com",
+		Priority: 5,
+		ZoneID:   "example.com",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.DNSRecord{ID: "r2", Name: "example.com", TTL: 3600, Type: "MX", Content: "mx3.example.com", Priority: 5}, rr)
+
+	require.Len(t, requests, 1)
+	assert.Equal(t, http.MethodPatch, requests[0].Method)
+	assert.Equal(t, map[string]any{
+		"name":     "",
+		"data":     "mx3.example.com",
+		"priority": float64(5),
+		"ttl":      float64(3600),
+	}, requests[0].Body)
+
+	// A TTL-only update keeps the content and the priority
+	requests = nil
+	rr, err = repo.UpdateDNSRecord(context.Background(), models.UpdateDNSRecordParams{
+		ID:     "r2",
+		TTL:    300,
+		ZoneID: "example.com",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.DNSRecord{ID: "r2", Name: "example.com", TTL: 300, Type: "MX", Content: "mail.example.com", Priority: 10}, rr)
+	require.Len(t, requests, 1)
+	assert.Equal(t, map[string]any{
+		"name": "",
+		"data": "mail.example.com",
+		"ttl":  float64(300),
+	}, requests[0].Body)
+
+	_, err = repo.UpdateDNSRecord(context.Background(), models.UpdateDNSRecordParams{
+		ID:      "r2",
+		Type:    "CNAME",
+		Content: "mail.example.net",
+		ZoneID:  "example.com",
+	})
+	assert.ErrorContains(t, err, "cannot change the type")
+}
+
+func TestRepoVultr_DeleteDNSRecord(t *testing.T) {
+	var requests []vultrRequest
+	srv := newVultrTestServer(t, &requests)
+	repo := NewRepoVultr(srv.URL, "secret", nil)
+
+	require.NoError(t, repo.DeleteDNSRecord(context.Background(), "example.com", "r1"))
+	require.Len(t, requests, 1)
+	assert.Equal(t, vultrRequest{Method: http.MethodDelete, Path: "/v2/domains/example.com/records/r1"}, requests[0])
+
+	err := repo.DeleteDNSRecord(context.Background(), "example.com", "missing")
+	var notFoundErr *NotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+}
+
+func TestVultrSplitPriority(t *testing.T) {
+	priority, content := vultrSplitPriority("MX", 0, "10 mail.example.com")
+	require.NotNil(t, priority)
+	assert.Equal(t, 10, *priority)
+	assert.Equal(t, "mail.example.com", content)
+
+	priority, content = vultrSplitPriority("MX", 5, "mail.example.com")
+	require.NotNil(t, priority)
+	assert.Equal(t, 5, *priority)
+	assert.Equal(t, "mail.example.com", content)
+
+	priority, content = vultrSplitPriority("A", 0, "192.0.2.1")
+	assert.Nil(t, priority)
+	assert.Equal(t, "192.0.2.1", content)
+}
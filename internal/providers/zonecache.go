@@ -0,0 +1,221 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mixanemca/cdnscli/internal/config"
+	"github.com/mixanemca/cdnscli/internal/models"
+)
+
+// ZoneCache is an on-disk cache of zone name to ID mappings of a single provider.
+// It lets repeated commands skip looking zones up through the API.
+type ZoneCache struct {
+	mu      sync.Mutex
+	path    string
+	ttl     time.Duration
+	refresh bool
+	now     func() time.Time
+}
+
+// zoneCacheEntry is a cached zone ID and the time it was looked up.
+type zoneCacheEntry struct {
+	ID      string    `json:"id"`
+	Updated time.Time `json:"updated"`
+}
+
+// NewZoneCache creates a cache of provider zones stored in dir. Entries older than
+// ttl are ignored; with refresh set all entries are ignored and rewritten.
+func NewZoneCache(dir, provider string, ttl time.Duration, refresh bool) *ZoneCache {
+	return &ZoneCache{
+		path:    filepath.Join(dir, "zones-"+provider+".json"),
+		ttl:     ttl,
+		refresh: refresh,
+		now:     time.Now,
+	}
+}
+
+// DefaultCacheDir returns the default cache directory, $XDG_CACHE_HOME/cdnscli.
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "cdnscli"), nil
+}
+
+// Get returns the cached ID of zone if it has not expired.
+func (c *ZoneCache) Get(zone string) (string, bool) {
+	if c.refresh {
+		return "", false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, err := c.read()
+	if err != nil {
+		return "", false
+	}
+
+	e, ok := entries[zoneCacheKey(zone)]
+	if !ok || e.ID == "" || c.now().Sub(e.Updated) > c.ttl {
+		return "", false
+	}
+
+	return e.ID, true
+}
+
+// Set stores the ID of zone, dropping expired entries.
+func (c *ZoneCache) Set(zone, id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, err := c.read()
+	if err != nil {
+		entries = make(map[string]zoneCacheEntry)
+	}
+
+	now := c.now()
+	for k, e := range entries {
+		if now.Sub(e.Updated) > c.ttl {
+			delete(entries, k)
+		}
+	}
+	entries[zoneCacheKey(zone)] = zoneCacheEntry{ID: id, Updated: now}
+
+	return c.write(entries)
+}
+
+// Warm replaces the cached entries with all zones of the provider, so zones
+// deleted since are dropped. It is used to fill the cache for shell completion.
+func (c *ZoneCache) Warm(zones []models.Zone) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	entries := make(map[string]zoneCacheEntry, len(zones))
+	for _, z := range zones {
+		entries[zoneCacheKey(z.Name)] = zoneCacheEntry{ID: z.ID, Updated: now}
+	}
+
+	return c.write(entries)
+}
+
+// Names returns the sorted names of the zones cached and not expired. With
+// refresh set no names are returned, as for Get.
+func (c *ZoneCache) Names() []string {
+	if c.refresh {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, err := c.read()
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for name, e := range entries {
+		if e.ID != "" && c.now().Sub(e.Updated) <= c.ttl {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// write stores entries in the cache file.
+func (c *ZoneCache) write(entries map[string]zoneCacheEntry) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	// Write to a temporary file first, so concurrent commands never read a partial file
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write zone cache: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write zone cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write zone cache: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), c.path)
+}
+
+// read loads all cache entries. A missing file is an empty cache.
+func (c *ZoneCache) read() (map[string]zoneCacheEntry, error) {
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return make(map[string]zoneCacheEntry), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]zoneCacheEntry)
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// zoneCacheKey normalizes a zone name, so example.com and Example.COM. share an entry.
+func zoneCacheKey(zone string) string {
+	return strings.ToLower(strings.TrimSuffix(zone, "."))
+}
+
+// ZoneCacheFromConfig returns the zone cache of the provider or nil if caching is off.
+func ZoneCacheFromConfig(cfg *config.ProviderConfig) *ZoneCache {
+	cache := cfg.Cache()
+	if cfg.Name() == "" || cache.ZoneTTL <= 0 {
+		return nil
+	}
+
+	dir := cache.Dir
+	if dir == "" {
+		var err error
+		if dir, err = DefaultCacheDir(); err != nil {
+			return nil
+		}
+	}
+
+	return NewZoneCache(dir, cfg.Name(), cache.ZoneTTL, cache.Refresh)
+}
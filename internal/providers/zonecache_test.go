@@ -0,0 +1,194 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mixanemca/cdnscli/internal/config"
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZoneCache(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	c := NewZoneCache(dir, "cf", time.Hour, false)
+	c.now = func() time.Time { return now }
+
+	_, ok := c.Get("example.com")
+	assert.False(t, ok, "empty cache")
+
+	require.NoError(t, c.Set("Example.COM.", "12345"))
+	assert.FileExists(t, filepath.Join(dir, "zones-cf.json"))
+
+	id, ok := c.Get("example.com")
+	assert.True(t, ok)
+	assert.Equal(t, "12345", id)
+
+	// Another cache of the same provider reads the same file
+	other := NewZoneCache(dir, "cf", time.Hour, false)
+	other.now = c.now
+	id, ok = other.Get("example.com")
+	assert.True(t, ok)
+	assert.Equal(t, "12345", id)
+
+	// Other providers have their own file
+	_, ok = NewZoneCache(dir, "pdns", time.Hour, false).Get("example.com")
+	assert.False(t, ok)
+
+	// Refresh ignores entries but still writes them
+	refresh := NewZoneCache(dir, "cf", time.Hour, true)
+	refresh.now = c.now
+	_, ok = refresh.Get("example.com")
+	assert.False(t, ok)
+	require.NoError(t, refresh.Set("example.com", "67890"))
+	id, _ = c.Get("example.com")
+	assert.Equal(t, "67890", id)
+
+	// Entries expire after the TTL
+	now = now.Add(time.Hour + time.Second)
+	_, ok = c.Get("example.com")
+	assert.False(t, ok)
+}
+
+func TestZoneCache_DropsExpiredEntries(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	c := NewZoneCache(dir, "cf", time.Minute, false)
+	c.now = func() time.Time { return now }
+
+	require.NoError(t, c.Set("old.com", "1"))
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, c.Set("new.com", "2"))
+
+	entries, err := c.read()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"new.com"}, keys(entries))
+}
+
+func TestZoneCache_CorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "zones-cf.json"), []byte("{not json"), 0o600))
+
+	c := NewZoneCache(dir, "cf", time.Hour, false)
+	_, ok := c.Get("example.com")
+	assert.False(t, ok)
+
+	// A broken cache is overwritten
+	require.NoError(t, c.Set("example.com", "12345"))
+	id, ok := c.Get("example.com")
+	assert.True(t, ok)
+	assert.Equal(t, "12345", id)
+}
+
+func TestZoneCache_Warm(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	c := NewZoneCache(dir, "cf", time.Hour, false)
+	c.now = func() time.Time { return now }
+
+	assert.Empty(t, c.Names(), "empty cache")
+
+	require.NoError(t, c.Set("deleted.com", "1"))
+	require.NoError(t, c.Warm([]models.Zone{
+		{ID: "2", Name: "example.org"},
+		{ID: "3", Name: "Example.COM."},
+	}))
+
+	// Warming replaces the entries, zones not listed are dropped
+	assert.Equal(t, []string{"example.com", "example.org"}, c.Names())
+	id, ok := c.Get("example.com")
+	assert.True(t, ok)
+	assert.Equal(t, "3", id)
+	_, ok = c.Get("deleted.com")
+	assert.False(t, ok)
+
+	// Zones looked up later are offered too
+	now = now.Add(30 * time.Minute)
+	require.NoError(t, c.Set("example.net", "4"))
+	assert.Equal(t, []string{"example.com", "example.net", "example.org"}, c.Names())
+
+	// Stale entries are not offered
+	now = now.Add(45 * time.Minute)
+	assert.Equal(t, []string{"example.net"}, c.Names())
+	now = now.Add(time.Hour)
+	assert.Empty(t, c.Names())
+
+	// With refresh nothing is offered, but warming still writes the cache
+	refresh := NewZoneCache(dir, "cf", time.Hour, true)
+	refresh.now = c.now
+	require.NoError(t, refresh.Warm([]models.Zone{{ID: "2", Name: "example.org"}}))
+	assert.Empty(t, refresh.Names())
+	assert.Equal(t, []string{"example.org"}, c.Names())
+}
+
+func TestZoneCacheFromConfig(t *testing.T) {
+	cfg := &config.Config{
+		Providers: map[string]config.ProviderConfig{"cf": {Type: TypeCloudflare}},
+	}
+
+	pc, err := cfg.GetProvider("cf")
+	require.NoError(t, err)
+	assert.Nil(t, ZoneCacheFromConfig(pc), "cache is off without zone_ttl")
+
+	cfg.Cache = config.CacheConfig{Dir: t.TempDir(), ZoneTTL: time.Hour}
+	pc, err = cfg.GetProvider("cf")
+	require.NoError(t, err)
+	c := ZoneCacheFromConfig(pc)
+	require.NotNil(t, c)
+	assert.Equal(t, filepath.Join(cfg.Cache.Dir, "zones-cf.json"), c.path)
+
+	// Provider configs not returned by GetProvider have no name to key the cache by
+	assert.Nil(t, ZoneCacheFromConfig(&config.ProviderConfig{Type: TypeCloudflare}))
+}
+
+func TestProvider_ZoneCache(t *testing.T) {
+	ctx := context.Background()
+	cache := NewZoneCache(t.TempDir(), "cf", time.Hour, false)
+
+	mockClient := new(MockClient)
+	mockClient.On("ZoneIDByName", "example.com").
+		Return("12345", nil).Once()
+	mockClient.On("ListDNSRecords", mock.Anything, "12345").
+		Return([]models.DNSRecord{}, nil)
+
+	// The first call looks the zone up, the second one takes it from the cache
+	for i := 0; i < 2; i++ {
+		p := NewProvider(mockClient, WithZoneCache(cache))
+		_, err := p.ListRecords(ctx, models.ListDNSRecordsParams{ZoneName: "example.com"})
+		require.NoError(t, err)
+	}
+	mockClient.AssertNumberOfCalls(t, "ZoneIDByName", 1)
+}
+
+func keys(m map[string]zoneCacheEntry) []string {
+	var ks []string
+	for k := range m {
+		ks = append(ks, k)
+	}
+	return ks
+}
@@ -0,0 +1,71 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import "sync"
+
+// zoneResolver keeps the zone IDs a provider looked up in memory, so repeated
+// operations of one process, e.g. in the TUI or a bulk command, look every zone
+// up once. It is the in-process counterpart of ZoneCache. Concurrent lookups of
+// the same zone share one call; failed lookups are not kept, so the next
+// operation looks the zone up again.
+type zoneResolver struct {
+	mu      sync.Mutex
+	lookup  func(zone string) (string, error)
+	entries map[string]*zoneResolution
+}
+
+// zoneResolution is a lookup of a zone ID, done once it is closed.
+type zoneResolution struct {
+	done chan struct{}
+	id   string
+	err  error
+}
+
+// newZoneResolver returns a resolver looking zones up with lookup.
+func newZoneResolver(lookup func(zone string) (string, error)) *zoneResolver {
+	return &zoneResolver{
+		lookup:  lookup,
+		entries: make(map[string]*zoneResolution),
+	}
+}
+
+// resolve returns the ID of zone, looking it up unless it is already known.
+// Zone names are compared case-insensitively and without the trailing dot.
+func (r *zoneResolver) resolve(zone string) (string, error) {
+	key := zoneCacheKey(zone)
+
+	r.mu.Lock()
+	if res, ok := r.entries[key]; ok {
+		r.mu.Unlock()
+		<-res.done
+		return res.id, res.err
+	}
+	res := &zoneResolution{done: make(chan struct{})}
+	r.entries[key] = res
+	r.mu.Unlock()
+
+	res.id, res.err = r.lookup(zone)
+	if res.err != nil {
+		r.mu.Lock()
+		delete(r.entries, key)
+		r.mu.Unlock()
+	}
+	close(res.done)
+
+	return res.id, res.err
+}
@@ -0,0 +1,118 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/mixanemca/regru-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZoneResolver(t *testing.T) {
+	calls := make(map[string]int)
+	fail := true
+	r := newZoneResolver(func(zone string) (string, error) {
+		calls[zone]++
+		if zone == "broken.com" && fail {
+			return "", errors.New("boom")
+		}
+		return "id-" + zone, nil
+	})
+
+	for _, zone := range []string{"example.com", "Example.COM.", "example.com"} {
+		id, err := r.resolve(zone)
+		require.NoError(t, err)
+		assert.Equal(t, "id-example.com", id)
+	}
+	assert.Equal(t, map[string]int{"example.com": 1}, calls)
+
+	// Failed lookups are not kept
+	_, err := r.resolve("broken.com")
+	assert.EqualError(t, err, "boom")
+	fail = false
+	id, err := r.resolve("broken.com")
+	require.NoError(t, err)
+	assert.Equal(t, "id-broken.com", id)
+	assert.Equal(t, 2, calls["broken.com"])
+}
+
+func TestZoneResolver_Concurrent(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	r := newZoneResolver(func(zone string) (string, error) {
+		calls.Add(1)
+		<-release
+		return "42", nil
+	})
+
+	var wg sync.WaitGroup
+	ids := make([]string, 8)
+	for i := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids[i], _ = r.resolve("example.com")
+		}()
+	}
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	for _, id := range ids {
+		assert.Equal(t, "42", id)
+	}
+}
+
+func TestProviderResolvesZonesOnce(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("cloudflare", func(t *testing.T) {
+		rrset := []models.DNSRecord{{ID: "1", Name: "www.example.com", Type: "A"}}
+		mockClient := new(MockClient)
+		mockClient.On("ZoneIDByName", "example.com").Return("12345", nil).Once()
+		mockClient.On("ListDNSRecords", mock.Anything, "12345").Return(rrset, nil)
+		mockClient.On("GetDNSRecord", mock.Anything, "12345", "1").Return(rrset[0], nil)
+
+		p := NewProvider(mockClient)
+		for _, zone := range []string{"example.com", "example.com", "EXAMPLE.com"} {
+			_, err := p.GetRRByName(ctx, zone, "www")
+			require.NoError(t, err)
+		}
+		mockClient.AssertNumberOfCalls(t, "ZoneIDByName", 1)
+	})
+
+	t.Run("regru", func(t *testing.T) {
+		client := new(MockRegRuClient)
+		client.On("ListZonesByName", mock.Anything, "example.com").Return([]regru.Zone{{ID: "42", Name: "example.com"}}, nil).Once()
+		client.On("ListRecords", mock.Anything, regru.ListDNSRecordsParams{ZoneName: "example.com"}).Return([]regru.DNSRecord{{ID: "1", Name: "www.example.com", Type: "A"}}, nil)
+
+		p := NewProvider(newRepoRegRu(client))
+		for i := 0; i < 3; i++ {
+			_, err := p.GetRRByName(ctx, "example.com", "www")
+			require.NoError(t, err)
+		}
+		client.AssertNumberOfCalls(t, "ListZonesByName", 1)
+	})
+}
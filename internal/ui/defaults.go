@@ -0,0 +1,70 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"strconv"
+
+	"github.com/mixanemca/cdnscli/internal/config"
+)
+
+// Built-in initial values of the record creation form.
+const (
+	defaultFormTTL     = 3600
+	defaultFormType    = "A"
+	defaultFormProxied = false
+)
+
+// recordDefaults returns the initial values of the record creation form
+// (Name, TTL, Type, Proxied, Content). The TTL, type and proxied values come
+// from the provider options default_ttl, default_type and default_proxied;
+// options that are missing or invalid fall back to the built-in defaults.
+func recordDefaults(pc *config.ProviderConfig) []string {
+	ttl := defaultFormTTL
+	rrtype := defaultFormType
+	proxied := defaultFormProxied
+
+	if pc != nil {
+		if v, err := pc.GetDefaultTTL(); err == nil && v > 0 {
+			ttl = v
+		}
+		if v := pc.GetDefaultType(); v != "" {
+			rrtype = v
+		}
+		if v, err := pc.GetDefaultProxied(); err == nil {
+			proxied = v
+		}
+	}
+
+	return []string{"", strconv.Itoa(ttl), rrtype, strconv.FormatBool(proxied), ""}
+}
+
+// recordDefaults returns the initial values of the record creation form
+// for the default provider of the application.
+func (m *Model) recordDefaults() []string {
+	a, err := m.getApp()
+	if err != nil {
+		return recordDefaults(nil)
+	}
+
+	pc, err := a.ProviderConfig("")
+	if err != nil {
+		return recordDefaults(nil)
+	}
+
+	return recordDefaults(pc)
+}
@@ -0,0 +1,74 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"testing"
+
+	"github.com/mixanemca/cdnscli/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordDefaults(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]interface{}
+		want    []string
+	}{
+		{
+			name: "no options",
+			want: []string{"", "3600", "A", "false", ""},
+		},
+		{
+			name: "all options",
+			options: map[string]interface{}{
+				"default_ttl":     300,
+				"default_type":    "cname",
+				"default_proxied": true,
+			},
+			want: []string{"", "300", "CNAME", "true", ""},
+		},
+		{
+			name: "string values",
+			options: map[string]interface{}{
+				"default_ttl":     "auto",
+				"default_proxied": "true",
+			},
+			want: []string{"", "1", "A", "true", ""},
+		},
+		{
+			name: "invalid values fall back to built-in defaults",
+			options: map[string]interface{}{
+				"default_ttl":     "soon",
+				"default_type":    " ",
+				"default_proxied": "maybe",
+			},
+			want: []string{"", "3600", "A", "false", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := &config.ProviderConfig{Type: "cloudflare", Options: tt.options}
+			assert.Equal(t, tt.want, recordDefaults(pc))
+		})
+	}
+}
+
+func TestRecordDefaults_NoConfig(t *testing.T) {
+	assert.Equal(t, []string{"", "3600", "A", "false", ""}, recordDefaults(nil))
+	assert.Equal(t, []string{"", "3600", "A", "false", ""}, NewModel().recordDefaults())
+}
@@ -18,10 +18,13 @@ limitations under the License.
 package ui
 
 import (
-	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
@@ -53,12 +56,27 @@ const (
 	tableStatusZones   = "zones"
 )
 
+var (
+	errNoZoneSelected   = errors.New("no zone selected")
+	errNoRecordSelected = errors.New("no record selected")
+)
+
 const (
 	headerHeight = 3
 	statusHeight = 1
 	menuHeight   = 1
 )
 
+const (
+	// rrsetIDColumn is the index of the optional ID column, the last one of the rrset table
+	rrsetIDColumn = 5
+	// shortIDLength is the number of record ID characters shown in the ID column
+	shortIDLength = 8
+)
+
+// defaultWatchInterval is how often the watch mode reloads records unless ui.watch_interval is set.
+const defaultWatchInterval = 30 * time.Second
+
 // Ensure that model fulfils the tea.Model interface at compile time.
 var _ tea.Model = (*Model)(nil)
 
@@ -72,20 +90,52 @@ type (
 		record models.DNSRecord
 	}
 	recordDeletedMsg struct {
+		id         string
 		recordName string
 	}
 	recordUpdatedMsg struct {
-		recordName string
+		zone   string
+		record models.DNSRecord
+	}
+	nameServersUpdatedMsg struct {
+		zone    string
+		servers []string
+	}
+	// recordsCountedMsg carries the record count of a zone whose records are not loaded yet.
+	recordsCountedMsg struct {
+		zone  string
+		count int
 	}
 	clearNotificationMsg struct{}
-	showNotificationMsg  struct {
-		message string
+	// watchTickMsg reloads the records of the selected zone in watch mode.
+	// Ticks scheduled before watching was toggled carry an outdated seq and are dropped.
+	watchTickMsg struct {
+		seq int
+	}
+	// statusMsg shows a transient message in the status bar.
+	statusMsg struct {
+		text     string
+		severity statusSeverity
 	}
 	errorMsg struct {
 		err error
 	}
 )
 
+// statusSeverity is the severity of a status bar message.
+type statusSeverity int
+
+const (
+	statusInfo statusSeverity = iota
+	statusSuccess
+	statusError
+)
+
+// errorStatus returns a status bar message for the given error.
+func errorStatus(err error) statusMsg {
+	return statusMsg{text: fmt.Sprintf("Error: %v", err), severity: statusError}
+}
+
 // Messages to control the popup window
 type editRowMsg struct {
 	row table.Row
@@ -102,10 +152,16 @@ type Model struct {
 	height            int
 	spinner           spinner.Model
 	loading           bool
-	notification      string      // текущая нотификация
+	notification      string // текущая нотификация
+	notificationLevel statusSeverity
 	notificationTimer *time.Timer // таймер для автоматического скрытия
 	current           *table.Model
 	rrsetCache        map[string][]models.DNSRecord
+	recordCounts      map[string]int // record counts of zones not in rrsetCache yet
+	rrsetIDs          []string       // IDs of the records in the rrset table, in row order
+	showIDs           bool           // show the record ID column in the rrset table
+	watching          bool           // reload the records of the selected zone periodically
+	watchSeq          int            // sequence of the current watch, see watchTickMsg
 
 	// editing
 	popup        *popup.Model
@@ -114,8 +170,17 @@ type Model struct {
 	editRow      table.Row
 	editBuffer   []string
 	cursor       int
-	creating     bool // флаг создания новой записи
-	deleteCursor int  // позиция курсора для удаления (-1 если не в процессе удаления)
+	creating     bool         // флаг создания новой записи
+	editOriginal []string     // values the edit popup was opened with
+	pendingEdit  []string     // edited values awaiting confirmation
+	pendingID    string       // ID of the record of pendingEdit
+	editPopup    *popup.Model // edit popup to return to when confirmation is declined
+	editApproved bool         // pendingEdit was confirmed and may be saved
+	deleteCursor int          // позиция курсора для удаления (-1 если не в процессе удаления)
+
+	app     app.App
+	appMu   sync.Mutex // guards app, which is built by the first command that needs it
+	session *session   // zone IDs shared by the provider actions
 
 	ClientTimeout time.Duration
 	Config        *config.Config
@@ -126,11 +191,13 @@ type Model struct {
 	ViewStyle  lipgloss.Style
 }
 
-// NewModel creates new Model for UI.
-func NewModel() *Model {
+// NewModel creates new Model for UI. Various options can be used to configure the model.
+func NewModel(opts ...Option) *Model {
 	var m Model
 
 	m.rrsetCache = make(map[string][]models.DNSRecord)
+	m.recordCounts = make(map[string]int)
+	m.session = newSession()
 	m.ViewStyle = lipgloss.NewStyle().
 		Padding(0, 0).
 		Width(m.width)
@@ -141,6 +208,10 @@ func NewModel() *Model {
 	// Initialize current to ZonesTable to avoid nil pointer dereference
 	m.current = &m.ZonesTable
 
+	for _, opt := range opts {
+		opt(&m)
+	}
+
 	return &m
 }
 
@@ -150,11 +221,7 @@ func (m *Model) Init() tea.Cmd {
 	return tea.Batch(
 		m.spinner.Tick, // Start the spinner
 		func() tea.Msg {
-			var opts []app.Option
-			if m.Config != nil {
-				opts = append(opts, app.WithConfig(m.Config))
-			}
-			a, err := app.New(opts...)
+			a, err := m.getApp()
 			if err != nil {
 				// Return error message if app creation fails
 				// Ensure current is set even on error
@@ -163,7 +230,7 @@ func (m *Model) Init() tea.Cmd {
 				}
 				return errorMsg{err: err}
 			}
-			ctx, cancel := context.WithTimeout(context.Background(), m.ClientTimeout)
+			ctx, cancel := m.session.context(m.ClientTimeout)
 			defer cancel()
 
 			zones, err := a.Provider().ListZones(ctx)
@@ -174,20 +241,26 @@ func (m *Model) Init() tea.Cmd {
 				}
 				return errorMsg{err: err}
 			}
+			m.session.rememberZones(zones)
 			providerName := a.DefaultProviderName()
 			rows := []table.Row{}
 			cmds := []tea.Cmd{} // Commands list for async updating
 
 			for _, zone := range zones {
+				// Zones are shown and cached by their Unicode name,
+				// the provider converts it back to punycode for API calls
+				zoneName := models.NameToUnicode(zone.Name)
 				rows = append(rows, table.Row{
-					zone.Name,
+					zoneName,
 					strings.Join(zone.NameServers, ", "),
 					providerName,
 				})
-				cmds = append(cmds, m.updateRRSet(zone.Name))
+				cmds = append(cmds, m.updateRRSet(zoneName))
 			}
 			m.ZonesTable.SetRows(rows)
 			m.current = &m.ZonesTable
+			// The count of the selected zone usually arrives before its records
+			cmds = append(cmds, m.countSelectedZone())
 
 			// Return the command that runs all async updates
 			if len(cmds) > 0 {
@@ -246,51 +319,50 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Key pressed
 	case tea.KeyMsg:
-		switch msg.String() {
+		switch m.keyAction(msg.String()) {
 		// Toggle focus between zones and records
-		case "esc":
+		case "back":
 			if m.showPopup {
 				m.switchTable(rrsetTable)
 				return m, nil
 			}
 			m.switchTable(zonesTable)
 			// Move focus up in the current table
-		case "up", "k":
+		case "up":
 			if m.current != nil {
 				m.current.MoveUp(1)
 			}
+			return m, m.countSelectedZone()
 			// Move focus down in the current table
-		case "down", "j":
+		case "down":
 			if m.current != nil {
 				m.current.MoveDown(1)
 			}
+			return m, m.countSelectedZone()
 			// Open popup editor for the selected record
-		case "e":
+		case "edit":
 			// If RRSet is focused, open record editor; if Zones is focused, show NameServers popup
 			if m.RRSetTable.Focused() && m.current != nil && m.current.Cursor() >= 0 {
 				rows := m.current.Rows()
 				cursor := m.current.Cursor()
 				if cursor < len(rows) {
-					row := rows[cursor]
-					if len(row) >= 5 { // Name, TTL, Type, Proxied, Content
-						// Convert current Proxied value to boolean string
-						proxiedStr := "false"
-						if row[3] == checkMark {
-							proxiedStr = "true"
-						}
-						initial := []string{row[0], row[1], row[2], proxiedStr, row[4]}
+					if initial := rowFields(rows[cursor]); initial != nil {
+						// The ID travels with the popup, the name may be edited
+						id := m.rowID(cursor)
 						m.showPopup = true
 						m.creating = false
+						m.editOriginal = append([]string{}, initial...)
 						m.overlay = nil // recreate overlay on render
 						m.popup = popup.New(
 							[]string{"Name", "TTL", "Type", "Proxied", "Content"},
 							initial,
 							"Resource record editing",
 							func(fields []string) tea.Msg {
-								return popup.SaveActionMsg{Fields: fields}
+								return popup.SaveActionMsg{ID: id, Fields: fields}
 							},
 							popup.CancelMsg{},
 						)
+						m.popup.RecordID = id
 					}
 				}
 			} else if m.ZonesTable.Focused() && m.ZonesTable.Cursor() >= 0 {
@@ -316,26 +388,25 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 		// Create new record
-		case "c":
+		case "create":
 			// If RRSet is focused, open create record popup
 			if m.RRSetTable.Focused() {
-				// Initial empty values for new record
-				initial := []string{"", "3600", "A", "false", ""}
-				m.showPopup = true
-				m.creating = true
-				m.overlay = nil
-				m.popup = popup.New(
-					[]string{"Name", "TTL", "Type", "Proxied", "Content"},
-					initial,
-					"Resource record creation",
-					func(fields []string) tea.Msg {
-						return popup.SaveActionMsg{Fields: fields}
-					},
-					popup.CancelMsg{},
-				)
+				// Initial values for new record
+				m.openCreatePopup(m.recordDefaults())
+			}
+		// Clone the selected record into a new one
+		case "clone":
+			if m.RRSetTable.Focused() && m.current != nil && m.current.Cursor() >= 0 {
+				rows := m.current.Rows()
+				cursor := m.current.Cursor()
+				if cursor < len(rows) {
+					if initial := rowFields(rows[cursor]); initial != nil {
+						m.openCreatePopup(initial)
+					}
+				}
 			}
 		// Delete record
-		case "d":
+		case "delete":
 			// If RRSet is focused, show confirmation dialog
 			if m.RRSetTable.Focused() && m.current != nil && m.current.Cursor() >= 0 {
 				rows := m.current.Rows()
@@ -351,11 +422,17 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				}
 			}
+		// Toggle the record ID column
+		case "ids":
+			m.toggleIDs()
+		// Toggle watch mode
+		case "watch":
+			return m, m.toggleWatch()
 		// Reload RRSet
-		case "r":
+		case "reload":
 			return m, func() tea.Msg { return dataLoadingMsg{} }
 		// Quits the program by returning the tea.Quit command.
-		case "q", "ctrl+c":
+		case "quit":
 			return m, tea.Quit
 		}
 		switch msg.Type {
@@ -379,7 +456,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Handle error - show notification and stop loading
 		m.loading = false
 		return m, func() tea.Msg {
-			return showNotificationMsg{message: fmt.Sprintf("Error: %v", msg.err)}
+			return errorStatus(msg.err)
 		}
 
 	case dataLoadingMsg:
@@ -394,8 +471,18 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loading = false
 		return m, nil // stop spinner
 
-	case showNotificationMsg:
-		return m, m.showNotification(msg.message)
+	case watchTickMsg:
+		if !m.watching || msg.seq != m.watchSeq {
+			return m, nil
+		}
+		zone := m.ZonesTable.SelectedRow()
+		if len(zone) == 0 {
+			return m, m.watchTick()
+		}
+		return m, tea.Batch(m.updateRRSet(zone[0]), m.watchTick())
+
+	case statusMsg:
+		return m, m.showNotification(msg.text, msg.severity)
 
 	case clearNotificationMsg:
 		m.notification = ""
@@ -417,15 +504,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.rrsetCache[zoneName] = append(m.rrsetCache[zoneName], msg.record)
 			// Add to table
 			rows := m.RRSetTable.Rows()
-			newRow := table.Row{
-				msg.record.Name,
-				strconv.Itoa(msg.record.TTL),
-				msg.record.Type,
-				boolToCheckMark(msg.record.Proxied),
-				msg.record.Content,
-			}
-			rows = append(rows, newRow)
+			rows = append(rows, m.rrsetRow(msg.record))
 			m.RRSetTable.SetRows(rows)
+			m.rrsetIDs = append(m.rrsetIDs, msg.record.ID)
 		}
 		// Close popup
 		m.popup.IsActive = false
@@ -433,43 +514,53 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.creating = false
 		m.overlay = nil
 		// Show notification
-		return m, func() tea.Msg { return showNotificationMsg{message: fmt.Sprintf("Record %s created", msg.record.Name)} }
+		return m, func() tea.Msg {
+			return statusMsg{text: fmt.Sprintf("Record %s created", msg.record.Name), severity: statusSuccess}
+		}
 
 	case recordDeletedMsg:
 		// Remove record from cache and table
 		zoneRow := m.ZonesTable.SelectedRow()
 		if len(zoneRow) > 0 {
 			zoneName := zoneRow[0]
-			// Remove from cache
+			// Remove from cache, by ID as several records may share a name
 			if rrset, ok := m.rrsetCache[zoneName]; ok {
-				for i, r := range rrset {
-					if r.Name == msg.recordName {
-						m.rrsetCache[zoneName] = append(rrset[:i], rrset[i+1:]...)
-						break
-					}
-				}
+				m.rrsetCache[zoneName] = slices.DeleteFunc(rrset, func(r models.DNSRecord) bool {
+					return r.ID == msg.id
+				})
 			}
 			// Remove from table
-			rows := m.RRSetTable.Rows()
-			for i, row := range rows {
-				if len(row) > 0 && row[0] == msg.recordName {
-					rows = append(rows[:i], rows[i+1:]...)
-					m.RRSetTable.SetRows(rows)
-					// Adjust cursor if needed
-					if m.RRSetTable.Cursor() >= len(rows) && len(rows) > 0 {
-						m.RRSetTable.SetCursor(len(rows) - 1)
-					}
-					break
+			if i := slices.Index(m.rrsetIDs, msg.id); i >= 0 && i < len(m.RRSetTable.Rows()) {
+				rows := slices.Delete(m.RRSetTable.Rows(), i, i+1)
+				m.RRSetTable.SetRows(rows)
+				m.rrsetIDs = slices.Delete(m.rrsetIDs, i, i+1)
+				// Adjust cursor if needed
+				if m.RRSetTable.Cursor() >= len(rows) && len(rows) > 0 {
+					m.RRSetTable.SetCursor(len(rows) - 1)
 				}
 			}
 		}
 		m.deleteCursor = -1
 		// Show notification
-		return m, func() tea.Msg { return showNotificationMsg{message: fmt.Sprintf("Record %s deleted", msg.recordName)} }
+		return m, func() tea.Msg {
+			return statusMsg{text: fmt.Sprintf("Record %s deleted", msg.recordName), severity: statusSuccess}
+		}
+
+	case recordsCountedMsg:
+		m.recordCounts[msg.zone] = msg.count
+		return m, nil
 
 	case recordUpdatedMsg:
+		// The update succeeded, write the record back to the cache and table
+		m.updateRecordRow(msg.zone, msg.record)
+		// Close popup
+		m.popup.IsActive = false
+		m.showPopup = false
+		m.overlay = nil
 		// Show notification for updated record
-		return m, func() tea.Msg { return showNotificationMsg{message: fmt.Sprintf("Record %s updated", msg.recordName)} }
+		return m, func() tea.Msg {
+			return statusMsg{text: fmt.Sprintf("Record %s updated", msg.record.Name), severity: statusSuccess}
+		}
 
 	case switchTableToRRSetCmd:
 		m.switchTable(rrsetTable)
@@ -498,34 +589,72 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Create new record
 			return m, m.createRRFromFields(msg.Fields)
 		}
-		// Update existing record
+		// Ask for confirmation before saving destructive changes
+		if m.editApproved {
+			m.editApproved = false
+		} else if m.needsEditConfirmation(msg.Fields) {
+			m.pendingEdit = append([]string{}, msg.Fields...)
+			m.pendingID = msg.ID
+			m.editPopup = m.popup
+			m.showPopup = true
+			m.overlay = nil
+			m.popup = popup.NewConfirmDialog(fmt.Sprintf("Save changes to %s?", msg.Fields[0]))
+			m.popup.Details = m.editChanges(msg.Fields)
+			return m, nil
+		}
+		// Update existing record, the table follows once the provider saved it
 		if m.current != nil {
-			m.updateTableRow(m.current.Cursor(), msg.Fields)
-			return m, m.updateRRFromFields(msg.Fields)
+			return m, m.updateRRFromFields(msg.ID, msg.Fields)
 		}
 		return m, nil
 	case popup.SaveNameServersMsg:
-		// Update zones table NameServers column with joined values
-		if m.ZonesTable.Focused() {
-			cursor := m.ZonesTable.Cursor()
-			rows := m.ZonesTable.Rows()
-			if cursor >= 0 && cursor < len(rows) {
-				joined := strings.Join(msg.Servers, ", ")
-				rows[cursor][1] = joined
-				m.ZonesTable.SetRows(rows)
-			}
-		}
+		// Save the name servers of the selected zone through the provider
 		m.popup.IsActive = false
 		m.showPopup = false
 		m.overlay = nil
+		if zoneRow := m.ZonesTable.SelectedRow(); m.ZonesTable.Focused() && len(zoneRow) > 0 {
+			return m, m.updateNameServers(zoneRow[0], msg.Servers)
+		}
 		return m, nil
+	case nameServersUpdatedMsg:
+		// Update zones table NameServers column with joined values
+		rows := m.ZonesTable.Rows()
+		for i, row := range rows {
+			if len(row) > 1 && row[0] == msg.zone {
+				rows[i][1] = strings.Join(msg.servers, ", ")
+			}
+		}
+		m.ZonesTable.SetRows(rows)
+		return m, func() tea.Msg {
+			return statusMsg{text: fmt.Sprintf("Name servers of %s updated", msg.zone), severity: statusSuccess}
+		}
 	case popup.ConfirmDeleteMsg:
+		// User confirmed saving the edited record, proceed with save
+		if m.pendingEdit != nil {
+			save := popup.SaveActionMsg{ID: m.pendingID, Fields: m.pendingEdit}
+			m.pendingEdit = nil
+			m.pendingID = ""
+			m.editPopup = nil
+			m.editApproved = true
+			return m, func() tea.Msg { return save }
+		}
 		// User confirmed deletion, perform delete
 		if m.deleteCursor >= 0 {
 			return m, m.deleteRR(m.deleteCursor)
 		}
 		m.deleteCursor = -1
 	case popup.CancelMsg:
+		// User declined saving the edited record, return to editing
+		if m.pendingEdit != nil {
+			m.popup = m.editPopup
+			m.popup.IsActive = true
+			m.pendingEdit = nil
+			m.pendingID = ""
+			m.editPopup = nil
+			m.showPopup = true
+			m.overlay = nil
+			return m, nil
+		}
 		// Cancel without persisting changes
 		m.popup.IsActive = false
 		m.creating = false
@@ -561,13 +690,19 @@ func (m *Model) viewMenu() string {
 		"[Esc] Exit",
 	}
 
+	item := func(action, title string) string {
+		return fmt.Sprintf("[%s] %s", m.keyFor(action), title)
+	}
+
 	// Add Create and Delete only for RRSet table
 	if m.RRSetTable.Focused() {
-		menu = append(menu, "[c] Create")
-		menu = append(menu, "[d] Delete")
+		menu = append(menu, item("create", "Create"))
+		menu = append(menu, item("clone", "Clone"))
+		menu = append(menu, item("delete", "Delete"))
+		menu = append(menu, item("ids", "IDs"))
 	}
 
-	menu = append(menu, "[e] Edit", "[r] Reload", "[q] Quit")
+	menu = append(menu, item("edit", "Edit"), item("reload", "Reload"), item("watch", "Watch"), item("quit", "Quit"))
 
 	return menuStyle.Render(strings.Join(menu, " | "))
 }
@@ -581,6 +716,12 @@ func (m *Model) viewStatusBar() string {
 
 	// Show notification if present
 	if m.notification != "" {
+		switch m.notificationLevel {
+		case statusSuccess:
+			statusStyle = statusStyle.Foreground(theme.Color.Green)
+		case statusError:
+			statusStyle = statusStyle.Foreground(theme.Color.Red)
+		}
 		return statusStyle.Render(m.notification)
 	}
 
@@ -600,7 +741,69 @@ func (m *Model) viewStatusBar() string {
 		}
 	}
 
-	return statusStyle.Render(fmt.Sprintf("Loaded %d %s", rows, table))
+	status := fmt.Sprintf("Loaded %d %s", rows, table)
+	if m.ZonesTable.Focused() {
+		if zone := m.ZonesTable.SelectedRow(); len(zone) > 0 {
+			if n, ok := m.zoneRecordCount(zone[0]); ok {
+				status += fmt.Sprintf(" | %s: %d %s", zone[0], n, pluralRecords(n))
+			}
+		}
+	}
+	if m.watching {
+		status += fmt.Sprintf(" | watching every %s", m.watchInterval())
+	}
+
+	return statusStyle.Render(status)
+}
+
+// zoneRecordCount returns the number of records of a zone, from the cached
+// records or, while they are not loaded, from the count of the provider.
+func (m *Model) zoneRecordCount(zone string) (int, bool) {
+	if rrset, ok := m.rrsetCache[zone]; ok {
+		return len(rrset), true
+	}
+	n, ok := m.recordCounts[zone]
+	return n, ok
+}
+
+// pluralRecords returns the status bar word for n records.
+func pluralRecords(n int) string {
+	if n == 1 {
+		return tableStatusRecord
+	}
+	return tableStatusRecords
+}
+
+// countSelectedZone asks the provider for the record count of the selected zone,
+// unless its records are cached or counted already. Listing a large zone takes
+// a while, the count shows its size in the status bar meanwhile.
+func (m *Model) countSelectedZone() tea.Cmd {
+	if !m.ZonesTable.Focused() {
+		return nil
+	}
+	zone := m.ZonesTable.SelectedRow()
+	if len(zone) == 0 {
+		return nil
+	}
+	if _, ok := m.zoneRecordCount(zone[0]); ok {
+		return nil
+	}
+
+	return func() tea.Msg {
+		a, err := m.getApp()
+		if err != nil {
+			return nil
+		}
+		ctx, cancel := m.session.context(m.ClientTimeout)
+		defer cancel()
+
+		n, err := a.Provider().CountRecords(ctx, zone[0])
+		if err != nil {
+			// The count is a hint only, listing the records reports the error
+			return nil
+		}
+		return recordsCountedMsg{zone: zone[0], count: n}
+	}
 }
 
 func (m *Model) viewZones() string {
@@ -617,19 +820,142 @@ func (m *Model) viewRRSet() string {
 		}
 	}
 
-	rows := []table.Row{}
+	m.setRRSetRows(rrset)
+
+	return m.RRSetTable.View()
+}
+
+// setRRSetRows fills the rrset table with the records and remembers their IDs.
+func (m *Model) setRRSetRows(rrset []models.DNSRecord) {
+	rows := make([]table.Row, 0, len(rrset))
+	ids := make([]string, 0, len(rrset))
 	for _, rr := range rrset {
-		rows = append(rows, table.Row{
-			rr.Name,
-			strconv.Itoa(rr.TTL),
-			rr.Type,
-			boolToCheckMark(rr.Proxied),
-			rr.Content,
-		})
+		rows = append(rows, m.rrsetRow(rr))
+		ids = append(ids, rr.ID)
 	}
 	m.RRSetTable.SetRows(rows)
+	m.rrsetIDs = ids
+}
 
-	return m.RRSetTable.View()
+// rowID returns the ID of the record in the given row of the rrset table.
+func (m *Model) rowID(index int) string {
+	if index < 0 || index >= len(m.rrsetIDs) {
+		return ""
+	}
+	return m.rrsetIDs[index]
+}
+
+// rrsetRow returns the rrset table row for a record. The ID is added only when
+// the ID column is shown, a row must not have more values than the table has columns.
+func (m *Model) rrsetRow(rr models.DNSRecord) table.Row {
+	row := table.Row{
+		models.NameToUnicode(rr.Name),
+		strconv.Itoa(rr.TTL),
+		rr.Type,
+		boolToCheckMark(rr.Proxied),
+		rr.Content,
+	}
+	if m.showIDs && len(m.RRSetTable.Columns()) > rrsetIDColumn {
+		row = append(row, shortID(rr.ID))
+	}
+	return row
+}
+
+// fixedKeys are the keys whose action cannot be rebound.
+var fixedKeys = map[string]string{
+	"esc":    "back",
+	"up":     "up",
+	"down":   "down",
+	"ctrl+c": "quit",
+}
+
+// keyAction returns the action bound to a key by ui.keybindings or the defaults,
+// or an empty string if the key is not bound.
+func (m *Model) keyAction(key string) string {
+	if action, ok := fixedKeys[key]; ok {
+		return action
+	}
+
+	var bindings map[string]string
+	if m.Config != nil {
+		bindings = m.Config.UI.Keybindings
+	}
+	// A key set in ui.keybindings wins over a default it clashes with
+	for _, action := range slices.Sorted(maps.Keys(bindings)) {
+		if bindings[action] == key {
+			return strings.ToLower(action)
+		}
+	}
+	for action, k := range config.DefaultKeybindings {
+		if _, ok := bindings[action]; !ok && k == key {
+			return action
+		}
+	}
+	return ""
+}
+
+// keyFor returns the key bound to an action, for the menu.
+func (m *Model) keyFor(action string) string {
+	if m.Config != nil {
+		return m.Config.UI.KeyMap()[action]
+	}
+	return config.DefaultKeybindings[action]
+}
+
+// rowFields returns the popup fields Name, TTL, Type, Proxied and Content of an
+// rrset table row, or nil if the row is too short.
+func rowFields(row table.Row) []string {
+	if len(row) < 5 {
+		return nil
+	}
+	// Convert current Proxied value to boolean string
+	proxied := "false"
+	if row[3] == checkMark {
+		proxied = "true"
+	}
+	return []string{row[0], row[1], row[2], proxied, row[4]}
+}
+
+// openCreatePopup opens the record creation popup with the given initial fields.
+// The popup carries no record ID, so saving it creates a new record.
+func (m *Model) openCreatePopup(initial []string) {
+	m.showPopup = true
+	m.creating = true
+	m.overlay = nil
+	m.popup = popup.New(
+		[]string{"Name", "TTL", "Type", "Proxied", "Content"},
+		initial,
+		"Resource record creation",
+		func(fields []string) tea.Msg {
+			return popup.SaveActionMsg{Fields: fields}
+		},
+		popup.CancelMsg{},
+	)
+}
+
+// toggleIDs shows or hides the record ID column of the rrset table.
+func (m *Model) toggleIDs() {
+	m.showIDs = !m.showIDs
+	if !m.showIDs {
+		// Drop the ID values before the column, the table renders every row value
+		rows := m.RRSetTable.Rows()
+		for i, row := range rows {
+			if len(row) > rrsetIDColumn {
+				rows[i] = row[:rrsetIDColumn]
+			}
+		}
+		m.RRSetTable.SetRows(rows)
+	}
+	m.resizeRRSetColumns()
+}
+
+// shortID truncates a record ID to its first characters followed by an ellipsis.
+func shortID(id string) string {
+	r := []rune(id)
+	if len(r) <= shortIDLength {
+		return id
+	}
+	return string(r[:shortIDLength]) + "…"
 }
 
 func (m *Model) handleEnter(tea.Msg) tea.Cmd {
@@ -641,18 +967,15 @@ func (m *Model) handleEnter(tea.Msg) tea.Cmd {
 // updateRRSet updates resource records set for the given zone name.
 func (m *Model) updateRRSet(zone string) tea.Cmd {
 	return func() tea.Msg {
-		var opts []app.Option
-		if m.Config != nil {
-			opts = append(opts, app.WithConfig(m.Config))
-		}
-		a, err := app.New(opts...)
+		a, err := m.getApp()
 		if err != nil {
 			return errorMsg{err: err}
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), m.ClientTimeout)
+		ctx, cancel := m.session.context(m.ClientTimeout)
 		defer cancel()
 
 		rrset, err := a.Provider().ListRecords(ctx, models.ListDNSRecordsParams{
+			ZoneID:   m.session.zoneID(zone),
 			ZoneName: zone,
 		})
 		if err != nil {
@@ -665,6 +988,34 @@ func (m *Model) updateRRSet(zone string) tea.Cmd {
 	}
 }
 
+// toggleWatch starts or stops reloading the records of the selected zone every watch interval.
+func (m *Model) toggleWatch() tea.Cmd {
+	m.watching = !m.watching
+	// Invalidate the tick of the previous watch, if any
+	m.watchSeq++
+
+	if !m.watching {
+		return func() tea.Msg { return statusMsg{text: "Stopped watching", severity: statusInfo} }
+	}
+	return m.watchTick()
+}
+
+// watchTick schedules the next reload of the watch mode.
+func (m *Model) watchTick() tea.Cmd {
+	seq := m.watchSeq
+	return tea.Tick(m.watchInterval(), func(time.Time) tea.Msg {
+		return watchTickMsg{seq: seq}
+	})
+}
+
+// watchInterval returns ui.watch_interval from the config or the default interval.
+func (m *Model) watchInterval() time.Duration {
+	if m.Config != nil && m.Config.UI.WatchInterval > 0 {
+		return m.Config.UI.WatchInterval
+	}
+	return defaultWatchInterval
+}
+
 // switchTable switches focus between zones and records tables
 func (m *Model) switchTable(name string) {
 	switch name {
@@ -679,6 +1030,11 @@ func (m *Model) switchTable(name string) {
 	}
 }
 
+// sameName reports whether two record names are equal, whether given in Unicode or punycode form.
+func sameName(a, b string) bool {
+	return models.NameToUnicode(a) == models.NameToUnicode(b)
+}
+
 func boolToCheckMark(b bool) string {
 	if b {
 		return checkMark
@@ -686,34 +1042,26 @@ func boolToCheckMark(b bool) string {
 	return crossMark
 }
 
-func (m *Model) updateTableRow(index int, newRow table.Row) {
-	if m.current == nil {
-		return
+// updateRecordRow replaces the record with the ID of rr in the cache of zone
+// and, while zone is selected, its row of the rrset table. Records are matched
+// by ID, the row of the record may have moved since the edit started.
+func (m *Model) updateRecordRow(zone string, rr models.DNSRecord) {
+	rrset := m.rrsetCache[zone]
+	if i := slices.IndexFunc(rrset, func(r models.DNSRecord) bool { return r.ID == rr.ID }); i >= 0 {
+		// Keep the cached form of an unchanged name given in Unicode
+		if sameName(rrset[i].Name, rr.Name) {
+			rr.Name = rrset[i].Name
+		}
+		rrset[i] = rr
 	}
-	var rrset []models.DNSRecord
 
-	rows := m.current.Rows()
-	if index >= 0 && index < len(rows) {
-		rows[index] = newRow
-		m.current.SetRows(rows) // Переназначаем строки таблице
-		// cache update
-		selectedRow := m.ZonesTable.SelectedRow()
-		if len(selectedRow) > 0 {
-			if _, ok := m.rrsetCache[selectedRow[0]]; ok {
-				rrset = m.rrsetCache[selectedRow[0]]
-			}
-			for i := range rrset {
-				if rrset[i].Name == newRow[0] {
-					rrset[i].TTL, _ = strconv.Atoi(newRow[1])
-					rrset[i].Type = newRow[2]
-					// newRow[3] is "true"/"false"; convert to bool
-					rrset[i].Proxied = strings.ToLower(newRow[3]) == "true"
-					rrset[i].Content = newRow[4]
-					break
-				}
-			}
-			m.rrsetCache[selectedRow[0]] = rrset
-		}
+	if zoneRow := m.ZonesTable.SelectedRow(); len(zoneRow) == 0 || zoneRow[0] != zone {
+		return
+	}
+	rows := m.RRSetTable.Rows()
+	if i := slices.Index(m.rrsetIDs, rr.ID); i >= 0 && i < len(rows) {
+		rows[i] = m.rrsetRow(rr)
+		m.RRSetTable.SetRows(rows)
 	}
 }
 
@@ -813,8 +1161,29 @@ func (m *Model) resizeRRSetColumns() {
 	if m.width <= 0 {
 		return
 	}
-	// 5 columns => reserve 2 chars of padding per column
-	available := m.width - 2*5
+
+	// Get Name width from ZonesTable to match it
+	zonesCols := m.ZonesTable.Columns()
+	nameW := 12 // default minimum
+	if len(zonesCols) > 0 {
+		nameW = zonesCols[0].Width
+	}
+
+	m.RRSetTable.SetColumns(rrsetColumns(m.width, nameW, m.showIDs))
+}
+
+// rrsetColumns returns the rrset table columns for the given terminal width and
+// Name column width. The optional ID column is added last, taking its width from Content.
+func rrsetColumns(width, nameW int, showID bool) []table.Column {
+	columns := 5
+	idW := 0
+	if showID {
+		columns++
+		idW = shortIDLength + 2 // the ellipsis and a spare cell
+	}
+
+	// reserve 2 chars of padding per column
+	available := width - 2*columns
 	if available < 40 {
 		available = 40
 	}
@@ -824,21 +1193,14 @@ func (m *Model) resizeRRSetColumns() {
 	typeW := 8
 	proxiedW := 10
 
-	// Get Name width from ZonesTable to match it
-	zonesCols := m.ZonesTable.Columns()
-	nameW := 12 // default minimum
-	if len(zonesCols) > 0 {
-		nameW = zonesCols[0].Width
-	}
-
 	// Allocate remaining space for Content
-	remaining := available - (nameW + ttlW + typeW + proxiedW)
+	remaining := available - (nameW + ttlW + typeW + proxiedW + idW)
 	if remaining < 20 {
 		// Adjust fixed widths if terminal is too narrow
 		ttlW = 6
 		typeW = 6
 		proxiedW = 8
-		remaining = available - (nameW + ttlW + typeW + proxiedW)
+		remaining = available - (nameW + ttlW + typeW + proxiedW + idW)
 	}
 
 	minContent := 10
@@ -851,7 +1213,7 @@ func (m *Model) resizeRRSetColumns() {
 				reduce = nameW - 8
 			}
 			nameW -= reduce
-			contentW = available - (nameW + ttlW + typeW + proxiedW)
+			contentW = available - (nameW + ttlW + typeW + proxiedW + idW)
 		} else {
 			contentW = minContent
 		}
@@ -864,7 +1226,10 @@ func (m *Model) resizeRRSetColumns() {
 		{Title: "Proxied", Width: proxiedW},
 		{Title: "Content", Width: contentW},
 	}
-	m.RRSetTable.SetColumns(cols)
+	if showID {
+		cols = append(cols, table.Column{Title: "ID", Width: idW})
+	}
+	return cols
 }
 
 // backgroundViewModel adapts base view to tea.Model for overlay background
@@ -880,68 +1245,147 @@ func (b *backgroundViewModel) View() string {
 	return b.parent.renderBase(table)
 }
 
-// updateRRFromFields builds DNSRecord and performs UpdateRR via provider
-func (m *Model) updateRRFromFields(fields []string) tea.Cmd {
+// updateNameServers sets the name servers of the zone via provider
+func (m *Model) updateNameServers(zone string, servers []string) tea.Cmd {
 	return func() tea.Msg {
-		var opts []app.Option
-		if m.Config != nil {
-			opts = append(opts, app.WithConfig(m.Config))
+		a, err := m.getApp()
+		if err != nil {
+			return errorStatus(err)
 		}
-		a, err := app.New(opts...)
+		ctx, cancel := m.session.context(m.ClientTimeout)
+		defer cancel()
+
+		if err := a.Provider().UpdateNameServers(ctx, zone, servers); err != nil {
+			return errorStatus(err)
+		}
+		return nameServersUpdatedMsg{zone: zone, servers: servers}
+	}
+}
+
+// updateRRFromFields applies the edited fields to the cached record with the given ID
+// and performs UpdateRR via provider
+func (m *Model) updateRRFromFields(id string, fields []string) tea.Cmd {
+	return func() tea.Msg {
+		a, err := m.getApp()
 		if err != nil {
-			return errorMsg{err: err}
+			return errorStatus(err)
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), m.ClientTimeout)
+		ctx, cancel := m.session.context(m.ClientTimeout)
 		defer cancel()
 
-		// Find selected zone and record by name
+		// Find selected zone and record by ID, the name may have been edited
 		zoneRow := m.ZonesTable.SelectedRow()
 		if len(zoneRow) == 0 {
-			return nil
+			return errorStatus(errNoZoneSelected)
 		}
 		zoneName := zoneRow[0]
 		var target models.DNSRecord
-		if rrset, ok := m.rrsetCache[zoneName]; ok {
+		if rrset, ok := m.rrsetCache[zoneName]; ok && id != "" {
 			for _, r := range rrset {
-				if r.Name == fields[0] {
+				if r.ID == id {
 					target = r
 					break
 				}
 			}
 		}
-
-		ttl, _ := strconv.Atoi(fields[1])
-		proxied := strings.ToLower(fields[3]) == "true"
+		if target.ID == "" {
+			return errorStatus(fmt.Errorf("record %s not found", fields[0]))
+		}
 
 		// Build updated record
-		target.Name = fields[0]
-		target.TTL = ttl
-		target.Type = fields[2]
-		target.Proxied = proxied
-		target.Content = fields[4]
+		target = recordFromFields(target, fields)
+		target.Name = expandApex(target.Name, zoneName)
+		if target.ZoneID == "" {
+			target.ZoneID = m.session.zoneID(zoneName)
+		}
 
 		// Perform update
 		if _, err := a.Provider().UpdateRR(ctx, zoneName, target); err != nil {
-			return errorMsg{err: err}
+			return errorStatus(err)
 		}
 
-		// Close popup
-		m.popup.IsActive = false
-		m.showPopup = false
-		m.overlay = nil
-		// Show notification
-		return recordUpdatedMsg{recordName: target.Name}
+		return recordUpdatedMsg{zone: zoneName, record: target}
 	}
 }
 
-// showNotification displays a notification in the status bar for 3 seconds
-func (m *Model) showNotification(message string) tea.Cmd {
+// expandApex returns the zone name for the @ apex shorthand and name otherwise.
+func expandApex(name, zone string) string {
+	if name == models.ApexName {
+		return zone
+	}
+	return name
+}
+
+// recordFromFields applies the popup fields Name, TTL, Type, Proxied and Content
+// to rr. The ID and the fields the popup does not show are kept.
+func recordFromFields(rr models.DNSRecord, fields []string) models.DNSRecord {
+	rr.Name = fields[0]
+	rr.TTL, _ = strconv.Atoi(fields[1])
+	rr.Type = fields[2]
+	rr.Proxied = strings.ToLower(fields[3]) == "true"
+	rr.Content = fields[4]
+	return rr
+}
+
+// needsEditConfirmation reports whether saving the edited fields must be confirmed:
+// ui.confirm_edits is enabled and the record type or content was changed.
+func (m *Model) needsEditConfirmation(fields []string) bool {
+	if m.Config == nil || !m.Config.UI.ConfirmEdits {
+		return false
+	}
+	if len(fields) < 5 || len(m.editOriginal) < 5 {
+		return false
+	}
+	return fields[2] != m.editOriginal[2] || fields[4] != m.editOriginal[4]
+}
+
+// editChanges returns the changes the edited fields make to the record the edit
+// popup was opened with, a "field: old -> new" line each.
+func (m *Model) editChanges(fields []string) []string {
+	if len(fields) < 5 || len(m.editOriginal) < 5 {
+		return nil
+	}
+	oldRR := recordFromFields(models.DNSRecord{}, m.editOriginal)
+	newRR := recordFromFields(models.DNSRecord{}, fields)
+
+	var lines []string
+	for _, c := range models.DiffRecord(oldRR, newRR) {
+		lines = append(lines, c.String())
+	}
+	return lines
+}
+
+// getApp returns the application set with WithApp, or creates one from the
+// model config on first use and keeps it for the later commands.
+func (m *Model) getApp() (app.App, error) {
+	m.appMu.Lock()
+	defer m.appMu.Unlock()
+
+	if m.app != nil {
+		return m.app, nil
+	}
+
+	var opts []app.Option
+	if m.Config != nil {
+		opts = append(opts, app.WithConfig(m.Config))
+	}
+	a, err := app.New(opts...)
+	if err != nil {
+		return nil, err
+	}
+	m.app = a
+	return a, nil
+}
+
+// showNotification displays a notification of the given severity in the status bar for 3 seconds
+func (m *Model) showNotification(message string, severity statusSeverity) tea.Cmd {
 	// Stop existing timer if any
 	if m.notificationTimer != nil {
 		m.notificationTimer.Stop()
 	}
 
 	m.notification = message
+	m.notificationLevel = severity
 
 	// Create timer to clear notification after 3 seconds
 	m.notificationTimer = time.NewTimer(3 * time.Second)
@@ -955,21 +1399,17 @@ func (m *Model) showNotification(message string) tea.Cmd {
 // createRRFromFields builds CreateDNSRecordParams and performs AddRR via provider
 func (m *Model) createRRFromFields(fields []string) tea.Cmd {
 	return func() tea.Msg {
-		var opts []app.Option
-		if m.Config != nil {
-			opts = append(opts, app.WithConfig(m.Config))
-		}
-		a, err := app.New(opts...)
+		a, err := m.getApp()
 		if err != nil {
-			return errorMsg{err: err}
+			return errorStatus(err)
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), m.ClientTimeout)
+		ctx, cancel := m.session.context(m.ClientTimeout)
 		defer cancel()
 
 		// Get selected zone
 		zoneRow := m.ZonesTable.SelectedRow()
 		if len(zoneRow) == 0 {
-			return nil
+			return errorStatus(errNoZoneSelected)
 		}
 		zoneName := zoneRow[0]
 
@@ -978,18 +1418,19 @@ func (m *Model) createRRFromFields(fields []string) tea.Cmd {
 
 		// Build create params
 		params := models.CreateDNSRecordParams{
-			Name:     fields[0],
+			Name:     expandApex(fields[0], zoneName),
 			TTL:      ttl,
 			Type:     fields[2],
 			Proxied:  proxied,
 			Content:  fields[4],
+			ZoneID:   m.session.zoneID(zoneName),
 			ZoneName: zoneName,
 		}
 
 		// Perform create
 		newRecord, err := a.Provider().AddRR(ctx, zoneName, params)
 		if err != nil {
-			return errorMsg{err: err}
+			return errorStatus(err)
 		}
 
 		// Return message to update UI
@@ -1000,57 +1441,45 @@ func (m *Model) createRRFromFields(fields []string) tea.Cmd {
 // deleteRR deletes a DNS record via provider
 func (m *Model) deleteRR(cursor int) tea.Cmd {
 	return func() tea.Msg {
-		var opts []app.Option
-		if m.Config != nil {
-			opts = append(opts, app.WithConfig(m.Config))
-		}
-		a, err := app.New(opts...)
+		a, err := m.getApp()
 		if err != nil {
-			return errorMsg{err: err}
+			return errorStatus(err)
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), m.ClientTimeout)
+		ctx, cancel := m.session.context(m.ClientTimeout)
 		defer cancel()
 
 		// Get selected zone
 		zoneRow := m.ZonesTable.SelectedRow()
 		if len(zoneRow) == 0 {
-			return nil
+			return errorStatus(errNoZoneSelected)
 		}
 		zoneName := zoneRow[0]
 
-		// Get record from table
-		rows := m.RRSetTable.Rows()
-		if cursor < 0 || cursor >= len(rows) {
-			return nil
+		// Find the record of the row by ID, several records may share a name
+		id := m.rowID(cursor)
+		if id == "" {
+			return errorStatus(errNoRecordSelected)
 		}
-		row := rows[cursor]
-		if len(row) == 0 {
-			return nil
-		}
-		recordName := row[0]
-
-		// Find record in cache to get ID
 		var target models.DNSRecord
-		if rrset, ok := m.rrsetCache[zoneName]; ok {
-			for _, r := range rrset {
-				if r.Name == recordName {
-					target = r
-					break
-				}
+		for _, r := range m.rrsetCache[zoneName] {
+			if r.ID == id {
+				target = r
+				break
 			}
 		}
-
-		// If record not found in cache, can't delete
 		if target.ID == "" {
-			return nil
+			return errorStatus(fmt.Errorf("record with ID %s not found", id))
+		}
+		if target.ZoneID == "" {
+			target.ZoneID = m.session.zoneID(zoneName)
 		}
 
 		// Perform delete
 		if err := a.Provider().DeleteRR(ctx, zoneName, target); err != nil {
-			return errorMsg{err: err}
+			return errorStatus(err)
 		}
 
 		// Return message to update UI
-		return recordDeletedMsg{recordName: recordName}
+		return recordDeletedMsg{id: target.ID, recordName: models.NameToUnicode(target.Name)}
 	}
 }
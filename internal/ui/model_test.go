@@ -0,0 +1,649 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mixanemca/cdnscli/internal/app"
+	"github.com/mixanemca/cdnscli/internal/config"
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/mixanemca/cdnscli/internal/providers"
+	"github.com/mixanemca/cdnscli/internal/ui/popup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeApp is an app.App returning a fixed provider.
+type fakeApp struct {
+	app.App
+	provider providers.Provider
+}
+
+func (a *fakeApp) Provider() providers.Provider {
+	return a.provider
+}
+
+// fakeProvider is a providers.Provider with a configurable UpdateRR result.
+// ListRecords records its params and returns rrset, AddRR records its params, CountRecords returns count,
+// UpdateNameServers keeps the name servers by zone or returns nsErr.
+type fakeProvider struct {
+	providers.Provider
+	updated   []models.DNSRecord
+	deleted   []models.DNSRecord
+	created   []models.CreateDNSRecordParams
+	updateErr error
+	rrset     []models.DNSRecord
+	listed    []models.ListDNSRecordsParams
+	count     int
+	counted   []string
+	ns        map[string][]string
+	nsErr     error
+}
+
+func (p *fakeProvider) DeleteRR(ctx context.Context, zone string, rr models.DNSRecord) error {
+	p.deleted = append(p.deleted, rr)
+	return nil
+}
+
+func (p *fakeProvider) UpdateNameServers(ctx context.Context, zone string, ns []string) error {
+	if p.nsErr != nil {
+		return p.nsErr
+	}
+	if len(ns) < 2 {
+		return errors.New("a zone needs 2 to 4 name servers")
+	}
+	if p.ns == nil {
+		p.ns = make(map[string][]string)
+	}
+	p.ns[zone] = ns
+	return nil
+}
+
+func (p *fakeProvider) CountRecords(ctx context.Context, zone string) (int, error) {
+	p.counted = append(p.counted, zone)
+	return p.count, nil
+}
+
+func (p *fakeProvider) ListRecords(ctx context.Context, params models.ListDNSRecordsParams) ([]models.DNSRecord, error) {
+	p.listed = append(p.listed, params)
+	return p.rrset, nil
+}
+
+func (p *fakeProvider) AddRR(ctx context.Context, zone string, params models.CreateDNSRecordParams) (models.DNSRecord, error) {
+	p.created = append(p.created, params)
+	return models.DNSRecord{ID: "new", Name: params.Name, TTL: params.TTL, Type: params.Type, Proxied: params.Proxied, Content: params.Content}, nil
+}
+
+func (p *fakeProvider) UpdateRR(ctx context.Context, zone string, rr models.DNSRecord) (models.DNSRecord, error) {
+	p.updated = append(p.updated, rr)
+	return rr, p.updateErr
+}
+
+// newTestModel returns a model with the zone example.com selected and one cached record.
+func newTestModel(p providers.Provider) *Model {
+	m := NewModel(WithApp(&fakeApp{provider: p}))
+	m.ZonesTable = table.New(
+		table.WithColumns([]table.Column{{Title: "Name", Width: 20}}),
+		table.WithRows([]table.Row{{"example.com"}}),
+	)
+	m.rrsetCache["example.com"] = []models.DNSRecord{
+		{ID: "1", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1"},
+	}
+	return m
+}
+
+func TestUpdateRRFromFields_Error(t *testing.T) {
+	p := &fakeProvider{updateErr: errors.New("permission denied")}
+	m := newTestModel(p)
+
+	msg := m.updateRRFromFields("1", []string{"www.example.com", "300", "A", "false", "192.0.2.2"})()
+
+	status, ok := msg.(statusMsg)
+	require.True(t, ok, "expected statusMsg, got %T", msg)
+	assert.Equal(t, statusError, status.severity)
+	assert.Contains(t, status.text, "permission denied")
+	require.Len(t, p.updated, 1)
+	assert.Equal(t, "1", p.updated[0].ID)
+}
+
+func TestUpdateRRFromFields_UnknownRecord(t *testing.T) {
+	p := &fakeProvider{}
+	m := newTestModel(p)
+
+	msg := m.updateRRFromFields("2", []string{"mail.example.com", "300", "A", "false", "192.0.2.2"})()
+
+	status, ok := msg.(statusMsg)
+	require.True(t, ok, "expected statusMsg, got %T", msg)
+	assert.Equal(t, statusError, status.severity)
+	assert.Empty(t, p.updated)
+}
+
+func TestStatusMsg_ShownInStatusBar(t *testing.T) {
+	m := NewModel()
+
+	_, cmd := m.Update(statusMsg{text: "Error: boom", severity: statusError})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "Error: boom", m.notification)
+	assert.Equal(t, statusError, m.notificationLevel)
+	assert.Contains(t, m.viewStatusBar(), "Error: boom")
+}
+
+// newEditingModel returns a test model with the edit popup open for www.example.com.
+func newEditingModel(p providers.Provider, confirmEdits bool) *Model {
+	m := newTestModel(p)
+	m.Config = &config.Config{UI: config.UIConfig{ConfirmEdits: confirmEdits}}
+	m.RRSetTable = table.New(
+		table.WithColumns([]table.Column{
+			{Title: "Name", Width: 20},
+			{Title: "TTL", Width: 10},
+			{Title: "Type", Width: 10},
+			{Title: "Proxied", Width: 10},
+			{Title: "Content", Width: 20},
+		}),
+		table.WithRows([]table.Row{{"www.example.com", "300", "A", crossMark, "192.0.2.1"}}),
+	)
+	m.rrsetIDs = []string{"1"}
+	m.current = &m.RRSetTable
+	m.editOriginal = []string{"www.example.com", "300", "A", "false", "192.0.2.1"}
+	m.popup = popup.New(
+		[]string{"Name", "TTL", "Type", "Proxied", "Content"},
+		append([]string{}, m.editOriginal...),
+		"Resource record editing",
+		func(fields []string) tea.Msg { return popup.SaveActionMsg{Fields: fields} },
+		popup.CancelMsg{},
+	)
+	m.popup.RecordID = "1"
+	m.showPopup = true
+	return m
+}
+
+// send passes msg to the model and returns the message produced by the resulting command.
+func send(t *testing.T, m *Model, msg tea.Msg) tea.Msg {
+	t.Helper()
+	_, cmd := m.Update(msg)
+	if cmd == nil {
+		return nil
+	}
+	return cmd()
+}
+
+func TestSaveEdit_Confirmation(t *testing.T) {
+	p := &fakeProvider{}
+	m := newEditingModel(p, true)
+	m.popup.Fields[4] = "192.0.2.2"
+
+	// Ctrl+S on a content change opens the confirmation dialog instead of saving
+	msg := send(t, m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	require.IsType(t, popup.SaveActionMsg{}, msg)
+	assert.Nil(t, send(t, m, msg))
+	assert.Equal(t, "confirm", m.popup.Mode)
+	assert.Equal(t, []string{"content: 192.0.2.1 -> 192.0.2.2"}, m.popup.Details)
+	assert.Contains(t, m.popup.View(), "content: 192.0.2.1 -> 192.0.2.2")
+	assert.True(t, m.showPopup)
+	assert.Empty(t, p.updated)
+
+	// Accepting the dialog proceeds with SaveActionMsg
+	msg = send(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, popup.ConfirmDeleteMsg{}, msg)
+	msg = send(t, m, msg)
+	assert.Equal(t, popup.SaveActionMsg{ID: "1", Fields: []string{"www.example.com", "300", "A", "false", "192.0.2.2"}}, msg)
+
+	// The confirmed save is performed without asking again
+	assert.Equal(t, "www.example.com", updatedRecord(t, send(t, m, msg)).Name)
+	require.Len(t, p.updated, 1)
+	assert.Equal(t, "192.0.2.2", p.updated[0].Content)
+	assert.False(t, m.editApproved)
+}
+
+func TestSaveEdit_ConfirmationDeclined(t *testing.T) {
+	p := &fakeProvider{}
+	m := newEditingModel(p, true)
+	editPopup := m.popup
+	m.popup.Fields[2] = "AAAA"
+	m.popup.Fields[4] = "2001:db8::1"
+
+	msg := send(t, m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	assert.Nil(t, send(t, m, msg))
+	require.Equal(t, "confirm", m.popup.Mode)
+
+	// Declining returns to the edit popup
+	msg = send(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, popup.CancelMsg{}, msg)
+	assert.Nil(t, send(t, m, msg))
+	assert.Same(t, editPopup, m.popup)
+	assert.True(t, m.popup.IsActive)
+	assert.True(t, m.showPopup)
+	assert.Equal(t, "AAAA", m.popup.Fields[2])
+	assert.Empty(t, p.updated)
+}
+
+func TestSaveEdit_NoConfirmation(t *testing.T) {
+	tests := []struct {
+		name         string
+		confirmEdits bool
+		field        int
+		value        string
+	}{
+		{name: "disabled", confirmEdits: false, field: 4, value: "192.0.2.2"},
+		{name: "TTL change only", confirmEdits: true, field: 1, value: "600"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &fakeProvider{}
+			m := newEditingModel(p, tt.confirmEdits)
+			m.popup.Fields[tt.field] = tt.value
+
+			msg := send(t, m, tea.KeyMsg{Type: tea.KeyCtrlS})
+			assert.Equal(t, "www.example.com", updatedRecord(t, send(t, m, msg)).Name)
+			assert.Len(t, p.updated, 1)
+		})
+	}
+}
+
+func TestSameName(t *testing.T) {
+	assert.True(t, sameName("www.xn--mnchen-3ya.de", "www.münchen.de"))
+	assert.True(t, sameName("www.example.com", "www.example.com"))
+	assert.False(t, sameName("www.example.com", "mail.example.com"))
+}
+
+func TestRRSetColumns_WithID(t *testing.T) {
+	widths := func(cols []table.Column) (sum int) {
+		for _, c := range cols {
+			sum += c.Width
+		}
+		return sum
+	}
+
+	cols := rrsetColumns(120, 30, false)
+	require.Len(t, cols, 5)
+	assert.Equal(t, 54, cols[4].Width)
+	assert.Equal(t, 120-2*5, widths(cols))
+
+	// The ID column takes its width and padding from Content
+	cols = rrsetColumns(120, 30, true)
+	require.Len(t, cols, 6)
+	assert.Equal(t, table.Column{Title: "ID", Width: 10}, cols[5])
+	assert.Equal(t, 30, cols[0].Width)
+	assert.Equal(t, 42, cols[4].Width)
+	assert.Equal(t, 120-2*6, widths(cols))
+
+	// Narrow terminal: small fields shrink, then Name, Content keeps its minimum
+	cols = rrsetColumns(80, 30, true)
+	assert.Equal(t, []int{28, 6, 6, 8, 10, 10}, []int{cols[0].Width, cols[1].Width, cols[2].Width, cols[3].Width, cols[4].Width, cols[5].Width})
+}
+
+func TestToggleIDs(t *testing.T) {
+	m := newTestModel(&fakeProvider{})
+	m.rrsetCache["example.com"][0].ID = "372e67954025e0ba6aaa6d586b9e0b59"
+	m.width = 120
+	m.applyLayout()
+	zonesCols := m.ZonesTable.Columns()
+	m.switchTable(rrsetTable)
+	m.viewRRSet()
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	assert.True(t, m.showIDs)
+	require.Len(t, m.RRSetTable.Columns(), 6)
+	m.viewRRSet()
+	assert.Equal(t, "372e6795…", m.RRSetTable.Rows()[0][rrsetIDColumn])
+	assert.Equal(t, zonesCols, m.ZonesTable.Columns())
+	assert.Contains(t, m.viewMenu(), "[i] IDs")
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	assert.False(t, m.showIDs)
+	assert.Len(t, m.RRSetTable.Columns(), 5)
+	assert.Len(t, m.RRSetTable.Rows()[0], 5)
+	assert.NotPanics(t, func() { m.viewRRSet() })
+}
+
+func TestShortID(t *testing.T) {
+	assert.Equal(t, "372e6795…", shortID("372e67954025e0ba6aaa6d586b9e0b59"))
+	assert.Equal(t, "12345", shortID("12345"))
+	assert.Equal(t, "", shortID(""))
+}
+
+func TestWatch_TickScheduling(t *testing.T) {
+	p := &fakeProvider{rrset: []models.DNSRecord{
+		{ID: "1", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1"},
+		{ID: "2", Name: "mail.example.com", TTL: 300, Type: "A", Content: "192.0.2.2"},
+	}}
+	m := newTestModel(p)
+	m.Config = &config.Config{UI: config.UIConfig{WatchInterval: 10 * time.Millisecond}}
+	m.loading = false
+
+	msg := send(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	assert.True(t, m.watching)
+	assert.Equal(t, watchTickMsg{seq: 1}, msg)
+	assert.Contains(t, m.viewStatusBar(), "watching every 10ms")
+
+	// A tick reloads the records of the selected zone and schedules the next tick
+	batch, ok := send(t, m, msg).(tea.BatchMsg)
+	require.True(t, ok, "expected tea.BatchMsg")
+	require.Len(t, batch, 2)
+	assert.Equal(t, dataLoadedMsg{}, batch[0]())
+	assert.Equal(t, p.rrset, m.rrsetCache["example.com"])
+	assert.Equal(t, watchTickMsg{seq: 1}, batch[1]())
+
+	// Stopping the watch drops the pending tick
+	msg = send(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	assert.False(t, m.watching)
+	assert.Equal(t, statusMsg{text: "Stopped watching", severity: statusInfo}, msg)
+	_, cmd := m.Update(watchTickMsg{seq: 1})
+	assert.Nil(t, cmd)
+	assert.NotContains(t, m.viewStatusBar(), "watching")
+
+	// A tick of an earlier watch is dropped after watching again
+	_ = m.toggleWatch()
+	_, cmd = m.Update(watchTickMsg{seq: 1})
+	assert.Nil(t, cmd)
+}
+
+func TestWatchInterval(t *testing.T) {
+	m := NewModel()
+	assert.Equal(t, defaultWatchInterval, m.watchInterval())
+
+	m.Config = &config.Config{UI: config.UIConfig{WatchInterval: time.Minute}}
+	assert.Equal(t, time.Minute, m.watchInterval())
+}
+
+func TestUpdateRecordRow_ByID(t *testing.T) {
+	m := newEditingModel(&fakeProvider{}, false)
+	m.rrsetCache["example.com"] = []models.DNSRecord{
+		{ID: "1", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1"},
+		{ID: "2", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.2"},
+	}
+	m.setRRSetRows(m.rrsetCache["example.com"])
+	m.current = &m.RRSetTable
+	m.showPopup = false
+
+	// The record saved is written back by ID, wherever the cursor is
+	msg := m.updateRRFromFields("2", []string{"www.example.com", "600", "A", "false", "192.0.2.3"})()
+	m.RRSetTable.SetCursor(0)
+	m.Update(msg)
+	rrset := m.rrsetCache["example.com"]
+	assert.Equal(t, models.DNSRecord{ID: "1", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1"}, rrset[0])
+	assert.Equal(t, models.DNSRecord{ID: "2", Name: "www.example.com", TTL: 600, Type: "A", Content: "192.0.2.3"}, rrset[1])
+	assert.Equal(t, "192.0.2.1", m.RRSetTable.Rows()[0][4])
+	assert.Equal(t, "192.0.2.3", m.RRSetTable.Rows()[1][4])
+
+	// A renamed record is still found
+	m.Update(recordUpdatedMsg{zone: "example.com", record: models.DNSRecord{ID: "1", Name: "web.example.com", TTL: 300, Type: "A", Content: "192.0.2.1"}})
+	rrset = m.rrsetCache["example.com"]
+	assert.Equal(t, "web.example.com", rrset[0].Name)
+	assert.Equal(t, "www.example.com", rrset[1].Name)
+	assert.Equal(t, "web.example.com", m.RRSetTable.Rows()[0][0])
+}
+
+func TestUpdateRecordRow_FailedSave(t *testing.T) {
+	p := &fakeProvider{updateErr: errors.New("permission denied")}
+	m := newEditingModel(p, false)
+	m.setRRSetRows(m.rrsetCache["example.com"])
+	m.current = &m.RRSetTable
+	m.popup.Fields[4] = "192.0.2.2"
+
+	// A failed save leaves the cache and the table as they were
+	msg := send(t, m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	_ = send(t, m, msg)
+	require.Len(t, p.updated, 1)
+	assert.Equal(t, "192.0.2.1", m.rrsetCache["example.com"][0].Content)
+	assert.Equal(t, "192.0.2.1", m.RRSetTable.Rows()[0][4])
+}
+
+// updatedRecord returns the record of a recordUpdatedMsg.
+func updatedRecord(t *testing.T, msg tea.Msg) models.DNSRecord {
+	t.Helper()
+	updated, ok := msg.(recordUpdatedMsg)
+	require.True(t, ok, "expected recordUpdatedMsg, got %T", msg)
+	return updated.record
+}
+
+func TestDeleteRR_ByID(t *testing.T) {
+	p := &fakeProvider{}
+	m := newEditingModel(p, false)
+	m.rrsetCache["example.com"] = []models.DNSRecord{
+		{ID: "1", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1"},
+		{ID: "2", Name: "www.example.com", TTL: 300, Type: "AAAA", Content: "2001:db8::1"},
+	}
+	m.setRRSetRows(m.rrsetCache["example.com"])
+	m.current = &m.RRSetTable
+	m.showPopup = false
+
+	// The second of two records sharing a name is deleted
+	msg := m.deleteRR(1)()
+	deleted, ok := msg.(recordDeletedMsg)
+	require.True(t, ok, "expected recordDeletedMsg, got %T", msg)
+	require.Len(t, p.deleted, 1)
+	assert.Equal(t, "2", p.deleted[0].ID)
+	assert.Equal(t, "AAAA", p.deleted[0].Type)
+
+	m.Update(deleted)
+	assert.Equal(t, []models.DNSRecord{
+		{ID: "1", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1"},
+	}, m.rrsetCache["example.com"])
+	assert.Equal(t, []string{"1"}, m.rrsetIDs)
+	require.Len(t, m.RRSetTable.Rows(), 1)
+	assert.Equal(t, "A", m.RRSetTable.Rows()[0][2])
+}
+
+func TestRecordFromFields(t *testing.T) {
+	rr := models.DNSRecord{ID: "1", ZoneID: "42", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1", Comment: "web"}
+
+	updated := recordFromFields(rr, []string{"web.example.com", "600", "A", "true", "192.0.2.2"})
+	assert.Equal(t, models.DNSRecord{
+		ID: "1", ZoneID: "42", Name: "web.example.com", TTL: 600, Type: "A", Proxied: true, Content: "192.0.2.2", Comment: "web",
+	}, updated)
+}
+
+func TestUpdateRRFromFields_RenamedRecord(t *testing.T) {
+	p := &fakeProvider{}
+	m := newEditingModel(p, false)
+	m.rrsetCache["example.com"] = append(m.rrsetCache["example.com"],
+		models.DNSRecord{ID: "2", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.2"})
+
+	// The renamed second record is updated, not the first one with the old name
+	msg := m.updateRRFromFields("2", []string{"web.example.com", "300", "A", "false", "192.0.2.2"})()
+	assert.Equal(t, "web.example.com", updatedRecord(t, msg).Name)
+	require.Len(t, p.updated, 1)
+	assert.Equal(t, "2", p.updated[0].ID)
+	assert.Equal(t, "web.example.com", p.updated[0].Name)
+}
+
+func TestFromFields_Apex(t *testing.T) {
+	p := &fakeProvider{}
+	m := newEditingModel(p, false)
+
+	msg := m.updateRRFromFields("1", []string{"@", "300", "A", "false", "192.0.2.2"})()
+	assert.Equal(t, "example.com", updatedRecord(t, msg).Name)
+	require.Len(t, p.updated, 1)
+	assert.Equal(t, "example.com", p.updated[0].Name)
+
+	_ = m.createRRFromFields([]string{"@", "300", "TXT", "false", "v=spf1 -all"})()
+	require.Len(t, p.created, 1)
+	assert.Equal(t, "example.com", p.created[0].Name)
+}
+
+func TestEditPopup_CarriesRecordID(t *testing.T) {
+	m := newTestModel(&fakeProvider{})
+	m.rrsetCache["example.com"] = append(m.rrsetCache["example.com"],
+		models.DNSRecord{ID: "2", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.2"})
+	m.RRSetTable = table.New(table.WithColumns(rrsetColumns(100, 20, false)))
+	m.setRRSetRows(m.rrsetCache["example.com"])
+	m.switchTable(rrsetTable)
+	m.RRSetTable.SetCursor(1)
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	require.NotNil(t, m.popup)
+	assert.Equal(t, "2", m.popup.RecordID)
+	assert.Equal(t, popup.SaveActionMsg{ID: "2", Fields: []string{"web.example.com"}}, m.popup.SaveAction([]string{"web.example.com"}))
+}
+
+func TestCloneRecord(t *testing.T) {
+	p := &fakeProvider{}
+	m := newTestModel(p)
+	m.rrsetCache["example.com"] = append(m.rrsetCache["example.com"],
+		models.DNSRecord{ID: "2", Name: "api.example.com", TTL: 600, Type: "CNAME", Proxied: true, Content: "www.example.com"})
+	m.RRSetTable = table.New(table.WithColumns(rrsetColumns(100, 20, false)))
+	m.setRRSetRows(m.rrsetCache["example.com"])
+	m.switchTable(rrsetTable)
+	m.RRSetTable.SetCursor(1)
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("C")})
+	require.NotNil(t, m.popup)
+	assert.True(t, m.creating)
+	assert.Empty(t, m.popup.RecordID)
+	assert.Equal(t, []string{"api.example.com", "600", "CNAME", "true", "www.example.com"}, m.popup.Fields)
+
+	// Saving the clone creates a new record from the pre-filled fields
+	msg := m.createRRFromFields(m.popup.Fields)()
+	assert.IsType(t, recordCreatedMsg{}, msg)
+	require.Len(t, p.created, 1)
+	assert.Equal(t, models.CreateDNSRecordParams{
+		Name: "api.example.com", TTL: 600, Type: "CNAME", Proxied: true, Content: "www.example.com", ZoneName: "example.com",
+	}, p.created[0])
+	assert.Empty(t, p.updated)
+}
+
+func TestRowFields(t *testing.T) {
+	assert.Equal(t, []string{"www.example.com", "300", "A", "false", "192.0.2.1"},
+		rowFields(table.Row{"www.example.com", "300", "A", crossMark, "192.0.2.1", "372e6795…"}))
+	assert.Nil(t, rowFields(table.Row{"www.example.com"}))
+}
+
+func TestKeybindings_Remapped(t *testing.T) {
+	m := newTestModel(&fakeProvider{})
+	m.Config = &config.Config{UI: config.UIConfig{Keybindings: map[string]string{"watch": "W", "reload": "w"}}}
+
+	// w now reloads instead of watching
+	msg := send(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	assert.Equal(t, dataLoadingMsg{}, msg)
+	assert.False(t, m.watching)
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("W")})
+	assert.True(t, m.watching)
+
+	// Keys of other actions and the fixed keys are kept
+	assert.Equal(t, "quit", m.keyAction("q"))
+	assert.Equal(t, "quit", m.keyAction("ctrl+c"))
+	assert.Equal(t, "", m.keyAction("r"))
+	assert.Contains(t, m.viewMenu(), "[w] Reload")
+	assert.Contains(t, m.viewMenu(), "[W] Watch")
+}
+
+func TestCountSelectedZone(t *testing.T) {
+	p := &fakeProvider{count: 1500}
+	m := newTestModel(p)
+	m.ZonesTable.SetRows([]table.Row{{"example.com"}, {"example.org"}})
+	m.ZonesTable.Focus()
+	m.loading = false
+
+	// The records of example.com are cached, nothing to count
+	assert.Nil(t, m.countSelectedZone())
+	assert.Contains(t, m.viewStatusBar(), "example.com: 1 record")
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	require.NotNil(t, cmd)
+	msg := cmd()
+	assert.Equal(t, recordsCountedMsg{zone: "example.org", count: 1500}, msg)
+	assert.Equal(t, []string{"example.org"}, p.counted)
+
+	m.Update(msg)
+	assert.Contains(t, m.viewStatusBar(), "example.org: 1500 records")
+	// Counted once
+	assert.Nil(t, m.countSelectedZone())
+
+	// Loaded records win over the count
+	m.rrsetCache["example.org"] = []models.DNSRecord{{ID: "2"}, {ID: "3"}}
+	assert.Contains(t, m.viewStatusBar(), "example.org: 2 records")
+}
+
+func TestSaveNameServers(t *testing.T) {
+	p := &fakeProvider{}
+	m := newTestModel(p)
+	m.ZonesTable = table.New(
+		table.WithColumns([]table.Column{{Title: "Name", Width: 20}, {Title: "NameServers", Width: 40}}),
+		table.WithRows([]table.Row{{"example.com", "a.ns.example, b.ns.example"}}),
+	)
+	m.ZonesTable.Focus()
+	m.popup = popup.NewNameServersEditor([]string{"ns1.example.net", "ns2.example.net"}, "NameServers")
+	m.showPopup = true
+
+	// The table is only updated once the provider saved the name servers
+	msg := send(t, m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	assert.Equal(t, popup.SaveNameServersMsg{Servers: []string{"ns1.example.net", "ns2.example.net"}}, msg)
+	assert.False(t, m.showPopup)
+
+	msg = send(t, m, msg)
+	assert.Equal(t, "a.ns.example, b.ns.example", m.ZonesTable.Rows()[0][1])
+	assert.Equal(t, nameServersUpdatedMsg{zone: "example.com", servers: []string{"ns1.example.net", "ns2.example.net"}}, msg)
+	assert.Equal(t, []string{"ns1.example.net", "ns2.example.net"}, p.ns["example.com"])
+
+	status := send(t, m, msg)
+	assert.Equal(t, statusMsg{text: "Name servers of example.com updated", severity: statusSuccess}, status)
+	assert.Equal(t, "ns1.example.net, ns2.example.net", m.ZonesTable.Rows()[0][1])
+
+	// A rejected update leaves the table alone
+	msg = send(t, m, popup.SaveNameServersMsg{Servers: []string{"ns3.example.net"}})
+	require.IsType(t, statusMsg{}, msg)
+	assert.Equal(t, statusError, msg.(statusMsg).severity)
+	assert.Equal(t, "ns1.example.net, ns2.example.net", m.ZonesTable.Rows()[0][1])
+}
+
+func TestSaveNameServers_Unsupported(t *testing.T) {
+	p := &fakeProvider{nsErr: &providers.UnsupportedFeatureError{Feature: "changing name servers"}}
+	m := newTestModel(p)
+	m.ZonesTable = table.New(
+		table.WithColumns([]table.Column{{Title: "Name", Width: 20}, {Title: "NameServers", Width: 40}}),
+		table.WithRows([]table.Row{{"example.com", "a.ns.example, b.ns.example"}}),
+	)
+	m.ZonesTable.Focus()
+	m.popup = popup.NewNameServersEditor([]string{"ns1.example.net", "ns2.example.net"}, "NameServers")
+	m.showPopup = true
+
+	msg := send(t, m, send(t, m, tea.KeyMsg{Type: tea.KeyCtrlS}))
+	assert.Equal(t, statusMsg{text: "Error: the provider does not support changing name servers", severity: statusError}, msg)
+	assert.Equal(t, "a.ns.example, b.ns.example", m.ZonesTable.Rows()[0][1])
+	assert.Empty(t, p.ns)
+}
+
+func TestGetApp_BuiltOnce(t *testing.T) {
+	m := NewModel(WithConfig(&config.Config{
+		DefaultProvider: "ns",
+		Providers: map[string]config.ProviderConfig{
+			"ns": {
+				Type: "rfc2136",
+				Credentials: map[string]interface{}{
+					"server":     "127.0.0.1:53",
+					"key_name":   "cdnscli.",
+					"key_secret": "c2VjcmV0",
+				},
+			},
+		},
+	}))
+	assert.Nil(t, m.app, "the application is built lazily")
+
+	a, err := m.getApp()
+	require.NoError(t, err)
+	again, err := m.getApp()
+	require.NoError(t, err)
+	assert.Same(t, a, again)
+}
@@ -0,0 +1,41 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"github.com/mixanemca/cdnscli/internal/app"
+	"github.com/mixanemca/cdnscli/internal/config"
+)
+
+// Option configures the UI model.
+type Option func(m *Model)
+
+// WithApp sets the application the UI works with. Provider settings such as
+// create form defaults are taken from the application's default provider.
+func WithApp(a app.App) Option {
+	return func(m *Model) {
+		m.app = a
+	}
+}
+
+// WithConfig sets the configuration the application is built from when the
+// UI starts. It is ignored if an application is set with WithApp.
+func WithConfig(cfg *config.Config) Option {
+	return func(m *Model) {
+		m.Config = cfg
+	}
+}
@@ -19,18 +19,21 @@ package popup
 
 import (
 	"fmt"
-    "net"
     "regexp"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+    "github.com/mixanemca/cdnscli/internal/models"
     "github.com/mixanemca/cdnscli/internal/ui/theme"
     overlay "github.com/rmhubbert/bubbletea-overlay"
 )
 
 // SaveActionMsg is a tea.Msg signaling that edited fields should be saved.
 type SaveActionMsg struct {
+	ID     string   // ID of the edited record, empty when creating one
 	Fields []string // Поля, которые были отредактированы
 }
 
@@ -40,7 +43,8 @@ type CancelMsg struct{}
 type SaveNameServersMsg struct {
     Servers []string
 }
-// ConfirmDeleteMsg is a tea.Msg signaling that deletion was confirmed.
+// ConfirmDeleteMsg is a tea.Msg signaling that the confirmation dialog was accepted
+// (a deletion or, when edits require confirmation, a save).
 type ConfirmDeleteMsg struct{}
 // Model implements tea.Model and represents the popup editor state.
 type Model struct {
@@ -52,6 +56,7 @@ type Model struct {
 	Title       string                 // Заголовок окна
 	SaveAction  func([]string) tea.Msg // Действие при сохранении
 	CancelMsg   tea.Msg                // Сообщение при отмене
+	RecordID    string                 // ID of the edited record, passed back in SaveActionMsg
 
     // Boolean selection mode for boolean fields
     inBoolSelect bool
@@ -62,6 +67,7 @@ type Model struct {
     // Text edit mode for simple fields (Name, TTL, Content)
     inTextEdit bool
     textBuf    string
+    textPos    int // text edit cursor position in runes
     textErr    string
     // Type selection mode (enum)
     inTypeSelect bool
@@ -72,7 +78,8 @@ type Model struct {
     ListValues  []string // values for list mode
     ListCursor  int      // cursor for list mode
     // Confirm dialog state
-    ConfirmIndex int    // 0 => Yes, 1 => No
+    ConfirmIndex int      // 0 => Yes, 1 => No
+    Details      []string // lines shown under the confirm dialog title, e.g. the changes to save
 }
 
 // Ensure that model fulfils the tea.Model interface at compile time.
@@ -188,12 +195,14 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
                     m.textBuf = ""
                     m.textErr = ""
                     m.ov = nil
-                case tea.KeyBackspace:
-                    if len(m.textBuf) > 0 { m.textBuf = m.textBuf[:len(m.textBuf)-1] }
-                case tea.KeyCtrlH:
-                    if len(m.textBuf) > 0 { m.textBuf = m.textBuf[:len(m.textBuf)-1] }
+                case tea.KeyBackspace, tea.KeyCtrlH:
+                    m.textBuf, m.textPos = deleteBefore(m.textBuf, m.textPos)
+                case tea.KeyDelete:
+                    m.textBuf, m.textPos = deleteAt(m.textBuf, m.textPos)
                 case tea.KeyRunes:
-                    if len(km.Runes) > 0 { m.textBuf += string(km.Runes) }
+                    m.textBuf, m.textPos = insertAt(m.textBuf, m.textPos, string(km.Runes))
+                default:
+                    m.textPos, _ = moveCursor(m.textBuf, m.textPos, km.String())
                 }
             }
             return m, nil
@@ -223,6 +232,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
                 // open text editor for current line
                 m.inTextEdit = true
                 m.textBuf = m.ListValues[m.ListCursor]
+                m.textPos = utf8.RuneCountInString(m.textBuf)
                 m.textErr = ""
                 return m, nil
             case tea.KeyCtrlD:
@@ -307,22 +317,15 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
                 m.textBuf = ""
                 m.textErr = ""
                 m.ov = nil
-            case tea.KeyLeft, tea.KeyRight, tea.KeyUp, tea.KeyDown:
-                // ignore navigation keys in text edit modal (no cursor rendering yet)
-            case tea.KeyBackspace:
-                if len(m.textBuf) > 0 {
-                    m.textBuf = m.textBuf[:len(m.textBuf)-1]
-                }
-            case tea.KeyCtrlH:
-                if len(m.textBuf) > 0 {
-                    m.textBuf = m.textBuf[:len(m.textBuf)-1]
-                }
+            case tea.KeyBackspace, tea.KeyCtrlH:
+                m.textBuf, m.textPos = deleteBefore(m.textBuf, m.textPos)
             case tea.KeyDelete:
-                // ignore for simplicity
+                m.textBuf, m.textPos = deleteAt(m.textBuf, m.textPos)
             case tea.KeyRunes:
-                if len(km.Runes) > 0 {
-                    m.textBuf += string(km.Runes)
-                }
+                m.textBuf, m.textPos = insertAt(m.textBuf, m.textPos, string(km.Runes))
+            default:
+                // Left/Right/Home/End move the cursor, other keys are ignored
+                m.textPos, _ = moveCursor(m.textBuf, m.textPos, km.String())
             }
         }
         return m, nil
@@ -425,10 +428,19 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
             // текстовые поля
             m.inTextEdit = true
             m.textBuf = m.Fields[m.Cursor]
+            m.textPos = utf8.RuneCountInString(m.textBuf)
             return m, nil
 		case tea.KeyCtrlS: // сохранить все изменения формы
+			// Keep the form open until every field required by the record type is valid
+			if i, errText := m.validateFields(); errText != "" {
+				m.Cursor = i
+				m.CharPos = len(m.Fields[i])
+				m.textErr = errText
+				return m, nil
+			}
+			m.textErr = ""
 			m.IsActive = false
-			return m, func() tea.Msg { return SaveActionMsg{Fields: m.Fields} }
+			return m, func() tea.Msg { return SaveActionMsg{ID: m.RecordID, Fields: m.Fields} }
 		case tea.KeyEsc: // выйти без сохранения
 			m.IsActive = false
 			return m, func() tea.Msg { return CancelMsg{} }
@@ -534,23 +546,29 @@ func validateInput(fieldName, value, rrType string) string {
         if !isNumber(value) {
             return "TTL must be a number in seconds (e.g. 60, 300, 1800)"
         }
+        ttl, err := strconv.Atoi(value)
+        if err == nil {
+            err = models.ValidateTTL(ttl)
+        }
+        if err != nil {
+            return fmt.Sprintf("TTL must be at most %d seconds", models.MaxTTL)
+        }
         return ""
     case "name":
-        if !isHostname(value) {
-            return "Name must be a valid hostname"
+        // Same RFC label rules as rr add/update, so the TUI rejects what the CLI rejects.
+        // The @ shorthand names the zone apex and is expanded to the zone name on save
+        if value == models.ApexName {
+            return ""
+        }
+        if err := models.ValidateName(value); err != nil {
+            return "Name must be a valid hostname: " + err.Error()
         }
         return ""
     case "content":
-        switch strings.ToUpper(rrType) {
-        case "A":
-            if !isIPv4(value) { return "Content must be a valid IPv4 address for A record" }
-        case "AAAA":
-            if !isIPv6(value) { return "Content must be a valid IPv6 address for AAAA record" }
-        case "CNAME", "NS", "MX":
-            if !isHostname(value) { return "Content must be a valid hostname" }
-        default:
-            // TXT, SRV, CAA — skip strict validation
-            return ""
+        // Same checks as the CLI and the providers make. Long TXT values are
+        // split into 255-byte character-strings on save
+        if err := models.ValidateRecordContent(rrType, value); err != nil {
+            return "Invalid content: " + err.Error()
         }
         return ""
     default:
@@ -558,6 +576,57 @@ func validateInput(fieldName, value, rrType string) string {
     }
 }
 
+// validatePriority checks that MX content "priority host" and SRV content
+// "priority weight port target" of a new record start with the priority, the
+// form has no field of its own for it. Providers such as Cloudflare keep the
+// priority of existing records apart from the content.
+func validatePriority(rrType, value string) string {
+    parts := strings.Fields(value)
+    switch strings.ToUpper(rrType) {
+    case "MX":
+        if len(parts) != 2 {
+            return `Content must be "priority host" for MX record, e.g. 10 mail.example.com`
+        }
+    case "SRV":
+        if len(parts) != 4 {
+            return `Content must be "priority weight port target" for SRV record, e.g. 10 5 5060 sip.example.com`
+        }
+    }
+    return ""
+}
+
+// validateFields checks the fields required by the record type before saving and
+// returns the index of the first invalid field with the error. A new record,
+// one without RecordID, must carry the MX and SRV priority in its content.
+func (m *Model) validateFields() (int, string) {
+    rrType := strings.ToUpper(m.currentType())
+    for i, value := range m.Fields {
+        if i >= len(m.ColumnNames) {
+            break
+        }
+        fieldName := strings.ToLower(m.ColumnNames[i])
+        switch fieldName {
+        case "name", "ttl", "content":
+            if strings.TrimSpace(value) == "" {
+                return i, m.ColumnNames[i] + " is required"
+            }
+        case "type":
+            if value == "" {
+                return i, "Type is required"
+            }
+        }
+        if errText := validateInput(fieldName, value, rrType); errText != "" {
+            return i, errText
+        }
+        if fieldName == "content" && m.RecordID == "" {
+            if errText := validatePriority(rrType, value); errText != "" {
+                return i, errText
+            }
+        }
+    }
+    return 0, ""
+}
+
 var hostnameRe = regexp.MustCompile(`^(?i:[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?)(?:\.(?i:[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?))*\.?$`)
 
 func isHostname(s string) bool {
@@ -572,16 +641,6 @@ func isNumber(s string) bool {
     return len(s) > 0
 }
 
-func isIPv4(s string) bool {
-    ip := net.ParseIP(s)
-    return ip != nil && ip.To4() != nil
-}
-
-func isIPv6(s string) bool {
-    ip := net.ParseIP(s)
-    return ip != nil && ip.To16() != nil && ip.To4() == nil
-}
-
 // stringWidth calculates display width of a string (runes length is enough here)
 func stringWidth(s string) int {
     return len([]rune(s))
@@ -617,7 +676,12 @@ func (m *Model) viewBase() string {
     )
 
     helpStyled := helpTextStyle.Render(helpPlain)
-    content := lipgloss.JoinVertical(lipgloss.Top, header, strings.Join(fieldLinesStyled, "\n"), helpStyled)
+    lines := []string{header, strings.Join(fieldLinesStyled, "\n")}
+    // A save rejected by validateFields leaves its error here
+    if m.textErr != "" && !m.inTextEdit {
+        lines = append(lines, lipgloss.NewStyle().Foreground(theme.Color.Red).Render(m.textErr))
+    }
+    content := lipgloss.JoinVertical(lipgloss.Top, append(lines, helpStyled)...)
 
     boxed := borderStyle.Render(content)
     // Центрируем всю коробку в пределах рассчитанной ширины
@@ -679,7 +743,7 @@ func (t *textView) View() string {
         lipgloss.Top,
         boolTitleStyle.Render("Edit value"),
     )
-    value := fieldStyle.Render(t.parent.textBuf)
+    value := renderCaret(t.parent.textBuf, t.parent.textPos, fieldStyle)
     // Build hint safely for both default and nslist modes
     var hintText string
     if t.parent.Mode == "nslist" {
@@ -697,7 +761,7 @@ func (t *textView) View() string {
         errStyle := lipgloss.NewStyle().Foreground(theme.Color.Red)
         errLine = errStyle.Render(t.parent.textErr)
     }
-    help := helpTextStyle.Render("[Enter] Apply  [Esc] Cancel")
+    help := helpTextStyle.Render("[←/→/Home/End] Move  [Enter] Apply  [Esc] Cancel")
     body := lipgloss.JoinVertical(lipgloss.Top, header, value, hint, errLine, help)
     return boolModalBorder.Render(body)
 }
@@ -718,7 +782,13 @@ func textHint(fieldName, rrType string) string {
         case "CNAME":
             return "Canonical hostname, e.g. target.example.com"
         case "MX":
-            return "Mail exchanger hostname, e.g. mail.example.com"
+            return "Priority and mail exchanger, e.g. 10 mail.example.com"
+        case "SRV":
+            return "Priority, weight, port and target, e.g. 10 5 5060 sip.example.com"
+        case "CAA":
+            return "Flags, tag and value, e.g. 0 issue \"letsencrypt.org\""
+        case "TXT":
+            return "Text, e.g. v=spf1 -all"
         case "NS":
             return "Nameserver hostname, e.g. ns1.example.com"
         default:
@@ -796,6 +866,10 @@ func (m *Model) viewConfirm() string {
         titleStyle.Render(fmt.Sprintf("--- %s ---", m.Title)),
     )
 
+    if len(m.Details) > 0 {
+        header = lipgloss.JoinVertical(lipgloss.Top, header, fieldStyle.MarginBottom(1).Render(strings.Join(m.Details, "\n")))
+    }
+
     yesLine := boolNormalStyle.Render("Yes")
     noLine := boolNormalStyle.Render("No")
     if m.ConfirmIndex == 0 {
@@ -0,0 +1,154 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package popup
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateInput_TXT(t *testing.T) {
+	assert.Empty(t, validateInput("content", "v=spf1 -all", "TXT"))
+	assert.Empty(t, validateInput("content", strings.Repeat("a", 300), "TXT"))
+	assert.NotEmpty(t, validateInput("content", "", "TXT"))
+	assert.NotEmpty(t, validateInput("content", strings.Repeat("a", 2049), "txt"))
+}
+
+func TestValidateInput_IDN(t *testing.T) {
+	assert.Empty(t, validateInput("name", "www.münchen.de", "A"))
+	assert.Empty(t, validateInput("content", "mail.münchen.de", "CNAME"))
+	assert.NotEmpty(t, validateInput("name", "a‍б.de", "A"))
+}
+
+func TestValidateInput_NameLabels(t *testing.T) {
+	assert.Empty(t, validateInput("name", "_dmarc", "TXT"))
+	assert.Empty(t, validateInput("name", "*.example.com", "A"))
+	assert.Contains(t, validateInput("name", strings.Repeat("a", 64), "A"), "the limit is 63")
+	assert.Contains(t, validateInput("name", "my host", "A"), "invalid character")
+}
+
+func TestValidateInput_Apex(t *testing.T) {
+	assert.Empty(t, validateInput("name", "@", "A"))
+	assert.Empty(t, validateInput("name", "example.com", "A"))
+	assert.Empty(t, validateInput("name", "example.com.", "MX"))
+	assert.NotEmpty(t, validateInput("name", "@@", "A"))
+	assert.NotEmpty(t, validateInput("name", "@.example.com", "A"))
+
+	// The content of an apex record is still checked for its type
+	m := recordForm("", "A", "mail.example.com")
+	m.Fields[0] = "@"
+	i, errText := m.validateFields()
+	assert.Equal(t, 4, i)
+	assert.Contains(t, errText, "valid IP address")
+
+	m.Fields[4] = "192.0.2.1"
+	_, errText = m.validateFields()
+	assert.Empty(t, errText)
+}
+
+func TestValidateInput_AddressFamily(t *testing.T) {
+	assert.Empty(t, validateInput("content", "192.0.2.1", "A"))
+	assert.Empty(t, validateInput("content", "2001:db8::1", "AAAA"))
+	assert.Contains(t, validateInput("content", "2001:db8::1", "A"), "use an AAAA record")
+	assert.Contains(t, validateInput("content", "192.0.2.1", "AAAA"), "use an A record")
+}
+
+func TestValidateInput_TTL(t *testing.T) {
+	assert.Empty(t, validateInput("ttl", "300", "A"))
+	assert.Contains(t, validateInput("ttl", "5m", "A"), "must be a number")
+	assert.Contains(t, validateInput("ttl", "4294967296", "A"), "at most 2147483647 seconds")
+}
+
+// recordForm returns a record popup with the given type and content, editing
+// the record with the given ID or creating one when it is empty.
+func recordForm(id, rrType, content string) *Model {
+	m := New(
+		[]string{"Name", "TTL", "Type", "Proxied", "Content"},
+		[]string{"www.example.com", "300", rrType, "false", content},
+		"Resource record",
+		func(fields []string) tea.Msg { return SaveActionMsg{Fields: fields} },
+		CancelMsg{},
+	)
+	m.RecordID = id
+	return m
+}
+
+func TestValidateFields_Types(t *testing.T) {
+	tests := []struct {
+		rrType   string
+		content  string
+		creating string // error when creating, empty if valid
+		editing  string // error when editing, empty if valid
+	}{
+		{"A", "192.0.2.1", "", ""},
+		{"A", "2001:db8::1", "use an AAAA record", "use an AAAA record"},
+		{"AAAA", "2001:db8::1", "", ""},
+		{"AAAA", "192.0.2.1", "use an A record", "use an A record"},
+		{"CNAME", "target.example.com", "", ""},
+		{"CNAME", "not a host", "valid hostname", "valid hostname"},
+		{"NS", "ns1.example.com", "", ""},
+		{"TXT", "v=spf1 -all", "", ""},
+		{"MX", "10 mail.example.com", "", ""},
+		{"MX", "mail.example.com", `"priority host"`, ""},
+		{"MX", "70000 mail.example.com", `"priority host"`, `"priority host"`},
+		{"MX", "10 mail example.com", "valid mail exchanger", "valid mail exchanger"},
+		{"SRV", "10 5 5060 sip.example.com", "", ""},
+		{"SRV", "5 5060 sip.example.com", `"priority weight port target"`, ""},
+		{"SRV", "10 sip.example.com", `"priority weight port target"`, `"priority weight port target"`},
+		{"SRV", "0 0 0 .", "", ""},
+		{"CAA", `0 issue "letsencrypt.org"`, "", ""},
+		{"CAA", "issue letsencrypt.org", `"flags tag value"`, `"flags tag value"`},
+		{"CAA", "0 issue", `"flags tag value"`, `"flags tag value"`},
+		{"", "192.0.2.1", "Type is required", "Type is required"},
+		{"TXT", " ", "Content is required", "Content is required"},
+	}
+
+	for _, tt := range tests {
+		for _, mode := range []struct{ id, want string }{{"", tt.creating}, {"1", tt.editing}} {
+			i, errText := recordForm(mode.id, tt.rrType, tt.content).validateFields()
+			if mode.want == "" {
+				assert.Empty(t, errText, "%s %q id=%q", tt.rrType, tt.content, mode.id)
+				continue
+			}
+			assert.Contains(t, errText, mode.want, "%s %q id=%q", tt.rrType, tt.content, mode.id)
+			assert.Contains(t, []int{2, 4}, i)
+		}
+	}
+}
+
+func TestSave_RejectsInvalidRecord(t *testing.T) {
+	m := recordForm("", "MX", "mail.example.com")
+	m.Cursor = 0
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	assert.Nil(t, cmd)
+	assert.True(t, m.IsActive)
+	assert.Equal(t, 4, m.Cursor)
+	assert.Contains(t, m.textErr, `"priority host"`)
+	assert.Contains(t, m.viewBase(), `"priority host"`)
+
+	m.Fields[4] = "10 mail.example.com"
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	require.NotNil(t, cmd)
+	assert.False(t, m.IsActive)
+	assert.Empty(t, m.textErr)
+	assert.Equal(t, SaveActionMsg{Fields: m.Fields}, cmd())
+}
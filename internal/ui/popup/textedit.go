@@ -0,0 +1,114 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package popup
+
+import "github.com/charmbracelet/lipgloss"
+
+// caretStyle highlights the character under the text edit cursor.
+var caretStyle = lipgloss.NewStyle().Reverse(true)
+
+// clampPos limits the rune position pos to the bounds of buf.
+func clampPos(buf []rune, pos int) int {
+	if pos < 0 {
+		return 0
+	}
+	if pos > len(buf) {
+		return len(buf)
+	}
+	return pos
+}
+
+// insertAt inserts s into buf at the rune position pos.
+// It returns the new buffer and the position after the inserted text.
+func insertAt(buf string, pos int, s string) (string, int) {
+	runes := []rune(buf)
+	pos = clampPos(runes, pos)
+	ins := []rune(s)
+
+	out := make([]rune, 0, len(runes)+len(ins))
+	out = append(out, runes[:pos]...)
+	out = append(out, ins...)
+	out = append(out, runes[pos:]...)
+
+	return string(out), pos + len(ins)
+}
+
+// deleteBefore removes the rune before the position pos (Backspace).
+// It returns the new buffer and position.
+func deleteBefore(buf string, pos int) (string, int) {
+	runes := []rune(buf)
+	pos = clampPos(runes, pos)
+	if pos == 0 {
+		return buf, 0
+	}
+
+	return string(append(runes[:pos-1:pos-1], runes[pos:]...)), pos - 1
+}
+
+// deleteAt removes the rune at the position pos (Delete).
+// It returns the new buffer and position.
+func deleteAt(buf string, pos int) (string, int) {
+	runes := []rune(buf)
+	pos = clampPos(runes, pos)
+	if pos == len(runes) {
+		return buf, pos
+	}
+
+	return string(append(runes[:pos:pos], runes[pos+1:]...)), pos
+}
+
+// moveCursor returns the text edit cursor position after the navigation key k,
+// and whether k is a navigation key.
+func moveCursor(buf string, pos int, k string) (int, bool) {
+	runes := []rune(buf)
+	pos = clampPos(runes, pos)
+
+	switch k {
+	case "left", "ctrl+b":
+		if pos > 0 {
+			pos--
+		}
+	case "right", "ctrl+f":
+		if pos < len(runes) {
+			pos++
+		}
+	case "home", "ctrl+a":
+		pos = 0
+	case "end", "ctrl+e":
+		pos = len(runes)
+	default:
+		return pos, false
+	}
+
+	return pos, true
+}
+
+// renderCaret renders buf with the character at the rune position pos highlighted as a caret.
+// At the end of the buffer the caret is rendered as a highlighted space.
+func renderCaret(buf string, pos int, style lipgloss.Style) string {
+	runes := []rune(buf)
+	pos = clampPos(runes, pos)
+
+	under := " "
+	after := ""
+	if pos < len(runes) {
+		under = string(runes[pos])
+		after = string(runes[pos+1:])
+	}
+
+	return style.Render(string(runes[:pos])) + caretStyle.Render(under) + style.Render(after)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package popup
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertAt(t *testing.T) {
+	tests := []struct {
+		name    string
+		buf     string
+		pos     int
+		s       string
+		want    string
+		wantPos int
+	}{
+		{name: "empty buffer", buf: "", pos: 0, s: "a", want: "a", wantPos: 1},
+		{name: "at start", buf: "ww.example.com", pos: 0, s: "w", want: "www.example.com", wantPos: 1},
+		{name: "in the middle", buf: "wwexample.com", pos: 2, s: "w.", want: "www.example.com", wantPos: 4},
+		{name: "at end", buf: "www", pos: 3, s: ".", want: "www.", wantPos: 4},
+		{name: "multibyte", buf: "пимер", pos: 1, s: "р", want: "пример", wantPos: 2},
+		{name: "position beyond end", buf: "ab", pos: 10, s: "c", want: "abc", wantPos: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, pos := insertAt(tt.buf, tt.pos, tt.s)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantPos, pos)
+		})
+	}
+}
+
+func TestDeleteBefore(t *testing.T) {
+	tests := []struct {
+		name    string
+		buf     string
+		pos     int
+		want    string
+		wantPos int
+	}{
+		{name: "at start", buf: "abc", pos: 0, want: "abc", wantPos: 0},
+		{name: "in the middle", buf: "abc", pos: 2, want: "ac", wantPos: 1},
+		{name: "at end", buf: "abc", pos: 3, want: "ab", wantPos: 2},
+		{name: "multibyte", buf: "пример", pos: 2, want: "пимер", wantPos: 1},
+		{name: "empty buffer", buf: "", pos: 0, want: "", wantPos: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, pos := deleteBefore(tt.buf, tt.pos)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantPos, pos)
+		})
+	}
+}
+
+func TestDeleteAt(t *testing.T) {
+	tests := []struct {
+		name    string
+		buf     string
+		pos     int
+		want    string
+		wantPos int
+	}{
+		{name: "at start", buf: "abc", pos: 0, want: "bc", wantPos: 0},
+		{name: "in the middle", buf: "abc", pos: 1, want: "ac", wantPos: 1},
+		{name: "at end", buf: "abc", pos: 3, want: "abc", wantPos: 3},
+		{name: "multibyte", buf: "пример", pos: 1, want: "пимер", wantPos: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, pos := deleteAt(tt.buf, tt.pos)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantPos, pos)
+		})
+	}
+}
+
+func TestMoveCursor(t *testing.T) {
+	tests := []struct {
+		key     string
+		pos     int
+		wantPos int
+		wantOK  bool
+	}{
+		{key: "left", pos: 2, wantPos: 1, wantOK: true},
+		{key: "left", pos: 0, wantPos: 0, wantOK: true},
+		{key: "right", pos: 2, wantPos: 3, wantOK: true},
+		{key: "right", pos: 3, wantPos: 3, wantOK: true},
+		{key: "home", pos: 2, wantPos: 0, wantOK: true},
+		{key: "end", pos: 0, wantPos: 3, wantOK: true},
+		{key: "up", pos: 1, wantPos: 1, wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			pos, ok := moveCursor("abc", tt.pos, tt.key)
+			assert.Equal(t, tt.wantPos, pos)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestTextEdit_MidStringEditing(t *testing.T) {
+	m := New([]string{"Name"}, []string{"ww.example.com"}, "test", nil, CancelMsg{})
+
+	keys := []tea.KeyMsg{
+		{Type: tea.KeyEnter}, // open text editor
+		{Type: tea.KeyHome},
+		{Type: tea.KeyRunes, Runes: []rune("w")},
+		{Type: tea.KeyEnd},
+		{Type: tea.KeyLeft},
+		{Type: tea.KeyLeft},
+		{Type: tea.KeyLeft},
+		{Type: tea.KeyBackspace}, // remove "."
+		{Type: tea.KeyRunes, Runes: []rune(".")},
+		{Type: tea.KeyDelete}, // remove "c"
+		{Type: tea.KeyRunes, Runes: []rune("c")},
+	}
+	for _, k := range keys {
+		m.Update(k)
+	}
+
+	assert.True(t, m.inTextEdit)
+	assert.Equal(t, "www.example.com", m.textBuf)
+	assert.Equal(t, 13, m.textPos)
+}
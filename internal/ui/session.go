@@ -0,0 +1,76 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mixanemca/cdnscli/internal/config"
+	"github.com/mixanemca/cdnscli/internal/models"
+)
+
+// session holds what the provider actions of the UI share for the life of the
+// model: the IDs of the zones listed so far, so actions on a zone pass its ID
+// and the provider does not resolve it again. Actions run as concurrent
+// commands, so the session is safe for concurrent use.
+type session struct {
+	mu      sync.Mutex
+	zoneIDs map[string]string // zone IDs by the zone name shown in the zones table
+}
+
+func newSession() *session {
+	return &session{zoneIDs: make(map[string]string)}
+}
+
+// context returns the context of a single provider action, bounded by timeout
+// or by the default client timeout when timeout is not set.
+func (s *session) context(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		timeout = config.DefaultClientTimeout
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+// rememberZones keeps the IDs of the listed zones. Zones without an ID are skipped.
+func (s *session) rememberZones(zones []models.Zone) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, z := range zones {
+		if z.ID != "" {
+			s.zoneIDs[sessionZoneKey(z.Name)] = z.ID
+		}
+	}
+}
+
+// zoneID returns the ID of zone as listed, or "" when it is unknown and the
+// provider has to resolve it.
+func (s *session) zoneID(zone string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.zoneIDs[sessionZoneKey(zone)]
+}
+
+// sessionZoneKey returns the key of zone in the session: the Unicode name as the
+// zones table shows it, in lower case without the trailing dot.
+func sessionZoneKey(zone string) string {
+	return strings.ToLower(strings.TrimSuffix(models.NameToUnicode(zone), "."))
+}
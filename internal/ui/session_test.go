@@ -0,0 +1,82 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_ZoneID(t *testing.T) {
+	s := newSession()
+	assert.Empty(t, s.zoneID("example.com"))
+
+	s.rememberZones([]models.Zone{
+		{ID: "zone-1", Name: "example.com"},
+		{ID: "zone-2", Name: "xn--mnchen-3ya.de"},
+		{Name: "example.net"},
+	})
+	assert.Equal(t, "zone-1", s.zoneID("example.com"))
+	assert.Equal(t, "zone-1", s.zoneID("Example.COM."))
+	// The zones table shows Unicode names
+	assert.Equal(t, "zone-2", s.zoneID("münchen.de"))
+	assert.Empty(t, s.zoneID("example.net"))
+}
+
+func TestSession_Context(t *testing.T) {
+	s := newSession()
+
+	ctx, cancel := s.context(time.Minute)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+
+	// An unset timeout does not expire the action at once
+	ctx, cancel = s.context(0)
+	defer cancel()
+	assert.NoError(t, ctx.Err())
+}
+
+func TestSession_ActionsReuseZoneID(t *testing.T) {
+	p := &fakeProvider{rrset: []models.DNSRecord{
+		{ID: "1", Name: "www.example.com", TTL: 300, Type: "A", Content: "192.0.2.1"},
+	}}
+	m := newEditingModel(p, false)
+	m.session.rememberZones([]models.Zone{{ID: "zone-1", Name: "example.com"}})
+
+	m.updateRRSet("example.com")()
+	require.Len(t, p.listed, 1)
+	assert.Equal(t, models.ListDNSRecordsParams{ZoneID: "zone-1", ZoneName: "example.com"}, p.listed[0])
+
+	m.createRRFromFields([]string{"mail", "300", "A", "false", "192.0.2.25"})()
+	require.Len(t, p.created, 1)
+	assert.Equal(t, "zone-1", p.created[0].ZoneID)
+
+	m.updateRRFromFields("1", []string{"www.example.com", "300", "A", "false", "192.0.2.2"})()
+	require.Len(t, p.updated, 1)
+	assert.Equal(t, "zone-1", p.updated[0].ZoneID)
+
+	// Zones not listed are left to the provider to resolve
+	m.updateRRSet("example.org")()
+	require.Len(t, p.listed, 2)
+	assert.Empty(t, p.listed[1].ZoneID)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package workerpool runs the tasks of a bulk operation on a bounded number of goroutines.
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const (
+	// DefaultSize is the number of workers when none is given, low enough for
+	// the rate limits of the provider APIs.
+	DefaultSize = 4
+	// MaxSize bounds the number of workers, so a provider is not overwhelmed.
+	MaxSize = 16
+)
+
+// Result is the outcome of the task of a single item.
+type Result[R any] struct {
+	Value R
+	Err   error
+	// Done reports whether the task ran. Tasks are not started once the context is done.
+	Done bool
+}
+
+// ValidateSize checks that n workers are within 1 and MaxSize.
+func ValidateSize(n int) error {
+	if n < 1 || n > MaxSize {
+		return fmt.Errorf("concurrency must be between 1 and %d, got %d", MaxSize, n)
+	}
+	return nil
+}
+
+// Run calls task for every item on at most n goroutines and returns the results
+// in the order of items. A size outside 1 and MaxSize is bounded to it. Once ctx
+// is done no more tasks are started, running tasks are waited for.
+func Run[T, R any](ctx context.Context, n int, items []T, task func(ctx context.Context, item T) (R, error)) []Result[R] {
+	n = min(max(n, 1), MaxSize, max(len(items), 1))
+	results := make([]Result[R], len(items))
+
+	var (
+		mu   sync.Mutex
+		next int
+		wg   sync.WaitGroup
+	)
+	// claim returns the index of the next item to run, false when all are taken
+	// or the context is done.
+	claim := func() (int, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if next >= len(items) || ctx.Err() != nil {
+			return 0, false
+		}
+		next++
+		return next - 1, true
+	}
+
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i, ok := claim()
+				if !ok {
+					return
+				}
+				v, err := task(ctx, items[i])
+				results[i] = Result[R]{Value: v, Err: err, Done: true}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
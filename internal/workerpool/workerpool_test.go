@@ -0,0 +1,153 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gauge tracks the number of tasks running at the same time.
+type gauge struct {
+	mu      sync.Mutex
+	running int
+	peak    int
+}
+
+func (g *gauge) enter() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.running++
+	g.peak = max(g.peak, g.running)
+}
+
+func (g *gauge) leave() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.running--
+}
+
+func TestRun(t *testing.T) {
+	items := make([]int, 50)
+	for i := range items {
+		items[i] = i
+	}
+
+	for _, n := range []int{1, 3, 8} {
+		var g gauge
+		results := Run(context.Background(), n, items, func(ctx context.Context, item int) (int, error) {
+			g.enter()
+			defer g.leave()
+			time.Sleep(time.Millisecond)
+			return item * item, nil
+		})
+
+		require.Len(t, results, len(items))
+		for i, r := range results {
+			// Results are in the order of the items, whatever order the tasks finished in
+			assert.Equal(t, Result[int]{Value: i * i, Done: true}, r)
+		}
+		assert.LessOrEqual(t, g.peak, n, "at most %d tasks at a time", n)
+		if n > 1 {
+			assert.Greater(t, g.peak, 1, "tasks run concurrently with %d workers", n)
+		}
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	errOdd := errors.New("odd")
+	results := Run(context.Background(), 4, []int{1, 2, 3, 4}, func(ctx context.Context, item int) (int, error) {
+		if item%2 == 1 {
+			return 0, errOdd
+		}
+		return item, nil
+	})
+
+	assert.Equal(t, []Result[int]{
+		{Err: errOdd, Done: true},
+		{Value: 2, Done: true},
+		{Err: errOdd, Done: true},
+		{Value: 4, Done: true},
+	}, results)
+}
+
+func TestRun_Bounds(t *testing.T) {
+	items := make([]struct{}, 3*MaxSize)
+
+	for _, n := range []int{0, -1, MaxSize + 10} {
+		var g gauge
+		results := Run(context.Background(), n, items, func(ctx context.Context, item struct{}) (bool, error) {
+			g.enter()
+			defer g.leave()
+			time.Sleep(time.Millisecond)
+			return true, nil
+		})
+
+		for _, r := range results {
+			assert.True(t, r.Value)
+		}
+		assert.GreaterOrEqual(t, g.peak, 1)
+		assert.LessOrEqual(t, g.peak, MaxSize)
+	}
+
+	assert.Empty(t, Run(context.Background(), 4, []int(nil), func(ctx context.Context, item int) (int, error) {
+		t.Error("no task to run")
+		return 0, nil
+	}))
+}
+
+func TestRun_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var started atomic.Int32
+	results := Run(ctx, 2, make([]int, 20), func(ctx context.Context, item int) (int, error) {
+		// The third task cancels the run, the other worker may start one more
+		if started.Add(1) == 3 {
+			cancel()
+		}
+		return 0, nil
+	})
+
+	done := 0
+	for _, r := range results {
+		if r.Done {
+			done++
+		}
+	}
+	assert.Equal(t, int(started.Load()), done)
+	assert.GreaterOrEqual(t, done, 3)
+	assert.LessOrEqual(t, done, 4)
+	assert.False(t, results[len(results)-1].Done)
+}
+
+func TestValidateSize(t *testing.T) {
+	assert.NoError(t, ValidateSize(1))
+	assert.NoError(t, ValidateSize(DefaultSize))
+	assert.NoError(t, ValidateSize(MaxSize))
+	assert.EqualError(t, ValidateSize(0), "concurrency must be between 1 and 16, got 0")
+	assert.Error(t, ValidateSize(MaxSize+1))
+}
@@ -0,0 +1,132 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package zonefile writes zones in the BIND master file format of RFC 1035.
+package zonefile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/miekg/dns"
+	"github.com/mixanemca/cdnscli/internal/models"
+)
+
+// Extension is the file name extension of zone files.
+const Extension = ".zone"
+
+// FileName returns the name of the zone file of zone, e.g. example.com.zone. The
+// zone is converted to ASCII and lower case, so every zone maps to one file.
+func FileName(zone string) (string, error) {
+	ascii, err := models.NameToASCII(zone)
+	if err != nil {
+		return "", err
+	}
+	ascii = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(ascii), "."))
+	if ascii == "" || ascii == "." || ascii == ".." || strings.ContainsAny(ascii, `/\`) {
+		return "", fmt.Errorf("invalid zone name %q", zone)
+	}
+	return ascii + Extension, nil
+}
+
+// Write writes the records of zone to w as a zone file. The header has the
+// $ORIGIN, the SOA record when soa is not nil and the NS records of the apex,
+// the other records follow sorted by name and type. SOA records in rrset are
+// skipped in favour of soa. Records that cannot be represented in the format
+// are written as comments.
+func Write(w io.Writer, zone string, soa *models.SOA, rrset []models.DNSRecord) error {
+	origin := dns.Fqdn(strings.ToLower(zone))
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "$ORIGIN %s\n", origin)
+	if soa != nil {
+		ttl := soa.TTL
+		if ttl == 0 {
+			ttl = soa.Minimum
+		}
+		rr := &dns.SOA{
+			Hdr:     dns.RR_Header{Name: origin, Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: ttl},
+			Ns:      dns.Fqdn(soa.MName),
+			Mbox:    dns.Fqdn(soa.RName),
+			Serial:  soa.Serial,
+			Refresh: soa.Refresh,
+			Retry:   soa.Retry,
+			Expire:  soa.Expire,
+			Minttl:  soa.Minimum,
+		}
+		fmt.Fprintln(bw, rr.String())
+	}
+
+	var apexNS, rest []models.DNSRecord
+	for _, rr := range rrset {
+		switch {
+		case strings.EqualFold(rr.Type, "SOA"):
+		case strings.EqualFold(rr.Type, "NS") && strings.EqualFold(dns.Fqdn(rr.Name), origin):
+			apexNS = append(apexNS, rr)
+		default:
+			rest = append(rest, rr)
+		}
+	}
+	for _, rrset := range [][]models.DNSRecord{apexNS, rest} {
+		if err := models.SortRecords(rrset, models.DefaultRecordSort, false); err != nil {
+			return err
+		}
+	}
+
+	for _, rr := range apexNS {
+		writeRecord(bw, rr)
+	}
+	if len(rest) > 0 {
+		fmt.Fprintln(bw)
+	}
+	for _, rr := range rest {
+		writeRecord(bw, rr)
+	}
+
+	return bw.Flush()
+}
+
+// writeRecord writes rr in presentation format, or as a comment when it is invalid.
+func writeRecord(w io.Writer, rr models.DNSRecord) {
+	r, err := dns.NewRR(fmt.Sprintf("%s %d IN %s %s", dns.Fqdn(rr.Name), ttl(rr), strings.ToUpper(rr.Type), content(rr)))
+	if err != nil || r == nil {
+		fmt.Fprintf(w, "; skipped %s %s %q: invalid record\n", rr.Name, rr.Type, rr.Content)
+		return
+	}
+	fmt.Fprintln(w, r.String())
+}
+
+// ttl returns the TTL of rr, automatic TTLs are written as 300 seconds.
+func ttl(rr models.DNSRecord) int {
+	if rr.TTL <= models.TTLAuto {
+		return 300
+	}
+	return rr.TTL
+}
+
+// content returns the presentation format content of rr: TXT values are quoted
+// and the priority is added to MX and SRV content that lacks it.
+func content(rr models.DNSRecord) string {
+	if strings.EqualFold(rr.Type, "TXT") {
+		if strings.HasPrefix(strings.TrimSpace(rr.Content), `"`) {
+			return rr.Content
+		}
+		return models.QuoteTXT(models.SplitTXT(rr.Content))
+	}
+	return models.JoinPriority(rr.Type, rr.Priority, rr.Content)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright © 2024-2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zonefile
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/mixanemca/cdnscli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileName(t *testing.T) {
+	tests := []struct {
+		zone    string
+		want    string
+		wantErr bool
+	}{
+		{zone: "example.com", want: "example.com.zone"},
+		{zone: "Example.COM.", want: "example.com.zone"},
+		{zone: "пример.рф", want: "xn--e1afmkfd.xn--p1ai.zone"},
+		{zone: "", wantErr: true},
+		{zone: ".", wantErr: true},
+		{zone: "../etc", wantErr: true},
+		{zone: `a\b.com`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.zone, func(t *testing.T) {
+			got, err := FileName(tt.zone)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	soa := &models.SOA{MName: "ns1.example.net", RName: "hostmaster.example.com", Serial: 2024010101, Refresh: 7200, Retry: 3600, Expire: 1209600, Minimum: 300, TTL: 3600}
+	rrset := []models.DNSRecord{
+		{Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 300},
+		{Name: "example.com", Type: "MX", Content: "mail.example.com", Priority: 10, TTL: 3600},
+		{Name: "example.com", Type: "TXT", Content: "v=spf1 -all", TTL: models.TTLAuto},
+		{Name: "example.com", Type: "NS", Content: "ns2.example.net", TTL: 86400},
+		{Name: "example.com", Type: "SOA", Content: "ignored"},
+		{Name: "example.com", Type: "NS", Content: "ns1.example.net", TTL: 86400},
+		{Name: "sub.example.com", Type: "NS", Content: "ns.sub.example.com", TTL: 86400},
+		{Name: "bad.example.com", Type: "A", Content: "not-an-address", TTL: 300},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, "Example.com", soa, rrset))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+
+	assert.Equal(t, "$ORIGIN example.com.", lines[0])
+	assert.Equal(t, "example.com.\t3600\tIN\tSOA\tns1.example.net. hostmaster.example.com. 2024010101 7200 3600 1209600 300", lines[1])
+	// Records are sorted as listings are, by name, type and content
+	assert.Equal(t, "example.com.\t86400\tIN\tNS\tns1.example.net.", lines[2])
+	assert.Equal(t, "example.com.\t86400\tIN\tNS\tns2.example.net.", lines[3])
+	assert.Equal(t, "", lines[4])
+	assert.Equal(t, []string{
+		`; skipped bad.example.com A "not-an-address": invalid record`,
+		"example.com.\t3600\tIN\tMX\t10 mail.example.com.",
+		"example.com.\t300\tIN\tTXT\t\"v=spf1 -all\"",
+		"sub.example.com.\t86400\tIN\tNS\tns.sub.example.com.",
+		"www.example.com.\t300\tIN\tA\t192.0.2.1",
+	}, lines[5:])
+
+	// The output is a valid zone file
+	zp := dns.NewZoneParser(strings.NewReader(buf.String()), "", "")
+	var n int
+	for _, ok := zp.Next(); ok; _, ok = zp.Next() {
+		n++
+	}
+	require.NoError(t, zp.Err())
+	assert.Equal(t, 7, n)
+}
+
+func TestWrite_WithoutSOA(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, "example.com", nil, nil))
+	assert.Equal(t, "$ORIGIN example.com.\n", buf.String())
+}